@@ -2,8 +2,12 @@
 package app
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/shayja/go-template-api/config"
@@ -14,12 +18,26 @@ import (
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 
+	attributerepo "github.com/shayja/go-template-api/internal/adapters/repositories/attribute"
+	categoryrepo "github.com/shayja/go-template-api/internal/adapters/repositories/category"
+	currencyrepo "github.com/shayja/go-template-api/internal/adapters/repositories/currency"
+	inventoryrepo "github.com/shayja/go-template-api/internal/adapters/repositories/inventory"
 	repositories "github.com/shayja/go-template-api/internal/adapters/repositories/order"
 	productrepo "github.com/shayja/go-template-api/internal/adapters/repositories/product"
+	promotionrepo "github.com/shayja/go-template-api/internal/adapters/repositories/promotion"
+	reviewrepo "github.com/shayja/go-template-api/internal/adapters/repositories/review"
+	translationrepo "github.com/shayja/go-template-api/internal/adapters/repositories/translation"
+	wishlistrepo "github.com/shayja/go-template-api/internal/adapters/repositories/wishlist"
+	variantrepo "github.com/shayja/go-template-api/internal/adapters/repositories/variant"
 	userrepo "github.com/shayja/go-template-api/internal/adapters/repositories/user"
 	"github.com/shayja/go-template-api/internal/usecases"
 	"github.com/shayja/go-template-api/pkg/constants"
 	sql_postgres "github.com/shayja/go-template-api/pkg/drivers/sql"
+	"github.com/shayja/go-template-api/pkg/embedding"
+	"github.com/shayja/go-template-api/pkg/imaging"
+	"github.com/shayja/go-template-api/pkg/scheduler"
+	"github.com/shayja/go-template-api/pkg/signedurl"
+	"github.com/shayja/go-template-api/pkg/storage"
 )
 
 type App struct {
@@ -52,10 +70,97 @@ func (app *App) Routes() {
 	publicRoutes.POST("/verify_otp", userController.VerifyOTP)
 	publicRoutes.POST("/resend_otp", userController.ResendOTP)
 
+	// Admin only routes are additionally guarded by the role claim of the token
+	adminRequired := middleware.RoleRequired(utils.CurrentRole, constants.RoleAdmin)
+
 	// Register the Product module
+	categoryRepo := &categoryrepo.CategoryRepository{Db: app.DB}
 	productRepo := &productrepo.ProductRepository{Db: app.DB}
-	productInteractor := usecases.ProductInteractor{ProductRepository: productRepo}
-	productController := controllers.ProductController{ProductInteractor: productInteractor}
+	variantRepo := &variantrepo.VariantRepository{Db: app.DB}
+	imageStorage, err := storage.NewFromConfig()
+	if err != nil {
+		fmt.Print("Error configuring the image storage:", err)
+		panic(err)
+	}
+	// The API serves the stored files at /images, the public ones may be served from a CDN or the bucket instead
+	mediaBaseUrl := config.Config("MEDIA_BASE_URL")
+	if mediaBaseUrl == "" {
+		mediaBaseUrl = fmt.Sprintf("http://localhost:%s/images", config.Config("SERVER_PORT"))
+	}
+	imageBaseUrl := config.Config("STORAGE_PUBLIC_BASE_URL")
+	if imageBaseUrl == "" {
+		imageBaseUrl = mediaBaseUrl
+	}
+	mediaController := &controllers.MediaController{Storage: imageStorage, MaxAge: config.Duration("MEDIA_CACHE_MAX_AGE", 365*24*time.Hour)}
+	var signURL func(key string) string
+	if signingKey := config.Config("MEDIA_SIGNING_KEY"); signingKey != "" {
+		signer := &signedurl.Signer{Key: []byte(signingKey)}
+		signedUrlTTL := config.Duration("MEDIA_SIGNED_URL_TTL", 15*time.Minute)
+		mediaController.Signer = signer
+		signURL = func(key string) string {
+			return signer.URL(strings.TrimRight(mediaBaseUrl, "/")+"/", key, signedUrlTTL)
+		}
+	}
+	router.GET("/images/*key", mediaController.Serve)
+	router.HEAD("/images/*key", mediaController.Serve)
+	maxImageSize, _ := strconv.ParseInt(config.Config("IMAGE_MAX_SIZE"), 10, 64)
+	images := &usecases.ImageConfig{Storage: imageStorage, PublicBaseURL: imageBaseUrl, MaxSize: maxImageSize, Repository: productRepo, Processor: imageProcessor(), SignURL: signURL}
+	maxImportSize, _ := strconv.ParseInt(config.Config("PRODUCT_IMPORT_MAX_SIZE"), 10, 64)
+	maxImportRows, _ := strconv.Atoi(config.Config("PRODUCT_IMPORT_MAX_ROWS"))
+	attributeRepo := &attributerepo.AttributeRepository{Db: app.DB}
+	priceBuckets, err := usecases.ParsePriceBuckets(config.Config("PRODUCT_PRICE_BUCKETS"))
+	if err != nil {
+		fmt.Print("Error configuring the price buckets:", err)
+		panic(err)
+	}
+	facetMaxValues, _ := strconv.Atoi(config.Config("PRODUCT_FACET_MAX_VALUES"))
+	productInteractor := usecases.ProductInteractor{ProductRepository: productRepo, CategoryRepository: categoryRepo, VariantRepository: variantRepo, AttributeRepository: attributeRepo, Images: images, MaxImportSize: maxImportSize, MaxImportRows: maxImportRows, PriceBuckets: priceBuckets, FacetMaxValues: facetMaxValues, BundleRepository: productRepo}
+
+	// Render the uploaded images in the background, requeueing the uploads the queue dropped or a restart lost
+	imageQueueSize, _ := strconv.Atoi(config.Config("IMAGE_QUEUE_SIZE"))
+	imageWorkers, _ := strconv.Atoi(config.Config("IMAGE_WORKERS"))
+	imageWorker := usecases.NewImageWorker(images, max(1, imageQueueSize))
+	images.Enqueue = imageWorker.Enqueue
+	imageWorker.Start(context.Background(), imageWorkers)
+	requeueInterval := config.Duration("IMAGE_REQUEUE_INTERVAL", time.Minute)
+	scheduler.Every(context.Background(), "image requeue", requeueInterval, func(context.Context) error {
+		_, err := imageWorker.Requeue(time.Now().Add(-requeueInterval))
+		return err
+	})
+	// Embed the product names and descriptions for the semantic search in the background, again once they change
+	embedder, err := embedding.NewFromConfig()
+	if err != nil {
+		fmt.Print("Error configuring the embedding provider:", err)
+		panic(err)
+	}
+	embeddingBatchSize, _ := strconv.Atoi(config.Config("EMBEDDING_BATCH_SIZE"))
+	textWeight, _ := strconv.ParseFloat(config.Config("SEMANTIC_SEARCH_TEXT_WEIGHT"), 64)
+	productInteractor.Embeddings = &usecases.EmbeddingConfig{Provider: embedder, Repository: productRepo, BatchSize: embeddingBatchSize, TextWeight: min(max(textWeight, 0), 1)}
+	if err := productInteractor.CheckEmbeddings(); err != nil {
+		fmt.Print("Error configuring the embedding provider:", err)
+		panic(err)
+	}
+	scheduler.Every(context.Background(), "product embeddings", config.Duration("EMBEDDING_INTERVAL", time.Minute), func(ctx context.Context) error {
+		_, err := productInteractor.Reembed(ctx)
+		return err
+	})
+	// Count the products bought together again periodically for the related products and cart recommendations
+	productInteractor.Recommendations = &usecases.RecommendationConfig{Repository: productRepo, OrderWindow: config.Duration("RECOMMENDATIONS_ORDER_WINDOW", 0)}
+	scheduler.Every(context.Background(), "co-purchase refresh", config.Duration("RECOMMENDATIONS_REFRESH_INTERVAL", time.Hour), func(context.Context) error {
+		_, err := productInteractor.RefreshCoPurchases()
+		return err
+	})
+	// Amounts are stored in the base currency and converted to the currency the caller asks for
+	currencyRepo := &currencyrepo.CurrencyRepository{Db: app.DB}
+	currencyInteractor := &usecases.CurrencyInteractor{CurrencyRepository: currencyRepo, BaseCurrency: config.Config("BASE_CURRENCY")}
+	supportedLocales, err := usecases.ParseLocales(config.Config("SUPPORTED_LOCALES"))
+	if err != nil {
+		fmt.Print("Error configuring the supported locales:", err)
+		panic(err)
+	}
+	localeInteractor := &usecases.LocaleInteractor{TranslationRepository: &translationrepo.TranslationRepository{Db: app.DB}, DefaultLocale: config.Config("DEFAULT_LOCALE"), SupportedLocales: supportedLocales}
+	ifMatchRequired, _ := strconv.ParseBool(config.Config("PRODUCT_IF_MATCH_REQUIRED"))
+	productController := controllers.ProductController{ProductInteractor: productInteractor, CurrentRole: utils.CurrentRole, CurrencyInteractor: currencyInteractor, LocaleInteractor: localeInteractor, IfMatchRequired: ifMatchRequired}
 
 	// Configure Product Routes
 	protectedRoutes := router.Group(fmt.Sprintf("%s/product", baseUrl))
@@ -65,17 +170,135 @@ func (app *App) Routes() {
 	// Set the product module routes.
 	protectedRoutes.POST("", productController.Create)
 	protectedRoutes.GET("", productController.GetAll)
+	protectedRoutes.GET("search/semantic", productController.SemanticSearch)
+	protectedRoutes.POST("recommendations", productController.RecommendForCart)
+	protectedRoutes.GET("sku/:sku", productController.GetBySku)
+	protectedRoutes.POST("sku/lookup", productController.LookupSkus)
 	protectedRoutes.GET(":id", productController.GetById)
+	protectedRoutes.GET(":id/related", productController.Related)
+	protectedRoutes.GET(":id/bundle", productController.GetBundle)
+	protectedRoutes.PUT(":id/bundle", adminRequired, productController.SetBundle)
+	protectedRoutes.DELETE(":id/bundle", adminRequired, productController.DeleteBundle)
 	protectedRoutes.PUT(":id", productController.Update)
 	protectedRoutes.PATCH(":id", productController.UpdatePrice)
-	protectedRoutes.POST("/image/:id", productController.UpdateImage)
+	protectedRoutes.POST("/image/:id", adminRequired, productController.UpdateImage)
 	protectedRoutes.DELETE(":id", productController.Delete)
+	protectedRoutes.POST(":id/restore", adminRequired, productController.Restore)
+	protectedRoutes.POST("import", adminRequired, productController.Import)
+	protectedRoutes.GET("export", adminRequired, productController.Export)
+
+	// Set the product gallery routes.
+	protectedRoutes.GET(":id/images", productController.GetImages)
+	protectedRoutes.POST(":id/images", adminRequired, productController.AddImage)
+	protectedRoutes.PUT(":id/images", adminRequired, productController.ReorderImages)
+	protectedRoutes.PATCH(":id/images/:image_id", adminRequired, productController.UpdateGalleryImage)
+	protectedRoutes.DELETE(":id/images/:image_id", adminRequired, productController.DeleteImage)
+
+	// Purge the soft deleted products past their retention period that no order references
+	purgeRetention := config.Duration("PRODUCT_PURGE_RETENTION", 30*24*time.Hour)
+	scheduler.Every(context.Background(), "product purge", config.Duration("PRODUCT_PURGE_INTERVAL", 24*time.Hour), func(context.Context) error {
+		purged, err := productInteractor.PurgeDeleted(purgeRetention)
+		if purged > 0 {
+			fmt.Printf("Purged %d deleted products\n", purged)
+		}
+		return err
+	})
+
+	// Register the Category module
+	categoryInteractor := &usecases.CategoryInteractor{CategoryRepository: categoryRepo}
+	categoryController := &controllers.CategoryController{CategoryInteractor: categoryInteractor, ProductInteractor: &productInteractor, CurrencyInteractor: currencyInteractor, LocaleInteractor: localeInteractor}
+
+	// Configure Category Routes
+	categoryRoutes := router.Group(fmt.Sprintf("%s/category", baseUrl))
+	categoryRoutes.Use(middleware.AuthRequired(utils.ValidateJWT))
+
+	// Set the category module routes.
+	categoryRoutes.GET("", categoryController.GetTree)
+	categoryRoutes.GET(":slug", categoryController.GetBySlug)
+	categoryRoutes.GET(":slug/products", categoryController.GetProducts)
+	categoryRoutes.POST("", adminRequired, categoryController.Create)
+	categoryRoutes.PUT(":id", adminRequired, categoryController.Update)
+	categoryRoutes.DELETE(":id", adminRequired, categoryController.Delete)
+	protectedRoutes.PUT(":id/categories", adminRequired, categoryController.SetProductCategories)
+
+	// Register the Translation module
+	translationController := &controllers.TranslationController{LocaleInteractor: localeInteractor, CategoryInteractor: categoryInteractor}
+	protectedRoutes.GET(":id/translations", adminRequired, translationController.GetProductTranslations)
+	protectedRoutes.PUT(":id/translations/:locale", adminRequired, translationController.SaveProductTranslation)
+	protectedRoutes.DELETE(":id/translations/:locale", adminRequired, translationController.DeleteProductTranslation)
+	// The category reads are by slug, the writes by id
+	categoryRoutes.GET(":slug/translations", adminRequired, translationController.GetCategoryTranslations)
+	categoryRoutes.PUT(":id/translations/:locale", adminRequired, translationController.SaveCategoryTranslation)
+	categoryRoutes.DELETE(":id/translations/:locale", adminRequired, translationController.DeleteCategoryTranslation)
+
+	// Register the Variant module
+	variantInteractor := &usecases.VariantInteractor{VariantRepository: variantRepo}
+	variantController := &controllers.VariantController{VariantInteractor: variantInteractor}
+
+	// Set the variant module routes.
+	protectedRoutes.GET(":id/options", variantController.GetOptions)
+	protectedRoutes.PUT(":id/options", adminRequired, variantController.SetOptions)
+	protectedRoutes.GET(":id/variants", variantController.GetAll)
+	protectedRoutes.GET(":id/variants/:variant_id", variantController.GetById)
+	protectedRoutes.POST(":id/variants", adminRequired, variantController.Create)
+	protectedRoutes.PUT(":id/variants/:variant_id", adminRequired, variantController.Update)
+	protectedRoutes.DELETE(":id/variants/:variant_id", adminRequired, variantController.Delete)
+
+	// Register the Inventory module
+	inventoryRepo := &inventoryrepo.InventoryRepository{Db: app.DB}
+	inventoryInteractor := &usecases.InventoryInteractor{InventoryRepository: inventoryRepo}
+	inventoryController := &controllers.InventoryController{InventoryInteractor: inventoryInteractor, CurrentUserId: utils.CurrentUserId}
+
+	// Set the inventory module routes.
+	protectedRoutes.GET(":id/stock", adminRequired, inventoryController.GetStock)
+	protectedRoutes.PUT(":id/stock", adminRequired, inventoryController.SetThreshold)
+	protectedRoutes.POST(":id/stock/adjustments", adminRequired, inventoryController.Adjust)
+	protectedRoutes.GET(":id/stock/movements", adminRequired, inventoryController.GetMovements)
+
+	inventoryRoutes := router.Group(fmt.Sprintf("%s/inventory", baseUrl))
+	inventoryRoutes.Use(middleware.AuthRequired(utils.ValidateJWT))
+	inventoryRoutes.GET("low-stock", adminRequired, inventoryController.GetLowStock)
+
+	// Register the Price module
+	priceInteractor := &usecases.PriceInteractor{PriceRepository: productRepo, ProductRepository: productRepo}
+	priceController := &controllers.PriceController{PriceInteractor: priceInteractor, CurrentRole: utils.CurrentRole}
+
+	// Set the price module routes.
+	protectedRoutes.GET(":id/prices", priceController.GetPrices)
+	protectedRoutes.POST(":id/prices", adminRequired, priceController.SchedulePrice)
+	protectedRoutes.DELETE(":id/prices/:schedule_id", adminRequired, priceController.CancelSchedule)
+
+	// Start and end the scheduled price changes when they are due
+	scheduler.Every(context.Background(), "price schedules", config.Duration("PRICE_SCHEDULE_INTERVAL", time.Minute), func(context.Context) error {
+		activated, reverted, err := priceInteractor.ApplySchedules()
+		if activated > 0 || reverted > 0 {
+			fmt.Printf("Started %d and ended %d price schedules\n", activated, reverted)
+		}
+		return err
+	})
+
 
+	// Register the Promotion module
+	promotionInteractor := &usecases.PromotionInteractor{PromotionRepository: &promotionrepo.PromotionRepository{Db: app.DB}}
+	promotionController := &controllers.PromotionController{PromotionInteractor: promotionInteractor}
+
+	// Configure Promotion Routes
+	promotionRoutes := router.Group(fmt.Sprintf("%s/promotion", baseUrl))
+	promotionRoutes.Use(middleware.AuthRequired(utils.ValidateJWT), adminRequired)
+
+	// Set the promotion module routes.
+	promotionRoutes.GET("", promotionController.GetAll)
+	promotionRoutes.POST("", promotionController.Create)
+	promotionRoutes.GET(":id", promotionController.GetById)
+	promotionRoutes.PUT(":id", promotionController.Update)
+	promotionRoutes.DELETE(":id", promotionController.Delete)
+	promotionRoutes.POST(":id/coupons", promotionController.CreateCoupon)
+	promotionRoutes.DELETE(":id/coupons/:coupon_id", promotionController.DeleteCoupon)
 
 	// Register the Order module
 	orderRepo := &repositories.OrderRepository{Db: app.DB}
-	orderUsecase := &usecases.OrderUsecase{OrderRepo: orderRepo}
-	orderController := &controllers.OrderController{OrderUsecase: orderUsecase}
+	orderUsecase := &usecases.OrderUsecase{OrderRepo: orderRepo, VariantRepo: variantRepo, BundleRepo: productRepo, Promotions: promotionInteractor}
+	orderController := &controllers.OrderController{OrderUsecase: orderUsecase, CurrencyInteractor: currencyInteractor, CurrentUserId: utils.CurrentUserId, CurrentRole: utils.CurrentRole}
 
 	// Configure Order Routes
 	orderRoutes := router.Group(fmt.Sprintf("%s/order", baseUrl))
@@ -83,10 +306,78 @@ func (app *App) Routes() {
 
 	// Set the order module routes.
 	orderRoutes.POST("", orderController.Create)
+	orderRoutes.POST("quote", orderController.Quote)
 	orderRoutes.GET("", orderController.GetOrders)
 	orderRoutes.GET(":id", orderController.GetById)
 	orderRoutes.PUT(":id/status", orderController.UpdateStatus)
 
+	// Register the Currency module
+	currencyController := &controllers.CurrencyController{CurrencyInteractor: currencyInteractor}
+
+	// Configure Currency Routes
+	currencyRoutes := router.Group(fmt.Sprintf("%s/currency", baseUrl))
+	currencyRoutes.Use(middleware.AuthRequired(utils.ValidateJWT))
+
+	// Set the currency module routes.
+	currencyRoutes.GET("", currencyController.GetAll)
+	currencyRoutes.POST("import", adminRequired, currencyController.Import)
+	currencyRoutes.PUT(":code", adminRequired, currencyController.Save)
+	currencyRoutes.DELETE(":code", adminRequired, currencyController.Delete)
+	protectedRoutes.GET(":id/currency-prices", adminRequired, currencyController.GetProductPrices)
+	protectedRoutes.PUT(":id/currency-prices", adminRequired, currencyController.SetProductPrices)
+
+	// Register the Review module
+	reviewRepo := &reviewrepo.ReviewRepository{Db: app.DB}
+	verifiedPurchaseRequired, _ := strconv.ParseBool(config.Config("REVIEWS_VERIFIED_PURCHASE_REQUIRED"))
+	reviewInteractor := &usecases.ReviewInteractor{ReviewRepository: reviewRepo, VerifiedPurchaseRequired: verifiedPurchaseRequired}
+	reviewController := &controllers.ReviewController{ReviewInteractor: reviewInteractor, CurrentUserId: utils.CurrentUserId, CurrentRole: utils.CurrentRole}
+
+	// Configure Review Routes
+	reviewRoutes := router.Group(fmt.Sprintf("%s/review", baseUrl))
+	reviewRoutes.Use(middleware.AuthRequired(utils.ValidateJWT))
+
+	// Set the review module routes.
+	protectedRoutes.GET(":id/reviews", reviewController.GetProductReviews)
+	protectedRoutes.POST(":id/reviews", reviewController.Create)
+	reviewRoutes.GET("", adminRequired, reviewController.GetQueue)
+	reviewRoutes.PUT(":id/status", adminRequired, reviewController.Moderate)
+	reviewRoutes.DELETE(":id", reviewController.Delete)
+
+	// Register the Attribute module
+	attributeController := &controllers.AttributeController{AttributeInteractor: &usecases.AttributeInteractor{AttributeRepository: attributeRepo}}
+
+	// Configure Attribute Routes
+	attributeRoutes := router.Group(fmt.Sprintf("%s/attribute", baseUrl))
+	attributeRoutes.Use(middleware.AuthRequired(utils.ValidateJWT))
+
+	// Set the attribute module routes.
+	attributeRoutes.GET("", attributeController.GetAll)
+	attributeRoutes.PUT(":code", adminRequired, attributeController.Save)
+	attributeRoutes.DELETE(":code", adminRequired, attributeController.Delete)
+
+	// Register the Wishlist module
+	maxWishlists, _ := strconv.Atoi(config.Config("WISHLIST_MAX_LISTS"))
+	maxWishlistItems, _ := strconv.Atoi(config.Config("WISHLIST_MAX_ITEMS"))
+	wishlistInteractor := &usecases.WishlistInteractor{WishlistRepository: &wishlistrepo.WishlistRepository{Db: app.DB}, MaxLists: maxWishlists, MaxItems: maxWishlistItems}
+	wishlistController := &controllers.WishlistController{WishlistInteractor: wishlistInteractor, CurrentUserId: utils.CurrentUserId, CurrencyInteractor: currencyInteractor}
+
+	// Configure Wishlist Routes, the shared lists are read without authentication
+	wishlistRoutes := router.Group(fmt.Sprintf("%s/wishlist", baseUrl))
+	router.GET(fmt.Sprintf("%s/wishlist/shared/:token", baseUrl), wishlistController.GetShared)
+	wishlistRoutes.Use(middleware.AuthRequired(utils.ValidateJWT))
+
+	// Set the wishlist module routes.
+	wishlistRoutes.GET("", wishlistController.GetAll)
+	wishlistRoutes.POST("", wishlistController.Create)
+	wishlistRoutes.GET(":id", wishlistController.GetById)
+	wishlistRoutes.PUT(":id", wishlistController.Update)
+	wishlistRoutes.DELETE(":id", wishlistController.Delete)
+	wishlistRoutes.POST(":id/items", wishlistController.AddItem)
+	wishlistRoutes.DELETE(":id/items/:product_id", wishlistController.RemoveItem)
+	wishlistRoutes.POST(":id/items/:product_id/move", wishlistController.MoveItem)
+	wishlistRoutes.POST(":id/share", wishlistController.Share)
+	wishlistRoutes.DELETE(":id/share", wishlistController.Unshare)
+
 
 
 	// Swagger setup
@@ -107,3 +398,35 @@ func (app *App) Routes() {
 func (app *App) Run() {
 	app.Router.Run(fmt.Sprintf(`:%s`, config.Config("SERVER_PORT")))
 }
+
+// imageProcessor configures the rendering of the uploaded product images
+func imageProcessor() *imaging.Processor {
+	renditions := imaging.DefaultRenditions
+	if spec := config.Config("IMAGE_RENDITIONS"); spec != "" {
+		parsed, err := imaging.ParseRenditions(spec)
+		if err != nil {
+			fmt.Print("Error configuring the image renditions:", err)
+			panic(err)
+		}
+		renditions = parsed
+	}
+	minDimension, _ := strconv.Atoi(config.Config("IMAGE_MIN_DIMENSION"))
+	maxDimension, _ := strconv.Atoi(config.Config("IMAGE_MAX_DIMENSION"))
+	maxPixels, _ := strconv.Atoi(config.Config("IMAGE_MAX_PIXELS"))
+	quality, _ := strconv.Atoi(config.Config("IMAGE_JPEG_QUALITY"))
+	processor := &imaging.Processor{
+		Renditions: renditions,
+		Limits:     imaging.Limits{MinDimension: minDimension, MaxDimension: maxDimension, MaxPixels: maxPixels},
+		Quality:    quality,
+	}
+
+	if webp, _ := strconv.ParseBool(config.Config("IMAGE_WEBP")); webp {
+		encoder, err := imaging.CWebP(config.Config("IMAGE_WEBP_ENCODER"), quality)
+		if err != nil {
+			fmt.Print("Error configuring the WebP renditions:", err)
+			panic(err)
+		}
+		processor.WebP = encoder
+	}
+	return processor
+}
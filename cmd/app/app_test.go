@@ -22,8 +22,11 @@ func setupMockDB() (*sql.DB, sqlmock.Sqlmock) {
 
 func TestRoutes_ProductEndpoints(t *testing.T) {
 	a := &app.App{}
-	db, _ := setupMockDB()
+	db, mock := setupMockDB()
 	defer db.Close()
+	// The embedding provider is checked against the vector column at startup
+	mock.ExpectQuery("SELECT atttypmod FROM pg_attribute").
+		WillReturnRows(sqlmock.NewRows([]string{"atttypmod"}).AddRow(384))
 
 	a.DB = db
 	a.Routes()
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -14,4 +15,18 @@ func Config(key string) string {
         fmt.Printf("Error getting env, not comming through %v", err)
     }
 	return os.Getenv(key)
-}
\ No newline at end of file
+}
+
+// Duration reads a duration env value such as "24h", falling back when it is unset or invalid
+func Duration(key string, fallback time.Duration) time.Duration {
+	value := Config(key)
+	if value == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		fmt.Printf("Invalid duration %s=%q, using %s\n", key, value, fallback)
+		return fallback
+	}
+	return d
+}
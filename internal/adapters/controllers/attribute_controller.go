@@ -0,0 +1,121 @@
+// internal/adapters/controllers/attribute_controller.go
+package controllers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/usecases"
+)
+
+// AttributeFilterPrefix prefixes the query parameters filtering a listing by an attribute, e.g. attr.brand=Acme
+const AttributeFilterPrefix = "attr."
+
+type AttributeController struct {
+	AttributeInteractor *usecases.AttributeInteractor
+}
+
+// attributeFilter collects the attr.<code> query parameters by attribute code, nil when there are none.
+// A repeated parameter matches any of its values.
+func attributeFilter(c *gin.Context) map[string][]interface{} {
+	var filter map[string][]interface{}
+	for key, values := range c.Request.URL.Query() {
+		code, ok := strings.CutPrefix(key, AttributeFilterPrefix)
+		if !ok || code == "" {
+			continue
+		}
+		if filter == nil {
+			filter = make(map[string][]interface{})
+		}
+		for _, value := range values {
+			filter[code] = append(filter[code], value)
+		}
+	}
+	return filter
+}
+
+// GetAll godoc
+// @Summary      Get the attribute definitions
+// @Description  Responds with the typed attributes products may have
+// @Tags         Attributes
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /attribute [get]
+// @Security apiKey
+func (ac *AttributeController) GetAll(c *gin.Context) {
+	AddRequestHeader(c)
+
+	res, err := ac.AttributeInteractor.GetAll()
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+// Save godoc
+// @Summary      Add or update an attribute definition
+// @Description  Define the type of an attribute, the unit of a number or the values of an enum, and whether every product must have it (admin only)
+// @Tags         Attributes
+// @Accept       json
+// @Produce      json
+// @Param        code       path      string                               true  "Attribute code"
+// @Param        attribute  body      entities.AttributeDefinitionRequest  true  "Attribute definition"
+// @Success      200        {object}  map[string]interface{}
+// @Failure      400        {object}  map[string]interface{}
+// @Router       /attribute/{code} [put]
+// @Security apiKey
+func (ac *AttributeController) Save(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var uri entities.AttributeCodeRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	var request entities.AttributeDefinitionRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	res, err := ac.AttributeInteractor.Save(uri.Code, &request)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+// Delete godoc
+// @Summary      Delete an attribute definition
+// @Description  Remove an attribute definition along with the values products have for it (admin only)
+// @Tags         Attributes
+// @Produce      json
+// @Param        code  path      string  true  "Attribute code"
+// @Success      200   {object}  map[string]interface{}
+// @Failure      404   {object}  map[string]interface{}
+// @Router       /attribute/{code} [delete]
+// @Security apiKey
+func (ac *AttributeController) Delete(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var uri entities.AttributeCodeRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	if err := ac.AttributeInteractor.Delete(uri.Code); err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "msg": nil})
+}
@@ -0,0 +1,26 @@
+package controllers
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttributeFilter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		url  string
+		want map[string][]interface{}
+	}{
+		{"/product?q=phone&tag=sale", nil},
+		{"/product?attr.brand=Acme", map[string][]interface{}{"brand": {"Acme"}}},
+		{"/product?attr.color=red&attr.color=blue&attr.weight=150&attr.=x", map[string][]interface{}{"color": {"red", "blue"}, "weight": {"150"}}},
+	}
+	for _, test := range tests {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest("GET", test.url, nil)
+		assert.Equal(t, test.want, attributeFilter(c), test.url)
+	}
+}
@@ -2,9 +2,98 @@
 package controllers
 
 import (
+	"errors"
+	"net/http"
+
 	"github.com/gin-gonic/gin"
+	appErrors "github.com/shayja/go-template-api/internal/errors"
+	"github.com/shayja/go-template-api/pkg/constants"
 )
 
 func AddRequestHeader(c *gin.Context) {
 	c.Header("Content-Type", "application/json")
 }
+
+// Status codes of the application errors a handler may surface to the client.
+var errorStatus = map[string]int{
+	appErrors.ErrInvalidInput.Code:       http.StatusBadRequest,
+	appErrors.ErrInvalidCursor.Code:      http.StatusBadRequest,
+	appErrors.ErrInvalidSort.Code:        http.StatusBadRequest,
+	appErrors.ErrInvalidSlug.Code:        http.StatusBadRequest,
+	appErrors.ErrCategoryNotFound.Code:   http.StatusNotFound,
+	appErrors.ErrCategoryCycle.Code:      http.StatusConflict,
+	appErrors.ErrCategoryNotEmpty.Code:   http.StatusConflict,
+	appErrors.ErrSlugConflict.Code:       http.StatusConflict,
+	appErrors.ErrProductNotFound.Code:    http.StatusNotFound,
+	appErrors.ErrVariantNotFound.Code:    http.StatusNotFound,
+	appErrors.ErrInvalidVariant.Code:     http.StatusBadRequest,
+	appErrors.ErrVariantConflict.Code:    http.StatusConflict,
+	appErrors.ErrVariantUnavailable.Code: http.StatusConflict,
+	appErrors.ErrVariantOrdered.Code:     http.StatusConflict,
+	appErrors.ErrOutOfStock.Code:         http.StatusConflict,
+	appErrors.ErrOrderCancelled.Code:     http.StatusConflict,
+	appErrors.ErrOrderNotFound.Code:      http.StatusNotFound,
+	appErrors.ErrOrderStatusForbidden.Code: http.StatusForbidden,
+	appErrors.ErrInvalidImage.Code:       http.StatusBadRequest,
+	appErrors.ErrImageTooLarge.Code:      http.StatusRequestEntityTooLarge,
+	appErrors.ErrImageDimensions.Code:    http.StatusBadRequest,
+	appErrors.ErrStorage.Code:            http.StatusBadGateway,
+	appErrors.ErrImageNotFound.Code:      http.StatusNotFound,
+	appErrors.ErrInvalidImageOrder.Code:  http.StatusBadRequest,
+	appErrors.ErrInvalidImportFile.Code:  http.StatusBadRequest,
+	appErrors.ErrImportTooLarge.Code:     http.StatusRequestEntityTooLarge,
+	appErrors.ErrPriceScheduleNotFound.Code: http.StatusNotFound,
+	appErrors.ErrInvalidPriceSchedule.Code:  http.StatusBadRequest,
+	appErrors.ErrPriceScheduleConflict.Code: http.StatusConflict,
+	appErrors.ErrUnsupportedCurrency.Code:   http.StatusBadRequest,
+	appErrors.ErrCurrencyNotFound.Code:      http.StatusNotFound,
+	appErrors.ErrInvalidRatesFile.Code:      http.StatusBadRequest,
+	appErrors.ErrOrderTotalMismatch.Code:    http.StatusBadRequest,
+	appErrors.ErrReviewNotFound.Code:        http.StatusNotFound,
+	appErrors.ErrReviewExists.Code:          http.StatusConflict,
+	appErrors.ErrPurchaseRequired.Code:      http.StatusForbidden,
+	appErrors.ErrVersionMismatch.Code:       http.StatusPreconditionFailed,
+	appErrors.ErrPreconditionRequired.Code:  http.StatusPreconditionRequired,
+	appErrors.ErrAttributeNotFound.Code:     http.StatusNotFound,
+	appErrors.ErrInvalidAttribute.Code:      http.StatusBadRequest,
+	appErrors.ErrInvalidTag.Code:            http.StatusBadRequest,
+	appErrors.ErrEmbedding.Code:             http.StatusBadGateway,
+	appErrors.ErrWishlistNotFound.Code:      http.StatusNotFound,
+	appErrors.ErrWishlistExists.Code:        http.StatusConflict,
+	appErrors.ErrWishlistLimit.Code:         http.StatusConflict,
+	appErrors.ErrDefaultWishlist.Code:       http.StatusConflict,
+	appErrors.ErrSkuConflict.Code:           http.StatusConflict,
+	appErrors.ErrPromotionNotFound.Code:     http.StatusNotFound,
+	appErrors.ErrInvalidPromotion.Code:      http.StatusBadRequest,
+	appErrors.ErrCouponNotFound.Code:        http.StatusNotFound,
+	appErrors.ErrCouponExists.Code:          http.StatusConflict,
+	appErrors.ErrInvalidCoupon.Code:         http.StatusBadRequest,
+	appErrors.ErrCouponNotApplicable.Code:   http.StatusBadRequest,
+	appErrors.ErrCouponExhausted.Code:       http.StatusConflict,
+	appErrors.ErrBundleNotFound.Code:        http.StatusNotFound,
+	appErrors.ErrInvalidBundle.Code:         http.StatusBadRequest,
+	appErrors.ErrBundleUnavailable.Code:     http.StatusConflict,
+	appErrors.ErrInvalidLocale.Code:         http.StatusBadRequest,
+	appErrors.ErrTranslationNotFound.Code:   http.StatusNotFound,
+}
+
+// ErrorResponse responds to a failed request, mapping application errors to their status code.
+func ErrorResponse(c *gin.Context, err error) {
+	var appErr *appErrors.AppError
+	if errors.As(err, &appErr) {
+		if status, ok := errorStatus[appErr.Code]; ok {
+			c.JSON(status, gin.H{"status": "failed", "msg": appErr.Message, "code": appErr.Code})
+			return
+		}
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"status": "failed", "msg": err.Error()})
+}
+
+// IsAdmin reports whether the caller has the admin role, false when the role cannot be resolved.
+func IsAdmin(c *gin.Context, resolveRole func(*gin.Context) (string, error)) bool {
+	if resolveRole == nil {
+		return false
+	}
+	role, err := resolveRole(c)
+	return err == nil && role == constants.RoleAdmin
+}
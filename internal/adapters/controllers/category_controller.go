@@ -0,0 +1,312 @@
+// internal/adapters/controllers/category_controller.go
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/usecases"
+)
+
+type CategoryController struct {
+	CategoryInteractor *usecases.CategoryInteractor
+	ProductInteractor  *usecases.ProductInteractor
+	// Optional, converts the product prices to the currency the caller asked for
+	CurrencyInteractor *usecases.CurrencyInteractor
+	// Optional, serves the names in the locale the caller asked for
+	LocaleInteractor *usecases.LocaleInteractor
+}
+
+// GetTree godoc
+// @Summary      Get the category tree
+// @Description  Responds with the root categories and their nested subcategories
+// @Tags         Categories
+// @Produce      json
+// @Param        locale  query     string  false  "Locale of the names, overrides the Accept-Language header"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /category [get]
+// @Security apiKey
+func (cc *CategoryController) GetTree(c *gin.Context) {
+	AddRequestHeader(c)
+
+	locales, err := resolveLocales(c, cc.LocaleInteractor)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	res, err := cc.CategoryInteractor.GetTree()
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+	if locales != nil {
+		if err := cc.LocaleInteractor.LocalizeCategories(locales, res...); err != nil {
+			ErrorResponse(c, err)
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+// GetBySlug godoc
+// @Summary      Get a category by slug
+// @Description  Retrieve category details by its slug
+// @Tags         Categories
+// @Produce      json
+// @Param        slug    path      string  true   "Category slug"
+// @Param        locale  query     string  false  "Locale of the name, overrides the Accept-Language header"
+// @Success      200   {object}  map[string]interface{}
+// @Failure      404   {object}  map[string]interface{}
+// @Router       /category/{slug} [get]
+// @Security apiKey
+func (cc *CategoryController) GetBySlug(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var uri entities.SlugRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	locales, err := resolveLocales(c, cc.LocaleInteractor)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	res, err := cc.CategoryInteractor.GetBySlug(uri.Slug)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+	if locales != nil {
+		if err := cc.LocaleInteractor.LocalizeCategories(locales, res); err != nil {
+			ErrorResponse(c, err)
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+// GetProducts godoc
+// @Summary      Get the products of a category
+// @Description  Retrieve a cursor paginated list of the products of a category and all of its descendants
+// @Tags         Categories
+// @Produce      json
+// @Param        slug           path      string  true   "Category slug"
+// @Param        tag            query     []string  false  "Products with all of these tags"  collectionFormat(multi)
+// @Param        attr.{code}    query     string  false  "Products with this attribute value, e.g. attr.brand=Acme, repeat for any of several values"
+// @Param        cursor         query     string  false  "Cursor of the page to fetch"
+// @Param        limit          query     int     false  "Page size"
+// @Param        sort           query     string  false  "Sort field (name, price, sku, created_at, updated_at, rating), prefix with - for descending"
+// @Param        include_total  query     bool    false  "Include the total number of matching products"
+// @Param        include_facets query     bool    false  "Include the number of matching products per category, price range, tag and attribute value"
+// @Param        currency       query     string  false  "Currency of the prices, overrides the Accept-Currency header"
+// @Param        locale         query     string  false  "Locale of the names and descriptions, overrides the Accept-Language header"
+// @Success      200   {object}  map[string]interface{}
+// @Failure      400   {object}  map[string]interface{}
+// @Failure      404   {object}  map[string]interface{}
+// @Router       /category/{slug}/products [get]
+// @Security apiKey
+func (cc *CategoryController) GetProducts(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var uri entities.SlugRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	var filter entities.ProductFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+	filter.Attributes = attributeFilter(c)
+	if !filter.Validate() {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": "Invalid filter range."})
+		return
+	}
+
+	currency, err := resolveCurrency(c, cc.CurrencyInteractor)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+	locales, err := resolveLocales(c, cc.LocaleInteractor)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	category, err := cc.CategoryInteractor.GetBySlug(uri.Slug)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+	filter.Category = category.Slug
+	filter.IncludeDeleted = false
+
+	res, err := cc.ProductInteractor.Search(&filter)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+	if currency != nil {
+		if err := cc.CurrencyInteractor.ConvertProducts(currency, res.Items...); err != nil {
+			ErrorResponse(c, err)
+			return
+		}
+	}
+	if locales != nil {
+		if err := cc.LocaleInteractor.LocalizeProducts(locales, res.Items...); err != nil {
+			ErrorResponse(c, err)
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+// Create godoc
+// @Summary      Create a new category
+// @Description  Add a category to the tree (admin only)
+// @Tags         Categories
+// @Accept       json
+// @Produce      json
+// @Param        category  body      entities.CategoryRequest  true  "Category data"
+// @Success      201       {object}  map[string]interface{}
+// @Failure      400       {object}  map[string]interface{}
+// @Failure      409       {object}  map[string]interface{}
+// @Router       /category [post]
+// @Security apiKey
+func (cc *CategoryController) Create(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var post entities.CategoryRequest
+	if err := c.ShouldBindJSON(&post); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	insertedId, err := cc.CategoryInteractor.Create(&post)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "msg": nil, "id": insertedId})
+}
+
+// Update godoc
+// @Summary      Update a category
+// @Description  Rename, re-slug, reorder or move a category (admin only)
+// @Tags         Categories
+// @Accept       json
+// @Produce      json
+// @Param        id        path      string                    true  "Category ID"
+// @Param        category  body      entities.CategoryRequest  true  "Category data"
+// @Success      200       {object}  map[string]interface{}
+// @Failure      400       {object}  map[string]interface{}
+// @Failure      404       {object}  map[string]interface{}
+// @Failure      409       {object}  map[string]interface{}
+// @Router       /category/{id} [put]
+// @Security apiKey
+func (cc *CategoryController) Update(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var uri entities.IdRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	var category entities.CategoryRequest
+	if err := c.ShouldBindJSON(&category); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	res, err := cc.CategoryInteractor.Update(uri.Id, &category)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+// Delete godoc
+// @Summary      Delete a category
+// @Description  Delete a category without subcategories, unassigning its products (admin only)
+// @Tags         Categories
+// @Produce      json
+// @Param        id   path      string  true  "Category ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      409  {object}  map[string]interface{}
+// @Router       /category/{id} [delete]
+// @Security apiKey
+func (cc *CategoryController) Delete(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var uri entities.IdRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	if _, err := cc.CategoryInteractor.Delete(uri.Id); err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "msg": nil})
+}
+
+// SetProductCategories godoc
+// @Summary      Assign a product to categories
+// @Description  Replace the categories a product belongs to (admin only)
+// @Tags         Categories
+// @Accept       json
+// @Produce      json
+// @Param        id          path      string                             true  "Product ID"
+// @Param        categories  body      entities.ProductCategoriesRequest  true  "Category IDs"
+// @Success      200         {object}  map[string]interface{}
+// @Failure      400         {object}  map[string]interface{}
+// @Router       /product/{id}/categories [put]
+// @Security apiKey
+func (cc *CategoryController) SetProductCategories(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var uri entities.IdRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	var request entities.ProductCategoriesRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	if err := cc.CategoryInteractor.SetProductCategories(uri.Id, &request); err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	res, err := cc.ProductInteractor.GetById(uri.Id)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
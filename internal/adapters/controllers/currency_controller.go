@@ -0,0 +1,218 @@
+// internal/adapters/controllers/currency_controller.go
+package controllers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/usecases"
+)
+
+type CurrencyController struct {
+	CurrencyInteractor *usecases.CurrencyInteractor
+}
+
+// RequestedCurrency returns the currency code the caller asked the amounts in, by the currency query
+// parameter or else the first Accept-Currency header value, empty for the base currency
+func RequestedCurrency(c *gin.Context) string {
+	c.Header("Vary", "Accept-Currency")
+	if code := c.Query("currency"); code != "" {
+		return code
+	}
+	code, _, _ := strings.Cut(c.GetHeader("Accept-Currency"), ",")
+	code, _, _ = strings.Cut(code, ";")
+	return strings.TrimSpace(code)
+}
+
+// resolveCurrency resolves the requested currency, nil when the controller converts no amounts
+func resolveCurrency(c *gin.Context, currencies *usecases.CurrencyInteractor) (*entities.Currency, error) {
+	if currencies == nil {
+		return nil, nil
+	}
+	return currencies.Resolve(RequestedCurrency(c))
+}
+
+// GetAll godoc
+// @Summary      Get the currencies
+// @Description  Responds with the store base currency followed by the currencies amounts can be requested in, with their exchange rate and rounding rule
+// @Tags         Currencies
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /currency [get]
+// @Security apiKey
+func (cc *CurrencyController) GetAll(c *gin.Context) {
+	AddRequestHeader(c)
+
+	res, err := cc.CurrencyInteractor.GetAll()
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+// Save godoc
+// @Summary      Add or update a currency
+// @Description  Set the exchange rate of a currency from the base currency and how converted amounts are rounded (admin only)
+// @Tags         Currencies
+// @Accept       json
+// @Produce      json
+// @Param        code      path      string                    true  "ISO 4217 currency code"
+// @Param        currency  body      entities.CurrencyRequest  true  "Rate and rounding rule"
+// @Success      200       {object}  map[string]interface{}
+// @Failure      400       {object}  map[string]interface{}
+// @Router       /currency/{code} [put]
+// @Security apiKey
+func (cc *CurrencyController) Save(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var uri entities.CurrencyCodeRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	var request entities.CurrencyRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	res, err := cc.CurrencyInteractor.Save(uri.Code, &request)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+// Delete godoc
+// @Summary      Delete a currency
+// @Description  Remove a currency along with the explicit product prices in it (admin only)
+// @Tags         Currencies
+// @Produce      json
+// @Param        code  path      string  true  "ISO 4217 currency code"
+// @Success      200   {object}  map[string]interface{}
+// @Failure      404   {object}  map[string]interface{}
+// @Router       /currency/{code} [delete]
+// @Security apiKey
+func (cc *CurrencyController) Delete(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var uri entities.CurrencyCodeRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	if err := cc.CurrencyInteractor.Delete(uri.Code); err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "msg": nil})
+}
+
+// Import godoc
+// @Summary      Import exchange rates
+// @Description  Add or update the currencies of a CSV file with a currency,rate[,decimals,rounding,rounding_increment] header, sent as the multipart file field or as the request body. Nothing is saved when a line is invalid (admin only)
+// @Tags         Currencies
+// @Accept       multipart/form-data,text/csv
+// @Produce      json
+// @Param        file  formData  file  false  "Rates file"
+// @Success      200   {object}  map[string]interface{}
+// @Failure      400   {object}  map[string]interface{}
+// @Router       /currency/import [post]
+// @Security apiKey
+func (cc *CurrencyController) Import(c *gin.Context) {
+	AddRequestHeader(c)
+
+	content := c.Request.Body
+	if file, err := c.FormFile("file"); err == nil {
+		opened, err := file.Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+			return
+		}
+		defer opened.Close()
+		content = opened
+	}
+
+	res, err := cc.CurrencyInteractor.ImportRates(content)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+// GetProductPrices godoc
+// @Summary      Get the explicit prices of a product
+// @Description  Responds with the prices of a product set per currency, used instead of converting its base price (admin only)
+// @Tags         Currencies
+// @Produce      json
+// @Param        id   path      string  true  "Product ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Router       /product/{id}/currency-prices [get]
+// @Security apiKey
+func (cc *CurrencyController) GetProductPrices(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var uri entities.IdRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	res, err := cc.CurrencyInteractor.GetProductPrices(uri.Id)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+// SetProductPrices godoc
+// @Summary      Set the explicit prices of a product
+// @Description  Replace the prices of a product set per currency, an empty map removes them (admin only)
+// @Tags         Currencies
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string                                 true  "Product ID"
+// @Param        prices  body      entities.ProductCurrencyPricesRequest  true  "Prices by currency code"
+// @Success      200     {object}  map[string]interface{}
+// @Failure      400     {object}  map[string]interface{}
+// @Failure      404     {object}  map[string]interface{}
+// @Router       /product/{id}/currency-prices [put]
+// @Security apiKey
+func (cc *CurrencyController) SetProductPrices(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var uri entities.IdRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	var request entities.ProductCurrencyPricesRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	res, err := cc.CurrencyInteractor.SetProductPrices(uri.Id, &request)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
@@ -0,0 +1,33 @@
+package controllers
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestedCurrency(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		url    string
+		header string
+		want   string
+	}{
+		{"/product", "", ""},
+		{"/product?currency=eur", "GBP", "eur"},
+		{"/product", "GBP", "GBP"},
+		{"/product", " JPY;q=1.0, USD;q=0.5", "JPY"},
+	}
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", test.url, nil)
+		if test.header != "" {
+			c.Request.Header.Set("Accept-Currency", test.header)
+		}
+		assert.Equal(t, test.want, RequestedCurrency(c), test.url+" "+test.header)
+		assert.Equal(t, "Accept-Currency", w.Header().Get("Vary"))
+	}
+}
@@ -0,0 +1,76 @@
+// internal/adapters/controllers/etag.go
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shayja/go-template-api/internal/entities"
+	appErrors "github.com/shayja/go-template-api/internal/errors"
+)
+
+// VersionETag returns the entity tag of a resource version. Amounts converted to another currency
+// make another representation, tagged with the currency and the time its rate last changed.
+func VersionETag(version int, currency *entities.Currency) string {
+	if currency == nil || currency.Base {
+		return fmt.Sprintf(`"%d"`, version)
+	}
+	return fmt.Sprintf(`"%d-%s-%d"`, version, currency.Code, currency.UpdatedAt.Unix())
+}
+
+// LocaleETag tags the content served in a locale as another representation, the tag is
+// unchanged when the content was not negotiated
+func LocaleETag(etag string, locale string) string {
+	if locale == "" {
+		return etag
+	}
+	return strings.TrimSuffix(etag, `"`) + "-" + locale + `"`
+}
+
+// NotModified sets the ETag of the response and reports whether it matches If-None-Match,
+// in which case it responds with 304 Not Modified
+func NotModified(c *gin.Context, etag string) bool {
+	c.Header("ETag", etag)
+	ifNoneMatch := c.GetHeader("If-None-Match")
+	if ifNoneMatch == "" {
+		return false
+	}
+	for _, tag := range strings.Split(ifNoneMatch, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == etag {
+			c.Status(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
+
+// IfMatchVersion returns the resource version the If-Match header makes the request conditional
+// on, 0 when the header is absent or "*". A tag of another resource can never match the current
+// version and fails with ErrVersionMismatch, a missing header fails with ErrPreconditionRequired
+// when required.
+func IfMatchVersion(c *gin.Context, required bool) (int, error) {
+	ifMatch := strings.TrimSpace(c.GetHeader("If-Match"))
+	if ifMatch == "" {
+		if required {
+			return 0, appErrors.ErrPreconditionRequired
+		}
+		return 0, nil
+	}
+	if ifMatch == "*" {
+		return 0, nil
+	}
+
+	// Only the first tag is compared, clients send the single ETag they read
+	tag, _, _ := strings.Cut(ifMatch, ",")
+	tag = strings.Trim(strings.TrimSpace(tag), `"`)
+	tag, _, _ = strings.Cut(tag, "-")
+	version, err := strconv.Atoi(tag)
+	if err != nil || version <= 0 {
+		return 0, appErrors.ErrVersionMismatch
+	}
+	return version, nil
+}
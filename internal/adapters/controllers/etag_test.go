@@ -0,0 +1,83 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shayja/go-template-api/internal/entities"
+	appErrors "github.com/shayja/go-template-api/internal/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionETag(t *testing.T) {
+	assert.Equal(t, `"3"`, VersionETag(3, nil))
+	assert.Equal(t, `"3"`, VersionETag(3, &entities.Currency{Code: "USD", Base: true}))
+	assert.Equal(t, `"3-EUR-1700000000"`, VersionETag(3, &entities.Currency{Code: "EUR", UpdatedAt: time.Unix(1700000000, 0)}))
+}
+
+func TestLocaleETag(t *testing.T) {
+	assert.Equal(t, `"3"`, LocaleETag(`"3"`, ""))
+	assert.Equal(t, `"3-fr-CA"`, LocaleETag(`"3"`, "fr-CA"))
+	assert.Equal(t, `"3-EUR-1700000000-de"`, LocaleETag(`"3-EUR-1700000000"`, "de"))
+}
+
+func TestIfMatchVersion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		header   string
+		required bool
+		want     int
+		err      error
+	}{
+		{"", false, 0, nil},
+		{"", true, 0, appErrors.ErrPreconditionRequired},
+		{"*", true, 0, nil},
+		{`"3"`, false, 3, nil},
+		{`"3-EUR-1700000000"`, false, 3, nil},
+		{`"3-fr-CA"`, false, 3, nil},
+		{`W/"3"`, false, 0, appErrors.ErrVersionMismatch},
+		{`"abc"`, false, 0, appErrors.ErrVersionMismatch},
+	}
+	for _, test := range tests {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest("PUT", "/product/1", nil)
+		if test.header != "" {
+			c.Request.Header.Set("If-Match", test.header)
+		}
+		version, err := IfMatchVersion(c, test.required)
+		assert.Equal(t, test.want, version, test.header)
+		if test.err != nil {
+			assert.ErrorIs(t, err, test.err, test.header)
+		} else {
+			assert.NoError(t, err, test.header)
+		}
+	}
+}
+
+func TestNotModified(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/product/1", func(c *gin.Context) {
+		if NotModified(c, `"3"`) {
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"status": "success"})
+	})
+
+	for header, want := range map[string]int{"": http.StatusOK, `"2"`: http.StatusOK, `"2", W/"3"`: http.StatusNotModified, "*": http.StatusNotModified} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/product/1", nil)
+		if header != "" {
+			req.Header.Set("If-None-Match", header)
+		}
+		router.ServeHTTP(w, req)
+		assert.Equal(t, want, w.Code, header)
+		assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+		if want == http.StatusNotModified {
+			assert.Empty(t, w.Body.String())
+		}
+	}
+}
@@ -0,0 +1,184 @@
+// internal/adapters/controllers/inventory_controller.go
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/usecases"
+)
+
+type InventoryController struct {
+	InventoryInteractor *usecases.InventoryInteractor
+	// Resolves the user making the request, recorded in the stock ledger
+	CurrentUserId func(*gin.Context) (string, error)
+}
+
+// GetStock godoc
+// @Summary      Get the stock of a product
+// @Description  Responds with the stock level of a product, data is null when the product is not stock tracked (admin only)
+// @Tags         Inventory
+// @Produce      json
+// @Param        id   path      string  true  "Product ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Router       /product/{id}/stock [get]
+// @Security apiKey
+func (ic *InventoryController) GetStock(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var uri entities.IdRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	res, err := ic.InventoryInteractor.GetStock(uri.Id)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+// Adjust godoc
+// @Summary      Adjust the stock of a product
+// @Description  Add or remove stock, recording the reason in the stock ledger. The first adjustment starts tracking the product stock (admin only)
+// @Tags         Inventory
+// @Accept       json
+// @Produce      json
+// @Param        id          path      string                           true  "Product ID"
+// @Param        adjustment  body      entities.StockAdjustmentRequest  true  "Stock adjustment"
+// @Success      200         {object}  map[string]interface{}
+// @Failure      400         {object}  map[string]interface{}
+// @Failure      404         {object}  map[string]interface{}
+// @Failure      409         {object}  map[string]interface{}
+// @Router       /product/{id}/stock/adjustments [post]
+// @Security apiKey
+func (ic *InventoryController) Adjust(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var uri entities.IdRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	var adjustment entities.StockAdjustmentRequest
+	if err := c.ShouldBindJSON(&adjustment); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	userId, err := ic.CurrentUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	res, err := ic.InventoryInteractor.Adjust(uri.Id, userId, &adjustment)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+// SetThreshold godoc
+// @Summary      Set the low stock threshold of a product
+// @Description  Set the quantity at or below which the product is reported as low on stock (admin only)
+// @Tags         Inventory
+// @Accept       json
+// @Produce      json
+// @Param        id         path      string                          true  "Product ID"
+// @Param        threshold  body      entities.StockThresholdRequest  true  "Low stock threshold"
+// @Success      200        {object}  map[string]interface{}
+// @Failure      400        {object}  map[string]interface{}
+// @Failure      404        {object}  map[string]interface{}
+// @Router       /product/{id}/stock [put]
+// @Security apiKey
+func (ic *InventoryController) SetThreshold(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var uri entities.IdRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	var request entities.StockThresholdRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	res, err := ic.InventoryInteractor.SetThreshold(uri.Id, &request)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+// GetMovements godoc
+// @Summary      Get the stock ledger of a product
+// @Description  Responds with a cursor paginated list of the stock movements of a product (admin only)
+// @Tags         Inventory
+// @Produce      json
+// @Param        id             path      string  true   "Product ID"
+// @Param        cursor         query     string  false  "Cursor of the page to fetch"
+// @Param        limit          query     int     false  "Page size"
+// @Param        sort           query     string  false  "Sort field (created_at), prefix with - for descending"
+// @Param        include_total  query     bool    false  "Include the total number of movements"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Router       /product/{id}/stock/movements [get]
+// @Security apiKey
+func (ic *InventoryController) GetMovements(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var uri entities.IdRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	var list entities.ListRequest
+	if err := c.ShouldBindQuery(&list); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	res, err := ic.InventoryInteractor.GetMovements(uri.Id, &list)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+// GetLowStock godoc
+// @Summary      Get the products low on stock
+// @Description  Responds with the stock levels at or below their low stock threshold, lowest first (admin only)
+// @Tags         Inventory
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /inventory/low-stock [get]
+// @Security apiKey
+func (ic *InventoryController) GetLowStock(c *gin.Context) {
+	AddRequestHeader(c)
+
+	res, err := ic.InventoryInteractor.GetLowStock()
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
@@ -0,0 +1,115 @@
+// internal/adapters/controllers/media_controller.go
+package controllers
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shayja/go-template-api/pkg/signedurl"
+	"github.com/shayja/go-template-api/pkg/storage"
+)
+
+// MediaController serves the stored objects, the keys under storage.PrivatePrefix only with a signed URL.
+type MediaController struct {
+	Storage storage.Storage
+	// Optional, private objects are not served without it
+	Signer *signedurl.Signer
+	// How long clients and CDNs may cache the public objects, their keys are never reused
+	MaxAge time.Duration
+}
+
+// Serve responds with a stored object and its ETag and Last-Modified validators, answering conditional
+// requests with 304. It is mounted outside of the API base path, so it is not part of the API docs.
+func (mc *MediaController) Serve(c *gin.Context) {
+	key := strings.TrimPrefix(c.Param("key"), "/")
+	if storage.ValidKey(key) != nil {
+		c.JSON(http.StatusNotFound, gin.H{"status": "failed", "msg": "The requested file does not exist."})
+		return
+	}
+
+	cacheControl := fmt.Sprintf("public, max-age=%d, immutable", int(mc.MaxAge.Seconds()))
+	if strings.HasPrefix(key, storage.PrivatePrefix) {
+		if mc.Signer == nil {
+			c.JSON(http.StatusNotFound, gin.H{"status": "failed", "msg": "The requested file does not exist."})
+			return
+		}
+		expiry, err := mc.Signer.Verify(key, c.Query(signedurl.ExpiresParam), c.Query(signedurl.SignatureParam))
+		if err != nil {
+			c.JSON(http.StatusForbidden, gin.H{"status": "failed", "msg": "The link is invalid or has expired."})
+			return
+		}
+		// Cached no longer than the link is valid
+		cacheControl = fmt.Sprintf("private, max-age=%d", int(time.Until(expiry).Seconds()))
+	}
+
+	content, info, err := mc.Storage.Open(c.Request.Context(), key)
+	if err == storage.ErrNotFound {
+		c.JSON(http.StatusNotFound, gin.H{"status": "failed", "msg": "The requested file does not exist."})
+		return
+	}
+	if err != nil {
+		fmt.Print(err)
+		c.JSON(http.StatusBadGateway, gin.H{"status": "failed", "msg": "The file could not be read."})
+		return
+	}
+	defer content.Close()
+
+	header := c.Writer.Header()
+	header.Set("Cache-Control", cacheControl)
+	if info.ETag != "" {
+		header.Set("ETag", info.ETag)
+	}
+	if !info.ModTime.IsZero() {
+		header.Set("Last-Modified", info.ModTime.UTC().Format(http.TimeFormat))
+	}
+	if notModified(c.Request, info) {
+		c.Status(http.StatusNotModified)
+		c.Writer.WriteHeaderNow()
+		return
+	}
+
+	contentType := info.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	header.Set("Content-Type", contentType)
+	header.Set("X-Content-Type-Options", "nosniff")
+	if info.Size > 0 {
+		header.Set("Content-Length", strconv.FormatInt(info.Size, 10))
+	}
+	c.Status(http.StatusOK)
+	c.Writer.WriteHeaderNow()
+	if c.Request.Method == http.MethodHead {
+		return
+	}
+	if _, err := io.Copy(c.Writer, content); err != nil {
+		fmt.Print(err)
+	}
+}
+
+// notModified evaluates the conditional request headers, If-None-Match takes precedence over If-Modified-Since
+func notModified(r *http.Request, info *storage.ObjectInfo) bool {
+	if match := r.Header.Get("If-None-Match"); match != "" {
+		if info.ETag == "" {
+			return false
+		}
+		for _, tag := range strings.Split(match, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == "*" || tag == strings.TrimPrefix(info.ETag, "W/") {
+				return true
+			}
+		}
+		return false
+	}
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil || info.ModTime.IsZero() {
+		return false
+	}
+	// Last-Modified has a one second precision
+	return !info.ModTime.Truncate(time.Second).After(since)
+}
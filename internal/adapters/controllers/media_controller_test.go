@@ -0,0 +1,103 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shayja/go-template-api/pkg/signedurl"
+	"github.com/shayja/go-template-api/pkg/storage"
+)
+
+func newMediaRouter(t *testing.T) (*gin.Engine, *signedurl.Signer) {
+	gin.SetMode(gin.TestMode)
+	store := &storage.LocalStorage{Root: t.TempDir()}
+	for _, key := range []string{"products/1/10/large.jpg", "private/products/1/abc/source.png"} {
+		assert.NoError(t, store.Put(context.Background(), key, strings.NewReader("image"), 5, ""))
+	}
+	signer := &signedurl.Signer{Key: []byte("secret")}
+	controller := &MediaController{Storage: store, Signer: signer, MaxAge: time.Hour}
+
+	router := gin.New()
+	router.GET("/images/*key", controller.Serve)
+	router.HEAD("/images/*key", controller.Serve)
+	return router, signer
+}
+
+func serveMedia(router *gin.Engine, method string, target string, header http.Header) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, target, nil)
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestMediaController_ServePublic(t *testing.T) {
+	router, _ := newMediaRouter(t)
+
+	w := serveMedia(router, http.MethodGet, "/images/products/1/10/large.jpg", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "image", w.Body.String())
+	assert.Equal(t, "image/jpeg", w.Header().Get("Content-Type"))
+	assert.Equal(t, "public, max-age=3600, immutable", w.Header().Get("Cache-Control"))
+	etag, lastModified := w.Header().Get("ETag"), w.Header().Get("Last-Modified")
+	assert.NotEmpty(t, etag)
+	assert.NotEmpty(t, lastModified)
+
+	w = serveMedia(router, http.MethodGet, "/images/products/1/10/large.jpg", http.Header{"If-None-Match": {`"other", ` + etag}})
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+
+	w = serveMedia(router, http.MethodGet, "/images/products/1/10/large.jpg", http.Header{"If-Modified-Since": {lastModified}})
+	assert.Equal(t, http.StatusNotModified, w.Code)
+
+	w = serveMedia(router, http.MethodGet, "/images/products/1/10/large.jpg", http.Header{"If-None-Match": {`"other"`}})
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = serveMedia(router, http.MethodHead, "/images/products/1/10/large.jpg", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "5", w.Header().Get("Content-Length"))
+	assert.Empty(t, w.Body.String())
+}
+
+func TestMediaController_ServeNotFound(t *testing.T) {
+	router, _ := newMediaRouter(t)
+
+	for _, target := range []string{"/images/products/1/10/missing.jpg", "/images/products/../private/products/1/abc/source.png", "/images/"} {
+		w := serveMedia(router, http.MethodGet, target, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code, target)
+	}
+}
+
+func TestMediaController_ServePrivate(t *testing.T) {
+	router, signer := newMediaRouter(t)
+	key := "private/products/1/abc/source.png"
+
+	w := serveMedia(router, http.MethodGet, "/images/"+key, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	signed, _ := url.Parse(signer.URL("/images/", key, time.Minute))
+	w = serveMedia(router, http.MethodGet, signed.String(), nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "image", w.Body.String())
+	assert.True(t, strings.HasPrefix(w.Header().Get("Cache-Control"), "private, max-age="))
+
+	// Signed for another object
+	other := signed.Query()
+	w = serveMedia(router, http.MethodGet, "/images/private/products/1/def/source.png?"+other.Encode(), nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// Expired
+	expired, _ := url.Parse(signer.URL("/images/", key, -time.Second))
+	w = serveMedia(router, http.MethodGet, expired.String(), nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
@@ -3,7 +3,6 @@ package controllers
 
 import (
 	"net/http"
-	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/shayja/go-template-api/internal/entities"
@@ -13,26 +12,32 @@ import (
 
 type OrderController struct {
 	OrderUsecase *usecases.OrderUsecase
+	// Optional, converts the order totals to the currency the caller asked for
+	CurrencyInteractor *usecases.CurrencyInteractor
+	CurrentUserId      func(*gin.Context) (string, error)
+	CurrentRole        func(*gin.Context) (string, error)
 }
 
-
 // GetOrders godoc
-// @Summary      Get orders (array) by the user ID
-// @Description  Responds with the list of user orders as JSON.
+// @Summary      Get orders by the user ID
+// @Description  Responds with a cursor paginated list of user orders as JSON.
 // @Tags         Orders
 // @Produce      json
-// @Param        page  query     int  true  "Page number"
-// @Param        userid  query   string  true  "User ID (uuid)"
-// @Success      200   {array}   entities.Order
+// @Param        userid         query     string  true   "User ID (uuid)"
+// @Param        cursor         query     string  false  "Cursor of the page to fetch"
+// @Param        limit          query     int     false  "Page size"
+// @Param        sort           query     string  false  "Sort field (created_at, updated_at, total_price, status), prefix with - for descending"
+// @Param        include_total  query     bool    false  "Include the total number of orders"
+// @Param        currency       query     string  false  "Currency of the totals, overrides the Accept-Currency header"
+// @Success      200   {object}  map[string]interface{}
 // @Failure      400   {object}  map[string]interface{}
-// @Failure      404   {object}  map[string]interface{}
+// @Failure      500   {object}  map[string]interface{}
 // @Router       /order [get]
 // @Security apiKey
 func (oc *OrderController) GetOrders(c *gin.Context) {
-	page, err := strconv.Atoi(c.Query("page"))
-
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": "Invalid page number"})
+	var list entities.ListRequest
+	if err := c.ShouldBindQuery(&list); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
 		return
 	}
 
@@ -43,18 +48,23 @@ func (oc *OrderController) GetOrders(c *gin.Context) {
 		return
 	}
 
-	// Fetch the orders using the userId from the token
-	res, err := oc.OrderUsecase.GetOrders(page, userId)
+	currency, err := resolveCurrency(c, oc.CurrencyInteractor)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ErrorResponse(c, err)
 		return
 	}
 
-	if res != nil {
-		c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
-	} else {
-		c.JSON(http.StatusNotFound, gin.H{"status": "failed", "msg": "No orders found for this page"})
+	// Fetch the orders using the userId from the token
+	res, err := oc.OrderUsecase.GetOrders(&list, userId)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+	if currency != nil {
+		oc.CurrencyInteractor.ConvertOrders(currency, res.Items...)
 	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
 }
 
 // GetById godoc
@@ -62,6 +72,7 @@ func (oc *OrderController) GetOrders(c *gin.Context) {
 // @Description  Responds with an entity of order as JSON.
 // @Tags         Orders
 // @Param        id   path      string  true  "Order ID"
+// @Param        currency  query  string  false  "Currency of the total, overrides the Accept-Currency header"
 // @Produce      json
 // @Success      200  {object}  entities.Order
 // @Failure      400  {object}  map[string]interface{}
@@ -76,23 +87,34 @@ func (oc *OrderController) GetById(c *gin.Context) {
 		return
 	}
 
+	currency, err := resolveCurrency(c, oc.CurrencyInteractor)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
 	res, err := oc.OrderUsecase.GetById(uri.Id)
 	if err != nil || !utils.IsValidUUID(res.Id) {
 		c.JSON(http.StatusNotFound, gin.H{"status": "failed", "msg": "Order not found"})
 		return
 	}
+	if currency != nil {
+		oc.CurrencyInteractor.ConvertOrders(currency, res)
+	}
 
 	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
 }
 
 // Create godoc
 // @Summary      Create and store a new order in the database.
-// @Description  Add a new order
+// @Description  Add a new order. The running automatic promotions and the promotion of the coupon code are applied, the total price stored is after the discounts
 // @Tags         Orders
 // @Produce      json
 // @Param        order  body      entities.OrderRequest  true  "Order data"
 // @Success      201      {object}  map[string]interface{}
 // @Failure      400      {object}  map[string]interface{}
+// @Failure      403      {object}  map[string]interface{}
+// @Failure      409      {object}  map[string]interface{}
 // @Router       /order [post]
 // @Security apiKey
 func (oc *OrderController) Create(c *gin.Context) {
@@ -102,24 +124,90 @@ func (oc *OrderController) Create(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err})
 		return
 	}
+	if !oc.orderUser(c, post) {
+		return
+	}
 
 	insertedId, err := oc.OrderUsecase.Create(post)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"status": "failed", "msg": err})
+		ErrorResponse(c, err)
 		return
 	}
 
 	c.JSON(http.StatusCreated, gin.H{"status": "success", "id": insertedId, "msg": nil})
 }
 
+// Quote godoc
+// @Summary      Price an order
+// @Description  Responds with the order priced as it would be created, with the subtotal, the discounts of the promotions and the coupon code, and the total. Nothing is stored
+// @Tags         Orders
+// @Accept       json
+// @Produce      json
+// @Param        order     body      entities.OrderRequest  true   "Order data"
+// @Param        currency  query     string                 false  "Currency of the prices, overrides the Accept-Currency header"
+// @Success      200      {object}  map[string]interface{}
+// @Failure      400      {object}  map[string]interface{}
+// @Failure      403      {object}  map[string]interface{}
+// @Failure      409      {object}  map[string]interface{}
+// @Router       /order/quote [post]
+// @Security apiKey
+func (oc *OrderController) Quote(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var post entities.OrderRequest
+	if err := c.ShouldBindJSON(&post); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+	if !oc.orderUser(c, &post) {
+		return
+	}
+
+	currency, err := resolveCurrency(c, oc.CurrencyInteractor)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	res, err := oc.OrderUsecase.Quote(&post)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+	if currency != nil {
+		oc.CurrencyInteractor.ConvertQuote(currency, res)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+// orderUser places an order for the user of the token, the coupon limits are counted per user.
+// An order for another user is forbidden
+func (oc *OrderController) orderUser(c *gin.Context, post *entities.OrderRequest) bool {
+	userId, err := oc.CurrentUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"status": "failed", "msg": err.Error()})
+		return false
+	}
+	if post.UserId != "" && post.UserId != userId {
+		c.JSON(http.StatusForbidden, gin.H{"status": "failed", "msg": "Orders can only be placed for the current user."})
+		return false
+	}
+	post.UserId = userId
+	return true
+}
+
 // UpdateStatus godoc
 // @Summary      Update order status
-// @Description  Update the status of an order
+// @Description  Update the status of an order. Admins may set any status, a customer may only cancel (4) their own pending order
 // @Tags         Orders
 // @Param        id      path      string  true  "Order ID"
 // @Param        status  body      int     true  "New status"
 // @Success      200     {object}  map[string]interface{}
 // @Failure      400     {object}  map[string]interface{}
+// @Failure      401     {object}  map[string]interface{}
+// @Failure      403     {object}  map[string]interface{}
+// @Failure      404     {object}  map[string]interface{}
 // @Router       /order/{id}/status [put]
 // @Security apiKey
 func (oc *OrderController) UpdateStatus(c *gin.Context) {
@@ -138,9 +226,15 @@ func (oc *OrderController) UpdateStatus(c *gin.Context) {
 		return
 	}
 
-	res, err := oc.OrderUsecase.UpdateStatus(uri.Id, status.Status)
+	userId, err := oc.CurrentUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	res, err := oc.OrderUsecase.UpdateStatus(uri.Id, status.Status, userId, IsAdmin(c, oc.CurrentRole))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"status": "failed", "msg": err})
+		ErrorResponse(c, err)
 		return
 	}
 
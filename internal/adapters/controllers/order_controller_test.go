@@ -0,0 +1,165 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/usecases"
+	"github.com/shayja/go-template-api/pkg/constants"
+	"github.com/shayja/go-template-api/pkg/money"
+)
+
+// MockOrderRepository mocks the OrderRepository, the methods not mocked are not expected to be called
+type MockOrderRepository struct {
+	mock.Mock
+	usecases.OrderRepository
+}
+
+func (m *MockOrderRepository) Create(orderRequest *entities.OrderRequest) (string, error) {
+	args := m.Called(orderRequest)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockOrderRepository) GetPrices(productIds []string) (map[string]money.Amount, error) {
+	args := m.Called(productIds)
+	return args.Get(0).(map[string]money.Amount), args.Error(1)
+}
+
+func (m *MockOrderRepository) GetById(id string) (*entities.Order, error) {
+	args := m.Called(id)
+	return args.Get(0).(*entities.Order), args.Error(1)
+}
+
+func (m *MockOrderRepository) UpdateStatus(id string, status int) (*entities.Order, error) {
+	args := m.Called(id, status)
+	return args.Get(0).(*entities.Order), args.Error(1)
+}
+
+// MockPromotionRepository mocks the coupon lookups of the PromotionRepository
+type MockPromotionRepository struct {
+	mock.Mock
+	usecases.PromotionRepository
+}
+
+func (m *MockPromotionRepository) GetAutomatic(now time.Time) ([]*entities.Promotion, error) {
+	args := m.Called(now)
+	return args.Get(0).([]*entities.Promotion), args.Error(1)
+}
+
+func (m *MockPromotionRepository) GetByCoupon(code string) (*entities.Promotion, *entities.Coupon, error) {
+	args := m.Called(code)
+	return args.Get(0).(*entities.Promotion), args.Get(1).(*entities.Coupon), args.Error(2)
+}
+
+func (m *MockPromotionRepository) CouponUsage(couponId string, userId string) (int, int, error) {
+	args := m.Called(couponId, userId)
+	return args.Int(0), args.Int(1), args.Error(2)
+}
+
+const (
+	orderUser  = "451fa817-41f4-40cf-8dc2-c9f22aa98a4f"
+	otherUser  = "063d0ff7-e17e-4957-8d92-a988caeda8a1"
+	orderPhone = "6204037c-30e6-408b-8aaa-dd8219860b4b"
+)
+
+// newOrderRouter serves the order routes for orderUser, with a coupon the user already used up
+func newOrderRouter() (*gin.Engine, *MockOrderRepository, *MockPromotionRepository) {
+	gin.SetMode(gin.TestMode)
+	orders := new(MockOrderRepository)
+	promotions := new(MockPromotionRepository)
+	limit := 1
+	promotion := &entities.Promotion{Id: "p1", Name: "Welcome", Kind: entities.PromotionFixed, Value: 5 * money.One, Active: true}
+	coupon := &entities.Coupon{Id: "c1", PromotionId: "p1", Code: "WELCOME5", PerUserLimit: &limit, Active: true}
+	orders.On("GetPrices", []string{orderPhone}).Return(map[string]money.Amount{orderPhone: 20 * money.One}, nil)
+	promotions.On("GetAutomatic", mock.Anything).Return([]*entities.Promotion{}, nil)
+	promotions.On("GetByCoupon", "WELCOME5").Return(promotion, coupon, nil)
+	promotions.On("CouponUsage", "c1", orderUser).Return(1, 1, nil)
+	promotions.On("CouponUsage", "c1", otherUser).Return(1, 0, nil)
+
+	controller := &OrderController{
+		OrderUsecase:  &usecases.OrderUsecase{OrderRepo: orders, Promotions: &usecases.PromotionInteractor{PromotionRepository: promotions}},
+		CurrentUserId: func(*gin.Context) (string, error) { return orderUser, nil },
+	}
+	router := gin.New()
+	router.POST("/order", controller.Create)
+	router.POST("/order/quote", controller.Quote)
+	router.PUT("/order/:id/status", controller.UpdateStatus)
+	return router, orders, promotions
+}
+
+func postOrder(router *gin.Engine, target string, userId string) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(gin.H{
+		"user_id":       userId,
+		"coupon_code":   "WELCOME5",
+		"order_details": []gin.H{{"product_id": orderPhone, "quantity": 1, "unit_price": 20}},
+	})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", target, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestOrderController_CouponLimitOfTheCaller(t *testing.T) {
+	for _, target := range []string{"/order", "/order/quote"} {
+		router, orders, promotions := newOrderRouter()
+
+		// Another user id cannot be used to redeem the coupon again
+		w := postOrder(router, target, otherUser)
+		assert.Equal(t, http.StatusForbidden, w.Code, target)
+		promotions.AssertNotCalled(t, "CouponUsage", "c1", otherUser)
+
+		// Without a user id the order is placed for the caller, who used up the coupon
+		w = postOrder(router, target, "")
+		assert.Equal(t, http.StatusConflict, w.Code, target)
+		assert.Contains(t, w.Body.String(), "COUPON_EXHAUSTED", target)
+		promotions.AssertCalled(t, "CouponUsage", "c1", orderUser)
+		orders.AssertNotCalled(t, "Create", mock.Anything)
+	}
+}
+
+func putStatus(router *gin.Engine, id string, status int) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(gin.H{"status": status})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/order/"+id+"/status", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestOrderController_UpdateStatus_Customer(t *testing.T) {
+	const (
+		pending    = "0b7c6f1e-7c35-4d43-9a4a-6f1f3c2b9e01"
+		shipped    = "0b7c6f1e-7c35-4d43-9a4a-6f1f3c2b9e02"
+		otherOrder = "0b7c6f1e-7c35-4d43-9a4a-6f1f3c2b9e03"
+	)
+	router, orders, _ := newOrderRouter()
+	orders.On("GetById", pending).Return(&entities.Order{Id: pending, UserId: orderUser, Status: constants.OrderStatusCreated}, nil)
+	orders.On("GetById", shipped).Return(&entities.Order{Id: shipped, UserId: orderUser, Status: constants.OrderStatusProcessing}, nil)
+	orders.On("GetById", otherOrder).Return(&entities.Order{Id: otherOrder, UserId: otherUser, Status: constants.OrderStatusCreated}, nil)
+	orders.On("UpdateStatus", pending, constants.OrderStatusCancelled).Return(&entities.Order{Id: pending, UserId: orderUser, Status: constants.OrderStatusCancelled}, nil)
+
+	// A customer cannot complete their own order, or cancel it once it is processed
+	w := putStatus(router, pending, constants.OrderStatusCompleted)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = putStatus(router, shipped, constants.OrderStatusCancelled)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// The orders of other users are not found
+	w = putStatus(router, otherOrder, constants.OrderStatusCancelled)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	orders.AssertNumberOfCalls(t, "UpdateStatus", 0)
+
+	w = putStatus(router, pending, constants.OrderStatusCancelled)
+	assert.Equal(t, http.StatusOK, w.Code)
+	orders.AssertCalled(t, "UpdateStatus", pending, constants.OrderStatusCancelled)
+}
@@ -0,0 +1,123 @@
+// internal/adapters/controllers/price_controller.go
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/usecases"
+)
+
+type PriceController struct {
+	PriceInteractor *usecases.PriceInteractor
+	// Resolves the role of the caller, the price schedules are only visible to admins
+	CurrentRole func(*gin.Context) (string, error)
+}
+
+// GetPrices godoc
+// @Summary      Get the prices of a product
+// @Description  Responds with the current price of a product and a cursor paginated history of its price changes. Admins also get the pending and active price schedules.
+// @Tags         Prices
+// @Produce      json
+// @Param        id             path      string  true   "Product ID"
+// @Param        cursor         query     string  false  "Cursor of the page to fetch"
+// @Param        limit          query     int     false  "Page size"
+// @Param        sort           query     string  false  "Sort field (created_at), prefix with - for descending"
+// @Param        include_total  query     bool    false  "Include the total number of price changes"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /product/{id}/prices [get]
+// @Security apiKey
+func (pc *PriceController) GetPrices(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var uri entities.IdRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	var list entities.ListRequest
+	if err := c.ShouldBindQuery(&list); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	res, err := pc.PriceInteractor.GetPrices(uri.Id, &list, IsAdmin(c, pc.CurrentRole))
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+// SchedulePrice godoc
+// @Summary      Schedule a price change
+// @Description  Schedule a future price of a product, applied at starts_at and, when ends_at is set, reverted to the replaced price at ends_at. Schedules of a product may not overlap (admin only)
+// @Tags         Prices
+// @Accept       json
+// @Produce      json
+// @Param        id        path      string                         true  "Product ID"
+// @Param        schedule  body      entities.PriceScheduleRequest  true  "Price and period"
+// @Success      201       {object}  map[string]interface{}
+// @Failure      400       {object}  map[string]interface{}
+// @Failure      404       {object}  map[string]interface{}
+// @Failure      409       {object}  map[string]interface{}
+// @Router       /product/{id}/prices [post]
+// @Security apiKey
+func (pc *PriceController) SchedulePrice(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var uri entities.IdRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	var request entities.PriceScheduleRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	res, err := pc.PriceInteractor.SchedulePrice(uri.Id, &request)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+// CancelSchedule godoc
+// @Summary      Cancel a price schedule
+// @Description  Cancel a pending price schedule, or end an active one restoring the price it replaced (admin only)
+// @Tags         Prices
+// @Produce      json
+// @Param        id           path      string  true  "Product ID"
+// @Param        schedule_id  path      string  true  "Price schedule ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /product/{id}/prices/{schedule_id} [delete]
+// @Security apiKey
+func (pc *PriceController) CancelSchedule(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var uri entities.PriceScheduleIdRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	res, err := pc.PriceInteractor.CancelSchedule(uri.Id, uri.ScheduleId)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
@@ -0,0 +1,156 @@
+// internal/adapters/controllers/product_bundle_controller.go
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/utils"
+)
+
+// GetBundle godoc
+// @Summary      Get the bundle of a product
+// @Description  Responds with a bundle product, its components with their quantities and whether the component stock makes up a bundle. The available quantity is the number of bundles the stock of the components makes up, null when no component is stock tracked.
+// @Tags         Bundles
+// @Produce      json
+// @Param        id        path      string  true   "Product ID"
+// @Param        currency  query     string  false  "Currency of the prices, overrides the Accept-Currency header"
+// @Param        locale    query     string  false  "Locale of the names and descriptions, overrides the Accept-Language header"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /product/{id}/bundle [get]
+// @Security apiKey
+func (uc *ProductController) GetBundle(c *gin.Context) {
+	AddRequestHeader(c)
+
+	id, ok := bundleId(c)
+	if !ok {
+		return
+	}
+
+	currency, err := resolveCurrency(c, uc.CurrencyInteractor)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+	locales, err := resolveLocales(c, uc.LocaleInteractor)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	res, err := uc.ProductInteractor.GetBundle(id)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+	uc.bundleResponse(c, currency, locales, res)
+}
+
+// SetBundle godoc
+// @Summary      Make a product a bundle
+// @Description  Set the components of a bundle product and their quantities. With a discount the bundle is priced at the components less the discount and follows their price changes, without one it keeps the price of the product. Ordering a bundle reserves the stock of its components (admin only)
+// @Tags         Bundles
+// @Accept       json
+// @Produce      json
+// @Param        id        path      string                  true   "Product ID"
+// @Param        bundle    body      entities.BundleRequest  true   "Bundle"
+// @Param        currency  query     string                  false  "Currency of the prices, overrides the Accept-Currency header"
+// @Param        locale    query     string                  false  "Locale of the names and descriptions, overrides the Accept-Language header"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /product/{id}/bundle [put]
+// @Security apiKey
+func (uc *ProductController) SetBundle(c *gin.Context) {
+	AddRequestHeader(c)
+
+	id, ok := bundleId(c)
+	if !ok {
+		return
+	}
+	var request entities.BundleRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	currency, err := resolveCurrency(c, uc.CurrencyInteractor)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+	locales, err := resolveLocales(c, uc.LocaleInteractor)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	res, err := uc.ProductInteractor.SetBundle(id, &request)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+	uc.bundleResponse(c, currency, locales, res)
+}
+
+// DeleteBundle godoc
+// @Summary      Remove the bundle of a product
+// @Description  Make a bundle a plain product again, it keeps its last price. The orders placed before keep their component lines (admin only)
+// @Tags         Bundles
+// @Produce      json
+// @Param        id   path      string  true  "Product ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /product/{id}/bundle [delete]
+// @Security apiKey
+func (uc *ProductController) DeleteBundle(c *gin.Context) {
+	AddRequestHeader(c)
+
+	id, ok := bundleId(c)
+	if !ok {
+		return
+	}
+
+	if err := uc.ProductInteractor.DeleteBundle(id); err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "msg": nil})
+}
+
+func (uc *ProductController) bundleResponse(c *gin.Context, currency *entities.Currency, locales []string, res *entities.Bundle) {
+	products := []*entities.Product{res.Product}
+	for _, component := range res.Components {
+		products = append(products, component.Product)
+	}
+	if currency != nil {
+		if err := uc.CurrencyInteractor.ConvertProducts(currency, products...); err != nil {
+			ErrorResponse(c, err)
+			return
+		}
+		res.SumComponents()
+	}
+	if locales != nil {
+		if err := uc.LocaleInteractor.LocalizeProducts(locales, products...); err != nil {
+			ErrorResponse(c, err)
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+// bundleId binds the product id of the path, responding with a bad request when it is not a UUID
+func bundleId(c *gin.Context) (string, bool) {
+	var uri entities.IdRequest
+	if err := c.ShouldBindUri(&uri); err != nil || !utils.IsValidUUID(uri.Id) {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": "Invalid product id."})
+		return "", false
+	}
+	return uri.Id, true
+}
@@ -4,8 +4,6 @@ package controllers
 import (
 	"fmt"
 	"net/http"
-	"strconv"
-	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/shayja/go-template-api/internal/entities"
@@ -15,39 +13,155 @@ import (
 
 type ProductController struct {
 	ProductInteractor usecases.ProductInteractor
+	// Resolves the role of the caller, soft deleted products are only visible to admins
+	CurrentRole func(*gin.Context) (string, error)
+	// Optional, converts the prices to the currency the caller asked for
+	CurrencyInteractor *usecases.CurrencyInteractor
+	// Optional, serves the names and descriptions in the locale the caller asked for
+	LocaleInteractor *usecases.LocaleInteractor
+	// Rejects the product writes without an If-Match header
+	IfMatchRequired bool
 }
 
 // GetAll godoc
 // @Summary      Get all products
-// @Description  Retrieve a paginated list of all products
+// @Description  Retrieve a cursor paginated list of products, optionally searched and filtered
 // @Tags         Products
-// @Param        page  query     int  true  "Page number"
+// @Param        q              query     string  false  "Full-text search over name, description and SKU"
+// @Param        min_price      query     number  false  "Minimum price"
+// @Param        max_price      query     number  false  "Maximum price"
+// @Param        sku_prefix     query     string  false  "SKU prefix (case-insensitive)"
+// @Param        created_from   query     string  false  "Created on or after (RFC 3339)"
+// @Param        created_to     query     string  false  "Created on or before (RFC 3339)"
+// @Param        updated_from   query     string  false  "Updated on or after (RFC 3339)"
+// @Param        updated_to     query     string  false  "Updated on or before (RFC 3339)"
+// @Param        tag            query     []string  false  "Products with all of these tags"  collectionFormat(multi)
+// @Param        attr.{code}    query     string  false  "Products with this attribute value, e.g. attr.brand=Acme, repeat for any of several values"
+// @Param        cursor         query     string  false  "Cursor of the page to fetch"
+// @Param        limit          query     int     false  "Page size"
+// @Param        sort           query     string  false  "Sort field (name, price, sku, created_at, updated_at, rating, relevance), prefix with - for descending"
+// @Param        include_total  query     bool    false  "Include the total number of matching products"
+// @Param        include_facets query     bool    false  "Include the number of matching products per category, price range, tag and attribute value"
+// @Param        include_deleted  query   bool    false  "Include soft deleted products (admin only)"
+// @Param        currency       query     string  false  "Currency of the amounts, overrides the Accept-Currency header"
+// @Param        locale         query     string  false  "Locale of the names and descriptions, overrides the Accept-Language header"
 // @Success      200   {object}  map[string]interface{}
 // @Failure      400   {object}  map[string]interface{}
-// @Failure      404   {object}  map[string]interface{}
+// @Failure      403   {object}  map[string]interface{}
+// @Failure      500   {object}  map[string]interface{}
 // @Router       /product [get]
 // @Security apiKey
 func (uc *ProductController) GetAll(c *gin.Context) {
 	AddRequestHeader(c)
-	page, err := strconv.Atoi(c.Query("page"))
 
+	var filter entities.ProductFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+	filter.Attributes = attributeFilter(c)
+	if !filter.Validate() {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": "Invalid filter range."})
+		return
+	}
+	if filter.IncludeDeleted && !IsAdmin(c, uc.CurrentRole) {
+		c.JSON(http.StatusForbidden, gin.H{"status": "failed", "msg": "Insufficient permissions"})
+		return
+	}
+
+	currency, err := resolveCurrency(c, uc.CurrencyInteractor)
 	if err != nil {
-		panic(err)
+		ErrorResponse(c, err)
+		return
+	}
+	locales, err := resolveLocales(c, uc.LocaleInteractor)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
 	}
 
-	res, err := uc.ProductInteractor.GetAll(page)
+	res, err := uc.ProductInteractor.Search(&filter)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err})
+		ErrorResponse(c, err)
 		return
 	}
+	if currency != nil {
+		if err := uc.CurrencyInteractor.ConvertProducts(currency, res.Items...); err != nil {
+			ErrorResponse(c, err)
+			return
+		}
+	}
+	if locales != nil {
+		if err := uc.LocaleInteractor.LocalizeProducts(locales, res.Items...); err != nil {
+			ErrorResponse(c, err)
+			return
+		}
+	}
 
-	if res != nil {
-		c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+// SemanticSearch godoc
+// @Summary      Search products by meaning
+// @Description  Find the products most similar in meaning to the query by their name and description embeddings, optionally blended with the full-text rank
+// @Tags         Products
+// @Produce      json
+// @Param        q            query     string  true   "What to find, in natural language"
+// @Param        text_weight  query     number  false  "Weight of the full-text rank in the score, from 0 (similarity only) to 1"
+// @Param        min_price    query     number  false  "Minimum price"
+// @Param        max_price    query     number  false  "Maximum price"
+// @Param        category     query     string  false  "Category slug, including its descendants"
+// @Param        limit        query     int     false  "Number of products"
+// @Param        currency     query     string  false  "Currency of the prices, overrides the Accept-Currency header"
+// @Param        locale       query     string  false  "Locale of the names and descriptions, overrides the Accept-Language header"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      502  {object}  map[string]interface{}
+// @Router       /product/search/semantic [get]
+// @Security apiKey
+func (uc *ProductController) SemanticSearch(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var request entities.SemanticSearchRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
 		return
-	} else {
-		c.JSON(404, gin.H{"status": "failed", "data": nil, "msg": "products not found for this page."})
+	}
+
+	currency, err := resolveCurrency(c, uc.CurrencyInteractor)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+	locales, err := resolveLocales(c, uc.LocaleInteractor)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	res, err := uc.ProductInteractor.SemanticSearch(c.Request.Context(), &request)
+	if err != nil {
+		ErrorResponse(c, err)
 		return
 	}
+	products := make([]*entities.Product, len(res))
+	for i, match := range res {
+		products[i] = match.Product
+	}
+	if currency != nil {
+		if err := uc.CurrencyInteractor.ConvertProducts(currency, products...); err != nil {
+			ErrorResponse(c, err)
+			return
+		}
+	}
+	if locales != nil {
+		if err := uc.LocaleInteractor.LocalizeProducts(locales, products...); err != nil {
+			ErrorResponse(c, err)
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
 }
 
 // GetById godoc
@@ -55,8 +169,14 @@ func (uc *ProductController) GetAll(c *gin.Context) {
 // @Description  Retrieve product details by product ID
 // @Tags         Products
 // @Param        id   path      string  true  "Product ID"
+// @Param        include_deleted  query  bool  false  "Return the product even if it is soft deleted (admin only)"
+// @Param        currency  query  string  false  "Currency of the prices, overrides the Accept-Currency header"
+// @Param        locale    query  string  false  "Locale of the names and descriptions, overrides the Accept-Language header"
+// @Param        If-None-Match  header  string  false  "ETag of a previous response, answered with 304 while the product is unchanged"
 // @Success      200  {object}  map[string]interface{}
+// @Success      304  "The product did not change"
 // @Failure      400  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]interface{}
 // @Failure      404  {object}  map[string]interface{}
 // @Router       /product/{id} [get]
 // @Security apiKey
@@ -69,19 +189,167 @@ func (uc *ProductController) GetById(c *gin.Context) {
 		return
 	}
 
-	res, err := uc.ProductInteractor.GetById(uri.Id)
+	var lookup entities.ProductLookupRequest
+	if err := c.ShouldBindQuery(&lookup); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+	if lookup.IncludeDeleted && !IsAdmin(c, uc.CurrentRole) {
+		c.JSON(http.StatusForbidden, gin.H{"status": "failed", "msg": "Insufficient permissions"})
+		return
+	}
+
+	currency, err := resolveCurrency(c, uc.CurrencyInteractor)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err})
+		ErrorResponse(c, err)
+		return
+	}
+	locales, err := resolveLocales(c, uc.LocaleInteractor)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	res, err := uc.ProductInteractor.Find(uri.Id, lookup.IncludeDeleted)
+	if err != nil {
+		ErrorResponse(c, err)
 		return
 	}
+	if currency != nil && utils.IsValidUUID(res.Id) {
+		if err := uc.CurrencyInteractor.ConvertProducts(currency, res); err != nil {
+			ErrorResponse(c, err)
+			return
+		}
+	}
+	if locales != nil && utils.IsValidUUID(res.Id) {
+		if err := uc.LocaleInteractor.LocalizeProducts(locales, res); err != nil {
+			ErrorResponse(c, err)
+			return
+		}
+	}
 
 	if utils.IsValidUUID(res.Id) {
+		if NotModified(c, LocaleETag(VersionETag(res.Version, currency), res.Locale)) {
+			return
+		}
 		c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
 	} else {
 		c.JSON(http.StatusNotFound, gin.H{"status": "success", "data": nil, "msg": "product not found"})
 	}
 }
 
+// GetBySku godoc
+// @Summary      Get a product by SKU
+// @Description  Retrieve the details of the active product with the SKU, regardless of case
+// @Tags         Products
+// @Param        sku       path      string  true   "Product SKU"
+// @Param        currency  query     string  false  "Currency of the prices, overrides the Accept-Currency header"
+// @Param        locale    query     string  false  "Locale of the names and descriptions, overrides the Accept-Language header"
+// @Param        If-None-Match  header  string  false  "ETag of a previous response, answered with 304 while the product is unchanged"
+// @Success      200  {object}  map[string]interface{}
+// @Success      304  "The product did not change"
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /product/sku/{sku} [get]
+// @Security apiKey
+func (uc *ProductController) GetBySku(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var uri entities.SkuRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	currency, err := resolveCurrency(c, uc.CurrencyInteractor)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+	locales, err := resolveLocales(c, uc.LocaleInteractor)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	res, err := uc.ProductInteractor.GetBySku(uri.Sku)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+	if currency != nil {
+		if err := uc.CurrencyInteractor.ConvertProducts(currency, res); err != nil {
+			ErrorResponse(c, err)
+			return
+		}
+	}
+	if locales != nil {
+		if err := uc.LocaleInteractor.LocalizeProducts(locales, res); err != nil {
+			ErrorResponse(c, err)
+			return
+		}
+	}
+
+	if NotModified(c, LocaleETag(VersionETag(res.Version, currency), res.Locale)) {
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+// LookupSkus godoc
+// @Summary      Get products by SKUs
+// @Description  Retrieve the active products with the SKUs, regardless of case, in the requested order. The SKUs no product uses are listed as missing.
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Param        request   body      entities.SkuLookupRequest  true   "Up to 100 SKUs"
+// @Param        currency  query     string                     false  "Currency of the prices, overrides the Accept-Currency header"
+// @Param        locale    query     string                     false  "Locale of the names and descriptions, overrides the Accept-Language header"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Router       /product/sku/lookup [post]
+// @Security apiKey
+func (uc *ProductController) LookupSkus(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var request entities.SkuLookupRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	currency, err := resolveCurrency(c, uc.CurrencyInteractor)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+	locales, err := resolveLocales(c, uc.LocaleInteractor)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	res, err := uc.ProductInteractor.LookupSkus(&request)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+	if currency != nil {
+		if err := uc.CurrencyInteractor.ConvertProducts(currency, res.Items...); err != nil {
+			ErrorResponse(c, err)
+			return
+		}
+	}
+	if locales != nil {
+		if err := uc.LocaleInteractor.LocalizeProducts(locales, res.Items...); err != nil {
+			ErrorResponse(c, err)
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
 // Create godoc
 // @Summary      Create a new product
 // @Description  Add a new product to the inventory
@@ -89,6 +357,7 @@ func (uc *ProductController) GetById(c *gin.Context) {
 // @Param        product  body      entities.ProductRequest  true  "Product data"
 // @Success      201      {object}  map[string]interface{}
 // @Failure      400      {object}  map[string]interface{}
+// @Failure      409      {object}  map[string]interface{}
 // @Router       /product [post]
 // @Security apiKey
 func (uc *ProductController) Create(c *gin.Context) {
@@ -99,11 +368,15 @@ func (uc *ProductController) Create(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err})
 		return
 	}
+	if errs := post.Validate(); len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": errs})
+		return
+	}
 
 	insertedId, err := uc.ProductInteractor.Create(post)
 
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err})
+		ErrorResponse(c, err)
 		return
 	}
 
@@ -120,8 +393,13 @@ func (uc *ProductController) Create(c *gin.Context) {
 // @Tags         Products
 // @Param        id       path      string                  true  "Product ID"
 // @Param        product  body      entities.ProductRequest true  "Updated product data"
+// @Param        If-Match header    string                  false "ETag of the product version the update applies to"
 // @Success      200      {object}  map[string]interface{}
 // @Failure      400      {object}  map[string]interface{}
+// @Failure      404      {object}  map[string]interface{}
+// @Failure      409      {object}  map[string]interface{}
+// @Failure      412      {object}  map[string]interface{}
+// @Failure      428      {object}  map[string]interface{}
 // @Router       /product/{id} [put]
 // @Security apiKey
 func (uc *ProductController) Update(c *gin.Context) {
@@ -134,6 +412,10 @@ func (uc *ProductController) Update(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err})
 		return
 	}
+	if errs := product.Validate(); len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": errs})
+		return
+	}
 
 	var uri entities.IdRequest
 	if err := c.ShouldBindUri(&uri); err != nil {
@@ -141,13 +423,20 @@ func (uc *ProductController) Update(c *gin.Context) {
 		return
 	}
 
-	res, err := uc.ProductInteractor.Update(uri.Id, product)
+	version, err := IfMatchVersion(c, uc.IfMatchRequired)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err})
+		ErrorResponse(c, err)
+		return
+	}
+
+	res, err := uc.ProductInteractor.Update(uri.Id, product, version)
+	if err != nil {
+		ErrorResponse(c, err)
 		return
 	}
 
 	if utils.IsValidUUID(res.Id) {
+		c.Header("ETag", VersionETag(res.Version, nil))
 		c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
 	} else {
 		c.JSON(http.StatusInternalServerError, gin.H{"status": "failed", "data": nil, "msg": "update product failed"})
@@ -156,15 +445,18 @@ func (uc *ProductController) Update(c *gin.Context) {
 
 //change a specific product price
 // @Summary Update Product Price
-// @Description Update the price of a specific product by ID
+// @Description Update the price of a specific product by ID. A bundle with a discount keeps the price of its components
 // @Tags Products
 // @Accept json
 // @Produce json
 // @Param id path string true "Product ID"
 // @Param price body entities.ProductPriceRequest true "Product Price"
+// @Param If-Match header string false "ETag of the product version the update applies to"
 // @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} map[string]interface{}
 // @Failure 404 {object} map[string]interface{}
+// @Failure 412 {object} map[string]interface{}
+// @Failure 428 {object} map[string]interface{}
 // @Router /products/{id}/price [put]
 // @Security apiKey
 func (uc *ProductController) UpdatePrice(c *gin.Context){
@@ -194,13 +486,20 @@ func (uc *ProductController) UpdatePrice(c *gin.Context){
         return
     }
 
-    res, err := uc.ProductInteractor.UpdatePrice(uri.Id, price)
+	version, err := IfMatchVersion(c, uc.IfMatchRequired)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err})
+		ErrorResponse(c, err)
+		return
+	}
+
+    res, err := uc.ProductInteractor.UpdatePrice(uri.Id, price, version)
+	if err != nil {
+		ErrorResponse(c, err)
 		return
 	}
 
 	if utils.IsValidUUID(res.Id)  {
+		c.Header("ETag", VersionETag(res.Version, nil))
 		c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
 	} else {
 		c.JSON(http.StatusInternalServerError, gin.H{"status": "failed", "data": nil, "msg": "update product failed"})
@@ -208,112 +507,115 @@ func (uc *ProductController) UpdatePrice(c *gin.Context){
 }
 
 // @Summary Update Product Image
-// @Description Upload and update the image of a specific product by ID
+// @Description Upload a JPEG, PNG, GIF or WebP image, it becomes the product image once its renditions are processed (admin only)
 // @Tags Products
 // @Accept multipart/form-data
 // @Produce json
 // @Param id path string true "Product ID"
 // @Param image formData file true "Product Image"
-// @Success 201 {object} map[string]interface{}
+// @Success 202 {object} map[string]interface{}
 // @Failure 400 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
-// @Router /products/{id}/image [put]
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 413 {object} map[string]interface{}
+// @Failure 502 {object} map[string]interface{}
+// @Router /product/image/{id} [post]
 // @Security apiKey
-func (uc *ProductController) UpdateImage(c *gin.Context){
+func (uc *ProductController) UpdateImage(c *gin.Context) {
 	AddRequestHeader(c)
-    var uri entities.IdRequest
+
+	var uri entities.IdRequest
 	if err := c.ShouldBindUri(&uri); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err})
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
 		return
 	}
 
 	file, err := c.FormFile("image")
-   
 	if err != nil {
-		fmt.Print("Error in uploading Image : ", err)
-	  	c.JSON(http.StatusInternalServerError, gin.H{"status": "failed", "data": nil, "msg": "update product failed"})
-	}
-	
-	 uniqueId := utils.CreateNewUUID()
-
-	 filename := strings.Replace(uniqueId.String(), "-", "", -1)
-	
-	 fileExt := strings.Split(file.Filename, ".")[1]
-	
-	 imageName := fmt.Sprintf("%s.%s", filename, fileExt)
-	
-	//todo: Add file size/dimentions and file ext. validation here.
-	// Just for the demo, Do not use this for any real world solution. 
-	// Do not store uploaded files on your web server, use AWS/GCP cloud bucket instead.
-	 err = c.SaveUploadedFile(file, fmt.Sprintf("./images/%s", imageName))
-	
-	 if err != nil {
-		fmt.Print("Error in saving Image :", err)
-	  c.JSON(http.StatusInternalServerError, gin.H{"status": "failed", "msg": "Error in saving Image"})
-	 }
-	
-	 
-	 imageUrl := fmt.Sprintf("http://YOUR_DOMAIN_HERE.com/images/%s", imageName)
-	
-	 data := map[string]interface{}{
-	
-	  "imageName": imageName,
-	  "imageUrl":  imageUrl,
-	  "header":    file.Header,
-	  "size":      file.Size,
-	 }
-	
-	 var image *entities.ProductImageRequest
-	 if err := c.ShouldBind(&image); err != nil {
-		 c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err})
-		 return
-	 }
- 
-	 
-	 
-	 res, err := uc.ProductInteractor.UpdateImage(uri.Id, image)
-	 if err != nil {
-		 c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err})
-		 return
-	 }
-	fmt.Print("UpdateImage res :", res)
-
-
-	 c.JSON(http.StatusCreated, gin.H{"status": "success", "data": "Image uploaded successfully", "msg": data})
-   }
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": "The image file is required."})
+		return
+	}
+
+	content, err := file.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+	defer content.Close()
+
+	res, err := uc.ProductInteractor.UploadImage(uri.Id, &entities.ImageUpload{Filename: file.Filename, Size: file.Size, Content: content})
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"status": "success", "data": res, "msg": nil})
+}
 
 // Delete implements ProductController Interface
 // @Summary Delete Product
-// @Description Delete a specific product by ID
+// @Description Soft delete a specific product by ID, it is hidden from the catalog until restored
 // @Tags Products
 // @Produce json
 // @Param id path string true "Product ID"
+// @Param If-Match header string false "ETag of the product version the deletion applies to"
 // @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 412 {object} map[string]interface{}
+// @Failure 428 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
-// @Router /products/{id} [delete]
+// @Router /product/{id} [delete]
 // @Security apiKey
 func (uc *ProductController) Delete(c *gin.Context) {
 	AddRequestHeader(c)
-	
+
 	var uri entities.IdRequest
 	if err := c.ShouldBindUri(&uri); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err})
 		return
 	}
-	
-	res, err := uc.ProductInteractor.Delete(uri.Id)
 
+	version, err := IfMatchVersion(c, uc.IfMatchRequired)
 	if err != nil {
-		fmt.Print("Error in Delete product: ", err)
-	  	c.JSON(http.StatusInternalServerError, gin.H{"status": "failed", "data": nil, "msg": "Delete product failed"})
+		ErrorResponse(c, err)
+		return
+	}
+
+	if _, err := uc.ProductInteractor.Delete(uri.Id, version); err != nil {
+		ErrorResponse(c, err)
+		return
 	}
-	
-	if res {
-		c.JSON(http.StatusOK, gin.H{"status": "success", "msg": nil})
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "msg": nil})
+}
+
+// Restore godoc
+// @Summary      Restore a deleted product
+// @Description  Bring a soft deleted product back to the catalog (admin only)
+// @Tags         Products
+// @Produce      json
+// @Param        id   path      string  true  "Product ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      409  {object}  map[string]interface{}
+// @Router       /product/{id}/restore [post]
+// @Security apiKey
+func (uc *ProductController) Restore(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var uri entities.IdRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
 		return
-	} else {
-		c.JSON(http.StatusInternalServerError, gin.H{"status": "failed", "msg": "delete product failed"})
+	}
+
+	res, err := uc.ProductInteractor.Restore(uri.Id)
+	if err != nil {
+		ErrorResponse(c, err)
 		return
 	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
 }
@@ -0,0 +1,77 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/usecases"
+)
+
+// MockProductRepository mocks the product reads of the ProductRepository
+type MockProductRepository struct {
+	mock.Mock
+	usecases.ProductRepository
+}
+
+func (m *MockProductRepository) GetById(id string) (*entities.Product, error) {
+	args := m.Called(id)
+	return args.Get(0).(*entities.Product), args.Error(1)
+}
+
+// MockCategoryRepository mocks the breadcrumbs of the CategoryRepository
+type MockCategoryRepository struct {
+	mock.Mock
+	usecases.CategoryRepository
+}
+
+func (m *MockCategoryRepository) GetBreadcrumbs(productIds []string) (map[string][][]entities.CategoryCrumb, error) {
+	args := m.Called(productIds)
+	return args.Get(0).(map[string][][]entities.CategoryCrumb), args.Error(1)
+}
+
+const shoeId = "8f2a6c1e-3b4d-4e5f-9a0b-1c2d3e4f5a6b"
+
+func getProduct(router *gin.Engine, ifNoneMatch string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/product/"+shoeId, nil)
+	if ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestProductController_GetById_CategoryRenamed(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	products := new(MockProductRepository)
+	categories := new(MockCategoryRepository)
+	controller := &ProductController{ProductInteractor: usecases.ProductInteractor{ProductRepository: products, CategoryRepository: categories}}
+	router := gin.New()
+	router.GET("/product/:id", controller.GetById)
+
+	crumbs := func(name string) map[string][][]entities.CategoryCrumb {
+		return map[string][][]entities.CategoryCrumb{shoeId: {{{Id: "c1", Name: name, Slug: "shoes"}}}}
+	}
+	products.On("GetById", shoeId).Return(&entities.Product{Id: shoeId, Name: "Running shoe", Version: 3}, nil).Once()
+	categories.On("GetBreadcrumbs", []string{shoeId}).Return(crumbs("Shoes"), nil).Once()
+
+	w := getProduct(router, "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	etag := w.Header().Get("ETag")
+	assert.Equal(t, `"3"`, etag)
+
+	// Renaming the category moves its products to the next version, the cached copy is stale
+	products.On("GetById", shoeId).Return(&entities.Product{Id: shoeId, Name: "Running shoe", Version: 4}, nil).Once()
+	categories.On("GetBreadcrumbs", []string{shoeId}).Return(crumbs("Footwear"), nil).Once()
+
+	w = getProduct(router, etag)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotEqual(t, etag, w.Header().Get("ETag"))
+	assert.Contains(t, w.Body.String(), "Footwear")
+}
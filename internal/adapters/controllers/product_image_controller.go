@@ -0,0 +1,194 @@
+// internal/adapters/controllers/product_image_controller.go
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shayja/go-template-api/internal/entities"
+)
+
+// GetImages godoc
+// @Summary      Get the gallery of a product
+// @Description  Responds with the images of a product in display order, each with its renditions. Admins also get signed links to the sources of the pending uploads.
+// @Tags         Product Images
+// @Produce      json
+// @Param        id   path      string  true  "Product ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /product/{id}/images [get]
+// @Security apiKey
+func (uc *ProductController) GetImages(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var uri entities.IdRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	res, err := uc.ProductInteractor.GetImages(uri.Id, IsAdmin(c, uc.CurrentRole))
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+// AddImage godoc
+// @Summary      Upload a gallery image
+// @Description  Upload a JPEG, PNG, GIF or WebP image to the end of the product gallery, its renditions are processed in the background (admin only)
+// @Tags         Product Images
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        id        path      string  true   "Product ID"
+// @Param        image     formData  file    true   "Image file"
+// @Param        alt_text  formData  string  false  "Alternative text"
+// @Param        primary   formData  bool    false  "Make it the product image"
+// @Success      202       {object}  map[string]interface{}
+// @Failure      400       {object}  map[string]interface{}
+// @Failure      404       {object}  map[string]interface{}
+// @Failure      413       {object}  map[string]interface{}
+// @Failure      502       {object}  map[string]interface{}
+// @Router       /product/{id}/images [post]
+// @Security apiKey
+func (uc *ProductController) AddImage(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var uri entities.IdRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	var details entities.ProductImageUpdate
+	if err := c.ShouldBind(&details); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	file, err := c.FormFile("image")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": "The image file is required."})
+		return
+	}
+
+	content, err := file.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+	defer content.Close()
+
+	res, err := uc.ProductInteractor.AddImage(uri.Id, &entities.ImageUpload{Filename: file.Filename, Size: file.Size, Content: content}, &details)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+// UpdateGalleryImage godoc
+// @Summary      Update a gallery image
+// @Description  Change the alternative text of a gallery image or make it the product image (admin only)
+// @Tags         Product Images
+// @Accept       json
+// @Produce      json
+// @Param        id        path      string                       true  "Product ID"
+// @Param        image_id  path      string                       true  "Image ID"
+// @Param        image     body      entities.ProductImageUpdate  true  "Image details"
+// @Success      200       {object}  map[string]interface{}
+// @Failure      400       {object}  map[string]interface{}
+// @Failure      404       {object}  map[string]interface{}
+// @Router       /product/{id}/images/{image_id} [patch]
+// @Security apiKey
+func (uc *ProductController) UpdateGalleryImage(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var uri entities.ImageIdRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	var update entities.ProductImageUpdate
+	if err := c.ShouldBindJSON(&update); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	res, err := uc.ProductInteractor.UpdateGalleryImage(uri.Id, uri.ImageId, &update)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+// ReorderImages godoc
+// @Summary      Reorder the gallery of a product
+// @Description  Put the gallery images in the order of the given ids, which must list every image of the product (admin only)
+// @Tags         Product Images
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string                             true  "Product ID"
+// @Param        order  body      entities.ProductImageOrderRequest  true  "Image ids in display order"
+// @Success      200    {object}  map[string]interface{}
+// @Failure      400    {object}  map[string]interface{}
+// @Failure      404    {object}  map[string]interface{}
+// @Router       /product/{id}/images [put]
+// @Security apiKey
+func (uc *ProductController) ReorderImages(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var uri entities.IdRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	var order entities.ProductImageOrderRequest
+	if err := c.ShouldBindJSON(&order); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	res, err := uc.ProductInteractor.ReorderImages(uri.Id, order.ImageIds)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+// DeleteImage godoc
+// @Summary      Delete a gallery image
+// @Description  Remove an image from the gallery and the storage, the next image becomes primary when it was the product image (admin only)
+// @Tags         Product Images
+// @Produce      json
+// @Param        id        path      string  true  "Product ID"
+// @Param        image_id  path      string  true  "Image ID"
+// @Success      200       {object}  map[string]interface{}
+// @Failure      404       {object}  map[string]interface{}
+// @Router       /product/{id}/images/{image_id} [delete]
+// @Security apiKey
+func (uc *ProductController) DeleteImage(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var uri entities.ImageIdRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	if _, err := uc.ProductInteractor.DeleteImage(uri.Id, uri.ImageId); err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "msg": nil})
+}
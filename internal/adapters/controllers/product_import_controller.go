@@ -0,0 +1,132 @@
+// internal/adapters/controllers/product_import_controller.go
+package controllers
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shayja/go-template-api/internal/entities"
+)
+
+// Content types of the import and export formats
+var formatContentTypes = map[string]string{
+	entities.FormatCSV:   "text/csv; charset=utf-8",
+	entities.FormatJSONL: "application/x-ndjson",
+}
+
+// Import godoc
+// @Summary      Import products
+// @Description  Create or update products by SKU from a CSV file with a header row (sku, name, description, price, image) or a JSON Lines file of product objects, sent as the file field of a multipart form or as the request body. Every row is validated like a single product and reported. A transactional import saves nothing unless every row succeeds, a best effort import saves the valid rows only (admin only)
+// @Tags         Products
+// @Accept       multipart/form-data
+// @Accept       text/csv
+// @Accept       application/x-ndjson
+// @Produce      json
+// @Param        file     formData  file    false  "CSV or JSON Lines file"
+// @Param        format   query     string  false  "File format (csv, jsonl), detected from the file name or content type when omitted"
+// @Param        dry_run  query     bool    false  "Validate and report without saving anything"
+// @Param        mode     query     string  false  "transactional (default) or best_effort"
+// @Success      200      {object}  map[string]interface{}
+// @Failure      400      {object}  map[string]interface{}
+// @Failure      413      {object}  map[string]interface{}
+// @Router       /product/import [post]
+// @Security apiKey
+func (uc *ProductController) Import(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var options entities.ProductImportRequest
+	if err := c.ShouldBindQuery(&options); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	var content io.Reader = c.Request.Body
+	contentType, filename := c.ContentType(), ""
+	if contentType == "multipart/form-data" {
+		file, err := c.FormFile("file")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": "The import file is required."})
+			return
+		}
+		opened, err := file.Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+			return
+		}
+		defer opened.Close()
+		content, contentType, filename = opened, file.Header.Get("Content-Type"), file.Filename
+	}
+	if options.Format == "" {
+		options.Format = importFormat(filename, contentType)
+	}
+
+	res, err := uc.ProductInteractor.ImportProducts(content, &options)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+// importFormat detects the format of an imported file from its extension, then its content type
+func importFormat(filename string, contentType string) string {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return entities.FormatCSV
+	case ".jsonl", ".ndjson":
+		return entities.FormatJSONL
+	}
+	mediaType, _, _ := strings.Cut(strings.ToLower(contentType), ";")
+	switch strings.TrimSpace(mediaType) {
+	case "text/csv", "application/csv":
+		return entities.FormatCSV
+	case "application/x-ndjson", "application/jsonl", "application/x-jsonlines":
+		return entities.FormatJSONL
+	}
+	return ""
+}
+
+// Export godoc
+// @Summary      Export products
+// @Description  Stream every active product ordered by SKU as a CSV file or JSON Lines, in the columns an import reads (admin only)
+// @Tags         Products
+// @Produce      text/csv
+// @Produce      application/x-ndjson
+// @Param        format  query  string  false  "File format (csv, jsonl), defaults to csv"
+// @Success      200
+// @Failure      400  {object}  map[string]interface{}
+// @Router       /product/export [get]
+// @Security apiKey
+func (uc *ProductController) Export(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var options entities.ProductExportRequest
+	if err := c.ShouldBindQuery(&options); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+	if options.Format == "" {
+		options.Format = entities.FormatCSV
+	}
+
+	c.Header("Content-Type", formatContentTypes[options.Format])
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="products-%s.%s"`, time.Now().UTC().Format("20060102"), options.Format))
+	c.Status(http.StatusOK)
+
+	if err := uc.ProductInteractor.ExportProducts(c.Writer, options.Format); err != nil {
+		if c.Writer.Written() {
+			// The response is already streaming, the client gets a truncated file
+			fmt.Print(err)
+			return
+		}
+		c.Writer.Header().Del("Content-Disposition")
+		AddRequestHeader(c)
+		ErrorResponse(c, err)
+	}
+}
@@ -0,0 +1,27 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shayja/go-template-api/internal/entities"
+)
+
+func TestImportFormat(t *testing.T) {
+	tests := []struct {
+		filename    string
+		contentType string
+		want        string
+	}{
+		{"products.CSV", "application/octet-stream", entities.FormatCSV},
+		{"products.jsonl", "", entities.FormatJSONL},
+		{"products.ndjson", "text/csv", entities.FormatJSONL},
+		{"", "text/csv; charset=utf-8", entities.FormatCSV},
+		{"", "application/x-ndjson", entities.FormatJSONL},
+		{"products.txt", "text/plain", ""},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.want, importFormat(test.filename, test.contentType), test.filename+" "+test.contentType)
+	}
+}
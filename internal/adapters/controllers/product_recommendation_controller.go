@@ -0,0 +1,119 @@
+// internal/adapters/controllers/product_recommendation_controller.go
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/utils"
+)
+
+// Related godoc
+// @Summary      Get the related products of a product
+// @Description  Responds with the products most often bought together with the product or sharing its categories and tags, best score first. Deleted products and products out of stock are left out.
+// @Tags         Recommendations
+// @Produce      json
+// @Param        id        path      string  true   "Product ID"
+// @Param        limit     query     int     false  "Number of products, up to 50"
+// @Param        currency  query     string  false  "Currency of the prices, overrides the Accept-Currency header"
+// @Param        locale    query     string  false  "Locale of the names and descriptions, overrides the Accept-Language header"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /product/{id}/related [get]
+// @Security apiKey
+func (uc *ProductController) Related(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var uri entities.IdRequest
+	if err := c.ShouldBindUri(&uri); err != nil || !utils.IsValidUUID(uri.Id) {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": "Invalid product id."})
+		return
+	}
+	var request entities.RelatedRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	currency, err := resolveCurrency(c, uc.CurrencyInteractor)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+	locales, err := resolveLocales(c, uc.LocaleInteractor)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	res, err := uc.ProductInteractor.Related(uri.Id, request.Limit)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+	uc.recommendationsResponse(c, currency, locales, res)
+}
+
+// RecommendForCart godoc
+// @Summary      Get recommendations for a cart
+// @Description  Responds with the products most often bought together with the cart products or sharing their categories and tags, best score first. The cart products, deleted products and products out of stock are left out.
+// @Tags         Recommendations
+// @Accept       json
+// @Produce      json
+// @Param        request   body      entities.CartRecommendationRequest  true   "The products in the cart"
+// @Param        currency  query     string  false  "Currency of the prices, overrides the Accept-Currency header"
+// @Param        locale    query     string  false  "Locale of the names and descriptions, overrides the Accept-Language header"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Router       /product/recommendations [post]
+// @Security apiKey
+func (uc *ProductController) RecommendForCart(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var request entities.CartRecommendationRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	currency, err := resolveCurrency(c, uc.CurrencyInteractor)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+	locales, err := resolveLocales(c, uc.LocaleInteractor)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	res, err := uc.ProductInteractor.RecommendForCart(&request)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+	uc.recommendationsResponse(c, currency, locales, res)
+}
+
+func (uc *ProductController) recommendationsResponse(c *gin.Context, currency *entities.Currency, locales []string, res []*entities.Recommendation) {
+	products := make([]*entities.Product, len(res))
+	for i, recommendation := range res {
+		products[i] = recommendation.Product
+	}
+	if currency != nil {
+		if err := uc.CurrencyInteractor.ConvertProducts(currency, products...); err != nil {
+			ErrorResponse(c, err)
+			return
+		}
+	}
+	if locales != nil {
+		if err := uc.LocaleInteractor.LocalizeProducts(locales, products...); err != nil {
+			ErrorResponse(c, err)
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
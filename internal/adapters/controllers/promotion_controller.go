@@ -0,0 +1,230 @@
+// internal/adapters/controllers/promotion_controller.go
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/usecases"
+	"github.com/shayja/go-template-api/internal/utils"
+)
+
+type PromotionController struct {
+	PromotionInteractor *usecases.PromotionInteractor
+}
+
+// GetAll godoc
+// @Summary      Get the promotions
+// @Description  Responds with every promotion, the latest first (admin only)
+// @Tags         Promotions
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /promotion [get]
+// @Security apiKey
+func (pc *PromotionController) GetAll(c *gin.Context) {
+	AddRequestHeader(c)
+
+	res, err := pc.PromotionInteractor.GetAll()
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+// GetById godoc
+// @Summary      Get a promotion
+// @Description  Responds with a promotion and its coupon codes with the number of orders each was used on (admin only)
+// @Tags         Promotions
+// @Produce      json
+// @Param        id   path      string  true  "Promotion ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /promotion/{id} [get]
+// @Security apiKey
+func (pc *PromotionController) GetById(c *gin.Context) {
+	AddRequestHeader(c)
+
+	id, ok := promotionId(c)
+	if !ok {
+		return
+	}
+
+	res, err := pc.PromotionInteractor.Get(id)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+// Create godoc
+// @Summary      Create a promotion
+// @Description  Add a percentage, fixed amount, free shipping or buy X get Y promotion, optionally limited to products and categories, a minimum order value and a validity window. Automatic promotions apply to every qualifying order, the others need one of their coupon codes (admin only)
+// @Tags         Promotions
+// @Accept       json
+// @Produce      json
+// @Param        promotion  body      entities.PromotionRequest  true  "Promotion"
+// @Success      201  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Router       /promotion [post]
+// @Security apiKey
+func (pc *PromotionController) Create(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var request entities.PromotionRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	res, err := pc.PromotionInteractor.Create(&request)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+// Update godoc
+// @Summary      Update a promotion
+// @Description  Replace the rule of a promotion, the orders placed before keep their discounts (admin only)
+// @Tags         Promotions
+// @Accept       json
+// @Produce      json
+// @Param        id         path      string                     true  "Promotion ID"
+// @Param        promotion  body      entities.PromotionRequest  true  "Promotion"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /promotion/{id} [put]
+// @Security apiKey
+func (pc *PromotionController) Update(c *gin.Context) {
+	AddRequestHeader(c)
+
+	id, ok := promotionId(c)
+	if !ok {
+		return
+	}
+	var request entities.PromotionRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	res, err := pc.PromotionInteractor.Update(id, &request)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+// Delete godoc
+// @Summary      Delete a promotion
+// @Description  Remove a promotion and its coupon codes, the orders placed before keep their discounts (admin only)
+// @Tags         Promotions
+// @Produce      json
+// @Param        id   path      string  true  "Promotion ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /promotion/{id} [delete]
+// @Security apiKey
+func (pc *PromotionController) Delete(c *gin.Context) {
+	AddRequestHeader(c)
+
+	id, ok := promotionId(c)
+	if !ok {
+		return
+	}
+
+	if err := pc.PromotionInteractor.Delete(id); err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "msg": nil})
+}
+
+// CreateCoupon godoc
+// @Summary      Add a coupon code to a promotion
+// @Description  Add a code that applies the promotion to an order, matched case insensitively, with optional limits on the orders it can be used on in total and per user. Cancelled orders give their use back (admin only)
+// @Tags         Promotions
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string                  true  "Promotion ID"
+// @Param        coupon  body      entities.CouponRequest  true  "Coupon"
+// @Success      201  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      409  {object}  map[string]interface{}
+// @Router       /promotion/{id}/coupons [post]
+// @Security apiKey
+func (pc *PromotionController) CreateCoupon(c *gin.Context) {
+	AddRequestHeader(c)
+
+	id, ok := promotionId(c)
+	if !ok {
+		return
+	}
+	var request entities.CouponRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	res, err := pc.PromotionInteractor.CreateCoupon(id, &request)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+// DeleteCoupon godoc
+// @Summary      Delete a coupon code
+// @Description  Remove a coupon code of a promotion, the orders it was used on keep the code (admin only)
+// @Tags         Promotions
+// @Produce      json
+// @Param        id         path      string  true  "Promotion ID"
+// @Param        coupon_id  path      string  true  "Coupon ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /promotion/{id}/coupons/{coupon_id} [delete]
+// @Security apiKey
+func (pc *PromotionController) DeleteCoupon(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var uri entities.CouponIdRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	if err := pc.PromotionInteractor.DeleteCoupon(uri.Id, uri.CouponId); err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "msg": nil})
+}
+
+// promotionId binds the promotion id of the path, responding with a bad request when it is not a UUID
+func promotionId(c *gin.Context) (string, bool) {
+	var uri entities.IdRequest
+	if err := c.ShouldBindUri(&uri); err != nil || !utils.IsValidUUID(uri.Id) {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": "Invalid promotion id."})
+		return "", false
+	}
+	return uri.Id, true
+}
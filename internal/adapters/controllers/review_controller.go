@@ -0,0 +1,203 @@
+// internal/adapters/controllers/review_controller.go
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/usecases"
+)
+
+type ReviewController struct {
+	ReviewInteractor *usecases.ReviewInteractor
+	// Resolves the user making the request, the author of the reviews
+	CurrentUserId func(*gin.Context) (string, error)
+	// Resolves the role of the caller, admins may delete any review
+	CurrentRole func(*gin.Context) (string, error)
+}
+
+// GetProductReviews godoc
+// @Summary      Get the reviews of a product
+// @Description  Responds with a cursor paginated list of the approved reviews of a product. The product rating_average and rating_count summarize them.
+// @Tags         Reviews
+// @Produce      json
+// @Param        id             path      string  true   "Product ID"
+// @Param        cursor         query     string  false  "Cursor of the page to fetch"
+// @Param        limit          query     int     false  "Page size"
+// @Param        sort           query     string  false  "Sort field (created_at, rating), prefix with - for descending"
+// @Param        include_total  query     bool    false  "Include the total number of reviews"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Router       /product/{id}/reviews [get]
+// @Security apiKey
+func (rc *ReviewController) GetProductReviews(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var uri entities.IdRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	var list entities.ListRequest
+	if err := c.ShouldBindQuery(&list); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	res, err := rc.ReviewInteractor.GetProductReviews(uri.Id, &list)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+// Create godoc
+// @Summary      Review a product
+// @Description  Submit a rating and review of a product. The review is published once a moderator approves it, and is flagged as a verified purchase when the user ordered the product. A user may review a product once.
+// @Tags         Reviews
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string                  true  "Product ID"
+// @Param        review  body      entities.ReviewRequest  true  "Review"
+// @Success      201     {object}  map[string]interface{}
+// @Failure      400     {object}  map[string]interface{}
+// @Failure      403     {object}  map[string]interface{}
+// @Failure      404     {object}  map[string]interface{}
+// @Failure      409     {object}  map[string]interface{}
+// @Router       /product/{id}/reviews [post]
+// @Security apiKey
+func (rc *ReviewController) Create(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var uri entities.IdRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	var request entities.ReviewRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	userId, err := rc.CurrentUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	res, err := rc.ReviewInteractor.Create(uri.Id, userId, &request)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+// Delete godoc
+// @Summary      Delete a review
+// @Description  Remove a review of the caller, admins may remove any review
+// @Tags         Reviews
+// @Produce      json
+// @Param        id   path      string  true  "Review ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /review/{id} [delete]
+// @Security apiKey
+func (rc *ReviewController) Delete(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var uri entities.IdRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	userId, err := rc.CurrentUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	if err := rc.ReviewInteractor.Delete(uri.Id, userId, IsAdmin(c, rc.CurrentRole)); err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "msg": nil})
+}
+
+// GetQueue godoc
+// @Summary      Get the review moderation queue
+// @Description  Responds with a cursor paginated list of the reviews in a moderation status, the pending ones oldest first by default (admin only)
+// @Tags         Reviews
+// @Produce      json
+// @Param        status         query     string  false  "Moderation status (pending, approved, rejected)"
+// @Param        cursor         query     string  false  "Cursor of the page to fetch"
+// @Param        limit          query     int     false  "Page size"
+// @Param        sort           query     string  false  "Sort field (created_at, rating), prefix with - for descending"
+// @Param        include_total  query     bool    false  "Include the total number of reviews"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Router       /review [get]
+// @Security apiKey
+func (rc *ReviewController) GetQueue(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var request entities.ReviewQueueRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	res, err := rc.ReviewInteractor.GetQueue(&request)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+// Moderate godoc
+// @Summary      Moderate a review
+// @Description  Approve a review to publish it and count it toward the product rating, or reject it (admin only)
+// @Tags         Reviews
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string                        true  "Review ID"
+// @Param        status  body      entities.ReviewStatusRequest  true  "Moderation decision"
+// @Success      200     {object}  map[string]interface{}
+// @Failure      400     {object}  map[string]interface{}
+// @Failure      404     {object}  map[string]interface{}
+// @Router       /review/{id}/status [put]
+// @Security apiKey
+func (rc *ReviewController) Moderate(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var uri entities.IdRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	var request entities.ReviewStatusRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	res, err := rc.ReviewInteractor.Moderate(uri.Id, &request)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
@@ -0,0 +1,195 @@
+// internal/adapters/controllers/translation_controller.go
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/usecases"
+	"github.com/shayja/go-template-api/internal/utils"
+)
+
+type TranslationController struct {
+	LocaleInteractor   *usecases.LocaleInteractor
+	CategoryInteractor *usecases.CategoryInteractor
+}
+
+// resolveLocales negotiates the locales to serve the content in by the locale query parameter or
+// else the Accept-Language header, nil when the controller serves no translations
+func resolveLocales(c *gin.Context, locales *usecases.LocaleInteractor) ([]string, error) {
+	if locales == nil {
+		return nil, nil
+	}
+	c.Writer.Header().Add("Vary", "Accept-Language")
+	return locales.Negotiate(c.Query("locale"), c.GetHeader("Accept-Language"))
+}
+
+// GetProductTranslations godoc
+// @Summary      Get the translations of a product
+// @Description  Responds with the name and description of a product in every locale it was translated to (admin only)
+// @Tags         Translations
+// @Produce      json
+// @Param        id   path      string  true  "Product ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Router       /product/{id}/translations [get]
+// @Security apiKey
+func (tc *TranslationController) GetProductTranslations(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var uri entities.IdRequest
+	if err := c.ShouldBindUri(&uri); err != nil || !utils.IsValidUUID(uri.Id) {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": "Invalid product id."})
+		return
+	}
+
+	res, err := tc.LocaleInteractor.GetTranslations(entities.TranslationProduct, uri.Id)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+// SaveProductTranslation godoc
+// @Summary      Translate a product
+// @Description  Add or replace the name and description of a product in a locale other than the default one. A translation without a description serves the description in the default locale (admin only)
+// @Tags         Translations
+// @Accept       json
+// @Produce      json
+// @Param        id           path      string                       true  "Product ID"
+// @Param        locale       path      string                       true  "BCP 47 language tag, e.g. fr-CA"
+// @Param        translation  body      entities.TranslationRequest  true  "Translation"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /product/{id}/translations/{locale} [put]
+// @Security apiKey
+func (tc *TranslationController) SaveProductTranslation(c *gin.Context) {
+	tc.save(c, entities.TranslationProduct)
+}
+
+// DeleteProductTranslation godoc
+// @Summary      Delete the translation of a product
+// @Description  Remove the content of a product in a locale, the next locale of the fallback chain is served instead (admin only)
+// @Tags         Translations
+// @Produce      json
+// @Param        id      path      string  true  "Product ID"
+// @Param        locale  path      string  true  "BCP 47 language tag"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /product/{id}/translations/{locale} [delete]
+// @Security apiKey
+func (tc *TranslationController) DeleteProductTranslation(c *gin.Context) {
+	tc.delete(c, entities.TranslationProduct)
+}
+
+// GetCategoryTranslations godoc
+// @Summary      Get the translations of a category
+// @Description  Responds with the name of a category in every locale it was translated to (admin only)
+// @Tags         Translations
+// @Produce      json
+// @Param        slug  path      string  true  "Category slug"
+// @Success      200   {object}  map[string]interface{}
+// @Failure      404   {object}  map[string]interface{}
+// @Router       /category/{slug}/translations [get]
+// @Security apiKey
+func (tc *TranslationController) GetCategoryTranslations(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var uri entities.SlugRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	category, err := tc.CategoryInteractor.GetBySlug(uri.Slug)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+	res, err := tc.LocaleInteractor.GetTranslations(entities.TranslationCategory, category.Id)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+// SaveCategoryTranslation godoc
+// @Summary      Translate a category
+// @Description  Add or replace the name of a category in a locale other than the default one (admin only)
+// @Tags         Translations
+// @Accept       json
+// @Produce      json
+// @Param        id           path      string                       true  "Category ID"
+// @Param        locale       path      string                       true  "BCP 47 language tag, e.g. fr-CA"
+// @Param        translation  body      entities.TranslationRequest  true  "Translation, without a description"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /category/{id}/translations/{locale} [put]
+// @Security apiKey
+func (tc *TranslationController) SaveCategoryTranslation(c *gin.Context) {
+	tc.save(c, entities.TranslationCategory)
+}
+
+// DeleteCategoryTranslation godoc
+// @Summary      Delete the translation of a category
+// @Description  Remove the name of a category in a locale, the next locale of the fallback chain is served instead (admin only)
+// @Tags         Translations
+// @Produce      json
+// @Param        id      path      string  true  "Category ID"
+// @Param        locale  path      string  true  "BCP 47 language tag"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /category/{id}/translations/{locale} [delete]
+// @Security apiKey
+func (tc *TranslationController) DeleteCategoryTranslation(c *gin.Context) {
+	tc.delete(c, entities.TranslationCategory)
+}
+
+func (tc *TranslationController) save(c *gin.Context, kind string) {
+	AddRequestHeader(c)
+
+	var uri entities.TranslationLocaleRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+	var request entities.TranslationRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	res, err := tc.LocaleInteractor.SaveTranslation(kind, uri.Id, uri.Locale, &request)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+func (tc *TranslationController) delete(c *gin.Context, kind string) {
+	AddRequestHeader(c)
+
+	var uri entities.TranslationLocaleRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	if err := tc.LocaleInteractor.DeleteTranslation(kind, uri.Id, uri.Locale); err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "msg": nil})
+}
@@ -0,0 +1,242 @@
+// internal/adapters/controllers/variant_controller.go
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/usecases"
+)
+
+type VariantController struct {
+	VariantInteractor *usecases.VariantInteractor
+}
+
+// GetOptions godoc
+// @Summary      Get the option axes of a product
+// @Description  Responds with the option axes (e.g. size, color) of a product and their allowed values
+// @Tags         Variants
+// @Produce      json
+// @Param        id   path      string  true  "Product ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Router       /product/{id}/options [get]
+// @Security apiKey
+func (vc *VariantController) GetOptions(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var uri entities.IdRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	res, err := vc.VariantInteractor.GetOptions(uri.Id)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+// SetOptions godoc
+// @Summary      Replace the option axes of a product
+// @Description  Replace the option axes of a product and their allowed values (admin only)
+// @Tags         Variants
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                          true  "Product ID"
+// @Param        options  body      entities.ProductOptionsRequest  true  "Option axes"
+// @Success      200      {object}  map[string]interface{}
+// @Failure      400      {object}  map[string]interface{}
+// @Failure      404      {object}  map[string]interface{}
+// @Router       /product/{id}/options [put]
+// @Security apiKey
+func (vc *VariantController) SetOptions(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var uri entities.IdRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	var request entities.ProductOptionsRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	res, err := vc.VariantInteractor.SetOptions(uri.Id, &request)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+// GetAll godoc
+// @Summary      Get the variants of a product
+// @Description  Responds with the variants of a product, each with its effective price
+// @Tags         Variants
+// @Produce      json
+// @Param        id   path      string  true  "Product ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Router       /product/{id}/variants [get]
+// @Security apiKey
+func (vc *VariantController) GetAll(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var uri entities.IdRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	res, err := vc.VariantInteractor.GetByProduct(uri.Id)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+// GetById godoc
+// @Summary      Get a product variant
+// @Description  Retrieve a single variant of a product
+// @Tags         Variants
+// @Produce      json
+// @Param        id          path      string  true  "Product ID"
+// @Param        variant_id  path      string  true  "Variant ID"
+// @Success      200         {object}  map[string]interface{}
+// @Failure      404         {object}  map[string]interface{}
+// @Router       /product/{id}/variants/{variant_id} [get]
+// @Security apiKey
+func (vc *VariantController) GetById(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var uri entities.VariantIdRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	res, err := vc.VariantInteractor.GetById(uri.Id, uri.VariantId)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+// Create godoc
+// @Summary      Create a product variant
+// @Description  Add a variant selecting one value of every option axis of the product (admin only)
+// @Tags         Variants
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                          true  "Product ID"
+// @Param        variant  body      entities.ProductVariantRequest  true  "Variant data"
+// @Success      201      {object}  map[string]interface{}
+// @Failure      400      {object}  map[string]interface{}
+// @Failure      404      {object}  map[string]interface{}
+// @Failure      409      {object}  map[string]interface{}
+// @Router       /product/{id}/variants [post]
+// @Security apiKey
+func (vc *VariantController) Create(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var uri entities.IdRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	var post entities.ProductVariantRequest
+	if err := c.ShouldBindJSON(&post); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	insertedId, err := vc.VariantInteractor.Create(uri.Id, &post)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "msg": nil, "id": insertedId})
+}
+
+// Update godoc
+// @Summary      Update a product variant
+// @Description  Update the SKU, price override, image, options or availability of a variant (admin only)
+// @Tags         Variants
+// @Accept       json
+// @Produce      json
+// @Param        id          path      string                          true  "Product ID"
+// @Param        variant_id  path      string                          true  "Variant ID"
+// @Param        variant     body      entities.ProductVariantRequest  true  "Variant data"
+// @Success      200         {object}  map[string]interface{}
+// @Failure      400         {object}  map[string]interface{}
+// @Failure      404         {object}  map[string]interface{}
+// @Failure      409         {object}  map[string]interface{}
+// @Router       /product/{id}/variants/{variant_id} [put]
+// @Security apiKey
+func (vc *VariantController) Update(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var uri entities.VariantIdRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	var variant entities.ProductVariantRequest
+	if err := c.ShouldBindJSON(&variant); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	res, err := vc.VariantInteractor.Update(uri.Id, uri.VariantId, &variant)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+// Delete godoc
+// @Summary      Delete a product variant
+// @Description  Delete a variant of a product. An ordered variant cannot be deleted, it can be made unavailable instead (admin only)
+// @Tags         Variants
+// @Produce      json
+// @Param        id          path      string  true  "Product ID"
+// @Param        variant_id  path      string  true  "Variant ID"
+// @Success      200         {object}  map[string]interface{}
+// @Failure      404         {object}  map[string]interface{}
+// @Failure      409         {object}  map[string]interface{}
+// @Router       /product/{id}/variants/{variant_id} [delete]
+// @Security apiKey
+func (vc *VariantController) Delete(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var uri entities.VariantIdRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	if _, err := vc.VariantInteractor.Delete(uri.Id, uri.VariantId); err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "msg": nil})
+}
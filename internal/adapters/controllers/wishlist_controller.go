@@ -0,0 +1,422 @@
+// internal/adapters/controllers/wishlist_controller.go
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/usecases"
+	"github.com/shayja/go-template-api/internal/utils"
+)
+
+type WishlistController struct {
+	WishlistInteractor *usecases.WishlistInteractor
+	// Resolves the user making the request, the owner of the wishlists
+	CurrentUserId func(*gin.Context) (string, error)
+	// Optional, converts the prices of the saved products to the requested currency
+	CurrencyInteractor *usecases.CurrencyInteractor
+}
+
+// GetAll godoc
+// @Summary      Get the wishlists of the caller
+// @Description  Responds with the wishlists of the caller and their number of products, the default list first. The default list is created on first use.
+// @Tags         Wishlists
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}
+// @Router       /wishlist [get]
+// @Security apiKey
+func (wc *WishlistController) GetAll(c *gin.Context) {
+	AddRequestHeader(c)
+
+	userId, err := wc.CurrentUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	res, err := wc.WishlistInteractor.GetAll(userId)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+// GetById godoc
+// @Summary      Get a wishlist
+// @Description  Responds with a wishlist of the caller and its products, latest first, with their current price and availability
+// @Tags         Wishlists
+// @Produce      json
+// @Param        id        path      string  true   "Wishlist ID, or default"
+// @Param        currency  query     string  false  "Currency of the prices, overrides the Accept-Currency header"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /wishlist/{id} [get]
+// @Security apiKey
+func (wc *WishlistController) GetById(c *gin.Context) {
+	AddRequestHeader(c)
+
+	userId, id, ok := wc.wishlist(c)
+	if !ok {
+		return
+	}
+
+	currency, err := resolveCurrency(c, wc.CurrencyInteractor)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	res, err := wc.WishlistInteractor.Get(userId, id)
+	wc.respond(c, currency, res, err)
+}
+
+// GetShared godoc
+// @Summary      Get a shared wishlist
+// @Description  Responds with the wishlist shared with the token and its products, read-only and without its owner. No authentication is required.
+// @Tags         Wishlists
+// @Produce      json
+// @Param        token     path      string  true   "Share token"
+// @Param        currency  query     string  false  "Currency of the prices, overrides the Accept-Currency header"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /wishlist/shared/{token} [get]
+func (wc *WishlistController) GetShared(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var uri entities.ShareTokenRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	currency, err := resolveCurrency(c, wc.CurrencyInteractor)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	res, err := wc.WishlistInteractor.GetShared(uri.Token)
+	wc.respond(c, currency, res, err)
+}
+
+// Create godoc
+// @Summary      Create a wishlist
+// @Description  Add a named wishlist for the caller. The first list of the caller becomes the default list.
+// @Tags         Wishlists
+// @Accept       json
+// @Produce      json
+// @Param        wishlist  body      entities.WishlistRequest  true  "Wishlist"
+// @Success      201  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      409  {object}  map[string]interface{}
+// @Router       /wishlist [post]
+// @Security apiKey
+func (wc *WishlistController) Create(c *gin.Context) {
+	AddRequestHeader(c)
+
+	var request entities.WishlistRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	userId, err := wc.CurrentUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	res, err := wc.WishlistInteractor.Create(userId, &request)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+// Update godoc
+// @Summary      Update a wishlist
+// @Description  Rename a wishlist of the caller, or make it the default list
+// @Tags         Wishlists
+// @Accept       json
+// @Produce      json
+// @Param        id        path      string                    true  "Wishlist ID, or default"
+// @Param        wishlist  body      entities.WishlistRequest  true  "Wishlist"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      409  {object}  map[string]interface{}
+// @Router       /wishlist/{id} [put]
+// @Security apiKey
+func (wc *WishlistController) Update(c *gin.Context) {
+	AddRequestHeader(c)
+
+	userId, id, ok := wc.wishlist(c)
+	if !ok {
+		return
+	}
+	var request entities.WishlistRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	res, err := wc.WishlistInteractor.Update(userId, id, &request)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+// Delete godoc
+// @Summary      Delete a wishlist
+// @Description  Remove a wishlist of the caller and its products. The default list cannot be deleted.
+// @Tags         Wishlists
+// @Produce      json
+// @Param        id   path      string  true  "Wishlist ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      409  {object}  map[string]interface{}
+// @Router       /wishlist/{id} [delete]
+// @Security apiKey
+func (wc *WishlistController) Delete(c *gin.Context) {
+	AddRequestHeader(c)
+
+	userId, id, ok := wc.wishlist(c)
+	if !ok {
+		return
+	}
+
+	if err := wc.WishlistInteractor.Delete(userId, id); err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "msg": nil})
+}
+
+// AddItem godoc
+// @Summary      Save a product to a wishlist
+// @Description  Save a product to a wishlist of the caller and respond with the list. Saving a product already in the list does nothing.
+// @Tags         Wishlists
+// @Accept       json
+// @Produce      json
+// @Param        id        path      string                        true   "Wishlist ID, or default"
+// @Param        item      body      entities.WishlistItemRequest  true   "Product"
+// @Param        currency  query     string                        false  "Currency of the prices, overrides the Accept-Currency header"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      409  {object}  map[string]interface{}
+// @Router       /wishlist/{id}/items [post]
+// @Security apiKey
+func (wc *WishlistController) AddItem(c *gin.Context) {
+	AddRequestHeader(c)
+
+	userId, id, ok := wc.wishlist(c)
+	if !ok {
+		return
+	}
+	var request entities.WishlistItemRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": err.Error()})
+		return
+	}
+
+	currency, err := resolveCurrency(c, wc.CurrencyInteractor)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	res, err := wc.WishlistInteractor.AddItem(userId, id, request.ProductId)
+	wc.respond(c, currency, res, err)
+}
+
+// RemoveItem godoc
+// @Summary      Remove a product from a wishlist
+// @Description  Remove a product from a wishlist of the caller and respond with the list
+// @Tags         Wishlists
+// @Produce      json
+// @Param        id          path      string  true   "Wishlist ID, or default"
+// @Param        product_id  path      string  true   "Product ID"
+// @Param        currency    query     string  false  "Currency of the prices, overrides the Accept-Currency header"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /wishlist/{id}/items/{product_id} [delete]
+// @Security apiKey
+func (wc *WishlistController) RemoveItem(c *gin.Context) {
+	AddRequestHeader(c)
+
+	userId, uri, ok := wc.item(c)
+	if !ok {
+		return
+	}
+
+	currency, err := resolveCurrency(c, wc.CurrencyInteractor)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	res, err := wc.WishlistInteractor.RemoveItem(userId, uri.Id, uri.ProductId)
+	wc.respond(c, currency, res, err)
+}
+
+// MoveItem godoc
+// @Summary      Move a product to another wishlist
+// @Description  Move a product from a wishlist of the caller to another of their lists, keeping the date it was saved, and respond with the target list
+// @Tags         Wishlists
+// @Accept       json
+// @Produce      json
+// @Param        id          path      string                        true   "Wishlist ID, or default"
+// @Param        product_id  path      string                        true   "Product ID"
+// @Param        target      body      entities.WishlistMoveRequest  true   "Target wishlist"
+// @Param        currency    query     string                        false  "Currency of the prices, overrides the Accept-Currency header"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      409  {object}  map[string]interface{}
+// @Router       /wishlist/{id}/items/{product_id}/move [post]
+// @Security apiKey
+func (wc *WishlistController) MoveItem(c *gin.Context) {
+	AddRequestHeader(c)
+
+	userId, uri, ok := wc.item(c)
+	if !ok {
+		return
+	}
+	var request entities.WishlistMoveRequest
+	if err := c.ShouldBindJSON(&request); err != nil || !validWishlistId(request.WishlistId) {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": "Invalid target wishlist id."})
+		return
+	}
+
+	currency, err := resolveCurrency(c, wc.CurrencyInteractor)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	res, err := wc.WishlistInteractor.MoveItem(userId, uri.Id, uri.ProductId, request.WishlistId)
+	wc.respond(c, currency, res, err)
+}
+
+// Share godoc
+// @Summary      Share a wishlist
+// @Description  Give a wishlist of the caller a new share token for a read-only link, the previous link stops working
+// @Tags         Wishlists
+// @Produce      json
+// @Param        id   path      string  true  "Wishlist ID, or default"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /wishlist/{id}/share [post]
+// @Security apiKey
+func (wc *WishlistController) Share(c *gin.Context) {
+	AddRequestHeader(c)
+
+	userId, id, ok := wc.wishlist(c)
+	if !ok {
+		return
+	}
+
+	res, err := wc.WishlistInteractor.Share(userId, id)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+// Unshare godoc
+// @Summary      Stop sharing a wishlist
+// @Description  Remove the share token of a wishlist of the caller, the read-only link stops working
+// @Tags         Wishlists
+// @Produce      json
+// @Param        id   path      string  true  "Wishlist ID, or default"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /wishlist/{id}/share [delete]
+// @Security apiKey
+func (wc *WishlistController) Unshare(c *gin.Context) {
+	AddRequestHeader(c)
+
+	userId, id, ok := wc.wishlist(c)
+	if !ok {
+		return
+	}
+
+	res, err := wc.WishlistInteractor.Unshare(userId, id)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+// wishlist resolves the caller and the wishlist id of the path, responding when either is invalid
+func (wc *WishlistController) wishlist(c *gin.Context) (string, string, bool) {
+	var uri entities.IdRequest
+	if err := c.ShouldBindUri(&uri); err != nil || !validWishlistId(uri.Id) {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": "Invalid wishlist id."})
+		return "", "", false
+	}
+	userId, err := wc.CurrentUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"status": "failed", "msg": err.Error()})
+		return "", "", false
+	}
+	return userId, uri.Id, true
+}
+
+// item resolves the caller and the wishlist and product ids of the path, responding when any is invalid
+func (wc *WishlistController) item(c *gin.Context) (string, *entities.WishlistItemUri, bool) {
+	var uri entities.WishlistItemUri
+	if err := c.ShouldBindUri(&uri); err != nil || !validWishlistId(uri.Id) {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "failed", "msg": "Invalid wishlist or product id."})
+		return "", nil, false
+	}
+	userId, err := wc.CurrentUserId(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"status": "failed", "msg": err.Error()})
+		return "", nil, false
+	}
+	return userId, &uri, true
+}
+
+// respond sends a wishlist with the prices of its products in the requested currency
+func (wc *WishlistController) respond(c *gin.Context, currency *entities.Currency, res *entities.Wishlist, err error) {
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+	if currency != nil {
+		products := make([]*entities.Product, len(res.Items))
+		for i, item := range res.Items {
+			products[i] = item.Product
+		}
+		if err := wc.CurrencyInteractor.ConvertProducts(currency, products...); err != nil {
+			ErrorResponse(c, err)
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": res, "msg": nil})
+}
+
+// validWishlistId accepts the UUID of a wishlist or default
+func validWishlistId(id string) bool {
+	return id == entities.DefaultWishlist || utils.IsValidUUID(id)
+}
@@ -22,3 +22,26 @@ func AuthRequired(validateJWT JWTValidator) gin.HandlerFunc {
 		context.Next()
 	}
 }
+
+type RoleResolver func(context *gin.Context) (string, error)
+
+// RoleRequired only lets requests through whose token carries one of the given roles.
+func RoleRequired(resolveRole RoleResolver, roles ...string) gin.HandlerFunc {
+	return func(context *gin.Context) {
+		role, err := resolveRole(context)
+		if err != nil {
+			context.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+			fmt.Println(err)
+			context.Abort()
+			return
+		}
+		for _, allowed := range roles {
+			if role == allowed {
+				context.Next()
+				return
+			}
+		}
+		context.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		context.Abort()
+	}
+}
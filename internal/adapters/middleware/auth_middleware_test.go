@@ -49,3 +49,46 @@ func TestAuthRequired_Unauthorized(t *testing.T) {
 	assert.Equal(t, http.StatusUnauthorized, w.Code)
 	assert.JSONEq(t, `{"error": "Authentication required"}`, w.Body.String())
 }
+
+func TestRoleRequired_Allowed(t *testing.T) {
+	router := gin.Default()
+	router.Use(middleware.RoleRequired(func(context *gin.Context) (string, error) { return "admin", nil }, "admin"))
+	router.GET("/test", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "success"})
+	})
+
+	req, _ := http.NewRequest("GET", "/test", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestRoleRequired_Forbidden(t *testing.T) {
+	router := gin.Default()
+	router.Use(middleware.RoleRequired(func(context *gin.Context) (string, error) { return "customer", nil }, "admin"))
+	router.GET("/test", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "success"})
+	})
+
+	req, _ := http.NewRequest("GET", "/test", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.JSONEq(t, `{"error": "Insufficient permissions"}`, w.Body.String())
+}
+
+func TestRoleRequired_Unauthorized(t *testing.T) {
+	router := gin.Default()
+	router.Use(middleware.RoleRequired(func(context *gin.Context) (string, error) { return "", errors.New("invalid token") }, "admin"))
+	router.GET("/test", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "success"})
+	})
+
+	req, _ := http.NewRequest("GET", "/test", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
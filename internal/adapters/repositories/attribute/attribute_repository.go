@@ -0,0 +1,93 @@
+// adapters/repositories/attribute_repository.go
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/errors"
+)
+
+type AttributeRepository struct {
+	Db *sql.DB
+}
+
+const attributeColumns = `code, name, type, unit, "values", required, updated_at`
+
+// Get all attribute definitions ordered by code
+func (m *AttributeRepository) GetAll() ([]*entities.AttributeDefinition, error) {
+	query, err := m.Db.Query(`SELECT ` + attributeColumns + ` FROM attribute_definitions ORDER BY code`)
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	defer query.Close()
+
+	definitions := []*entities.AttributeDefinition{}
+	for query.Next() {
+		definition := &entities.AttributeDefinition{}
+		var unit sql.NullString
+		var values pq.StringArray
+		if err := query.Scan(&definition.Code, &definition.Name, &definition.Type, &unit, &values, &definition.Required, &definition.UpdatedAt); err != nil {
+			fmt.Print(err)
+			return nil, errors.ErrDatabase
+		}
+		if unit.Valid {
+			definition.Unit = &unit.String
+		}
+		if len(values) > 0 {
+			definition.Values = values
+		}
+		definitions = append(definitions, definition)
+	}
+	return definitions, nil
+}
+
+// Add or update an attribute definition
+func (m *AttributeRepository) Save(definition *entities.AttributeDefinition) error {
+	values := definition.Values
+	if values == nil {
+		values = []string{}
+	}
+	_, err := m.Db.Exec(`INSERT INTO attribute_definitions (code, name, type, unit, "values", required)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (code) DO UPDATE SET name = EXCLUDED.name, type = EXCLUDED.type, unit = EXCLUDED.unit,
+		"values" = EXCLUDED."values", required = EXCLUDED.required, updated_at = NOW()`,
+		definition.Code, definition.Name, definition.Type, definition.Unit, pq.Array(values), definition.Required)
+	if err != nil {
+		fmt.Print(err)
+		return errors.ErrDatabase
+	}
+	return nil
+}
+
+// Delete an attribute definition along with the product values of the attribute
+func (m *AttributeRepository) Delete(code string) error {
+	tx, err := m.Db.Begin()
+	if err != nil {
+		fmt.Print(err)
+		return errors.ErrDatabase
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(`DELETE FROM attribute_definitions WHERE code = $1`, code)
+	if err != nil {
+		fmt.Print(err)
+		return errors.ErrDatabase
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return errors.ErrAttributeNotFound
+	}
+	if _, err := tx.Exec(`UPDATE products SET attributes = attributes - $1::text WHERE attributes ? $1`, code); err != nil {
+		fmt.Print(err)
+		return errors.ErrDatabase
+	}
+
+	if err := tx.Commit(); err != nil {
+		fmt.Print(err)
+		return errors.ErrDatabase
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package repositories_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	repositories "github.com/shayja/go-template-api/internal/adapters/repositories/attribute"
+	"github.com/shayja/go-template-api/internal/entities"
+	appErrors "github.com/shayja/go-template-api/internal/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+var attributeColumns = []string{"code", "name", "type", "unit", "values", "required", "updated_at"}
+
+func TestGetAllAttributes(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.AttributeRepository{Db: db}
+	now := time.Now()
+
+	mock.ExpectQuery("SELECT (.+) FROM attribute_definitions ORDER BY code").
+		WillReturnRows(sqlmock.NewRows(attributeColumns).
+			AddRow("color", "Color", "enum", nil, "{red,blue}", false, now).
+			AddRow("weight", "Weight", "number", "g", "{}", true, now))
+
+	definitions, err := repo.GetAll()
+	assert.NoError(t, err)
+	assert.Len(t, definitions, 2)
+	assert.Equal(t, []string{"red", "blue"}, definitions[0].Values)
+	assert.Nil(t, definitions[0].Unit)
+	assert.Equal(t, "g", *definitions[1].Unit)
+	assert.Nil(t, definitions[1].Values)
+	assert.True(t, definitions[1].Required)
+}
+
+func TestSaveAttribute(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.AttributeRepository{Db: db}
+
+	mock.ExpectExec("INSERT INTO attribute_definitions (.+) ON CONFLICT \\(code\\) DO UPDATE").
+		WithArgs("brand", "Brand", "string", nil, "{}", false).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.Save(&entities.AttributeDefinition{Code: "brand", Name: "Brand", Type: entities.AttributeString})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteAttribute(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.AttributeRepository{Db: db}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM attribute_definitions WHERE code = \\$1").
+		WithArgs("brand").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE products SET attributes = attributes - \\$1::text WHERE attributes \\? \\$1").
+		WithArgs("brand").
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+
+	assert.NoError(t, repo.Delete("brand"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteAttribute_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.AttributeRepository{Db: db}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM attribute_definitions WHERE code = \\$1").
+		WithArgs("brand").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	assert.Equal(t, appErrors.ErrAttributeNotFound, repo.Delete("brand"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,203 @@
+// adapters/repositories/category_repository.go
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/errors"
+	"github.com/shayja/go-template-api/internal/utils"
+)
+
+type CategoryRepository struct {
+	Db *sql.DB
+}
+
+const categoryColumns = `id, parent_id, name, slug, position, updated_at, created_at`
+
+// Get all categories, ordered by their position among siblings
+func (m *CategoryRepository) GetAll() ([]*entities.Category, error) {
+	query, err := m.Db.Query(`SELECT ` + categoryColumns + ` FROM categories ORDER BY position, name`)
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	defer query.Close()
+
+	var categories []*entities.Category
+	for query.Next() {
+		category, err := scanCategory(query)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, category)
+	}
+	return categories, nil
+}
+
+// Get a single category by id
+func (m *CategoryRepository) GetById(id string) (*entities.Category, error) {
+	return m.getOne(`SELECT `+categoryColumns+` FROM categories WHERE id = $1`, id)
+}
+
+// Get a single category by slug
+func (m *CategoryRepository) GetBySlug(slug string) (*entities.Category, error) {
+	return m.getOne(`SELECT `+categoryColumns+` FROM categories WHERE slug = $1`, slug)
+}
+
+func (m *CategoryRepository) getOne(SQL string, arg string) (*entities.Category, error) {
+	query, err := m.Db.Query(SQL, arg)
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	defer query.Close()
+
+	if !query.Next() {
+		return nil, errors.ErrCategoryNotFound
+	}
+	return scanCategory(query)
+}
+
+// Get the ids of all descendants of a category
+func (m *CategoryRepository) GetDescendantIds(id string) ([]string, error) {
+	query, err := m.Db.Query(`SELECT t FROM get_category_tree_ids($1) t WHERE t <> $1`, id)
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	defer query.Close()
+
+	var ids []string
+	for query.Next() {
+		var childId string
+		if err := query.Scan(&childId); err != nil {
+			fmt.Print(err)
+			return nil, errors.ErrDatabase
+		}
+		ids = append(ids, childId)
+	}
+	return ids, nil
+}
+
+// Create a new category
+func (m *CategoryRepository) Create(category *entities.CategoryRequest) (string, error) {
+	newId := utils.CreateNewUUID().String()
+	_, err := m.Db.Exec(`INSERT INTO categories (id, parent_id, name, slug, position) VALUES ($1, $2, $3, $4, $5)`,
+		newId, category.ParentId, category.Name, category.Slug, category.Position)
+	if err != nil {
+		fmt.Print(err)
+		return "", mapError(err, errors.ErrCategoryNotFound)
+	}
+
+	fmt.Printf("Category %s created successfully (new id is %s)\n", category.Name, newId)
+	return newId, nil
+}
+
+// Update a category
+func (m *CategoryRepository) Update(id string, category *entities.CategoryRequest) (*entities.Category, error) {
+	res, err := m.Db.Exec(`UPDATE categories SET parent_id = $2, name = $3, slug = $4, position = $5, updated_at = NOW() WHERE id = $1`,
+		id, category.ParentId, category.Name, category.Slug, category.Position)
+	if err != nil {
+		fmt.Print(err)
+		return nil, mapError(err, errors.ErrCategoryNotFound)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, errors.ErrCategoryNotFound
+	}
+	return m.GetById(id)
+}
+
+// Delete a category without subcategories
+func (m *CategoryRepository) Delete(id string) (bool, error) {
+	res, err := m.Db.Exec(`DELETE FROM categories WHERE id = $1`, id)
+	if err != nil {
+		fmt.Print(err)
+		return false, mapError(err, errors.ErrCategoryNotEmpty)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return false, errors.ErrCategoryNotFound
+	}
+	return true, nil
+}
+
+// Replace the categories a product is assigned to
+func (m *CategoryRepository) SetProductCategories(productId string, categoryIds []string) error {
+	if categoryIds == nil {
+		categoryIds = []string{}
+	}
+	_, err := m.Db.Exec(`CALL product_categories_set($1, $2)`, productId, pq.Array(categoryIds))
+	if err != nil {
+		fmt.Print(err)
+		return mapError(err, errors.ErrInvalidInput)
+	}
+	return nil
+}
+
+// Get the category breadcrumbs (root first) of the given products
+func (m *CategoryRepository) GetBreadcrumbs(productIds []string) (map[string][][]entities.CategoryCrumb, error) {
+	SQL := `WITH RECURSIVE path AS (
+		SELECT pc.product_id, c.id AS leaf_id, c.id, c.parent_id, c.name, c.slug, 0 AS depth
+		FROM product_categories pc JOIN categories c ON c.id = pc.category_id
+		WHERE pc.product_id = ANY($1::uuid[])
+		UNION ALL
+		SELECT p.product_id, p.leaf_id, c.id, c.parent_id, c.name, c.slug, p.depth + 1
+		FROM path p JOIN categories c ON c.id = p.parent_id
+	)
+	SELECT product_id, leaf_id, id, name, slug FROM path ORDER BY product_id, leaf_id, depth DESC`
+
+	query, err := m.Db.Query(SQL, pq.Array(productIds))
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	defer query.Close()
+
+	breadcrumbs := map[string][][]entities.CategoryCrumb{}
+	var lastProduct, lastLeaf string
+	for query.Next() {
+		var productId, leafId string
+		var crumb entities.CategoryCrumb
+		if err := query.Scan(&productId, &leafId, &crumb.Id, &crumb.Name, &crumb.Slug); err != nil {
+			fmt.Print(err)
+			return nil, errors.ErrDatabase
+		}
+		if productId != lastProduct || leafId != lastLeaf {
+			breadcrumbs[productId] = append(breadcrumbs[productId], nil)
+			lastProduct, lastLeaf = productId, leafId
+		}
+		paths := breadcrumbs[productId]
+		paths[len(paths)-1] = append(paths[len(paths)-1], crumb)
+	}
+	return breadcrumbs, nil
+}
+
+func scanCategory(query *sql.Rows) (*entities.Category, error) {
+	category := &entities.Category{}
+	var parentId sql.NullString
+	err := query.Scan(&category.Id, &parentId, &category.Name, &category.Slug, &category.Position, &category.UpdatedAt, &category.CreatedAt)
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	if parentId.Valid {
+		category.ParentId = &parentId.String
+	}
+	return category, nil
+}
+
+// mapError translates constraint violations into application errors,
+// fkErr being the error of a foreign key violation in the calling context
+func mapError(err error, fkErr error) error {
+	if pqErr, ok := err.(*pq.Error); ok {
+		switch pqErr.Code {
+		case "23505": // unique_violation
+			return errors.ErrSlugConflict
+		case "23503": // foreign_key_violation
+			return fkErr
+		}
+	}
+	return errors.ErrDatabase
+}
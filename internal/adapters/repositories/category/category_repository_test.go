@@ -0,0 +1,100 @@
+package repositories_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	repositories "github.com/shayja/go-template-api/internal/adapters/repositories/category"
+	"github.com/shayja/go-template-api/internal/entities"
+	appErrors "github.com/shayja/go-template-api/internal/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBySlug_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.CategoryRepository{Db: db}
+
+	mock.ExpectQuery("SELECT (.+) FROM categories WHERE slug = \\$1").
+		WithArgs("missing").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "parent_id", "name", "slug", "position", "updated_at", "created_at"}))
+
+	category, err := repo.GetBySlug("missing")
+	assert.ErrorIs(t, err, appErrors.ErrCategoryNotFound)
+	assert.Nil(t, category)
+}
+
+func TestGetBySlug_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.CategoryRepository{Db: db}
+
+	mock.ExpectQuery("SELECT (.+) FROM categories WHERE slug = \\$1").
+		WithArgs("android").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "parent_id", "name", "slug", "position", "updated_at", "created_at"}).
+			AddRow("2", "1", "Android", "android", 1, time.Now(), time.Now()))
+
+	category, err := repo.GetBySlug("android")
+	assert.NoError(t, err)
+	assert.Equal(t, "Android", category.Name)
+	assert.Equal(t, "1", *category.ParentId)
+}
+
+func TestCreate_SlugConflict(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.CategoryRepository{Db: db}
+
+	mock.ExpectExec("INSERT INTO categories").
+		WillReturnError(&pq.Error{Code: "23505"})
+
+	id, err := repo.Create(&entities.CategoryRequest{Name: "Phones", Slug: "phones"})
+	assert.ErrorIs(t, err, appErrors.ErrSlugConflict)
+	assert.Empty(t, id)
+}
+
+func TestDelete_HasChildren(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.CategoryRepository{Db: db}
+
+	mock.ExpectExec("DELETE FROM categories WHERE id = \\$1").
+		WithArgs("1").
+		WillReturnError(&pq.Error{Code: "23503"})
+
+	deleted, err := repo.Delete("1")
+	assert.ErrorIs(t, err, appErrors.ErrCategoryNotEmpty)
+	assert.False(t, deleted)
+}
+
+func TestGetBreadcrumbs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.CategoryRepository{Db: db}
+
+	mock.ExpectQuery("WITH RECURSIVE path AS").
+		WillReturnRows(sqlmock.NewRows([]string{"product_id", "leaf_id", "id", "name", "slug"}).
+			AddRow("p1", "android", "phones", "Phones", "phones").
+			AddRow("p1", "android", "android", "Android", "android").
+			AddRow("p1", "sale", "sale", "Sale", "sale").
+			AddRow("p2", "phones", "phones", "Phones", "phones"))
+
+	breadcrumbs, err := repo.GetBreadcrumbs([]string{"p1", "p2"})
+	assert.NoError(t, err)
+	assert.Len(t, breadcrumbs["p1"], 2)
+	assert.Equal(t, []string{"phones", "android"}, []string{breadcrumbs["p1"][0][0].Slug, breadcrumbs["p1"][0][1].Slug})
+	assert.Equal(t, "sale", breadcrumbs["p1"][1][0].Slug)
+	assert.Len(t, breadcrumbs["p2"], 1)
+}
@@ -0,0 +1,198 @@
+// adapters/repositories/currency_repository.go
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/errors"
+	"github.com/shayja/go-template-api/pkg/money"
+)
+
+type CurrencyRepository struct {
+	Db *sql.DB
+}
+
+const currencyColumns = `code, rate, decimals, rounding, rounding_increment, updated_at`
+
+// Get all currencies ordered by code
+func (m *CurrencyRepository) GetAll() ([]*entities.Currency, error) {
+	query, err := m.Db.Query(`SELECT ` + currencyColumns + ` FROM currencies ORDER BY code`)
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	defer query.Close()
+
+	currencies := []*entities.Currency{}
+	for query.Next() {
+		currency, err := scanCurrency(query)
+		if err != nil {
+			return nil, err
+		}
+		currencies = append(currencies, currency)
+	}
+	return currencies, nil
+}
+
+// Get a currency by code, nil when it does not exist
+func (m *CurrencyRepository) Get(code string) (*entities.Currency, error) {
+	query, err := m.Db.Query(`SELECT `+currencyColumns+` FROM currencies WHERE code = $1`, code)
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	defer query.Close()
+
+	if !query.Next() {
+		return nil, nil
+	}
+	return scanCurrency(query)
+}
+
+// Add or update currencies in a single transaction
+func (m *CurrencyRepository) Save(currencies ...*entities.Currency) error {
+	tx, err := m.Db.Begin()
+	if err != nil {
+		fmt.Print(err)
+		return errors.ErrDatabase
+	}
+	defer tx.Rollback()
+
+	for _, currency := range currencies {
+		_, err := tx.Exec(`INSERT INTO currencies (code, rate, decimals, rounding, rounding_increment, updated_at)
+			VALUES ($1, $2, $3, $4, $5, NOW())
+			ON CONFLICT (code) DO UPDATE SET rate = EXCLUDED.rate, decimals = EXCLUDED.decimals,
+			rounding = EXCLUDED.rounding, rounding_increment = EXCLUDED.rounding_increment, updated_at = NOW()`,
+			currency.Code, currency.Rate, currency.Decimals, currency.Rounding, currency.RoundingIncrement)
+		if err != nil {
+			fmt.Print(err)
+			return errors.ErrDatabase
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		fmt.Print(err)
+		return errors.ErrDatabase
+	}
+	return nil
+}
+
+// Delete a currency along with the product prices in it
+func (m *CurrencyRepository) Delete(code string) error {
+	res, err := m.Db.Exec(`DELETE FROM currencies WHERE code = $1`, code)
+	if err != nil {
+		fmt.Print(err)
+		return errors.ErrDatabase
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return errors.ErrCurrencyNotFound
+	}
+	return nil
+}
+
+// Get the explicit prices of a product by currency code
+func (m *CurrencyRepository) GetProductPrices(productId string) (map[string]money.Amount, error) {
+	query, err := m.Db.Query(`SELECT currency, price FROM product_currency_prices WHERE product_id = $1 ORDER BY currency`, productId)
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	defer query.Close()
+
+	prices := make(map[string]money.Amount)
+	for query.Next() {
+		var code string
+		var price money.Amount
+		if err := query.Scan(&code, &price); err != nil {
+			fmt.Print(err)
+			return nil, errors.ErrDatabase
+		}
+		prices[code] = price
+	}
+	return prices, nil
+}
+
+// Get the explicit prices in a currency of the given products, by product id
+func (m *CurrencyRepository) GetPricesIn(code string, productIds []string) (map[string]money.Amount, error) {
+	prices := make(map[string]money.Amount)
+	if len(productIds) == 0 {
+		return prices, nil
+	}
+	query, err := m.Db.Query(`SELECT product_id, price FROM product_currency_prices WHERE currency = $1 AND product_id = ANY($2::uuid[])`,
+		code, pq.Array(productIds))
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	defer query.Close()
+
+	for query.Next() {
+		var productId string
+		var price money.Amount
+		if err := query.Scan(&productId, &price); err != nil {
+			fmt.Print(err)
+			return nil, errors.ErrDatabase
+		}
+		prices[productId] = price
+	}
+	return prices, nil
+}
+
+// Replace the explicit prices of an active product
+func (m *CurrencyRepository) SetProductPrices(productId string, prices map[string]money.Amount) error {
+	tx, err := m.Db.Begin()
+	if err != nil {
+		fmt.Print(err)
+		return errors.ErrDatabase
+	}
+	defer tx.Rollback()
+
+	var locked string
+	err = tx.QueryRow("SELECT id FROM products WHERE id = $1 AND deleted_at IS NULL FOR UPDATE", productId).Scan(&locked)
+	if err == sql.ErrNoRows {
+		return errors.ErrProductNotFound
+	}
+	if err != nil {
+		fmt.Print(err)
+		return errors.ErrDatabase
+	}
+
+	if _, err := tx.Exec(`DELETE FROM product_currency_prices WHERE product_id = $1`, productId); err != nil {
+		fmt.Print(err)
+		return errors.ErrDatabase
+	}
+	for code, price := range prices {
+		_, err := tx.Exec(`INSERT INTO product_currency_prices (product_id, currency, price) VALUES ($1, $2, $3)`, productId, code, price)
+		if err != nil {
+			return mapError(err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		fmt.Print(err)
+		return errors.ErrDatabase
+	}
+	return nil
+}
+
+// scanCurrency reads a row of currencyColumns
+func scanCurrency(query *sql.Rows) (*entities.Currency, error) {
+	currency := &entities.Currency{}
+	if err := query.Scan(&currency.Code, &currency.Rate, &currency.Decimals, &currency.Rounding, &currency.RoundingIncrement, &currency.UpdatedAt); err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	return currency, nil
+}
+
+// mapError translates constraint violations into application errors
+func mapError(err error) error {
+	fmt.Print(err)
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" { // foreign_key_violation
+		return errors.ErrUnsupportedCurrency
+	}
+	return errors.ErrDatabase
+}
@@ -0,0 +1,132 @@
+package repositories_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	repositories "github.com/shayja/go-template-api/internal/adapters/repositories/currency"
+	"github.com/shayja/go-template-api/internal/entities"
+	appErrors "github.com/shayja/go-template-api/internal/errors"
+	"github.com/shayja/go-template-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+)
+
+var currencyColumns = []string{"code", "rate", "decimals", "rounding", "rounding_increment", "updated_at"}
+
+func TestGetAllCurrencies(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.CurrencyRepository{Db: db}
+	now := time.Now()
+
+	mock.ExpectQuery("SELECT code, rate, decimals, rounding, rounding_increment, updated_at FROM currencies ORDER BY code").
+		WillReturnRows(sqlmock.NewRows(currencyColumns).
+			AddRow("CHF", 0.88, 2, "half_up", 0.05, now).
+			AddRow("JPY", 151.2, 0, "half_even", nil, now))
+
+	currencies, err := repo.GetAll()
+	assert.NoError(t, err)
+	assert.Len(t, currencies, 2)
+	assert.Equal(t, money.New(0, 5), *currencies[0].RoundingIncrement)
+	assert.Nil(t, currencies[1].RoundingIncrement)
+	assert.Equal(t, 0, currencies[1].Decimals)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetCurrency_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.CurrencyRepository{Db: db}
+
+	mock.ExpectQuery("SELECT (.+) FROM currencies WHERE code = \\$1").
+		WithArgs("EUR").
+		WillReturnRows(sqlmock.NewRows(currencyColumns))
+
+	currency, err := repo.Get("EUR")
+	assert.NoError(t, err)
+	assert.Nil(t, currency)
+}
+
+func TestSaveCurrencies(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.CurrencyRepository{Db: db}
+	eur := &entities.Currency{Code: "EUR", Rate: 0.92, Decimals: 2, Rounding: "half_up"}
+	jpy := &entities.Currency{Code: "JPY", Rate: 151.2, Decimals: 0, Rounding: "half_even"}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO currencies (.+) ON CONFLICT \\(code\\) DO UPDATE").
+		WithArgs("EUR", 0.92, 2, "half_up", eur.RoundingIncrement).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO currencies (.+) ON CONFLICT \\(code\\) DO UPDATE").
+		WithArgs("JPY", 151.2, 0, "half_even", jpy.RoundingIncrement).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	assert.NoError(t, repo.Save(eur, jpy))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteCurrency_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.CurrencyRepository{Db: db}
+
+	mock.ExpectExec("DELETE FROM currencies WHERE code = \\$1").
+		WithArgs("EUR").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.ErrorIs(t, repo.Delete("EUR"), appErrors.ErrCurrencyNotFound)
+}
+
+func TestGetPricesIn(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.CurrencyRepository{Db: db}
+	ids := []string{"1", "2"}
+
+	mock.ExpectQuery("SELECT product_id, price FROM product_currency_prices WHERE currency = \\$1 AND product_id = ANY\\(\\$2::uuid\\[\\]\\)").
+		WithArgs("EUR", pq.Array(ids)).
+		WillReturnRows(sqlmock.NewRows([]string{"product_id", "price"}).AddRow("2", 89.9))
+
+	prices, err := repo.GetPricesIn("EUR", ids)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]money.Amount{"2": money.New(89, 90)}, prices)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetProductPrices_UnknownCurrency(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.CurrencyRepository{Db: db}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id FROM products WHERE id = \\$1 AND deleted_at IS NULL FOR UPDATE").
+		WithArgs("1").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+	mock.ExpectExec("DELETE FROM product_currency_prices WHERE product_id = \\$1").
+		WithArgs("1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO product_currency_prices").
+		WithArgs("1", "XYZ", "10.00").
+		WillReturnError(&pq.Error{Code: "23503"})
+	mock.ExpectRollback()
+
+	err = repo.SetProductPrices("1", map[string]money.Amount{"XYZ": money.New(10, 0)})
+	assert.ErrorIs(t, err, appErrors.ErrUnsupportedCurrency)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
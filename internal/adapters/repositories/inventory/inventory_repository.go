@@ -0,0 +1,211 @@
+// adapters/repositories/inventory_repository.go
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/shayja/go-template-api/internal/adapters/repositories/pagination"
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/errors"
+)
+
+type InventoryRepository struct {
+	Db *sql.DB
+}
+
+const stockColumns = `product_id, quantity, low_stock_threshold, quantity <= low_stock_threshold, updated_at`
+
+// Whitelisted stock movement sort fields
+var movementSortFields = map[string]pagination.SortField{
+	"created_at": {Column: "created_at", Type: "timestamp"},
+}
+
+// Get the stock level of a product, nil when the product is not stock tracked
+func (m *InventoryRepository) GetStock(productId string) (*entities.StockLevel, error) {
+	query, err := m.Db.Query(`SELECT `+stockColumns+` FROM inventory WHERE product_id = $1`, productId)
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	defer query.Close()
+
+	if !query.Next() {
+		return nil, nil
+	}
+	return scanStock(query)
+}
+
+// Get the stock levels at or below their low stock threshold, lowest first
+func (m *InventoryRepository) GetLowStock() ([]*entities.StockLevel, error) {
+	query, err := m.Db.Query(`SELECT ` + stockColumns + ` FROM inventory WHERE quantity <= low_stock_threshold ORDER BY quantity, product_id`)
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	defer query.Close()
+
+	levels := []*entities.StockLevel{}
+	for query.Next() {
+		level, err := scanStock(query)
+		if err != nil {
+			return nil, err
+		}
+		levels = append(levels, level)
+	}
+	return levels, nil
+}
+
+// Adjust the stock of a product and record the movement, starting to track the product if needed
+func (m *InventoryRepository) Adjust(productId string, userId string, adjustment *entities.StockAdjustmentRequest) (*entities.StockLevel, error) {
+	tx, err := m.Db.Begin()
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`INSERT INTO inventory (product_id) VALUES ($1) ON CONFLICT (product_id) DO NOTHING`, productId); err != nil {
+		fmt.Print(err)
+		return nil, mapError(err)
+	}
+
+	// The row lock taken by the update serializes concurrent adjustments and orders
+	query, err := tx.Query(`UPDATE inventory SET quantity = quantity + $2, updated_at = NOW() WHERE product_id = $1 RETURNING `+stockColumns, productId, adjustment.Delta)
+	if err != nil {
+		fmt.Print(err)
+		return nil, mapError(err)
+	}
+	if !query.Next() {
+		query.Close()
+		if err := query.Err(); err != nil {
+			fmt.Print(err)
+			return nil, mapError(err)
+		}
+		return nil, errors.ErrProductNotFound
+	}
+	level, err := scanStock(query)
+	query.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(`INSERT INTO inventory_movements (product_id, delta, quantity_after, kind, reason, user_id) VALUES ($1, $2, $3, $4, $5, $6)`,
+		productId, adjustment.Delta, level.Quantity, entities.MovementAdjustment, adjustment.Reason, sql.NullString{String: userId, Valid: userId != ""})
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+
+	if err := tx.Commit(); err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	return level, nil
+}
+
+// Set the low stock threshold of a product, starting to track the product if needed
+func (m *InventoryRepository) SetThreshold(productId string, threshold int) (*entities.StockLevel, error) {
+	query, err := m.Db.Query(`INSERT INTO inventory (product_id, low_stock_threshold) VALUES ($1, $2)
+		ON CONFLICT (product_id) DO UPDATE SET low_stock_threshold = EXCLUDED.low_stock_threshold, updated_at = NOW()
+		RETURNING `+stockColumns, productId, threshold)
+	if err != nil {
+		fmt.Print(err)
+		return nil, mapError(err)
+	}
+	defer query.Close()
+
+	if !query.Next() {
+		if err := query.Err(); err != nil {
+			fmt.Print(err)
+			return nil, mapError(err)
+		}
+		return nil, errors.ErrDatabase
+	}
+	return scanStock(query)
+}
+
+// Get the stock movements of a product, newest first by default
+func (m *InventoryRepository) GetMovements(productId string, list *entities.ListRequest) (*entities.List[*entities.StockMovement], error) {
+	page, err := pagination.New(list, movementSortFields, "-created_at")
+	if err != nil {
+		return nil, err
+	}
+
+	var total *int64
+	if list.IncludeTotal {
+		var count int64
+		if err := m.Db.QueryRow(`SELECT COUNT(*) FROM inventory_movements WHERE product_id = $1`, productId).Scan(&count); err != nil {
+			fmt.Print(err)
+			return nil, errors.ErrDatabase
+		}
+		total = &count
+	}
+
+	args := []interface{}{productId}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	where := "product_id = $1"
+	if keyset := page.Where(arg); keyset != "" {
+		where += " AND " + keyset
+	}
+	query, err := m.Db.Query(fmt.Sprintf(`SELECT id, product_id, delta, quantity_after, kind, reason, order_id, user_id, created_at FROM inventory_movements WHERE %s ORDER BY %s LIMIT %s`,
+		where, page.OrderBy(), arg(page.Fetch())), args...)
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	defer query.Close()
+
+	var movements []*entities.StockMovement
+	for query.Next() {
+		movement := &entities.StockMovement{}
+		var reason, orderId, userId sql.NullString
+		if err := query.Scan(&movement.Id, &movement.ProductId, &movement.Delta, &movement.QuantityAfter, &movement.Kind, &reason, &orderId, &userId, &movement.CreatedAt); err != nil {
+			fmt.Print(err)
+			return nil, errors.ErrDatabase
+		}
+		movement.Reason = nullString(reason)
+		movement.OrderId = nullString(orderId)
+		movement.UserId = nullString(userId)
+		movements = append(movements, movement)
+	}
+
+	res := pagination.Result(page, movements, func(s *entities.StockMovement) (interface{}, string) {
+		return s.CreatedAt, s.Id
+	})
+	res.Total = total
+	return res, nil
+}
+
+func scanStock(query *sql.Rows) (*entities.StockLevel, error) {
+	level := &entities.StockLevel{}
+	if err := query.Scan(&level.ProductId, &level.Quantity, &level.LowStockThreshold, &level.LowStock, &level.UpdatedAt); err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	return level, nil
+}
+
+func nullString(s sql.NullString) *string {
+	if !s.Valid {
+		return nil
+	}
+	return &s.String
+}
+
+// mapError translates constraint violations into application errors
+func mapError(err error) error {
+	if pqErr, ok := err.(*pq.Error); ok {
+		switch pqErr.Code {
+		case "23514": // check_violation, the stock would drop below zero
+			return errors.ErrOutOfStock
+		case "23503": // foreign_key_violation
+			return errors.ErrProductNotFound
+		}
+	}
+	return errors.ErrDatabase
+}
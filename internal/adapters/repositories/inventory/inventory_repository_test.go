@@ -0,0 +1,73 @@
+package repositories_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	repositories "github.com/shayja/go-template-api/internal/adapters/repositories/inventory"
+	"github.com/shayja/go-template-api/internal/entities"
+	appErrors "github.com/shayja/go-template-api/internal/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+var stockColumns = []string{"product_id", "quantity", "low_stock_threshold", "low_stock", "updated_at"}
+
+func TestGetStock_NotTracked(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.InventoryRepository{Db: db}
+
+	mock.ExpectQuery("SELECT (.+) FROM inventory WHERE product_id = \\$1").
+		WithArgs("1").
+		WillReturnRows(sqlmock.NewRows(stockColumns))
+
+	level, err := repo.GetStock("1")
+	assert.NoError(t, err)
+	assert.Nil(t, level)
+}
+
+func TestAdjust_RecordsMovement(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.InventoryRepository{Db: db}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO inventory \\(product_id\\)").WithArgs("1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("UPDATE inventory SET quantity = quantity \\+ \\$2").
+		WithArgs("1", 20).
+		WillReturnRows(sqlmock.NewRows(stockColumns).AddRow("1", 22, 5, false, time.Now()))
+	mock.ExpectExec("INSERT INTO inventory_movements").
+		WithArgs("1", 20, 22, entities.MovementAdjustment, "Received shipment", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	level, err := repo.Adjust("1", "admin", &entities.StockAdjustmentRequest{Delta: 20, Reason: "Received shipment"})
+	assert.NoError(t, err)
+	assert.Equal(t, 22, level.Quantity)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAdjust_BelowZero(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.InventoryRepository{Db: db}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO inventory \\(product_id\\)").WithArgs("1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("UPDATE inventory SET quantity = quantity \\+ \\$2").
+		WithArgs("1", -50).
+		WillReturnError(&pq.Error{Code: "23514"})
+	mock.ExpectRollback()
+
+	_, err = repo.Adjust("1", "admin", &entities.StockAdjustmentRequest{Delta: -50, Reason: "Damaged"})
+	assert.ErrorIs(t, err, appErrors.ErrOutOfStock)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
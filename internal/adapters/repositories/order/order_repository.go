@@ -2,24 +2,60 @@ package repositories
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/lib/pq"
+	"github.com/shayja/go-template-api/internal/adapters/repositories/pagination"
 	"github.com/shayja/go-template-api/internal/entities"
 	"github.com/shayja/go-template-api/internal/errors"
 	"github.com/shayja/go-template-api/internal/utils"
+	"github.com/shayja/go-template-api/pkg/money"
 )
 
 type OrderRepository struct {
 	Db *sql.DB
 }
 
-const PAGE_SIZE = 20
-// Get all user orders
-func (r *OrderRepository) GetAllOrders(page int, userId string) ([]*entities.Order, error) {
-	offset := PAGE_SIZE * (page - 1)
-	query := `SELECT * FROM get_user_orders($1, $2, $3)`
-	rows, err := r.Db.Query(query, userId, offset, PAGE_SIZE)
+const orderColumns = `id, user_id, total_price, discount_total, free_shipping, status, created_at, updated_at`
+
+// Whitelisted order sort fields
+var orderSortFields = map[string]pagination.SortField{
+	"created_at":  {Column: "created_at", Type: "timestamp"},
+	"updated_at":  {Column: "updated_at", Type: "timestamp"},
+	"total_price": {Column: "total_price", Type: "numeric"},
+	"status":      {Column: "status", Type: "numeric"},
+}
+
+// Get all user orders, one keyset page at a time
+func (r *OrderRepository) GetAllOrders(list *entities.ListRequest, userId string) (*entities.List[*entities.Order], error) {
+	page, err := pagination.New(list, orderSortFields, "-created_at")
+	if err != nil {
+		return nil, err
+	}
+
+	var total *int64
+	if list.IncludeTotal {
+		var count int64
+		if err := r.Db.QueryRow(`SELECT COUNT(*) FROM orders WHERE user_id = $1`, userId).Scan(&count); err != nil {
+			fmt.Print(err)
+			return nil, errors.ErrDatabase
+		}
+		total = &count
+	}
+
+	args := []interface{}{userId}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	where := "user_id = $1"
+	if keyset := page.Where(arg); keyset != "" {
+		where += " AND " + keyset
+	}
+	query := fmt.Sprintf(`SELECT %s FROM orders WHERE %s ORDER BY %s LIMIT %s`, orderColumns, where, page.OrderBy(), arg(page.Fetch()))
+	rows, err := r.Db.Query(query, args...)
 	if err != nil {
 		fmt.Print(err)
 		return nil, err
@@ -29,17 +65,37 @@ func (r *OrderRepository) GetAllOrders(page int, userId string) ([]*entities.Ord
 	var orders []*entities.Order
 	for rows.Next() {
 		order := &entities.Order{}
-		if err := rows.Scan(&order.Id, &order.UserId, &order.TotalPrice, &order.Status, &order.CreatedAt, &order.UpdatedAt); err != nil {
+		if err := rows.Scan(orderFields(order)...); err != nil {
 			return nil, errors.ErrDatabase
 		}
 		orders = append(orders, order)
 	}
-	return orders, nil
+
+	res := pagination.Result(page, orders, func(o *entities.Order) (interface{}, string) {
+		return orderSortValue(page.Sort, o), o.Id
+	})
+	res.Total = total
+	return res, nil
 }
 
-// Get order by ID
+// orderSortValue returns the keyset value of an order for the given sort
+func orderSortValue(sort string, order *entities.Order) interface{} {
+	switch strings.TrimPrefix(sort, "-") {
+	case "updated_at":
+		return order.UpdatedAt
+	case "total_price":
+		return order.TotalPrice
+	case "status":
+		return order.Status
+	default:
+		return order.CreatedAt
+	}
+}
+
+// Get order by ID with its discounts
 func (r *OrderRepository) GetById(id string) (*entities.Order, error) {
-	query := `SELECT * FROM get_order($1)`
+	// The orders gained columns the get_order function does not list
+	query := `SELECT ` + orderColumns + ` FROM orders WHERE id = $1`
 	rows, err := r.Db.Query(query, id)
 	if err != nil {
 		fmt.Print(err)
@@ -49,29 +105,79 @@ func (r *OrderRepository) GetById(id string) (*entities.Order, error) {
 
 	order := &entities.Order{}
 	if rows.Next() {
-		err := rows.Scan(&order.Id, &order.UserId, &order.TotalPrice, &order.Status, &order.CreatedAt, &order.UpdatedAt)
+		err := rows.Scan(orderFields(order)...)
 		if err != nil {
 			fmt.Print(err)
 			return nil, errors.ErrDatabase
 		}
 	}
+	if order.Id == "" {
+		return order, nil
+	}
+
+	discounts, err := r.Db.Query(`SELECT promotion_id, coupon_code, name, kind, amount FROM order_discounts WHERE order_id = $1 ORDER BY position`, id)
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	defer discounts.Close()
+	for discounts.Next() {
+		discount := &entities.OrderDiscount{}
+		var promotionId, couponCode sql.NullString
+		if err := discounts.Scan(&promotionId, &couponCode, &discount.Name, &discount.Kind, &discount.Amount); err != nil {
+			fmt.Print(err)
+			return nil, errors.ErrDatabase
+		}
+		if promotionId.Valid {
+			discount.PromotionId = &promotionId.String
+		}
+		discount.CouponCode = couponCode.String
+		order.Discounts = append(order.Discounts, discount)
+	}
 	return order, nil
 }
 
 // Create a new order
 func (r *OrderRepository) Create(orderRequest *entities.OrderRequest) (string, error) {
 	newId := utils.CreateNewUUID().String()
-	_, err := r.Db.Exec(
-		`CALL orders_insert($1, $2, $3, $4::order_detail_type[], $5)`,
+	discounts := orderRequest.Discounts
+	if discounts == nil {
+		discounts = []*entities.OrderDiscount{}
+	}
+	discountsJson, err := json.Marshal(discounts)
+	if err != nil {
+		return "", errors.ErrInternal
+	}
+	_, err = r.Db.Exec(
+		`CALL orders_insert($1, $2, $3, $4::order_detail_type[], $5, $6, $7::jsonb, $8, $9)`,
 		orderRequest.UserId,
 		orderRequest.TotalPrice,
 		orderRequest.Status,
 		pq.Array(orderRequest.OrderDetails),
+		orderRequest.DiscountTotal,
+		orderRequest.FreeShipping,
+		string(discountsJson),
+		orderRequest.CouponId,
 		&newId,
 	)
 
 	if err != nil {
 		fmt.Print(err)
+		// The inventory check constraint rejects orders that would oversell a product
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23514" {
+			return "", errors.ErrOutOfStock
+		}
+		// Unknown and soft deleted products both fail the product reference
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" {
+			return "", errors.ErrProductNotFound
+		}
+		// The coupon was deactivated or used up by concurrent orders since the order was priced
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "P0002" {
+			return "", errors.ErrInvalidCoupon
+		}
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "CP001" {
+			return "", errors.ErrCouponExhausted
+		}
 		return "", errors.ErrDatabase
 	}
 
@@ -79,12 +185,42 @@ func (r *OrderRepository) Create(orderRequest *entities.OrderRequest) (string, e
 	return newId, nil
 }
 
+// Get the catalog prices of the active products, by product id
+func (r *OrderRepository) GetPrices(productIds []string) (map[string]money.Amount, error) {
+	query, err := r.Db.Query(`SELECT id, price FROM products WHERE id = ANY($1) AND deleted_at IS NULL`, pq.Array(productIds))
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	defer query.Close()
+
+	prices := make(map[string]money.Amount)
+	for query.Next() {
+		var id string
+		var price money.Amount
+		if err := query.Scan(&id, &price); err != nil {
+			fmt.Print(err)
+			return nil, errors.ErrDatabase
+		}
+		prices[id] = price
+	}
+	return prices, nil
+}
+
 // Update order status
 func (r *OrderRepository) UpdateStatus(id string, status int) (*entities.Order, error) {
 	_, err := r.Db.Exec("CALL orders_update_status($1, $2)", id, status)
 	if err != nil {
 		fmt.Print(err)
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "55000" {
+			return nil, errors.ErrOrderCancelled
+		}
 		return nil, errors.ErrDatabase
 	}
 	return r.GetById(id)
-}
\ No newline at end of file
+}
+
+// orderFields returns the scan destinations of orderColumns
+func orderFields(order *entities.Order) []interface{} {
+	return []interface{}{&order.Id, &order.UserId, &order.TotalPrice, &order.DiscountTotal, &order.FreeShipping, &order.Status, &order.CreatedAt, &order.UpdatedAt}
+}
@@ -0,0 +1,28 @@
+package repositories_test
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	repositories "github.com/shayja/go-template-api/internal/adapters/repositories/order"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPrices(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.OrderRepository{Db: db}
+
+	// The soft deleted products are left out
+	mock.ExpectQuery("SELECT id, price FROM products WHERE id = ANY\\(\\$1\\) AND deleted_at IS NULL").
+		WithArgs(`{"phone","case"}`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "price"}).AddRow("phone", "99.99"))
+
+	prices, err := repo.GetPrices([]string{"phone", "case"})
+	assert.NoError(t, err)
+	assert.Len(t, prices, 1)
+	assert.Equal(t, "99.99", prices["phone"].String())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,186 @@
+// adapters/repositories/pagination/pagination.go
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/shayja/go-template-api/config"
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/errors"
+)
+
+const (
+	defaultLimit    = 20
+	defaultMaxLimit = 100
+)
+
+// Limits read from the env, falling back to the defaults above.
+var (
+	DefaultLimit = envInt("PAGE_DEFAULT_LIMIT", defaultLimit)
+	MaxLimit     = envInt("PAGE_MAX_LIMIT", defaultMaxLimit)
+)
+
+// SortField is a whitelisted sort key of a collection.
+type SortField struct {
+	// SQL expression the rows are ordered by, never NULL as the keyset comparison skips NULL rows
+	Column string
+	// Postgres type the cursor value is cast to
+	Type string
+}
+
+// Cursor is the decoded keyset position of a listing.
+type Cursor struct {
+	Sort     string      `json:"s"`
+	Value    interface{} `json:"v"`
+	Id       string      `json:"id"`
+	Backward bool        `json:"b,omitempty"`
+}
+
+// Page is a resolved listing request: sort, direction, limit and position.
+type Page struct {
+	Sort   string
+	Field  SortField
+	Desc   bool
+	Limit  int
+	Cursor *Cursor
+	// Column holding the unique row id, used as the keyset tie breaker
+	IdColumn string
+}
+
+// New validates a listing request against the whitelisted sort fields of a collection.
+func New(list *entities.ListRequest, fields map[string]SortField, defaultSort string) (*Page, error) {
+	if list == nil {
+		list = &entities.ListRequest{}
+	}
+
+	sort := strings.TrimSpace(list.Sort)
+	if sort == "" {
+		sort = defaultSort
+	}
+	name := strings.TrimPrefix(sort, "-")
+	field, ok := fields[name]
+	if !ok {
+		return nil, errors.ErrInvalidSort
+	}
+
+	limit := list.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	page := &Page{Sort: sort, Field: field, Desc: strings.HasPrefix(sort, "-"), Limit: limit, IdColumn: "id"}
+
+	if list.Cursor != "" {
+		cursor, err := Decode(list.Cursor)
+		if err != nil || cursor.Sort != sort {
+			return nil, errors.ErrInvalidCursor
+		}
+		page.Cursor = cursor
+	}
+	return page, nil
+}
+
+// Where returns the keyset condition of the page, or an empty string on the first page.
+// arg registers a query argument and returns its placeholder.
+func (p *Page) Where(arg func(interface{}) string) string {
+	if p.Cursor == nil {
+		return ""
+	}
+	op := ">"
+	if p.Desc != p.Cursor.Backward {
+		op = "<"
+	}
+	return fmt.Sprintf("(%s, %s) %s (%s::%s, %s::uuid)", p.Field.Column, p.IdColumn, op, arg(p.Cursor.Value), p.Field.Type, arg(p.Cursor.Id))
+}
+
+// OrderBy returns the ORDER BY expression of the page.
+func (p *Page) OrderBy() string {
+	dir := "ASC"
+	if p.Desc != p.backward() {
+		dir = "DESC"
+	}
+	return fmt.Sprintf("%s %s, %s %s", p.Field.Column, dir, p.IdColumn, dir)
+}
+
+// Fetch returns the number of rows to query, one more than the limit to detect further pages.
+func (p *Page) Fetch() int {
+	return p.Limit + 1
+}
+
+func (p *Page) backward() bool {
+	return p.Cursor != nil && p.Cursor.Backward
+}
+
+// Result trims the fetched rows to the page and builds the next and previous cursors.
+// key returns the sort value and id of an item.
+func Result[T any](p *Page, rows []T, key func(T) (interface{}, string)) *entities.List[T] {
+	more := len(rows) > p.Limit
+	if more {
+		rows = rows[:p.Limit]
+	}
+	if p.backward() {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+	}
+
+	list := &entities.List[T]{Items: rows}
+	if list.Items == nil {
+		list.Items = []T{}
+	}
+	if len(rows) == 0 {
+		return list
+	}
+
+	first, last := rows[0], rows[len(rows)-1]
+	hasNext, hasPrev := more, p.Cursor != nil
+	if p.backward() {
+		hasNext, hasPrev = true, more
+	}
+	if hasNext {
+		value, id := key(last)
+		list.NextCursor = Encode(&Cursor{Sort: p.Sort, Value: value, Id: id})
+	}
+	if hasPrev {
+		value, id := key(first)
+		list.PrevCursor = Encode(&Cursor{Sort: p.Sort, Value: value, Id: id, Backward: true})
+	}
+	return list
+}
+
+// Encode serializes a cursor into an opaque URL safe token.
+func Encode(c *Cursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// Decode parses a token produced by Encode.
+func Decode(token string) (*Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+	cursor := &Cursor{}
+	if err := json.Unmarshal(b, cursor); err != nil {
+		return nil, err
+	}
+	if cursor.Id == "" {
+		return nil, errors.ErrInvalidCursor
+	}
+	return cursor, nil
+}
+
+func envInt(key string, fallback int) int {
+	v, err := strconv.Atoi(config.Config(key))
+	if err != nil || v <= 0 {
+		return fallback
+	}
+	return v
+}
@@ -0,0 +1,121 @@
+package pagination_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/shayja/go-template-api/internal/adapters/repositories/pagination"
+	"github.com/shayja/go-template-api/internal/entities"
+	appErrors "github.com/shayja/go-template-api/internal/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+var fields = map[string]pagination.SortField{
+	"created_at": {Column: "created_at", Type: "timestamp"},
+	"price":      {Column: "price", Type: "numeric"},
+}
+
+type item struct {
+	Id    string
+	Price float64
+}
+
+func key(i item) (interface{}, string) {
+	return i.Price, i.Id
+}
+
+func placeholders() (func(interface{}) string, *[]interface{}) {
+	var args []interface{}
+	return func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}, &args
+}
+
+func TestNew_Defaults(t *testing.T) {
+	page, err := pagination.New(&entities.ListRequest{}, fields, "-created_at")
+
+	assert.NoError(t, err)
+	assert.Equal(t, pagination.DefaultLimit, page.Limit)
+	assert.True(t, page.Desc)
+	assert.Equal(t, "created_at DESC, id DESC", page.OrderBy())
+
+	arg, _ := placeholders()
+	assert.Empty(t, page.Where(arg))
+}
+
+func TestNew_LimitIsCapped(t *testing.T) {
+	page, err := pagination.New(&entities.ListRequest{Limit: pagination.MaxLimit + 1}, fields, "price")
+
+	assert.NoError(t, err)
+	assert.Equal(t, pagination.MaxLimit, page.Limit)
+	assert.Equal(t, pagination.MaxLimit+1, page.Fetch())
+}
+
+func TestNew_InvalidSort(t *testing.T) {
+	page, err := pagination.New(&entities.ListRequest{Sort: "-password"}, fields, "price")
+
+	assert.ErrorIs(t, err, appErrors.ErrInvalidSort)
+	assert.Nil(t, page)
+}
+
+func TestNew_InvalidCursor(t *testing.T) {
+	_, err := pagination.New(&entities.ListRequest{Cursor: "not-a-cursor"}, fields, "price")
+	assert.ErrorIs(t, err, appErrors.ErrInvalidCursor)
+
+	// A cursor issued for another sort order is rejected
+	cursor := pagination.Encode(&pagination.Cursor{Sort: "price", Value: 10.0, Id: "a"})
+	_, err = pagination.New(&entities.ListRequest{Cursor: cursor, Sort: "-price"}, fields, "price")
+	assert.ErrorIs(t, err, appErrors.ErrInvalidCursor)
+}
+
+func TestResult_ForwardPages(t *testing.T) {
+	page, _ := pagination.New(&entities.ListRequest{Limit: 2}, fields, "price")
+
+	list := pagination.Result(page, []item{{"a", 1}, {"b", 2}, {"c", 3}}, key)
+	assert.Equal(t, []item{{"a", 1}, {"b", 2}}, list.Items)
+	assert.Empty(t, list.PrevCursor)
+	assert.NotEmpty(t, list.NextCursor)
+
+	page, err := pagination.New(&entities.ListRequest{Limit: 2, Cursor: list.NextCursor}, fields, "price")
+	assert.NoError(t, err)
+
+	arg, args := placeholders()
+	assert.Equal(t, "(price, id) > ($1::numeric, $2::uuid)", page.Where(arg))
+	assert.Equal(t, []interface{}{2.0, "b"}, *args)
+
+	list = pagination.Result(page, []item{{"c", 3}}, key)
+	assert.Equal(t, []item{{"c", 3}}, list.Items)
+	assert.Empty(t, list.NextCursor)
+	assert.NotEmpty(t, list.PrevCursor)
+}
+
+func TestResult_BackwardPage(t *testing.T) {
+	cursor := pagination.Encode(&pagination.Cursor{Sort: "price", Value: 3.0, Id: "c", Backward: true})
+	page, err := pagination.New(&entities.ListRequest{Limit: 1, Cursor: cursor}, fields, "price")
+	assert.NoError(t, err)
+
+	arg, _ := placeholders()
+	assert.Equal(t, "(price, id) < ($1::numeric, $2::uuid)", page.Where(arg))
+	assert.Equal(t, "price DESC, id DESC", page.OrderBy())
+
+	// Rows arrive in reverse order and are flipped back
+	list := pagination.Result(page, []item{{"b", 2}, {"a", 1}}, key)
+	assert.Equal(t, []item{{"b", 2}}, list.Items)
+	assert.NotEmpty(t, list.NextCursor)
+	assert.NotEmpty(t, list.PrevCursor)
+
+	list = pagination.Result(page, []item{{"b", 2}}, key)
+	assert.NotEmpty(t, list.NextCursor)
+	assert.Empty(t, list.PrevCursor)
+}
+
+func TestResult_Empty(t *testing.T) {
+	page, _ := pagination.New(nil, fields, "price")
+
+	list := pagination.Result(page, nil, key)
+	assert.NotNil(t, list.Items)
+	assert.Empty(t, list.Items)
+	assert.Empty(t, list.NextCursor)
+	assert.Empty(t, list.PrevCursor)
+}
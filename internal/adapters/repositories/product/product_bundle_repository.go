@@ -0,0 +1,170 @@
+// adapters/repositories/product_bundle_repository.go
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/errors"
+)
+
+// GetBundle returns the bundle of a product with its components and their stock, nil when the product is not a bundle
+func (m *ProductRepository) GetBundle(productId string) (*entities.Bundle, error) {
+	bundle := &entities.Bundle{Components: []*entities.BundleComponent{}}
+	err := m.Db.QueryRow("SELECT discount_percent FROM product_bundles WHERE product_id = $1", productId).Scan(&bundle.DiscountPercent)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+
+	query, err := m.Db.Query(`SELECT p.id, p.name, p.description, p.image, p.price, p.sku, p.updated_at, p.created_at, p.deleted_at, p.image_status, p.image_renditions,
+		p.rating_average, p.rating_count, p.version, p.attributes, p.tags, bc.quantity, i.quantity
+		FROM bundle_components bc JOIN products p ON p.id = bc.component_id LEFT JOIN inventory i ON i.product_id = p.id
+		WHERE bc.bundle_id = $1 ORDER BY bc.position`, productId)
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	defer query.Close()
+
+	for query.Next() {
+		component := &entities.BundleComponent{Product: &entities.Product{}}
+		product := component.Product
+		var deletedAt sql.NullTime
+		var imageStatus sql.NullString
+		var renditions, attributes []byte
+		var tags pq.StringArray
+		var stock sql.NullInt64
+		err := query.Scan(&product.Id, &product.Name, &product.Description, &product.ImageURL, &product.Price, &product.Sku, &product.UpdatedAt, &product.CreatedAt, &deletedAt, &imageStatus, &renditions, &product.RatingAverage, &product.RatingCount, &product.Version, &attributes, &tags, &component.Quantity, &stock)
+		if err != nil {
+			fmt.Print(err)
+			return nil, errors.ErrDatabase
+		}
+		if deletedAt.Valid {
+			product.DeletedAt = &deletedAt.Time
+		}
+		if stock.Valid {
+			quantity := int(stock.Int64)
+			component.Stock = &quantity
+		}
+		if err := scanImage(product, imageStatus, renditions); err != nil {
+			return nil, err
+		}
+		if err := scanAttributes(product, attributes, tags); err != nil {
+			return nil, err
+		}
+		bundle.Components = append(bundle.Components, component)
+	}
+	return bundle, nil
+}
+
+// SetBundle makes an active product a bundle of the components or replaces its components, pricing it
+// from the components when the bundle has a discount
+func (m *ProductRepository) SetBundle(productId string, request *entities.BundleRequest) error {
+	tx, err := m.Db.Begin()
+	if err != nil {
+		fmt.Print(err)
+		return errors.ErrDatabase
+	}
+	defer tx.Rollback()
+
+	ids := make([]string, len(request.Components))
+	quantities := make([]int64, len(request.Components))
+	for i, component := range request.Components {
+		ids[i] = component.ProductId
+		quantities[i] = int64(component.Quantity)
+	}
+
+	// Serializes the bundle changes of the product and its components, so that two bundles made of
+	// each other cannot both pass the nesting check. The rows are locked in id order to not deadlock
+	query, err := tx.Query("SELECT id, deleted_at IS NULL FROM products WHERE id = ANY($1) ORDER BY id FOR UPDATE",
+		pq.Array(append([]string{productId}, ids...)))
+	if err != nil {
+		fmt.Print(err)
+		return errors.ErrDatabase
+	}
+	found := false
+	for query.Next() {
+		var id string
+		var active bool
+		if err := query.Scan(&id, &active); err != nil {
+			query.Close()
+			fmt.Print(err)
+			return errors.ErrDatabase
+		}
+		found = found || (id == productId && active)
+	}
+	query.Close()
+	if err := query.Err(); err != nil {
+		fmt.Print(err)
+		return errors.ErrDatabase
+	}
+	if !found {
+		return errors.ErrProductNotFound
+	}
+
+	// Bundles do not nest and the component lines of an order have no variant
+	var nested bool
+	var valid int
+	err = tx.QueryRow(`SELECT EXISTS (SELECT 1 FROM bundle_components WHERE component_id = $1),
+		(SELECT COUNT(*) FROM products p WHERE p.id = ANY($2) AND p.id <> $1 AND p.deleted_at IS NULL
+			AND NOT EXISTS (SELECT 1 FROM product_bundles b WHERE b.product_id = p.id)
+			AND NOT EXISTS (SELECT 1 FROM product_variants v WHERE v.product_id = p.id))`,
+		productId, pq.Array(ids)).Scan(&nested, &valid)
+	if err != nil {
+		fmt.Print(err)
+		return errors.ErrDatabase
+	}
+	if nested {
+		return errors.New(errors.ErrInvalidBundle.Code, "The product is a component of another bundle", nil)
+	}
+	if valid != len(ids) {
+		return errors.New(errors.ErrInvalidBundle.Code, "The components must be active products without variants that are not bundles", nil)
+	}
+
+	_, err = tx.Exec(`INSERT INTO product_bundles (product_id, discount_percent) VALUES ($1, $2)
+		ON CONFLICT (product_id) DO UPDATE SET discount_percent = EXCLUDED.discount_percent, updated_at = NOW()`, productId, request.DiscountPercent)
+	if err != nil {
+		fmt.Print(err)
+		return errors.ErrDatabase
+	}
+	if _, err := tx.Exec("DELETE FROM bundle_components WHERE bundle_id = $1", productId); err != nil {
+		fmt.Print(err)
+		return errors.ErrDatabase
+	}
+	_, err = tx.Exec(`INSERT INTO bundle_components (bundle_id, component_id, quantity, position)
+		SELECT $1, c.id, c.quantity, c.position - 1 FROM unnest($2::uuid[], $3::integer[]) WITH ORDINALITY AS c(id, quantity, position)`,
+		productId, pq.Array(ids), pq.Array(quantities))
+	if err != nil {
+		fmt.Print(err)
+		return errors.ErrDatabase
+	}
+	if _, err := tx.Exec("SELECT refresh_bundle_prices(ARRAY[$1]::uuid[])", productId); err != nil {
+		fmt.Print(err)
+		return errors.ErrDatabase
+	}
+
+	if err := tx.Commit(); err != nil {
+		fmt.Print(err)
+		return errors.ErrDatabase
+	}
+	return nil
+}
+
+// DeleteBundle makes a bundle a plain product again, keeping its last price
+func (m *ProductRepository) DeleteBundle(productId string) error {
+	res, err := m.Db.Exec("DELETE FROM product_bundles WHERE product_id = $1", productId)
+	if err != nil {
+		fmt.Print(err)
+		return errors.ErrDatabase
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return errors.ErrBundleNotFound
+	}
+	return nil
+}
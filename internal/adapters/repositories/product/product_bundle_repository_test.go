@@ -0,0 +1,148 @@
+package repositories_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	repositories "github.com/shayja/go-template-api/internal/adapters/repositories/product"
+	"github.com/shayja/go-template-api/internal/entities"
+	appErrors "github.com/shayja/go-template-api/internal/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+var bundleComponentColumns = []string{"id", "name", "description", "image", "price", "sku", "updated_at", "created_at", "deleted_at", "image_status", "image_renditions", "rating_average", "rating_count", "version", "attributes", "tags", "quantity", "quantity"}
+
+func TestGetBundle(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ProductRepository{Db: db}
+
+	mock.ExpectQuery("SELECT discount_percent FROM product_bundles WHERE product_id = \\$1").
+		WithArgs("kit").
+		WillReturnRows(sqlmock.NewRows([]string{"discount_percent"}).AddRow("15.00"))
+	mock.ExpectQuery("FROM bundle_components bc JOIN products p ON p.id = bc.component_id LEFT JOIN inventory i ON i.product_id = p.id WHERE bc.bundle_id = \\$1 ORDER BY bc.position").
+		WithArgs("kit").
+		WillReturnRows(sqlmock.NewRows(bundleComponentColumns).
+			AddRow("phone", "Phone", "", "", "99.99", "PH-1", time.Now(), time.Now(), nil, nil, nil, 0, 0, 1, nil, "{}", 1, 5).
+			AddRow("charger", "Charger", "", "", "9.99", "CH-1", time.Now(), time.Now(), nil, nil, nil, 0, 0, 1, nil, "{}", 2, nil))
+
+	bundle, err := repo.GetBundle("kit")
+	assert.NoError(t, err)
+	assert.Equal(t, "15.00", bundle.DiscountPercent.String())
+	assert.Len(t, bundle.Components, 2)
+	assert.Equal(t, 5, *bundle.Components[0].Stock)
+	assert.Equal(t, 2, bundle.Components[1].Quantity)
+	assert.Nil(t, bundle.Components[1].Stock)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetBundle_NotABundle(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ProductRepository{Db: db}
+
+	mock.ExpectQuery("SELECT discount_percent FROM product_bundles").
+		WithArgs("phone").
+		WillReturnRows(sqlmock.NewRows([]string{"discount_percent"}))
+
+	bundle, err := repo.GetBundle("phone")
+	assert.NoError(t, err)
+	assert.Nil(t, bundle)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetBundle(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ProductRepository{Db: db}
+	request := &entities.BundleRequest{Components: []entities.BundleComponentRequest{{ProductId: "phone", Quantity: 1}, {ProductId: "charger", Quantity: 2}}}
+
+	mock.ExpectBegin()
+	// The bundle and its components are locked in id order
+	mock.ExpectQuery("SELECT id, deleted_at IS NULL FROM products WHERE id = ANY\\(\\$1\\) ORDER BY id FOR UPDATE").
+		WithArgs(`{"kit","phone","charger"}`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "active"}).AddRow("charger", true).AddRow("kit", true).AddRow("phone", true))
+	mock.ExpectQuery("SELECT EXISTS \\(SELECT 1 FROM bundle_components WHERE component_id = \\$1\\)").
+		WithArgs("kit", `{"phone","charger"}`).
+		WillReturnRows(sqlmock.NewRows([]string{"exists", "count"}).AddRow(false, 2))
+	mock.ExpectExec("INSERT INTO product_bundles \\(product_id, discount_percent\\) VALUES \\(\\$1, \\$2\\) ON CONFLICT").
+		WithArgs("kit", nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM bundle_components WHERE bundle_id = \\$1").
+		WithArgs("kit").
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec("INSERT INTO bundle_components (.+) FROM unnest\\(\\$2::uuid\\[\\], \\$3::integer\\[\\]\\) WITH ORDINALITY").
+		WithArgs("kit", `{"phone","charger"}`, "{1,2}").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("SELECT refresh_bundle_prices\\(ARRAY\\[\\$1\\]::uuid\\[\\]\\)").
+		WithArgs("kit").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	assert.NoError(t, repo.SetBundle("kit", request))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetBundle_InvalidComponent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ProductRepository{Db: db}
+	request := &entities.BundleRequest{Components: []entities.BundleComponentRequest{{ProductId: "phone", Quantity: 1}, {ProductId: "other-kit", Quantity: 1}}}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id, deleted_at IS NULL FROM products").
+		WithArgs(`{"kit","phone","other-kit"}`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "active"}).AddRow("kit", true).AddRow("other-kit", true).AddRow("phone", true))
+	// The other kit is a bundle itself
+	mock.ExpectQuery("SELECT EXISTS").
+		WithArgs("kit", `{"phone","other-kit"}`).
+		WillReturnRows(sqlmock.NewRows([]string{"exists", "count"}).AddRow(false, 1))
+	mock.ExpectRollback()
+
+	err = repo.SetBundle("kit", request)
+	assert.ErrorContains(t, err, appErrors.ErrInvalidBundle.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetBundle_ProductNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ProductRepository{Db: db}
+	request := &entities.BundleRequest{Components: []entities.BundleComponentRequest{{ProductId: "phone", Quantity: 1}}}
+
+	// The bundle product was soft deleted
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id, deleted_at IS NULL FROM products").
+		WithArgs(`{"kit","phone"}`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "active"}).AddRow("kit", false).AddRow("phone", true))
+	mock.ExpectRollback()
+
+	assert.ErrorIs(t, repo.SetBundle("kit", request), appErrors.ErrProductNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteBundle_NotABundle(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ProductRepository{Db: db}
+
+	mock.ExpectExec("DELETE FROM product_bundles WHERE product_id = \\$1").
+		WithArgs("phone").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.ErrorIs(t, repo.DeleteBundle("phone"), appErrors.ErrBundleNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
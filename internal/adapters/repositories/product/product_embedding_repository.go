@@ -0,0 +1,149 @@
+// adapters/repositories/product_embedding_repository.go
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/shayja/go-template-api/internal/adapters/repositories/pagination"
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/errors"
+	"github.com/shayja/go-template-api/pkg/embedding"
+)
+
+// The embedded text of a product and its md5, compared to the source hash of the stored vector
+const embeddingText = `p.name || E'\n' || COALESCE(p.description, '')`
+
+// The fewest candidates the vector index and the full-text search each add to the ranking, the
+// index returns up to hnsw.ef_search (40 by default) nearest products per scan
+const minSemanticCandidates = 40
+
+// Get the active products that have no vector of the model yet, or whose name or description changed since
+func (m *ProductRepository) GetStaleEmbeddings(model string, limit int) ([]*entities.EmbeddingJob, error) {
+	query, err := m.Db.Query(`SELECT p.id, `+embeddingText+`, md5(`+embeddingText+`) FROM products p
+		LEFT JOIN product_embeddings e ON e.product_id = p.id
+		WHERE p.deleted_at IS NULL AND (e.product_id IS NULL OR e.model <> $1 OR e.source_hash <> md5(`+embeddingText+`))
+		ORDER BY p.updated_at LIMIT $2`, model, limit)
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	defer query.Close()
+
+	jobs := []*entities.EmbeddingJob{}
+	for query.Next() {
+		job := &entities.EmbeddingJob{}
+		if err := query.Scan(&job.ProductId, &job.Text, &job.SourceHash); err != nil {
+			fmt.Print(err)
+			return nil, errors.ErrDatabase
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// Get the length of the vectors the product_embeddings table stores, the type modifier of its vector column
+func (m *ProductRepository) GetEmbeddingDimensions() (int, error) {
+	var dimensions int
+	err := m.Db.QueryRow(`SELECT atttypmod FROM pg_attribute
+		WHERE attrelid = 'product_embeddings'::regclass AND attname = 'embedding' AND NOT attisdropped`).Scan(&dimensions)
+	if err != nil {
+		fmt.Print(err)
+		return 0, errors.ErrDatabase
+	}
+	return dimensions, nil
+}
+
+// Store the vector of a product, replacing its previous vector. Products deleted meanwhile are skipped
+func (m *ProductRepository) SaveEmbedding(productId string, model string, sourceHash string, vector []float32) error {
+	_, err := m.Db.Exec(`INSERT INTO product_embeddings (product_id, embedding, model, source_hash, embedded_at)
+		SELECT id, $2::vector, $3, $4, NOW() FROM products WHERE id = $1
+		ON CONFLICT (product_id) DO UPDATE SET embedding = EXCLUDED.embedding, model = EXCLUDED.model,
+		source_hash = EXCLUDED.source_hash, embedded_at = EXCLUDED.embedded_at`,
+		productId, embedding.Format(vector), model, sourceHash)
+	if err != nil {
+		fmt.Print(err)
+		return errors.ErrDatabase
+	}
+	return nil
+}
+
+// Find the active products matching the filter that are most similar to the query vector. The score
+// blends the cosine similarity with the full-text rank of the query by the text weight, the full-text
+// matches are ranked too so products that are not embedded yet can still be found
+func (m *ProductRepository) SemanticSearch(vector []float32, query string, textWeight float64, filter *entities.ProductFilter, limit int) ([]*entities.ProductMatch, error) {
+	if limit <= 0 {
+		limit = pagination.DefaultLimit
+	}
+	limit = min(limit, pagination.MaxLimit)
+
+	var args []interface{}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	// The query is matched by meaning, not required to match the full-text search
+	conditions := *filter
+	conditions.Query = ""
+	conditions.IncludeDeleted = false
+	where, _, err := filterConditions(&conditions, arg)
+	if err != nil {
+		return nil, err
+	}
+	queryVector := arg(embedding.Format(vector)) + "::vector"
+	tsQuery := fmt.Sprintf("websearch_to_tsquery('english', %s)", arg(query))
+	candidates := arg(max(minSemanticCandidates, limit*4))
+
+	candidateQueries := []string{fmt.Sprintf(`(SELECT id FROM products JOIN product_embeddings ON product_id = id%s ORDER BY embedding <=> %s LIMIT %s)`,
+		whereClause(where), queryVector, candidates)}
+	if textWeight > 0 {
+		textWhere := append(append([]string{}, where...), fmt.Sprintf("search_vector @@ %s", tsQuery))
+		candidateQueries = append(candidateQueries, fmt.Sprintf(`(SELECT id FROM products%s ORDER BY ts_rank(search_vector, %s) DESC LIMIT %s)`,
+			whereClause(textWhere), tsQuery, candidates))
+	}
+
+	// The rank is normalized to [0, 1) by the 32 flag, like the similarity of most matches
+	weight := arg(textWeight) + "::float8"
+	SQL := fmt.Sprintf(`WITH candidates AS (%s)
+		SELECT * FROM (SELECT p.id, p.name, p.description, p.image, p.price, p.sku, p.updated_at, p.created_at, p.image_status, p.image_renditions,
+			p.rating_average, p.rating_count, p.version, p.attributes, p.tags,
+			COALESCE(1 - (e.embedding <=> %s), 0) AS similarity, ts_rank(p.search_vector, %s, 32) AS rank
+			FROM products p LEFT JOIN product_embeddings e ON e.product_id = p.id
+			WHERE p.id IN (SELECT id FROM candidates)) matches
+		ORDER BY (1 - %s) * similarity + %s * rank DESC, id LIMIT %s`,
+		strings.Join(candidateQueries, " UNION "), queryVector, tsQuery, weight, weight, arg(limit))
+
+	rows, err := m.Db.Query(SQL, args...)
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	defer rows.Close()
+
+	matches := []*entities.ProductMatch{}
+	for rows.Next() {
+		match := &entities.ProductMatch{Product: &entities.Product{}}
+		product := match.Product
+		var imageStatus sql.NullString
+		var renditions, attributes []byte
+		var tags pq.StringArray
+		var rank float64
+		err := rows.Scan(&product.Id, &product.Name, &product.Description, &product.ImageURL, &product.Price, &product.Sku, &product.UpdatedAt, &product.CreatedAt, &imageStatus, &renditions, &product.RatingAverage, &product.RatingCount, &product.Version, &attributes, &tags, &match.Similarity, &rank)
+		if err != nil {
+			fmt.Print(err)
+			return nil, errors.ErrDatabase
+		}
+		if err := scanImage(product, imageStatus, renditions); err != nil {
+			return nil, err
+		}
+		if err := scanAttributes(product, attributes, tags); err != nil {
+			return nil, err
+		}
+		match.Score = (1-textWeight)*match.Similarity + textWeight*rank
+		matches = append(matches, match)
+	}
+	return matches, nil
+}
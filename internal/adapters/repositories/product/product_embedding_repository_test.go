@@ -0,0 +1,103 @@
+package repositories_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	repositories "github.com/shayja/go-template-api/internal/adapters/repositories/product"
+	"github.com/shayja/go-template-api/internal/entities"
+	appErrors "github.com/shayja/go-template-api/internal/errors"
+	"github.com/shayja/go-template-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetStaleEmbeddings(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ProductRepository{Db: db}
+
+	mock.ExpectQuery("SELECT p.id, (.+) FROM products p LEFT JOIN product_embeddings e ON e.product_id = p.id WHERE p.deleted_at IS NULL AND \\(e.product_id IS NULL OR e.model <> \\$1 OR e.source_hash <> md5\\((.+)\\)\\) ORDER BY p.updated_at LIMIT \\$2").
+		WithArgs("local-hash-384", 50).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "text", "hash"}).
+			AddRow("1", "Nokia 3310\nA phone", "0cc175b9c0f1b6a831c399e269772661"))
+
+	jobs, err := repo.GetStaleEmbeddings("local-hash-384", 50)
+	assert.NoError(t, err)
+	assert.Equal(t, []*entities.EmbeddingJob{{ProductId: "1", Text: "Nokia 3310\nA phone", SourceHash: "0cc175b9c0f1b6a831c399e269772661"}}, jobs)
+}
+
+func TestSaveEmbedding(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ProductRepository{Db: db}
+
+	mock.ExpectExec("INSERT INTO product_embeddings (.+) SELECT id, \\$2::vector, \\$3, \\$4, NOW\\(\\) FROM products WHERE id = \\$1 ON CONFLICT \\(product_id\\) DO UPDATE").
+		WithArgs("1", "[0.6,0.8]", "local-hash-2", "0cc175b9c0f1b6a831c399e269772661").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.SaveEmbedding("1", "local-hash-2", "0cc175b9c0f1b6a831c399e269772661", []float32{0.6, 0.8})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetEmbeddingDimensions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ProductRepository{Db: db}
+
+	mock.ExpectQuery("SELECT atttypmod FROM pg_attribute WHERE attrelid = 'product_embeddings'::regclass AND attname = 'embedding'").
+		WillReturnRows(sqlmock.NewRows([]string{"atttypmod"}).AddRow(384))
+
+	dimensions, err := repo.GetEmbeddingDimensions()
+	assert.NoError(t, err)
+	assert.Equal(t, 384, dimensions)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+var matchColumns = []string{"id", "name", "description", "image", "price", "sku", "updated_at", "created_at", "image_status", "image_renditions", "rating_average", "rating_count", "version", "attributes", "tags", "similarity", "rank"}
+
+func TestSemanticSearch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ProductRepository{Db: db}
+
+	maxPrice := money.New(200, 0)
+	mock.ExpectQuery("WITH candidates AS \\(\\(SELECT id FROM products JOIN product_embeddings ON product_id = id WHERE deleted_at IS NULL AND price <= \\$1 ORDER BY embedding <=> \\$2::vector LIMIT \\$4\\) UNION \\(SELECT id FROM products WHERE deleted_at IS NULL AND price <= \\$1 AND search_vector @@ websearch_to_tsquery\\('english', \\$3\\) ORDER BY ts_rank\\(search_vector, websearch_to_tsquery\\('english', \\$3\\)\\) DESC LIMIT \\$4\\)\\)(.+)ORDER BY \\(1 - \\$5::float8\\) \\* similarity \\+ \\$5::float8 \\* rank DESC, id LIMIT \\$6").
+		WithArgs("200.00", "[1,0]", "headphones", 40, 0.5, 5).
+		WillReturnRows(sqlmock.NewRows(matchColumns).
+			AddRow("1", "Wireless Headphones", "", "", 99.0, "wh-1", time.Now(), time.Now(), nil, nil, 0, 0, 1, nil, nil, 0.8, 0.4).
+			AddRow("2", "Headphone Stand", "", "", 19.0, "hs-1", time.Now(), time.Now(), nil, nil, 0, 0, 1, nil, nil, 0, 0.5))
+
+	matches, err := repo.SemanticSearch([]float32{1, 0}, "headphones", 0.5, &entities.ProductFilter{Query: "ignored", MaxPrice: &maxPrice}, 5)
+	assert.NoError(t, err)
+	assert.Len(t, matches, 2)
+	assert.Equal(t, "Wireless Headphones", matches[0].Name)
+	assert.InDelta(t, 0.6, matches[0].Score, 1e-9)
+	assert.InDelta(t, 0.25, matches[1].Score, 1e-9)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSemanticSearch_SimilarityOnly(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ProductRepository{Db: db}
+
+	mock.ExpectQuery("WITH candidates AS \\(\\(SELECT id FROM products JOIN product_embeddings ON product_id = id WHERE deleted_at IS NULL ORDER BY embedding <=> \\$1::vector LIMIT \\$3\\)\\)").
+		WithArgs("[1,0]", "headphones", 80, 0.0, 20).
+		WillReturnError(sqlmock.ErrCancelled)
+
+	_, err = repo.SemanticSearch([]float32{1, 0}, "headphones", 0, &entities.ProductFilter{}, 0)
+	assert.Equal(t, appErrors.ErrDatabase, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
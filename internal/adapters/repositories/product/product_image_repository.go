@@ -0,0 +1,287 @@
+// adapters/repositories/product_image_repository.go
+package repositories
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/errors"
+)
+
+const productImageColumns = `id, product_id, COALESCE(url, ''), alt_text, sort_order, is_primary, status, COALESCE(source, ''), renditions, created_at, updated_at`
+
+// Add an uploaded image waiting to be processed to the end of the gallery of an active product.
+// The first image of a product is always primary.
+func (m *ProductRepository) CreateImage(productId string, source string, altText string, primary bool) (string, error) {
+	tx, err := m.Db.Begin()
+	if err != nil {
+		fmt.Print(err)
+		return "", errors.ErrDatabase
+	}
+	defer tx.Rollback()
+
+	// Serializes the gallery changes of the product
+	var locked string
+	err = tx.QueryRow("SELECT id FROM products WHERE id = $1 AND deleted_at IS NULL FOR UPDATE", productId).Scan(&locked)
+	if err == sql.ErrNoRows {
+		return "", errors.ErrProductNotFound
+	}
+	if err != nil {
+		fmt.Print(err)
+		return "", errors.ErrDatabase
+	}
+
+	var count, position int
+	err = tx.QueryRow("SELECT COUNT(*), COALESCE(MAX(sort_order) + 1, 0) FROM product_images WHERE product_id = $1", productId).Scan(&count, &position)
+	if err != nil {
+		fmt.Print(err)
+		return "", errors.ErrDatabase
+	}
+	primary = primary || count == 0
+	if primary {
+		if _, err := tx.Exec("UPDATE product_images SET is_primary = false, updated_at = NOW() WHERE product_id = $1 AND is_primary", productId); err != nil {
+			fmt.Print(err)
+			return "", errors.ErrDatabase
+		}
+	}
+
+	var id string
+	err = tx.QueryRow(`INSERT INTO product_images (product_id, alt_text, sort_order, is_primary, status, source, queued_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW()) RETURNING id`,
+		productId, altText, position, primary, entities.ImageStatusPending, source).Scan(&id)
+	if err != nil {
+		fmt.Print(err)
+		return "", errors.ErrDatabase
+	}
+
+	if err := tx.Commit(); err != nil {
+		fmt.Print(err)
+		return "", errors.ErrDatabase
+	}
+	return id, nil
+}
+
+// Get a gallery image of a product, nil when it does not exist
+func (m *ProductRepository) GetImage(productId string, imageId string) (*entities.ProductImage, error) {
+	query, err := m.Db.Query(`SELECT `+productImageColumns+` FROM product_images WHERE product_id = $1 AND id = $2`, productId, imageId)
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	defer query.Close()
+
+	if !query.Next() {
+		return nil, nil
+	}
+	return scanProductImage(query)
+}
+
+// Get the galleries of the given products in display order, keyed by product id
+func (m *ProductRepository) GetImages(productIds []string) (map[string][]*entities.ProductImage, error) {
+	query, err := m.Db.Query(`SELECT `+productImageColumns+` FROM product_images
+		WHERE product_id = ANY($1::uuid[]) ORDER BY product_id, sort_order, created_at`, pq.Array(productIds))
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	defer query.Close()
+
+	galleries := map[string][]*entities.ProductImage{}
+	for query.Next() {
+		image, err := scanProductImage(query)
+		if err != nil {
+			return nil, err
+		}
+		galleries[image.ProductId] = append(galleries[image.ProductId], image)
+	}
+	return galleries, nil
+}
+
+// Update the alternative text of a gallery image or make it the primary image
+func (m *ProductRepository) UpdateGalleryImage(productId string, imageId string, update *entities.ProductImageUpdate) (bool, error) {
+	tx, err := m.Db.Begin()
+	if err != nil {
+		fmt.Print(err)
+		return false, errors.ErrDatabase
+	}
+	defer tx.Rollback()
+
+	if update.Primary != nil && *update.Primary {
+		if _, err := tx.Exec("UPDATE product_images SET is_primary = false, updated_at = NOW() WHERE product_id = $1 AND is_primary AND id <> $2", productId, imageId); err != nil {
+			fmt.Print(err)
+			return false, errors.ErrDatabase
+		}
+	}
+
+	// Unsetting the primary flag is ignored, another image has to be made primary instead
+	res, err := tx.Exec(`UPDATE product_images SET alt_text = COALESCE($3, alt_text), is_primary = is_primary OR COALESCE($4, false), updated_at = NOW()
+		WHERE product_id = $1 AND id = $2`, productId, imageId, update.AltText, update.Primary)
+	if err != nil {
+		fmt.Print(err)
+		return false, errors.ErrDatabase
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return false, nil
+	}
+
+	if err := tx.Commit(); err != nil {
+		fmt.Print(err)
+		return false, errors.ErrDatabase
+	}
+	return true, nil
+}
+
+// Reorder the gallery of a product, the ids must be all the images of the product
+func (m *ProductRepository) ReorderImages(productId string, imageIds []string) error {
+	tx, err := m.Db.Begin()
+	if err != nil {
+		fmt.Print(err)
+		return errors.ErrDatabase
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(`UPDATE product_images SET sort_order = array_position($2::uuid[], id) - 1, updated_at = NOW()
+		WHERE product_id = $1 AND id = ANY($2::uuid[])`, productId, pq.Array(imageIds))
+	if err != nil {
+		fmt.Print(err)
+		return errors.ErrDatabase
+	}
+	var total int64
+	if err := tx.QueryRow("SELECT COUNT(*) FROM product_images WHERE product_id = $1", productId).Scan(&total); err != nil {
+		fmt.Print(err)
+		return errors.ErrDatabase
+	}
+	if n, _ := res.RowsAffected(); n != int64(len(imageIds)) || total != n {
+		return errors.ErrInvalidImageOrder
+	}
+
+	if err := tx.Commit(); err != nil {
+		fmt.Print(err)
+		return errors.ErrDatabase
+	}
+	return nil
+}
+
+// Delete a gallery image, returning it. When it was primary the first remaining image becomes primary.
+func (m *ProductRepository) DeleteImage(productId string, imageId string) (*entities.ProductImage, error) {
+	tx, err := m.Db.Begin()
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	defer tx.Rollback()
+
+	query, err := tx.Query(`DELETE FROM product_images WHERE product_id = $1 AND id = $2 RETURNING `+productImageColumns, productId, imageId)
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	if !query.Next() {
+		query.Close()
+		return nil, errors.ErrImageNotFound
+	}
+	image, err := scanProductImage(query)
+	query.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	if image.Primary {
+		_, err := tx.Exec(`UPDATE product_images SET is_primary = true, updated_at = NOW()
+			WHERE id = (SELECT id FROM product_images WHERE product_id = $1 ORDER BY sort_order, created_at LIMIT 1)`, productId)
+		if err != nil {
+			fmt.Print(err)
+			return nil, errors.ErrDatabase
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	return image, nil
+}
+
+// Get the source images waiting to be processed since before the given time, oldest first
+func (m *ProductRepository) GetPendingImages(queuedBefore time.Time, limit int) ([]*entities.ImageJob, error) {
+	query, err := m.Db.Query(`SELECT product_id, id, source FROM product_images
+		WHERE source IS NOT NULL AND queued_at < $1 ORDER BY queued_at LIMIT $2`, queuedBefore, limit)
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	defer query.Close()
+
+	jobs := []*entities.ImageJob{}
+	for query.Next() {
+		job := &entities.ImageJob{}
+		if err := query.Scan(&job.ProductId, &job.ImageId, &job.Source); err != nil {
+			fmt.Print(err)
+			return nil, errors.ErrDatabase
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// Set the processed renditions of a gallery image, false when the image was deleted meanwhile
+func (m *ProductRepository) CompleteImage(imageId string, source string, url string, renditions []entities.ImageRendition) (bool, error) {
+	data, err := json.Marshal(renditions)
+	if err != nil {
+		return false, err
+	}
+	res, err := m.Db.Exec(`UPDATE product_images SET url = $3, renditions = $4, status = $5, source = NULL, queued_at = NULL, updated_at = NOW()
+		WHERE id = $1 AND source = $2`, imageId, source, url, string(data), entities.ImageStatusReady)
+	if err != nil {
+		fmt.Print(err)
+		return false, errors.ErrDatabase
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+// Mark a gallery image that could not be processed as failed
+func (m *ProductRepository) FailImage(imageId string, source string) error {
+	_, err := m.Db.Exec(`UPDATE product_images SET status = $3, source = NULL, queued_at = NULL, updated_at = NOW()
+		WHERE id = $1 AND source = $2`, imageId, source, entities.ImageStatusFailed)
+	if err != nil {
+		fmt.Print(err)
+		return errors.ErrDatabase
+	}
+	return nil
+}
+
+func scanProductImage(query *sql.Rows) (*entities.ProductImage, error) {
+	image := &entities.ProductImage{}
+	var renditions []byte
+	err := query.Scan(&image.Id, &image.ProductId, &image.URL, &image.AltText, &image.SortOrder, &image.Primary, &image.Status, &image.Source, &renditions, &image.CreatedAt, &image.UpdatedAt)
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	if err := unmarshalRenditions(renditions, &image.Renditions); err != nil {
+		return nil, err
+	}
+	return image, nil
+}
+
+// scanImage sets the image columns of the primary gallery image on a scanned product
+func scanImage(product *entities.Product, status sql.NullString, renditions []byte) error {
+	product.ImageStatus = status.String
+	return unmarshalRenditions(renditions, &product.Renditions)
+}
+
+func unmarshalRenditions(data []byte, renditions *[]entities.ImageRendition) error {
+	if len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, renditions); err != nil {
+		fmt.Print(err)
+		return errors.ErrDatabase
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package repositories_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	repositories "github.com/shayja/go-template-api/internal/adapters/repositories/product"
+	"github.com/shayja/go-template-api/internal/entities"
+	appErrors "github.com/shayja/go-template-api/internal/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+var imageColumns = []string{"id", "product_id", "url", "alt_text", "sort_order", "is_primary", "status", "source", "renditions", "created_at", "updated_at"}
+
+func TestCreateImage_FirstImageIsPrimary(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ProductRepository{Db: db}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id FROM products WHERE id = \\$1 AND deleted_at IS NULL FOR UPDATE").
+		WithArgs("1").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\), COALESCE\\(MAX\\(sort_order\\) \\+ 1, 0\\) FROM product_images WHERE product_id = \\$1").
+		WithArgs("1").
+		WillReturnRows(sqlmock.NewRows([]string{"count", "position"}).AddRow(0, 0))
+	mock.ExpectExec("UPDATE product_images SET is_primary = false").
+		WithArgs("1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("INSERT INTO product_images \\(product_id, alt_text, sort_order, is_primary, status, source, queued_at\\)").
+		WithArgs("1", "Front", 0, true, entities.ImageStatusPending, "products/1/abc/source.png").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("10"))
+	mock.ExpectCommit()
+
+	id, err := repo.CreateImage("1", "products/1/abc/source.png", "Front", false)
+	assert.NoError(t, err)
+	assert.Equal(t, "10", id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateImage_DeletedProduct(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ProductRepository{Db: db}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id FROM products WHERE id = \\$1 AND deleted_at IS NULL FOR UPDATE").
+		WithArgs("1").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectRollback()
+
+	_, err = repo.CreateImage("1", "products/1/abc/source.png", "", true)
+	assert.ErrorIs(t, err, appErrors.ErrProductNotFound)
+}
+
+func TestGetImages(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ProductRepository{Db: db}
+
+	mock.ExpectQuery("SELECT (.+) FROM product_images\\s+WHERE product_id = ANY\\(\\$1::uuid\\[\\]\\) ORDER BY product_id, sort_order, created_at").
+		WithArgs(pq.Array([]string{"1", "2"})).
+		WillReturnRows(sqlmock.NewRows(imageColumns).
+			AddRow("10", "1", "https://cdn/10/large.jpg", "Front", 0, true, "ready", "", []byte(`[{"name":"large","format":"jpeg","width":1200,"height":800,"url":"https://cdn/10/large.jpg"}]`), time.Now(), time.Now()).
+			AddRow("11", "1", "", "Back", 1, false, "pending", "products/1/11/source.png", nil, time.Now(), time.Now()))
+
+	galleries, err := repo.GetImages([]string{"1", "2"})
+	assert.NoError(t, err)
+	if assert.Len(t, galleries["1"], 2) {
+		assert.True(t, galleries["1"][0].Primary)
+		assert.Equal(t, "https://cdn/10/large.jpg", galleries["1"][0].Renditions[0].URL)
+		assert.Equal(t, entities.ImageStatusPending, galleries["1"][1].Status)
+	}
+	assert.Empty(t, galleries["2"])
+}
+
+func TestReorderImages_Incomplete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ProductRepository{Db: db}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE product_images SET sort_order = array_position\\(\\$2::uuid\\[\\], id\\) - 1").
+		WithArgs("1", pq.Array([]string{"11", "10"})).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM product_images WHERE product_id = \\$1").
+		WithArgs("1").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectRollback()
+
+	err = repo.ReorderImages("1", []string{"11", "10"})
+	assert.ErrorIs(t, err, appErrors.ErrInvalidImageOrder)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteImage_PromotesNextPrimary(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ProductRepository{Db: db}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("DELETE FROM product_images WHERE product_id = \\$1 AND id = \\$2 RETURNING").
+		WithArgs("1", "10").
+		WillReturnRows(sqlmock.NewRows(imageColumns).
+			AddRow("10", "1", "https://cdn/10/large.jpg", "Front", 0, true, "ready", "", nil, time.Now(), time.Now()))
+	mock.ExpectExec("UPDATE product_images SET is_primary = true, updated_at = NOW\\(\\)\\s+WHERE id = \\(SELECT id FROM product_images WHERE product_id = \\$1 ORDER BY sort_order, created_at LIMIT 1\\)").
+		WithArgs("1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	image, err := repo.DeleteImage("1", "10")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://cdn/10/large.jpg", image.URL)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteImage_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ProductRepository{Db: db}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("DELETE FROM product_images").
+		WithArgs("1", "10").
+		WillReturnRows(sqlmock.NewRows(imageColumns))
+	mock.ExpectRollback()
+
+	_, err = repo.DeleteImage("1", "10")
+	assert.ErrorIs(t, err, appErrors.ErrImageNotFound)
+}
+
+func TestCompleteImage_Deleted(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ProductRepository{Db: db}
+
+	renditions := []entities.ImageRendition{{Name: "large", Format: "jpeg", Width: 1200, Height: 800, URL: "https://cdn/large.jpg"}}
+	mock.ExpectExec("UPDATE product_images SET url = \\$3, renditions = \\$4, (.+) WHERE id = \\$1 AND source = \\$2").
+		WithArgs("10", "products/1/abc/source.png", "https://cdn/large.jpg", `[{"name":"large","format":"jpeg","width":1200,"height":800,"url":"https://cdn/large.jpg"}]`, entities.ImageStatusReady).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	ok, err := repo.CompleteImage("10", "products/1/abc/source.png", "https://cdn/large.jpg", renditions)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestGetPendingImages(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ProductRepository{Db: db}
+
+	before := time.Now()
+	mock.ExpectQuery("SELECT product_id, id, source FROM product_images\\s+WHERE source IS NOT NULL AND queued_at < \\$1 ORDER BY queued_at LIMIT \\$2").
+		WithArgs(before, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"product_id", "id", "source"}).AddRow("1", "10", "products/1/abc/source.png"))
+
+	jobs, err := repo.GetPendingImages(before, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []*entities.ImageJob{{ProductId: "1", ImageId: "10", Source: "products/1/abc/source.png"}}, jobs)
+}
@@ -0,0 +1,150 @@
+// adapters/repositories/product_import_repository.go
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/errors"
+	"github.com/shayja/go-template-api/internal/utils"
+)
+
+// errAmbiguousSku rejects a row whose SKU matches more than one active product
+var errAmbiguousSku = errors.New("AMBIGUOUS_SKU", "the sku matches more than one product", nil)
+
+// Upsert the imported rows by SKU in a single transaction, each row under its own savepoint so a failing row
+// is rolled back alone. Sets the action and product id of every row, or the reason it failed.
+// The transaction is committed only when commit is true and, if atomic, no row failed.
+func (m *ProductRepository) Import(rows []*entities.ProductImportRow, atomic bool, commit bool) (bool, error) {
+	tx, err := m.Db.Begin()
+	if err != nil {
+		fmt.Print(err)
+		return false, errors.ErrDatabase
+	}
+	defer tx.Rollback()
+
+	failed := false
+	for _, row := range rows {
+		if _, err := tx.Exec("SAVEPOINT import_row"); err != nil {
+			fmt.Print(err)
+			return false, errors.ErrDatabase
+		}
+		if err := importRow(tx, row); err != nil {
+			if _, err := tx.Exec("ROLLBACK TO SAVEPOINT import_row"); err != nil {
+				fmt.Print(err)
+				return false, errors.ErrDatabase
+			}
+			row.Action = entities.ImportFailed
+			row.Id = ""
+			row.Errors = append(row.Errors, importErrorMessage(err))
+			failed = true
+			continue
+		}
+		if _, err := tx.Exec("RELEASE SAVEPOINT import_row"); err != nil {
+			fmt.Print(err)
+			return false, errors.ErrDatabase
+		}
+	}
+
+	if !commit || (atomic && failed) {
+		return false, nil
+	}
+	if err := tx.Commit(); err != nil {
+		fmt.Print(err)
+		return false, errors.ErrDatabase
+	}
+	return true, nil
+}
+
+// importRow updates the active product with the SKU of the row, or creates it when there is none
+func importRow(tx *sql.Tx, row *entities.ProductImportRow) error {
+	product := row.Product
+	query, err := tx.Query(`UPDATE products
+		SET name = $2, description = $3, price = $4,
+		image_renditions = CASE WHEN image IS DISTINCT FROM $5 THEN NULL ELSE image_renditions END,
+		image = $5, updated_at = NOW()
+		WHERE lower(sku) = lower($1) AND deleted_at IS NULL RETURNING id`,
+		product.Sku, product.Name, product.Description, product.Price, product.ImageURL)
+	if err != nil {
+		return err
+	}
+	var ids []string
+	for query.Next() {
+		var id string
+		if err := query.Scan(&id); err != nil {
+			query.Close()
+			return err
+		}
+		ids = append(ids, id)
+	}
+	query.Close()
+	if err := query.Err(); err != nil {
+		return err
+	}
+
+	switch len(ids) {
+	case 0:
+		id := utils.CreateNewUUID().String()
+		_, err := tx.Exec(`INSERT INTO products (id, name, description, price, image, sku, updated_at, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW())`,
+			id, product.Name, product.Description, product.Price, product.ImageURL, product.Sku)
+		if err != nil {
+			return err
+		}
+		row.Action, row.Id = entities.ImportCreated, id
+	case 1:
+		row.Action, row.Id = entities.ImportUpdated, ids[0]
+	default:
+		return errAmbiguousSku
+	}
+	return nil
+}
+
+// importErrorMessage describes why a row could not be saved without leaking database details
+func importErrorMessage(err error) string {
+	if err == errAmbiguousSku {
+		return errAmbiguousSku.Message
+	}
+	fmt.Print(err)
+	if pqErr, ok := err.(*pq.Error); ok {
+		switch pqErr.Code {
+		case "22003": // numeric_value_out_of_range
+			return "the price is out of range"
+		case "22001": // string_data_right_truncation
+			return "a value is too long"
+		case "23505": // unique_violation
+			return "the sku is already used by another product"
+		}
+	}
+	return "the product could not be saved"
+}
+
+// Stream every active product ordered by SKU to the callback, stopping at the first error it returns
+func (m *ProductRepository) Export(fn func(*entities.Product) error) error {
+	query, err := m.Db.Query(`SELECT id, name, description, image, price, sku, updated_at, created_at, rating_average, rating_count, version FROM products
+		WHERE deleted_at IS NULL ORDER BY sku, id`)
+	if err != nil {
+		fmt.Print(err)
+		return errors.ErrDatabase
+	}
+	defer query.Close()
+
+	for query.Next() {
+		product := &entities.Product{}
+		err := query.Scan(&product.Id, &product.Name, &product.Description, &product.ImageURL, &product.Price, &product.Sku, &product.UpdatedAt, &product.CreatedAt, &product.RatingAverage, &product.RatingCount, &product.Version)
+		if err != nil {
+			fmt.Print(err)
+			return errors.ErrDatabase
+		}
+		if err := fn(product); err != nil {
+			return err
+		}
+	}
+	if err := query.Err(); err != nil {
+		fmt.Print(err)
+		return errors.ErrDatabase
+	}
+	return nil
+}
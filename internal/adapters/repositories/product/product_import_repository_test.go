@@ -0,0 +1,132 @@
+package repositories_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	repositories "github.com/shayja/go-template-api/internal/adapters/repositories/product"
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func importRows(skus ...string) []*entities.ProductImportRow {
+	rows := make([]*entities.ProductImportRow, len(skus))
+	for i, sku := range skus {
+		rows[i] = &entities.ProductImportRow{Row: i + 2, Sku: sku, Product: &entities.ProductRequest{Name: "Name " + sku, Description: "Description", ImageURL: "http://img/" + sku, Price: money.New(9, 99), Sku: sku}}
+	}
+	return rows
+}
+
+func TestImport_BestEffort(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ProductRepository{Db: db}
+	rows := importRows("A-1", "A-2", "A-3")
+
+	mock.ExpectBegin()
+	// An existing product is updated
+	mock.ExpectExec("SAVEPOINT import_row").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("UPDATE products (.+) WHERE lower\\(sku\\) = lower\\(\\$1\\) AND deleted_at IS NULL RETURNING id").
+		WithArgs("A-1", "Name A-1", "Description", "9.99", "http://img/A-1").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+	mock.ExpectExec("RELEASE SAVEPOINT import_row").WillReturnResult(sqlmock.NewResult(0, 0))
+	// A new SKU is created
+	mock.ExpectExec("SAVEPOINT import_row").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("UPDATE products").WithArgs("A-2", "Name A-2", "Description", "9.99", "http://img/A-2").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectExec("INSERT INTO products \\(id, name, description, price, image, sku, updated_at, created_at\\)").
+		WithArgs(sqlmock.AnyArg(), "Name A-2", "Description", "9.99", "http://img/A-2", "A-2").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("RELEASE SAVEPOINT import_row").WillReturnResult(sqlmock.NewResult(0, 0))
+	// A failing row is rolled back alone
+	mock.ExpectExec("SAVEPOINT import_row").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("UPDATE products").WithArgs("A-3", "Name A-3", "Description", "9.99", "http://img/A-3").
+		WillReturnError(&pq.Error{Code: "22003"})
+	mock.ExpectExec("ROLLBACK TO SAVEPOINT import_row").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	committed, err := repo.Import(rows, false, true)
+	assert.NoError(t, err)
+	assert.True(t, committed)
+
+	assert.Equal(t, entities.ImportUpdated, rows[0].Action)
+	assert.Equal(t, "1", rows[0].Id)
+	assert.Equal(t, entities.ImportCreated, rows[1].Action)
+	assert.NotEmpty(t, rows[1].Id)
+	assert.Equal(t, entities.ImportFailed, rows[2].Action)
+	assert.Equal(t, []string{"the price is out of range"}, rows[2].Errors)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestImport_TransactionalFailureRollsBack(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ProductRepository{Db: db}
+	rows := importRows("A-1")
+
+	mock.ExpectBegin()
+	mock.ExpectExec("SAVEPOINT import_row").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("UPDATE products").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1").AddRow("2"))
+	mock.ExpectExec("ROLLBACK TO SAVEPOINT import_row").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	committed, err := repo.Import(rows, true, true)
+	assert.NoError(t, err)
+	assert.False(t, committed)
+	assert.Equal(t, entities.ImportFailed, rows[0].Action)
+	assert.Equal(t, []string{"the sku matches more than one product"}, rows[0].Errors)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestImport_DryRunRollsBack(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ProductRepository{Db: db}
+	rows := importRows("A-1")
+
+	mock.ExpectBegin()
+	mock.ExpectExec("SAVEPOINT import_row").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("UPDATE products").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+	mock.ExpectExec("RELEASE SAVEPOINT import_row").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	committed, err := repo.Import(rows, true, false)
+	assert.NoError(t, err)
+	assert.False(t, committed)
+	assert.Equal(t, entities.ImportUpdated, rows[0].Action)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExport(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ProductRepository{Db: db}
+	now := time.Now()
+
+	mock.ExpectQuery("SELECT id, name, description, image, price, sku, updated_at, created_at, rating_average, rating_count, version FROM products\\s+WHERE deleted_at IS NULL ORDER BY sku, id").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "image", "price", "sku", "updated_at", "created_at", "rating_average", "rating_count", "version"}).
+			AddRow("1", "Phone", "A phone", "http://img/a.png", 9.99, "A-1", now, now, 4.5, 2, 3).
+			AddRow("2", "Case", "A case", "http://img/b.png", 4.5, "A-2", now, now, 0, 0, 1))
+
+	var skus []string
+	err = repo.Export(func(product *entities.Product) error {
+		skus = append(skus, product.Sku)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A-1", "A-2"}, skus)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,210 @@
+// adapters/repositories/product_price_repository.go
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/shayja/go-template-api/internal/adapters/repositories/pagination"
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/errors"
+)
+
+const priceScheduleColumns = `id, product_id, price, starts_at, ends_at, status, revert_price, created_at, updated_at`
+
+// Whitelisted price history sort fields
+var priceHistorySortFields = map[string]pagination.SortField{
+	"created_at": {Column: "created_at", Type: "timestamp"},
+}
+
+// Get the price changes of a product one keyset page at a time, newest first by default
+func (m *ProductRepository) GetPriceHistory(productId string, list *entities.ListRequest) (*entities.List[*entities.PriceChange], error) {
+	page, err := pagination.New(list, priceHistorySortFields, "-created_at")
+	if err != nil {
+		return nil, err
+	}
+
+	var total *int64
+	if list.IncludeTotal {
+		var count int64
+		if err := m.Db.QueryRow(`SELECT COUNT(*) FROM product_price_history WHERE product_id = $1`, productId).Scan(&count); err != nil {
+			fmt.Print(err)
+			return nil, errors.ErrDatabase
+		}
+		total = &count
+	}
+
+	args := []interface{}{productId}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	where := "product_id = $1"
+	if keyset := page.Where(arg); keyset != "" {
+		where += " AND " + keyset
+	}
+	query, err := m.Db.Query(fmt.Sprintf(`SELECT id, product_id, price, previous_price, source, schedule_id, created_at FROM product_price_history WHERE %s ORDER BY %s LIMIT %s`,
+		where, page.OrderBy(), arg(page.Fetch())), args...)
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	defer query.Close()
+
+	var changes []*entities.PriceChange
+	for query.Next() {
+		change := &entities.PriceChange{}
+		var scheduleId sql.NullString
+		if err := query.Scan(&change.Id, &change.ProductId, &change.Price, &change.PreviousPrice, &change.Source, &scheduleId, &change.CreatedAt); err != nil {
+			fmt.Print(err)
+			return nil, errors.ErrDatabase
+		}
+		if scheduleId.Valid {
+			change.ScheduleId = &scheduleId.String
+		}
+		changes = append(changes, change)
+	}
+
+	res := pagination.Result(page, changes, func(c *entities.PriceChange) (interface{}, string) {
+		return c.CreatedAt, c.Id
+	})
+	res.Total = total
+	return res, nil
+}
+
+// Get the price schedules of a product that are still to start or to end, soonest first
+func (m *ProductRepository) GetPriceSchedules(productId string) ([]*entities.PriceSchedule, error) {
+	query, err := m.Db.Query(`SELECT `+priceScheduleColumns+` FROM product_price_schedules
+		WHERE product_id = $1 AND status IN ('scheduled', 'active') ORDER BY starts_at, id`, productId)
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	defer query.Close()
+
+	schedules := []*entities.PriceSchedule{}
+	for query.Next() {
+		schedule, err := scanPriceSchedule(query)
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, schedule)
+	}
+	return schedules, nil
+}
+
+// Schedule a price change of an active product. A schedule may not overlap the period of
+// another pending or active schedule of the product, a permanent change being a point in time.
+func (m *ProductRepository) CreatePriceSchedule(productId string, request *entities.PriceScheduleRequest) (*entities.PriceSchedule, error) {
+	tx, err := m.Db.Begin()
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	defer tx.Rollback()
+
+	// Serializes the schedule changes of the product
+	var locked string
+	err = tx.QueryRow("SELECT id FROM products WHERE id = $1 AND deleted_at IS NULL FOR UPDATE", productId).Scan(&locked)
+	if err == sql.ErrNoRows {
+		return nil, errors.ErrProductNotFound
+	}
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+
+	// The schedule times are stored in UTC, the columns have no time zone
+	startsAt, endsAt := request.StartsAt.UTC(), utcTime(request.EndsAt)
+	var overlaps bool
+	err = tx.QueryRow(`SELECT EXISTS (SELECT 1 FROM product_price_schedules
+		WHERE product_id = $1 AND status IN ('scheduled', 'active')
+		AND starts_at < COALESCE($3::timestamp, $2::timestamp + interval '1 microsecond')
+		AND $2::timestamp < COALESCE(ends_at, starts_at + interval '1 microsecond'))`,
+		productId, startsAt, endsAt).Scan(&overlaps)
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	if overlaps {
+		return nil, errors.ErrPriceScheduleConflict
+	}
+
+	query, err := tx.Query(`INSERT INTO product_price_schedules (product_id, price, starts_at, ends_at)
+		VALUES ($1, $2, $3, $4) RETURNING `+priceScheduleColumns,
+		productId, request.Price, startsAt, endsAt)
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	if !query.Next() {
+		query.Close()
+		return nil, errors.ErrDatabase
+	}
+	schedule, err := scanPriceSchedule(query)
+	query.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	return schedule, nil
+}
+
+// Cancel a pending price schedule, or end an active one at the given time
+func (m *ProductRepository) CancelPriceSchedule(productId string, scheduleId string, now time.Time) (*entities.PriceSchedule, error) {
+	query, err := m.Db.Query(`UPDATE product_price_schedules
+		SET status = CASE status WHEN 'scheduled' THEN 'cancelled' ELSE status END,
+		ends_at = CASE status WHEN 'active' THEN GREATEST($3::timestamp, starts_at + interval '1 microsecond') ELSE ends_at END,
+		updated_at = NOW()
+		WHERE id = $1 AND product_id = $2 AND status IN ('scheduled', 'active')
+		RETURNING `+priceScheduleColumns, scheduleId, productId, now.UTC())
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	defer query.Close()
+
+	if !query.Next() {
+		return nil, errors.ErrPriceScheduleNotFound
+	}
+	return scanPriceSchedule(query)
+}
+
+// Start and end the price schedules due at the given time
+func (m *ProductRepository) ApplyPriceSchedules(now time.Time) (int, int, error) {
+	var activated, reverted int
+	if err := m.Db.QueryRow(`SELECT * FROM apply_price_schedules($1)`, now.UTC()).Scan(&activated, &reverted); err != nil {
+		fmt.Print(err)
+		return 0, 0, errors.ErrDatabase
+	}
+	return activated, reverted, nil
+}
+
+// utcTime returns the time in UTC, nil when it is not set
+func utcTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
+
+// scanPriceSchedule reads a row of priceScheduleColumns
+func scanPriceSchedule(query *sql.Rows) (*entities.PriceSchedule, error) {
+	schedule := &entities.PriceSchedule{}
+	var endsAt sql.NullTime
+	err := query.Scan(&schedule.Id, &schedule.ProductId, &schedule.Price, &schedule.StartsAt, &endsAt, &schedule.Status, &schedule.RevertPrice, &schedule.CreatedAt, &schedule.UpdatedAt)
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	if endsAt.Valid {
+		schedule.EndsAt = &endsAt.Time
+	}
+	return schedule, nil
+}
@@ -0,0 +1,128 @@
+package repositories_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	repositories "github.com/shayja/go-template-api/internal/adapters/repositories/product"
+	"github.com/shayja/go-template-api/internal/entities"
+	appErrors "github.com/shayja/go-template-api/internal/errors"
+	"github.com/shayja/go-template-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+)
+
+var priceScheduleColumns = []string{"id", "product_id", "price", "starts_at", "ends_at", "status", "revert_price", "created_at", "updated_at"}
+
+func TestGetPriceHistory(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ProductRepository{Db: db}
+	now := time.Now()
+
+	mock.ExpectQuery("SELECT id, product_id, price, previous_price, source, schedule_id, created_at FROM product_price_history WHERE product_id = \\$1 ORDER BY created_at DESC, id DESC LIMIT \\$2").
+		WithArgs("1", 21).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "price", "previous_price", "source", "schedule_id", "created_at"}).
+			AddRow("11", "1", 79.9, 99.9, entities.PriceSourceScheduled, "20", now).
+			AddRow("10", "1", 99.9, nil, entities.PriceSourceInitial, nil, now.Add(-time.Hour)))
+
+	list, err := repo.GetPriceHistory("1", &entities.ListRequest{Limit: 20})
+	assert.NoError(t, err)
+	assert.Len(t, list.Items, 2)
+	assert.Equal(t, money.New(99, 90), *list.Items[0].PreviousPrice)
+	assert.Equal(t, "20", *list.Items[0].ScheduleId)
+	assert.Nil(t, list.Items[1].PreviousPrice)
+	assert.Nil(t, list.Items[1].ScheduleId)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreatePriceSchedule(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ProductRepository{Db: db}
+	startsAt := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	endsAt := startsAt.Add(48 * time.Hour)
+	// The times sent with an offset are stored in UTC
+	israel := time.FixedZone("IDT", 3*60*60)
+	requestEndsAt := endsAt.In(israel)
+	request := &entities.PriceScheduleRequest{Price: money.New(79, 90), StartsAt: startsAt.In(israel), EndsAt: &requestEndsAt}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id FROM products WHERE id = \\$1 AND deleted_at IS NULL FOR UPDATE").
+		WithArgs("1").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+	mock.ExpectQuery("SELECT EXISTS \\(SELECT 1 FROM product_price_schedules").
+		WithArgs("1", startsAt, &endsAt).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectQuery("INSERT INTO product_price_schedules \\(product_id, price, starts_at, ends_at\\)").
+		WithArgs("1", "79.90", startsAt, &endsAt).
+		WillReturnRows(sqlmock.NewRows(priceScheduleColumns).
+			AddRow("20", "1", 79.9, startsAt, endsAt, entities.PriceScheduleScheduled, nil, startsAt, startsAt))
+	mock.ExpectCommit()
+
+	schedule, err := repo.CreatePriceSchedule("1", request)
+	assert.NoError(t, err)
+	assert.Equal(t, "20", schedule.Id)
+	assert.Equal(t, endsAt, *schedule.EndsAt)
+	assert.Nil(t, schedule.RevertPrice)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreatePriceSchedule_Overlap(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ProductRepository{Db: db}
+	request := &entities.PriceScheduleRequest{Price: money.New(79, 90), StartsAt: time.Now().Add(time.Hour)}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id FROM products WHERE id = \\$1 AND deleted_at IS NULL FOR UPDATE").
+		WithArgs("1").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+	mock.ExpectQuery("SELECT EXISTS \\(SELECT 1 FROM product_price_schedules").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectRollback()
+
+	_, err = repo.CreatePriceSchedule("1", request)
+	assert.ErrorIs(t, err, appErrors.ErrPriceScheduleConflict)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCancelPriceSchedule_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ProductRepository{Db: db}
+	now := time.Now()
+
+	mock.ExpectQuery("UPDATE product_price_schedules").
+		WithArgs("20", "1", now.UTC()).
+		WillReturnRows(sqlmock.NewRows(priceScheduleColumns))
+
+	_, err = repo.CancelPriceSchedule("1", "20", now)
+	assert.ErrorIs(t, err, appErrors.ErrPriceScheduleNotFound)
+}
+
+func TestApplyPriceSchedules(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ProductRepository{Db: db}
+	now := time.Now()
+
+	mock.ExpectQuery("SELECT \\* FROM apply_price_schedules\\(\\$1\\)").
+		WithArgs(now.UTC()).
+		WillReturnRows(sqlmock.NewRows([]string{"activated", "reverted"}).AddRow(2, 1))
+
+	activated, reverted, err := repo.ApplyPriceSchedules(now)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, activated)
+	assert.Equal(t, 1, reverted)
+}
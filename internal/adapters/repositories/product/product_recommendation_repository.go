@@ -0,0 +1,104 @@
+// adapters/repositories/product_recommendation_repository.go
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/errors"
+	"github.com/shayja/go-template-api/pkg/constants"
+)
+
+// Rebuild the co-purchase counts from the orders placed since the given time that were not cancelled,
+// returning the number of product pairs. The previous counts are served until the rebuild commits
+func (m *ProductRepository) RefreshCoPurchases(since time.Time) (int64, error) {
+	tx, err := m.Db.Begin()
+	if err != nil {
+		fmt.Print(err)
+		return 0, errors.ErrDatabase
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM product_copurchases`); err != nil {
+		fmt.Print(err)
+		return 0, errors.ErrDatabase
+	}
+	res, err := tx.Exec(`INSERT INTO product_copurchases (product_id, related_id, orders, refreshed_at)
+		SELECT a.product_id, b.product_id, COUNT(DISTINCT a.order_id), NOW()
+		FROM order_details a
+		JOIN order_details b ON b.order_id = a.order_id AND b.product_id <> a.product_id
+		JOIN orders o ON o.id = a.order_id
+		WHERE o.status <> $1 AND o.created_at >= $2
+		GROUP BY a.product_id, b.product_id`, constants.OrderStatusCancelled, since)
+	if err != nil {
+		fmt.Print(err)
+		return 0, errors.ErrDatabase
+	}
+	pairs, _ := res.RowsAffected()
+
+	if err := tx.Commit(); err != nil {
+		fmt.Print(err)
+		return 0, errors.ErrDatabase
+	}
+	return pairs, nil
+}
+
+// Recommend the products bought together with the source products or sharing their categories and tags,
+// best score first. The source products, deleted products and products out of stock are left out
+func (m *ProductRepository) Recommend(productIds []string, weights entities.RecommendationWeights, limit int) ([]*entities.Recommendation, error) {
+	// The candidates are the co-purchased products and the products sharing a category or tag. Each
+	// weight is fully added for the most co-purchased candidate, and for a candidate sharing all the
+	// categories or tags of the source products
+	query, err := m.Db.Query(`WITH source_categories AS (SELECT DISTINCT category_id FROM product_categories WHERE product_id = ANY($1::uuid[])),
+		source_tags AS (SELECT DISTINCT unnest(tags) AS tag FROM products WHERE id = ANY($1::uuid[])),
+		copurchased AS (SELECT related_id AS id, SUM(orders) AS orders FROM product_copurchases WHERE product_id = ANY($1::uuid[]) GROUP BY related_id),
+		candidates AS (
+			SELECT id FROM copurchased
+			UNION SELECT product_id FROM product_categories WHERE category_id IN (SELECT category_id FROM source_categories)
+			UNION SELECT id FROM products WHERE tags && ARRAY(SELECT tag FROM source_tags)),
+		scored AS (
+			SELECT p.id, COALESCE(c.orders, 0) AS orders,
+				(SELECT COUNT(*) FROM product_categories pc WHERE pc.product_id = p.id AND pc.category_id IN (SELECT category_id FROM source_categories)) AS shared_categories,
+				(SELECT COUNT(*) FROM unnest(p.tags) AS t(tag) WHERE t.tag IN (SELECT tag FROM source_tags)) AS shared_tags
+			FROM products p JOIN candidates ca ON ca.id = p.id LEFT JOIN copurchased c ON c.id = p.id
+			WHERE p.id <> ALL($1::uuid[]) AND p.deleted_at IS NULL
+			AND NOT EXISTS (SELECT 1 FROM inventory i WHERE i.product_id = p.id AND i.quantity <= 0))
+		SELECT p.id, p.name, p.description, p.image, p.price, p.sku, p.updated_at, p.created_at, p.image_status, p.image_renditions,
+			p.rating_average, p.rating_count, p.version, p.attributes, p.tags, s.orders,
+			$2::float8 * s.orders / GREATEST(MAX(s.orders) OVER (), 1)
+			+ $3::float8 * s.shared_categories / GREATEST((SELECT COUNT(*) FROM source_categories), 1)
+			+ $4::float8 * s.shared_tags / GREATEST((SELECT COUNT(*) FROM source_tags), 1) AS score
+		FROM scored s JOIN products p ON p.id = s.id
+		ORDER BY score DESC, p.id LIMIT $5`,
+		pq.Array(productIds), weights.CoPurchase, weights.Category, weights.Tag, limit)
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	defer query.Close()
+
+	recommendations := []*entities.Recommendation{}
+	for query.Next() {
+		recommendation := &entities.Recommendation{Product: &entities.Product{}}
+		product := recommendation.Product
+		var imageStatus sql.NullString
+		var renditions, attributes []byte
+		var tags pq.StringArray
+		err := query.Scan(&product.Id, &product.Name, &product.Description, &product.ImageURL, &product.Price, &product.Sku, &product.UpdatedAt, &product.CreatedAt, &imageStatus, &renditions, &product.RatingAverage, &product.RatingCount, &product.Version, &attributes, &tags, &recommendation.CoPurchases, &recommendation.Score)
+		if err != nil {
+			fmt.Print(err)
+			return nil, errors.ErrDatabase
+		}
+		if err := scanImage(product, imageStatus, renditions); err != nil {
+			return nil, err
+		}
+		if err := scanAttributes(product, attributes, tags); err != nil {
+			return nil, err
+		}
+		recommendations = append(recommendations, recommendation)
+	}
+	return recommendations, nil
+}
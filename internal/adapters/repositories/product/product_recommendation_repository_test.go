@@ -0,0 +1,58 @@
+package repositories_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	repositories "github.com/shayja/go-template-api/internal/adapters/repositories/product"
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/pkg/constants"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRefreshCoPurchases(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ProductRepository{Db: db}
+
+	since := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM product_copurchases").WillReturnResult(sqlmock.NewResult(0, 4))
+	mock.ExpectExec("INSERT INTO product_copurchases (.+) FROM order_details a JOIN order_details b ON b.order_id = a.order_id AND b.product_id <> a.product_id JOIN orders o ON o.id = a.order_id WHERE o.status <> \\$1 AND o.created_at >= \\$2 GROUP BY a.product_id, b.product_id").
+		WithArgs(constants.OrderStatusCancelled, since).
+		WillReturnResult(sqlmock.NewResult(0, 6))
+	mock.ExpectCommit()
+
+	pairs, err := repo.RefreshCoPurchases(since)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6), pairs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+var recommendationColumns = []string{"id", "name", "description", "image", "price", "sku", "updated_at", "created_at", "image_status", "image_renditions", "rating_average", "rating_count", "version", "attributes", "tags", "orders", "score"}
+
+func TestRecommend(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ProductRepository{Db: db}
+
+	now := time.Now()
+	mock.ExpectQuery("WITH source_categories AS (.+) WHERE p.id <> ALL\\(\\$1::uuid\\[\\]\\) AND p.deleted_at IS NULL AND NOT EXISTS \\(SELECT 1 FROM inventory i WHERE i.product_id = p.id AND i.quantity <= 0\\)\\)(.+)ORDER BY score DESC, p.id LIMIT \\$5").
+		WithArgs("{\"1\",\"2\"}", 0.6, 0.25, 0.15, 8).
+		WillReturnRows(sqlmock.NewRows(recommendationColumns).
+			AddRow("3", "Phone Case", "A case", "", "9.90", "CASE-1", now, now, nil, nil, 4.5, 2, 1, []byte(`{"color":"black"}`), "{accessories}", 8, 0.85))
+
+	recommendations, err := repo.Recommend([]string{"1", "2"}, entities.RecommendationWeights{CoPurchase: 0.6, Category: 0.25, Tag: 0.15}, 8)
+	assert.NoError(t, err)
+	assert.Len(t, recommendations, 1)
+	assert.Equal(t, "3", recommendations[0].Id)
+	assert.Equal(t, int64(8), recommendations[0].CoPurchases)
+	assert.Equal(t, 0.85, recommendations[0].Score)
+	assert.Equal(t, []string{"accessories"}, recommendations[0].Tags)
+	assert.Equal(t, "black", recommendations[0].Attributes["color"])
+}
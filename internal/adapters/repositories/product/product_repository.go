@@ -3,12 +3,19 @@ package repositories
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/lib/pq"
+	"github.com/shayja/go-template-api/internal/adapters/repositories/pagination"
 	"github.com/shayja/go-template-api/internal/entities"
 	"github.com/shayja/go-template-api/internal/errors"
 	"github.com/shayja/go-template-api/internal/utils"
+	"github.com/shayja/go-template-api/pkg/money"
 )
 
 
@@ -16,37 +23,339 @@ type ProductRepository struct {
 	Db *sql.DB
 }
 
-const PAGE_SIZE = 20
+// Whitelisted product sort fields
+var productSortFields = map[string]pagination.SortField{
+	"name":       {Column: "name", Type: "text"},
+	"price":      {Column: "price", Type: "numeric"},
+	"sku":        {Column: "sku", Type: "text"},
+	"created_at": {Column: "created_at", Type: "timestamp"},
+	"updated_at": {Column: "updated_at", Type: "timestamp"},
+	"rating":     {Column: "rating_average", Type: "numeric"},
+}
+
+// Search product items by a full-text query and filters, one keyset page at a time
+func (m *ProductRepository) Search(filter *entities.ProductFilter) (*entities.List[*entities.Product], error) {
 
-// Get all product items
-func (m *ProductRepository) GetAll(page int) ([]*entities.Product, error) {
+	var args []interface{}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
 
-	offset := PAGE_SIZE * (page - 1)
-	// Optional: Use query text or call a DB Function.
-	//SQL := `SELECT id, name, description, image, price, sku FROM "products" ORDER BY "id" LIMIT $2 OFFSET $1`
-	SQL := `SELECT * FROM get_products($1, $2)`
-	query, err := m.Db.Query(SQL, offset, PAGE_SIZE)
+	where, tsQuery, err := filterConditions(filter, arg)
+	if err != nil {
+		return nil, err
+	}
 
-    if err != nil {
+	fields := productSortFields
+	defaultSort := "name"
+	rank := "0::real"
+	if tsQuery != "" {
+		rank = fmt.Sprintf("ts_rank(search_vector, %s)", tsQuery)
+
+		fields = map[string]pagination.SortField{"relevance": {Column: rank, Type: "real"}}
+		for name, field := range productSortFields {
+			fields[name] = field
+		}
+		defaultSort = "-relevance"
+	}
+
+	page, err := pagination.New(&filter.ListRequest, fields, defaultSort)
+	if err != nil {
+		return nil, err
+	}
+
+	var total *int64
+	if filter.IncludeTotal {
+		count, err := m.count(whereClause(where), args)
+		if err != nil {
+			return nil, err
+		}
+		total = &count
+	}
+
+	if keyset := page.Where(arg); keyset != "" {
+		where = append(where, keyset)
+	}
+	SQL := fmt.Sprintf(`SELECT id, name, description, image, price, sku, updated_at, created_at, deleted_at, image_status, image_renditions, rating_average, rating_count, version, attributes, tags, %s AS rank FROM products%s ORDER BY %s LIMIT %s`,
+		rank, whereClause(where), page.OrderBy(), arg(page.Fetch()))
+
+	query, err := m.Db.Query(SQL, args...)
+	if err != nil {
 		fmt.Print(err)
 		return nil, err
 	}
-    defer query.Close()
-	
-	var products []*entities.Product
-	
-	if query != nil {
-		for query.Next() {
-			product := &entities.Product{}
-			err := query.Scan(&product.Id, &product.Name, &product.Description, &product.ImageURL, &product.Price, &product.Sku, &product.UpdatedAt, &product.CreatedAt)
+	defer query.Close()
+
+	type row struct {
+		product *entities.Product
+		rank    float64
+	}
+	var rows []row
+	for query.Next() {
+		product := &entities.Product{}
+		var productRank float64
+		var deletedAt sql.NullTime
+		var imageStatus sql.NullString
+		var renditions, attributes []byte
+		var tags pq.StringArray
+		err := query.Scan(&product.Id, &product.Name, &product.Description, &product.ImageURL, &product.Price, &product.Sku, &product.UpdatedAt, &product.CreatedAt, &deletedAt, &imageStatus, &renditions, &product.RatingAverage, &product.RatingCount, &product.Version, &attributes, &tags, &productRank)
+		if err != nil {
+			fmt.Print(err)
+			return nil, errors.ErrDatabase
+		}
+		if deletedAt.Valid {
+			product.DeletedAt = &deletedAt.Time
+		}
+		if err := scanImage(product, imageStatus, renditions); err != nil {
+			return nil, err
+		}
+		if err := scanAttributes(product, attributes, tags); err != nil {
+			return nil, err
+		}
+		rows = append(rows, row{product, productRank})
+	}
+
+	result := pagination.Result(page, rows, func(r row) (interface{}, string) {
+		return productSortValue(page.Sort, r.product, r.rank), r.product.Id
+	})
+
+	list := &entities.List[*entities.Product]{NextCursor: result.NextCursor, PrevCursor: result.PrevCursor, Total: total}
+	list.Items = make([]*entities.Product, len(result.Items))
+	for i, r := range result.Items {
+		list.Items[i] = r.product
+	}
+	return list, nil
+}
+
+// filterConditions builds the where conditions of a product filter, adding their arguments with arg.
+// The full-text query is returned for ranking, empty without a query
+func filterConditions(filter *entities.ProductFilter, arg func(interface{}) string) ([]string, string, error) {
+	var where []string
+
+	if !filter.IncludeDeleted {
+		where = append(where, "deleted_at IS NULL")
+	}
+
+	var tsQuery string
+	if q := strings.TrimSpace(filter.Query); q != "" {
+		tsQuery = fmt.Sprintf("websearch_to_tsquery('english', %s)", arg(q))
+		where = append(where, fmt.Sprintf("search_vector @@ %s", tsQuery))
+	}
+	if filter.MinPrice != nil {
+		where = append(where, fmt.Sprintf("price >= %s", arg(*filter.MinPrice)))
+	}
+	if filter.MaxPrice != nil {
+		where = append(where, fmt.Sprintf("price <= %s", arg(*filter.MaxPrice)))
+	}
+	if prefix := strings.TrimSpace(filter.SkuPrefix); prefix != "" {
+		where = append(where, fmt.Sprintf("lower(sku) LIKE %s", arg(escapeLike(strings.ToLower(prefix))+"%")))
+	}
+	if slug := strings.TrimSpace(filter.Category); slug != "" {
+		where = append(where, fmt.Sprintf("id IN (SELECT product_id FROM product_categories WHERE category_id IN (SELECT get_category_tree_ids(id) FROM categories WHERE slug = %s))", arg(slug)))
+	}
+	if len(filter.Tags) > 0 {
+		where = append(where, fmt.Sprintf("tags @> %s", arg(pq.Array(filter.Tags))))
+	}
+	codes := make([]string, 0, len(filter.Attributes))
+	for code := range filter.Attributes {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		// Containment of {"code": value} is served by the jsonb_path_ops index
+		var anyOf []string
+		for _, value := range filter.Attributes[code] {
+			contained, err := json.Marshal(map[string]interface{}{code: value})
 			if err != nil {
+				return nil, "", errors.ErrInvalidInput
+			}
+			anyOf = append(anyOf, fmt.Sprintf("attributes @> %s::jsonb", arg(string(contained))))
+		}
+		if len(anyOf) > 0 {
+			where = append(where, "("+strings.Join(anyOf, " OR ")+")")
+		}
+	}
+	if filter.CreatedFrom != nil {
+		where = append(where, fmt.Sprintf("created_at >= %s", arg(*filter.CreatedFrom)))
+	}
+	if filter.CreatedTo != nil {
+		where = append(where, fmt.Sprintf("created_at <= %s", arg(*filter.CreatedTo)))
+	}
+	if filter.UpdatedFrom != nil {
+		where = append(where, fmt.Sprintf("updated_at >= %s", arg(*filter.UpdatedFrom)))
+	}
+	if filter.UpdatedTo != nil {
+		where = append(where, fmt.Sprintf("updated_at <= %s", arg(*filter.UpdatedTo)))
+	}
+	return where, tsQuery, nil
+}
+
+// count returns the number of products matching a where clause
+func (m *ProductRepository) count(where string, args []interface{}) (int64, error) {
+	var total int64
+	err := m.Db.QueryRow(`SELECT COUNT(*) FROM products`+where, args...).Scan(&total)
+	if err != nil {
+		fmt.Print(err)
+		return 0, errors.ErrDatabase
+	}
+	return total, nil
+}
+
+// Facets counts the products matching a filter per category, price bucket, tag and attribute value.
+// The price bounds split the prices into the ranges below the first bound (unless it is 0), between
+// each pair of bounds and from the last bound up. Only the maxValues most frequent tags and values of
+// each attribute are counted
+func (m *ProductRepository) Facets(filter *entities.ProductFilter, priceBounds []money.Amount, maxValues int) (*entities.ProductFacets, error) {
+	var args []interface{}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	where, _, err := filterConditions(filter, arg)
+	if err != nil {
+		return nil, err
+	}
+	bounds := make([]string, len(priceBounds))
+	for i, bound := range priceBounds {
+		bounds[i] = bound.String()
+	}
+
+	// The matching products are collected once, the categories count the products of their descendants
+	SQL := fmt.Sprintf(`WITH RECURSIVE matched AS MATERIALIZED (SELECT id, price, tags, attributes FROM products%s),
+		ancestors AS (SELECT id AS category_id, id AS ancestor_id, parent_id FROM categories
+			UNION ALL SELECT a.category_id, c.id, c.parent_id FROM ancestors a JOIN categories c ON c.id = a.parent_id)
+		SELECT 'category', NULL::text, c.slug::text, c.name::text, COUNT(DISTINCT m.id) FROM matched m
+			JOIN product_categories pc ON pc.product_id = m.id
+			JOIN ancestors a ON a.category_id = pc.category_id
+			JOIN categories c ON c.id = a.ancestor_id
+			GROUP BY c.slug, c.name
+		UNION ALL
+		SELECT 'price', NULL, width_bucket(m.price, %s::numeric[])::text, NULL, COUNT(*) FROM matched m GROUP BY 3
+		UNION ALL
+		SELECT 'tag', NULL, t.tag, NULL, COUNT(*) FROM matched m CROSS JOIN LATERAL unnest(m.tags) AS t(tag) GROUP BY t.tag
+		UNION ALL
+		SELECT 'attribute', a.key, a.value::text, NULL, COUNT(*) FROM matched m CROSS JOIN LATERAL jsonb_each(m.attributes) AS a GROUP BY a.key, a.value`,
+		whereClause(where), arg(pq.Array(bounds)))
+
+	query, err := m.Db.Query(SQL, args...)
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	defer query.Close()
+
+	facets := &entities.ProductFacets{Categories: []entities.FacetCount{}, Tags: []entities.FacetCount{}, Attributes: map[string][]entities.FacetCount{}}
+	buckets := priceBuckets(priceBounds)
+	for query.Next() {
+		var facet, value string
+		var key, label sql.NullString
+		var count int64
+		if err := query.Scan(&facet, &key, &value, &label, &count); err != nil {
+			fmt.Print(err)
+			return nil, errors.ErrDatabase
+		}
+		switch facet {
+		case "category":
+			facets.Categories = append(facets.Categories, entities.FacetCount{Value: value, Label: label.String, Count: count})
+		case "price":
+			bucket, _ := strconv.Atoi(value)
+			if len(priceBounds) > 0 && priceBounds[0] == 0 {
+				bucket--
+			}
+			if bucket >= 0 && bucket < len(buckets) {
+				buckets[bucket].Count = count
+			}
+		case "tag":
+			facets.Tags = append(facets.Tags, entities.FacetCount{Value: value, Count: count})
+		case "attribute":
+			var decoded interface{}
+			if err := json.Unmarshal([]byte(value), &decoded); err != nil {
 				fmt.Print(err)
 				return nil, errors.ErrDatabase
 			}
-			products = append(products, product)
+			facets.Attributes[key.String] = append(facets.Attributes[key.String], entities.FacetCount{Value: decoded, Count: count})
 		}
 	}
-	return products, nil
+	if err := query.Err(); err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+
+	facets.Prices = buckets
+	sortFacet(facets.Categories, 0)
+	facets.Tags = sortFacet(facets.Tags, maxValues)
+	for code, values := range facets.Attributes {
+		facets.Attributes[code] = sortFacet(values, maxValues)
+	}
+	return facets, nil
+}
+
+// priceBuckets returns the empty price ranges split by the bounds
+func priceBuckets(bounds []money.Amount) []entities.PriceBucketCount {
+	buckets := []entities.PriceBucketCount{}
+	for i := range bounds {
+		if i == 0 && bounds[0] == 0 {
+			continue
+		}
+		bucket := entities.PriceBucketCount{Max: &bounds[i]}
+		if i > 0 {
+			bucket.Min = &bounds[i-1]
+		}
+		buckets = append(buckets, bucket)
+	}
+	if len(bounds) > 0 {
+		buckets = append(buckets, entities.PriceBucketCount{Min: &bounds[len(bounds)-1]})
+	}
+	return buckets
+}
+
+// sortFacet orders the facet values by count, most frequent first, keeping at most limit values (0 for all)
+func sortFacet(values []entities.FacetCount, limit int) []entities.FacetCount {
+	sort.SliceStable(values, func(i, j int) bool {
+		if values[i].Count != values[j].Count {
+			return values[i].Count > values[j].Count
+		}
+		return fmt.Sprint(values[i].Value) < fmt.Sprint(values[j].Value)
+	})
+	if limit > 0 && len(values) > limit {
+		values = values[:limit]
+	}
+	return values
+}
+
+// productSortValue returns the keyset value of a product for the given sort
+func productSortValue(sort string, product *entities.Product, rank float64) interface{} {
+	switch strings.TrimPrefix(sort, "-") {
+	case "price":
+		return product.Price
+	case "sku":
+		return product.Sku
+	case "created_at":
+		return product.CreatedAt
+	case "updated_at":
+		return product.UpdatedAt
+	case "rating":
+		return product.RatingAverage
+	case "relevance":
+		return rank
+	default:
+		return product.Name
+	}
+}
+
+// whereClause joins the conditions into a WHERE clause, or an empty string
+func whereClause(where []string) string {
+	if len(where) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(where, " AND ")
+}
+
+// escapeLike escapes the LIKE wildcards in a user supplied pattern
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
 }
 
 // Get a single item by id
@@ -61,24 +370,120 @@ func (m *ProductRepository) GetById(id string) (*entities.Product, error) {
 	product := &entities.Product{}
 	if query != nil {
 		for query.Next() {
-			err := query.Scan(&product.Id, &product.Name, &product.Description, &product.ImageURL, &product.Price, &product.Sku, &product.UpdatedAt, &product.CreatedAt)
+			var deletedAt sql.NullTime
+			var imageStatus sql.NullString
+			var renditions, attributes []byte
+			var tags pq.StringArray
+			err := query.Scan(&product.Id, &product.Name, &product.Description, &product.ImageURL, &product.Price, &product.Sku, &product.UpdatedAt, &product.CreatedAt, &deletedAt, &imageStatus, &renditions, &product.RatingAverage, &product.RatingCount, &product.Version, &attributes, &tags)
 			if err != nil {
 				fmt.Print(err)
 				return nil, errors.ErrDatabase
 			}
+			if deletedAt.Valid {
+				product.DeletedAt = &deletedAt.Time
+			}
+			if err := scanImage(product, imageStatus, renditions); err != nil {
+				return nil, err
+			}
+			if err := scanAttributes(product, attributes, tags); err != nil {
+				return nil, err
+			}
 		}
 	}
 	return product, nil
 }
 
+// Get the active products with the given SKUs regardless of case, in no particular order
+func (m *ProductRepository) GetBySkus(skus []string) ([]*entities.Product, error) {
+	keys := make([]string, len(skus))
+	for i, sku := range skus {
+		keys[i] = strings.ToLower(sku)
+	}
+	query, err := m.Db.Query(`SELECT id, name, description, image, price, sku, updated_at, created_at, image_status, image_renditions,
+		rating_average, rating_count, version, attributes, tags
+		FROM products WHERE lower(sku) = ANY($1) AND deleted_at IS NULL`, pq.Array(keys))
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	defer query.Close()
+
+	products := []*entities.Product{}
+	for query.Next() {
+		product := &entities.Product{}
+		var imageStatus sql.NullString
+		var renditions, attributes []byte
+		var tags pq.StringArray
+		err := query.Scan(&product.Id, &product.Name, &product.Description, &product.ImageURL, &product.Price, &product.Sku, &product.UpdatedAt, &product.CreatedAt, &imageStatus, &renditions, &product.RatingAverage, &product.RatingCount, &product.Version, &attributes, &tags)
+		if err != nil {
+			fmt.Print(err)
+			return nil, errors.ErrDatabase
+		}
+		if err := scanImage(product, imageStatus, renditions); err != nil {
+			return nil, err
+		}
+		if err := scanAttributes(product, attributes, tags); err != nil {
+			return nil, err
+		}
+		products = append(products, product)
+	}
+	return products, nil
+}
+
+// scanAttributes sets the attributes and tags columns of a product
+func scanAttributes(product *entities.Product, attributes []byte, tags pq.StringArray) error {
+	if len(attributes) > 0 {
+		if err := json.Unmarshal(attributes, &product.Attributes); err != nil {
+			fmt.Print(err)
+			return errors.ErrDatabase
+		}
+	}
+	if len(tags) > 0 {
+		product.Tags = tags
+	}
+	return nil
+}
+
+// attributesValue encodes the attributes of a request as jsonb, NULL when they are not set
+func attributesValue(attributes map[string]interface{}) (interface{}, error) {
+	if attributes == nil {
+		return nil, nil
+	}
+	encoded, err := json.Marshal(attributes)
+	if err != nil {
+		return nil, errors.ErrInvalidAttribute
+	}
+	return string(encoded), nil
+}
+
+// tagsValue encodes the tags of a request as a text array, NULL when they are not set
+func tagsValue(tags []string) interface{} {
+	if tags == nil {
+		return nil
+	}
+	return pq.Array(tags)
+}
+
+// writeError translates a failed product write, an active product already using the SKU is a conflict
+func writeError(err error) error {
+	fmt.Print(err)
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" { // unique_violation
+		return errors.ErrSkuConflict
+	}
+	return errors.ErrDatabase
+}
+
 // Create implements ProductRepositoryInterface
 func (m *ProductRepository) Create(product *entities.ProductRequest) (string, error) {
 	
 	newId := utils.CreateNewUUID().String()
-	err := m.Db.QueryRow("CALL products_insert($1, $2, $3, $4, $5, $6, $7)", product.Name, product.Description, product.Price, product.ImageURL, product.Sku, time.Now(), newId).Scan(&newId)
+	attributes, err := attributesValue(product.Attributes)
 	if err != nil {
-		fmt.Print(err)
-		return "", errors.ErrDatabase
+		return "", err
+	}
+	err = m.Db.QueryRow("CALL products_insert($1, $2, $3, $4, $5, $6, $7, $8, $9)", product.Name, product.Description, product.Price, product.ImageURL, product.Sku, time.Now(), attributes, tagsValue(product.Tags), newId).Scan(&newId)
+	if err != nil {
+		return "", writeError(err)
 	}
 
 	fmt.Printf("Product %s created successfully (new id is %s)\n", product.Name, newId)
@@ -87,26 +492,37 @@ func (m *ProductRepository) Create(product *entities.ProductRequest) (string, er
 	return newId, nil
 }
 
-// Update product item
-func (m *ProductRepository) Update(id string, product *entities.ProductRequest) (*entities.Product, error) {
+// Update product item, given the version the caller read or 0 to skip the check
+func (m *ProductRepository) Update(id string, product *entities.ProductRequest, version int) (*entities.Product, error) {
 
-	_, err := m.Db.Exec("CALL products_update($1, $2, $3, $4, $5, $6)", id, product.Name, product.Description, product.Price, product.ImageURL, product.Sku)
+	attributes, err := attributesValue(product.Attributes)
 	if err != nil {
-		fmt.Print(err)
-		return nil, errors.ErrDatabase
+		return nil, err
+	}
+	var newVersion sql.NullInt64
+	err = m.Db.QueryRow("CALL products_update($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)", id, product.Name, product.Description, product.Price, product.ImageURL, product.Sku, attributes, tagsValue(product.Tags), expectedVersion(version), nil).Scan(&newVersion)
+	if err != nil {
+		return nil, writeError(err)
+	}
+	if !newVersion.Valid {
+		return nil, m.writeSkipped(id)
 	}
 
 	return m.GetById(id)
 }
 
-// Update product item price
-func (m *ProductRepository) UpdatePrice(id string, product *entities.ProductPriceRequest) (*entities.Product, error) {
+// Update product item price, given the version the caller read or 0 to skip the check
+func (m *ProductRepository) UpdatePrice(id string, product *entities.ProductPriceRequest, version int) (*entities.Product, error) {
 	
-	res, err := m.Db.Exec("CALL products_update_price($1, $2)", id, product.Price)
+	var newVersion sql.NullInt64
+	err := m.Db.QueryRow("CALL products_update_price($1, $2, $3, $4)", id, product.Price, expectedVersion(version), nil).Scan(&newVersion)
 	if err != nil {
-		fmt.Print(err, res)
+		fmt.Print(err)
 		return nil, errors.ErrDatabase
 	}
+	if !newVersion.Valid {
+		return nil, m.writeSkipped(id)
+	}
 	return m.GetById(id)
 }
 
@@ -120,13 +536,60 @@ func (m *ProductRepository) UpdateImage(id string, product *entities.ProductImag
 	return m.GetById(id)
 }
 
-// Delete product by id
-func (m *ProductRepository) Delete(id string) (bool, error) {
-	_, err := m.Db.Exec("DELETE FROM products WHERE id = $1", id)
+// Soft delete product by id, keeping the row for the order history, given the version the caller read or 0 to skip the check
+func (m *ProductRepository) Delete(id string, version int) (bool, error) {
+	res, err := m.Db.Exec("UPDATE products SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL AND ($2::integer IS NULL OR version = $2)", id, expectedVersion(version))
 	if err != nil {
 		fmt.Print(err)
 		return false, errors.ErrDatabase
 	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return false, m.writeSkipped(id)
+	}
+	return true, nil
+}
+
+// writeSkipped tells why a conditional write changed no product: the product is missing or
+// soft deleted, or it moved past the version the caller read
+func (m *ProductRepository) writeSkipped(id string) error {
+	var deleted bool
+	err := m.Db.QueryRow("SELECT deleted_at IS NOT NULL FROM products WHERE id = $1", id).Scan(&deleted)
+	if err == sql.ErrNoRows || (err == nil && deleted) {
+		return errors.ErrProductNotFound
+	}
+	if err != nil {
+		fmt.Print(err)
+		return errors.ErrDatabase
+	}
+	return errors.ErrVersionMismatch
+}
+
+// expectedVersion returns the version a write is conditional on, NULL for unconditional writes
+func expectedVersion(version int) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(version), Valid: version > 0}
+}
+
+// Restore a soft deleted product
+func (m *ProductRepository) Restore(id string) (bool, error) {
+	res, err := m.Db.Exec("UPDATE products SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL", id)
+	if err != nil {
+		return false, writeError(err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return false, errors.ErrProductNotFound
+	}
 	return true, nil
 }
 
+// Permanently delete the products soft deleted before the given time that no order references
+func (m *ProductRepository) Purge(deletedBefore time.Time) (int64, error) {
+	res, err := m.Db.Exec(`DELETE FROM products p WHERE p.deleted_at < $1
+		AND NOT EXISTS (SELECT 1 FROM order_details d WHERE d.product_id = p.id)`, deletedBefore)
+	if err != nil {
+		fmt.Print(err)
+		return 0, errors.ErrDatabase
+	}
+	n, _ := res.RowsAffected()
+	return n, nil
+}
+
@@ -6,91 +6,115 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
 	repositories "github.com/shayja/go-template-api/internal/adapters/repositories/product"
 	"github.com/shayja/go-template-api/internal/entities"
+	appErrors "github.com/shayja/go-template-api/internal/errors"
+	"github.com/shayja/go-template-api/pkg/money"
 	"github.com/stretchr/testify/assert"
 )
 
-func TestGetAll_Success(t *testing.T) {
+func TestCreate_Success(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
 	defer db.Close()
 
 	repo := &repositories.ProductRepository{Db: db}
 
-	mockRows := sqlmock.NewRows([]string{"id", "name", "description", "image", "price", "sku", "updated_at", "created_at"}).
-		AddRow("1", "Product1", "Description1", "image1.jpg", 10.5, "SKU1", time.Now(), time.Now()).
-		AddRow("2", "Product2", "Description2", "image2.jpg", 20.5, "SKU2", time.Now(), time.Now())
-
-	mock.ExpectQuery("SELECT \\* FROM get_products\\(\\$1, \\$2\\)").
-		WithArgs(0, 20).
-		WillReturnRows(mockRows)
+	mock.ExpectQuery("CALL products_insert\\(\\$1, \\$2, \\$3, \\$4, \\$5, \\$6, \\$7, \\$8, \\$9\\)").
+		WithArgs("Product1", "Description1", "10.50", "image1.jpg", "SKU1", sqlmock.AnyArg(), `{"brand":"Acme"}`, `{"sale"}`, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
 
-	products, err := repo.GetAll(1)
+	id, err := repo.Create(&entities.ProductRequest{
+		Name:        "Product1",
+		Description: "Description1",
+		Price:       money.New(10, 50),
+		ImageURL:    "image1.jpg",
+		Sku:         "SKU1",
+		Attributes:  map[string]interface{}{"brand": "Acme"},
+		Tags:        []string{"sale"},
+	})
 	assert.NoError(t, err)
-	assert.Len(t, products, 2)
-	assert.Equal(t, "Product1", products[0].Name)
-	assert.Equal(t, "Product2", products[1].Name)
+	assert.Equal(t, "1", id)
 }
 
-func TestGetAll_Error(t *testing.T) {
+func TestCreate_Error(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
 	defer db.Close()
 
 	repo := &repositories.ProductRepository{Db: db}
 
-	mock.ExpectQuery("SELECT \\* FROM get_products\\(\\$1, \\$2\\)").
-		WithArgs(0, 20).
-		WillReturnError(errors.New("query error"))
+	mock.ExpectQuery("CALL products_insert\\(\\$1, \\$2, \\$3, \\$4, \\$5, \\$6, \\$7, \\$8, \\$9\\)").
+		WithArgs("Product1", "Description1", "10.50", "image1.jpg", "SKU1", sqlmock.AnyArg(), nil, nil, sqlmock.AnyArg()).
+		WillReturnError(errors.New("insert error"))
 
-	products, err := repo.GetAll(1)
+	id, err := repo.Create(&entities.ProductRequest{
+		Name:        "Product1",
+		Description: "Description1",
+		Price:       money.New(10, 50),
+		ImageURL:    "image1.jpg",
+		Sku:         "SKU1",
+	})
 	assert.Error(t, err)
-	assert.Nil(t, products)
+	assert.Empty(t, id)
 }
 
-func TestCreate_Success(t *testing.T) {
+func TestCreate_SkuConflict(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
 	defer db.Close()
 
 	repo := &repositories.ProductRepository{Db: db}
 
-	mock.ExpectQuery("CALL products_insert\\(\\$1, \\$2, \\$3, \\$4, \\$5, \\$6, \\$7\\)").
-		WithArgs("Product1", "Description1", 10.5, "image1.jpg", "SKU1", sqlmock.AnyArg(), sqlmock.AnyArg()).
-		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+	mock.ExpectQuery("CALL products_insert").
+		WithArgs("Product1", "Description1", "10.50", "image1.jpg", "sku1", sqlmock.AnyArg(), nil, nil, sqlmock.AnyArg()).
+		WillReturnError(&pq.Error{Code: "23505", Constraint: "idx_products_sku_unique"})
 
-	id, err := repo.Create(&entities.ProductRequest{
+	_, err = repo.Create(&entities.ProductRequest{
 		Name:        "Product1",
 		Description: "Description1",
-		Price:       10.5,
+		Price:       money.New(10, 50),
 		ImageURL:    "image1.jpg",
-		Sku:         "SKU1",
+		Sku:         "sku1",
 	})
+	assert.ErrorIs(t, err, appErrors.ErrSkuConflict)
+}
+
+func TestRestore_SkuConflict(t *testing.T) {
+	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
-	assert.Equal(t, "1", id)
+	defer db.Close()
+
+	repo := &repositories.ProductRepository{Db: db}
+
+	mock.ExpectExec("UPDATE products SET deleted_at = NULL WHERE id = \\$1 AND deleted_at IS NOT NULL").
+		WithArgs("1").
+		WillReturnError(&pq.Error{Code: "23505", Constraint: "idx_products_sku_unique"})
+
+	_, err = repo.Restore("1")
+	assert.ErrorIs(t, err, appErrors.ErrSkuConflict)
 }
 
-func TestCreate_Error(t *testing.T) {
+func TestGetBySkus(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
 	defer db.Close()
 
 	repo := &repositories.ProductRepository{Db: db}
 
-	mock.ExpectQuery("CALL products_insert\\(\\$1, \\$2, \\$3, \\$4, \\$5, \\$6, \\$7\\)").
-		WithArgs("Product1", "Description1", 10.5, "image1.jpg", "SKU1", sqlmock.AnyArg(), sqlmock.AnyArg()).
-		WillReturnError(errors.New("insert error"))
+	columns := []string{"id", "name", "description", "image", "price", "sku", "updated_at", "created_at", "image_status", "image_renditions", "rating_average", "rating_count", "version", "attributes", "tags"}
+	mock.ExpectQuery("SELECT (.+) FROM products WHERE lower\\(sku\\) = ANY\\(\\$1\\) AND deleted_at IS NULL").
+		WithArgs(`{"nokia-3310","case-1"}`).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("1", "Nokia 3310", "A phone", "", "49.90", "NOKIA-3310", time.Now(), time.Now(), nil, nil, 4.5, 2, 3, nil, nil))
 
-	id, err := repo.Create(&entities.ProductRequest{
-		Name:        "Product1",
-		Description: "Description1",
-		Price:       10.5,
-		ImageURL:    "image1.jpg",
-		Sku:         "SKU1",
-	})
-	assert.Error(t, err)
-	assert.Empty(t, id)
+	products, err := repo.GetBySkus([]string{"Nokia-3310", "CASE-1"})
+	assert.NoError(t, err)
+	assert.Len(t, products, 1)
+	assert.Equal(t, "NOKIA-3310", products[0].Sku)
+	assert.Equal(t, 3, products[0].Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestDelete_Success(t *testing.T) {
@@ -100,11 +124,11 @@ func TestDelete_Success(t *testing.T) {
 
 	repo := &repositories.ProductRepository{Db: db}
 
-	mock.ExpectExec("DELETE FROM products WHERE id = \\$1").
-		WithArgs("1").
+	mock.ExpectExec("UPDATE products SET deleted_at = NOW\\(\\) WHERE id = \\$1 AND deleted_at IS NULL").
+		WithArgs("1", nil).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	result, err := repo.Delete("1")
+	result, err := repo.Delete("1", 0)
 	assert.NoError(t, err)
 	assert.True(t, result)
 }
@@ -116,11 +140,330 @@ func TestDelete_Error(t *testing.T) {
 
 	repo := &repositories.ProductRepository{Db: db}
 
-	mock.ExpectExec("DELETE FROM products WHERE id = \\$1").
-		WithArgs("1").
+	mock.ExpectExec("UPDATE products SET deleted_at = NOW\\(\\) WHERE id = \\$1 AND deleted_at IS NULL").
+		WithArgs("1", nil).
 		WillReturnError(errors.New("delete error"))
 
-	result, err := repo.Delete("1")
+	result, err := repo.Delete("1", 0)
+	assert.Error(t, err)
+	assert.False(t, result)
+}
+
+func TestSearch_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ProductRepository{Db: db}
+
+	columns := []string{"id", "name", "description", "image", "price", "sku", "updated_at", "created_at", "deleted_at", "image_status", "image_renditions", "rating_average", "rating_count", "version", "attributes", "tags", "rank"}
+	mockRows := sqlmock.NewRows(columns).
+		AddRow("1", "Samsung Galaxy S24 Ultra", "Description1", "image1.jpg", 1167.0, "samsung-galaxy-s24-ultra", time.Now(), time.Now(), nil, nil, nil, 0, 0, 1, nil, nil, 0.6).
+		AddRow("2", "Samsung Galaxy Z Flip 6", "Description2", "image2.jpg", 1111.0, "samsung-galaxy-z-flip-6", time.Now(), time.Now(), nil, nil, nil, 0, 0, 1, nil, nil, 0.3)
+
+	maxPrice := money.New(1200, 0)
+	mock.ExpectQuery("SELECT (.+), ts_rank\\(search_vector, websearch_to_tsquery\\('english', \\$1\\)\\) AS rank FROM products WHERE deleted_at IS NULL AND search_vector @@ websearch_to_tsquery\\('english', \\$1\\) AND price <= \\$2 AND lower\\(sku\\) LIKE \\$3 ORDER BY ts_rank\\(search_vector, websearch_to_tsquery\\('english', \\$1\\)\\) DESC, id DESC LIMIT \\$4").
+		WithArgs("galaxy", "1200.00", "samsung\\_%", 2).
+		WillReturnRows(mockRows)
+
+	filter := &entities.ProductFilter{Query: " galaxy ", MaxPrice: &maxPrice, SkuPrefix: "Samsung_"}
+	filter.Limit = 1
+	products, err := repo.Search(filter)
+	assert.NoError(t, err)
+	assert.Len(t, products.Items, 1)
+	assert.Equal(t, "Samsung Galaxy S24 Ultra", products.Items[0].Name)
+	assert.NotEmpty(t, products.NextCursor)
+	assert.Empty(t, products.PrevCursor)
+	assert.Nil(t, products.Total)
+}
+
+func TestSearch_NextPage(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ProductRepository{Db: db}
+
+	columns := []string{"id", "name", "description", "image", "price", "sku", "updated_at", "created_at", "deleted_at", "image_status", "image_renditions", "rating_average", "rating_count", "version", "attributes", "tags", "rank"}
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM products WHERE deleted_at IS NULL$").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(8))
+	mock.ExpectQuery("SELECT (.+), 0::real AS rank FROM products WHERE deleted_at IS NULL ORDER BY price DESC, id DESC LIMIT \\$1").
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("c3e4bc7a-4a0d-4881-87fc-4c6d0b30037d", "Google Pixel 9 Pro XL", "", "", 1367.0, "pixel-9-pro-xl", time.Now(), time.Now(), nil, nil, nil, 0, 0, 1, nil, nil, 0).
+			AddRow("6369403b-4c58-4ae9-89bd-a7884e4e6b66", "Xiaomi 14T Pro", "", "", 1299.0, "xiaomi-14t-pro", time.Now(), time.Now(), nil, nil, nil, 0, 0, 1, nil, nil, 0).
+			AddRow("48dd8c7a-9ac1-4263-88e4-bb01b5e29001", "iPhone 16 Pro Max", "", "", 1257.0, "iphone-16-pro", time.Now(), time.Now(), nil, nil, nil, 0, 0, 1, nil, nil, 0))
+
+	first := &entities.ProductFilter{}
+	first.Sort = "-price"
+	first.Limit = 2
+	first.IncludeTotal = true
+	page1, err := repo.Search(first)
+	assert.NoError(t, err)
+	assert.Len(t, page1.Items, 2)
+	assert.Equal(t, int64(8), *page1.Total)
+	assert.NotEmpty(t, page1.NextCursor)
+
+	mock.ExpectQuery("SELECT (.+) FROM products WHERE deleted_at IS NULL AND \\(price, id\\) < \\(\\$1::numeric, \\$2::uuid\\) ORDER BY price DESC, id DESC LIMIT \\$3").
+		WithArgs(1299.0, "6369403b-4c58-4ae9-89bd-a7884e4e6b66", 3).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("48dd8c7a-9ac1-4263-88e4-bb01b5e29001", "iPhone 16 Pro Max", "", "", 1257.0, "iphone-16-pro", time.Now(), time.Now(), nil, nil, nil, 0, 0, 1, nil, nil, 0))
+
+	second := &entities.ProductFilter{}
+	second.Sort = "-price"
+	second.Limit = 2
+	second.Cursor = page1.NextCursor
+	page2, err := repo.Search(second)
+	assert.NoError(t, err)
+	assert.Len(t, page2.Items, 1)
+	assert.Empty(t, page2.NextCursor)
+	assert.NotEmpty(t, page2.PrevCursor)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSearch_InvalidSort(t *testing.T) {
+	db, _, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ProductRepository{Db: db}
+
+	filter := &entities.ProductFilter{}
+	filter.Sort = "description"
+	products, err := repo.Search(filter)
+	assert.ErrorIs(t, err, appErrors.ErrInvalidSort)
+	assert.Nil(t, products)
+}
+
+func TestSearch_Error(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ProductRepository{Db: db}
+
+	mock.ExpectQuery("SELECT (.+) FROM products WHERE deleted_at IS NULL AND price >= \\$1 ORDER BY name ASC, id ASC LIMIT \\$2").
+		WithArgs("10.00", 21).
+		WillReturnError(errors.New("query error"))
+
+	minPrice := money.New(10, 0)
+	products, err := repo.Search(&entities.ProductFilter{MinPrice: &minPrice})
 	assert.Error(t, err)
+	assert.Nil(t, products)
+}
+
+func TestDelete_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ProductRepository{Db: db}
+
+	mock.ExpectExec("UPDATE products SET deleted_at = NOW\\(\\)").
+		WithArgs("1", nil).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT deleted_at IS NOT NULL FROM products WHERE id = \\$1").
+		WithArgs("1").
+		WillReturnRows(sqlmock.NewRows([]string{"deleted"}))
+
+	result, err := repo.Delete("1", 0)
+	assert.ErrorIs(t, err, appErrors.ErrProductNotFound)
 	assert.False(t, result)
 }
+
+func TestRestore_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ProductRepository{Db: db}
+
+	mock.ExpectExec("UPDATE products SET deleted_at = NULL WHERE id = \\$1 AND deleted_at IS NOT NULL").
+		WithArgs("1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	result, err := repo.Restore("1")
+	assert.NoError(t, err)
+	assert.True(t, result)
+}
+
+func TestPurge_SkipsOrderedProducts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ProductRepository{Db: db}
+
+	before := time.Now().Add(-time.Hour)
+	mock.ExpectExec("DELETE FROM products p WHERE p.deleted_at < \\$1\\s+AND NOT EXISTS \\(SELECT 1 FROM order_details d WHERE d.product_id = p.id\\)").
+		WithArgs(before).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	purged, err := repo.Purge(before)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), purged)
+}
+
+func TestSearch_IncludeDeleted(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ProductRepository{Db: db}
+
+	columns := []string{"id", "name", "description", "image", "price", "sku", "updated_at", "created_at", "deleted_at", "image_status", "image_renditions", "rating_average", "rating_count", "version", "attributes", "tags", "rank"}
+	mock.ExpectQuery("SELECT (.+) AS rank FROM products ORDER BY name ASC, id ASC LIMIT \\$1").
+		WithArgs(21).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("1", "Nokia 3310", "", "", 59.0, "nokia-3310", time.Now(), time.Now(), time.Now(), nil, nil, 0, 0, 1, nil, nil, 0))
+
+	products, err := repo.Search(&entities.ProductFilter{IncludeDeleted: true})
+	assert.NoError(t, err)
+	assert.Len(t, products.Items, 1)
+	assert.NotNil(t, products.Items[0].DeletedAt)
+}
+
+func TestSearch_TagsAndAttributes(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ProductRepository{Db: db}
+
+	columns := []string{"id", "name", "description", "image", "price", "sku", "updated_at", "created_at", "deleted_at", "image_status", "image_renditions", "rating_average", "rating_count", "version", "attributes", "tags", "rank"}
+	mock.ExpectQuery("SELECT (.+) AS rank FROM products WHERE deleted_at IS NULL AND tags @> \\$1 AND \\(attributes @> \\$2::jsonb OR attributes @> \\$3::jsonb\\) AND \\(attributes @> \\$4::jsonb\\) ORDER BY name ASC, id ASC LIMIT \\$5").
+		WithArgs(`{"sale"}`, `{"brand":"Acme"}`, `{"brand":"Globex"}`, `{"weight":150}`, 21).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("1", "Anvil", "", "", 59.0, "anvil", time.Now(), time.Now(), nil, nil, nil, 0, 0, 1, []byte(`{"brand":"Acme","weight":150}`), "{sale}", 0))
+
+	products, err := repo.Search(&entities.ProductFilter{
+		Tags:       []string{"sale"},
+		Attributes: map[string][]interface{}{"weight": {150.0}, "brand": {"Acme", "Globex"}},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, products.Items, 1)
+	assert.Equal(t, []string{"sale"}, products.Items[0].Tags)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetById_ImageRenditions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ProductRepository{Db: db}
+
+	columns := []string{"id", "name", "description", "image", "price", "sku", "updated_at", "created_at", "deleted_at", "image_status", "image_renditions", "rating_average", "rating_count", "version", "attributes", "tags"}
+	mock.ExpectQuery("SELECT \\* FROM get_product\\(\\$1\\)").
+		WithArgs("1").
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("1", "Nokia 3310", "", "https://cdn/large.jpg", 59.0, "nokia-3310", time.Now(), time.Now(), nil, "ready",
+				[]byte(`[{"name":"thumbnail","format":"jpeg","width":150,"height":100,"url":"https://cdn/thumbnail.jpg"}]`), 4.25, 12, 3, []byte(`{"brand":"Acme","weight":150}`), "{sale,new}"))
+
+	product, err := repo.GetById("1")
+	assert.NoError(t, err)
+	assert.Equal(t, entities.ImageStatusReady, product.ImageStatus)
+	assert.Equal(t, []entities.ImageRendition{{Name: "thumbnail", Format: "jpeg", Width: 150, Height: 100, URL: "https://cdn/thumbnail.jpg"}}, product.Renditions)
+	assert.Equal(t, 4.25, product.RatingAverage)
+	assert.Equal(t, 12, product.RatingCount)
+	assert.Equal(t, map[string]interface{}{"brand": "Acme", "weight": 150.0}, product.Attributes)
+	assert.Equal(t, []string{"sale", "new"}, product.Tags)
+}
+
+func TestUpdate_VersionMismatch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ProductRepository{Db: db}
+
+	request := &entities.ProductRequest{Name: "Nokia 3310", Description: "A phone", ImageURL: "", Price: money.New(59, 0), Sku: "nokia-3310"}
+	mock.ExpectQuery("CALL products_update\\(\\$1, \\$2, \\$3, \\$4, \\$5, \\$6, \\$7, \\$8, \\$9, \\$10\\)").
+		WithArgs("1", "Nokia 3310", "A phone", "59.00", "", "nokia-3310", nil, nil, 2, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"new_version"}).AddRow(nil))
+	mock.ExpectQuery("SELECT deleted_at IS NOT NULL FROM products WHERE id = \\$1").
+		WithArgs("1").
+		WillReturnRows(sqlmock.NewRows([]string{"deleted"}).AddRow(false))
+
+	_, err = repo.Update("1", request, 2)
+	assert.ErrorIs(t, err, appErrors.ErrVersionMismatch)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdatePrice_Version(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ProductRepository{Db: db}
+
+	columns := []string{"id", "name", "description", "image", "price", "sku", "updated_at", "created_at", "deleted_at", "image_status", "image_renditions", "rating_average", "rating_count", "version", "attributes", "tags"}
+	mock.ExpectQuery("CALL products_update_price\\(\\$1, \\$2, \\$3, \\$4\\)").
+		WithArgs("1", "49.00", 2, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"new_version"}).AddRow(3))
+	mock.ExpectQuery("SELECT \\* FROM get_product\\(\\$1\\)").
+		WithArgs("1").
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("1", "Nokia 3310", "", "", 49.0, "nokia-3310", time.Now(), time.Now(), nil, nil, nil, 0, 0, 3, nil, nil))
+
+	product, err := repo.UpdatePrice("1", &entities.ProductPriceRequest{Price: money.New(49, 0)}, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, product.Version)
+}
+
+func TestFacets(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ProductRepository{Db: db}
+
+	columns := []string{"facet", "key", "value", "label", "count"}
+	mock.ExpectQuery("WITH RECURSIVE matched AS MATERIALIZED \\(SELECT id, price, tags, attributes FROM products WHERE deleted_at IS NULL AND tags @> \\$1\\)(.+)width_bucket\\(m.price, \\$2::numeric\\[\\]\\)(.+)unnest\\(m.tags\\)(.+)jsonb_each\\(m.attributes\\)").
+		WithArgs(`{"sale"}`, `{"0.00","100.00","500.00"}`).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("category", nil, "phones", "Phones", 3).
+			AddRow("category", nil, "electronics", "Electronics", 5).
+			AddRow("price", nil, "1", nil, 4).
+			AddRow("price", nil, "3", nil, 1).
+			AddRow("tag", nil, "sale", nil, 5).
+			AddRow("tag", nil, "new", nil, 2).
+			AddRow("tag", nil, "clearance", nil, 1).
+			AddRow("attribute", "brand", `"Acme"`, nil, 2).
+			AddRow("attribute", "brand", `"Globex"`, nil, 3).
+			AddRow("attribute", "weight", `150`, nil, 1))
+
+	bounds := []money.Amount{0, money.New(100, 0), money.New(500, 0)}
+	facets, err := repo.Facets(&entities.ProductFilter{Tags: []string{"sale"}}, bounds, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []entities.FacetCount{{Value: "electronics", Label: "Electronics", Count: 5}, {Value: "phones", Label: "Phones", Count: 3}}, facets.Categories)
+	assert.Equal(t, []entities.PriceBucketCount{
+		{Min: &bounds[0], Max: &bounds[1], Count: 4},
+		{Min: &bounds[1], Max: &bounds[2], Count: 0},
+		{Min: &bounds[2], Count: 1},
+	}, facets.Prices)
+	assert.Equal(t, []entities.FacetCount{{Value: "sale", Count: 5}, {Value: "new", Count: 2}}, facets.Tags)
+	assert.Equal(t, []entities.FacetCount{{Value: "Globex", Count: 3}, {Value: "Acme", Count: 2}}, facets.Attributes["brand"])
+	assert.Equal(t, []entities.FacetCount{{Value: 150.0, Count: 1}}, facets.Attributes["weight"])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFacets_OpenFirstBucket(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ProductRepository{Db: db}
+
+	mock.ExpectQuery("WITH RECURSIVE matched AS MATERIALIZED (.+)").
+		WithArgs(`{"50.00"}`).
+		WillReturnRows(sqlmock.NewRows([]string{"facet", "key", "value", "label", "count"}).
+			AddRow("price", nil, "0", nil, 7))
+
+	bounds := []money.Amount{money.New(50, 0)}
+	facets, err := repo.Facets(&entities.ProductFilter{IncludeDeleted: true}, bounds, 20)
+	assert.NoError(t, err)
+	assert.Equal(t, []entities.PriceBucketCount{{Max: &bounds[0], Count: 7}, {Min: &bounds[0]}}, facets.Prices)
+	assert.Empty(t, facets.Categories)
+	assert.Empty(t, facets.Attributes)
+}
@@ -0,0 +1,334 @@
+// adapters/repositories/promotion_repository.go
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/errors"
+)
+
+type PromotionRepository struct {
+	Db *sql.DB
+}
+
+const promotionColumns = `p.id, p.name, p.description, p.kind, p.value, p.buy_quantity, p.get_quantity, p.min_order,
+	p.product_ids, p.category_ids, p.automatic, p.active, p.starts_at, p.ends_at, p.created_at, p.updated_at`
+
+// The redemptions of cancelled orders give their use back
+const couponColumns = `c.id, c.promotion_id, c.code, c.usage_limit, c.per_user_limit,
+	(SELECT COUNT(*) FROM coupon_redemptions r JOIN orders o ON o.id = r.order_id WHERE r.coupon_id = c.id AND o.status <> 4),
+	c.active, c.created_at`
+
+// Get every promotion, the latest first
+func (m *PromotionRepository) GetAll() ([]*entities.Promotion, error) {
+	return m.list(`SELECT ` + promotionColumns + ` FROM promotions p ORDER BY p.created_at DESC, p.id`)
+}
+
+// Get a promotion by id, nil when it does not exist
+func (m *PromotionRepository) Get(id string) (*entities.Promotion, error) {
+	query, err := m.Db.Query(`SELECT `+promotionColumns+` FROM promotions p WHERE p.id = $1`, id)
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	return scanOne(query)
+}
+
+// Create a promotion
+func (m *PromotionRepository) Create(request *entities.PromotionRequest) (*entities.Promotion, error) {
+	query, err := m.Db.Query(`INSERT INTO promotions AS p (name, description, kind, value, buy_quantity, get_quantity, min_order,
+			product_ids, category_ids, automatic, active, starts_at, ends_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, COALESCE($11, true), $12, $13)
+		RETURNING `+promotionColumns, promotionArgs(request)...)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return scanOne(query)
+}
+
+// Replace the rule of a promotion, nil when it does not exist
+func (m *PromotionRepository) Update(id string, request *entities.PromotionRequest) (*entities.Promotion, error) {
+	query, err := m.Db.Query(`UPDATE promotions AS p SET name = $1, description = $2, kind = $3, value = $4, buy_quantity = $5,
+			get_quantity = $6, min_order = $7, product_ids = $8, category_ids = $9, automatic = $10, active = COALESCE($11, true),
+			starts_at = $12, ends_at = $13, updated_at = NOW()
+		WHERE p.id = $14
+		RETURNING `+promotionColumns, append(promotionArgs(request), id)...)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return scanOne(query)
+}
+
+// Delete a promotion and its coupon codes, the discounts of the orders keep their copy of the promotion
+func (m *PromotionRepository) Delete(id string) error {
+	res, err := m.Db.Exec(`DELETE FROM promotions WHERE id = $1`, id)
+	if err != nil {
+		fmt.Print(err)
+		return errors.ErrDatabase
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return errors.ErrPromotionNotFound
+	}
+	return nil
+}
+
+// Get the coupon codes of a promotion, the latest first
+func (m *PromotionRepository) GetCoupons(promotionId string) ([]*entities.Coupon, error) {
+	query, err := m.Db.Query(`SELECT `+couponColumns+` FROM coupons c WHERE c.promotion_id = $1 ORDER BY c.created_at DESC, c.id`, promotionId)
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	defer query.Close()
+
+	coupons := []*entities.Coupon{}
+	for query.Next() {
+		coupon, err := scanCoupon(query)
+		if err != nil {
+			return nil, err
+		}
+		coupons = append(coupons, coupon)
+	}
+	return coupons, nil
+}
+
+// Add a coupon code to a promotion
+func (m *PromotionRepository) CreateCoupon(promotionId string, request *entities.CouponRequest) (*entities.Coupon, error) {
+	query, err := m.Db.Query(`INSERT INTO coupons AS c (promotion_id, code, usage_limit, per_user_limit) VALUES ($1, $2, $3, $4)
+		RETURNING `+couponColumns, promotionId, request.Code, request.UsageLimit, request.PerUserLimit)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	defer query.Close()
+	if !query.Next() {
+		return nil, mapError(query.Err())
+	}
+	return scanCoupon(query)
+}
+
+// Delete a coupon code of a promotion, the orders it was used on keep the code
+func (m *PromotionRepository) DeleteCoupon(promotionId string, couponId string) error {
+	res, err := m.Db.Exec(`DELETE FROM coupons WHERE id = $1 AND promotion_id = $2`, couponId, promotionId)
+	if err != nil {
+		fmt.Print(err)
+		return errors.ErrDatabase
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return errors.ErrCouponNotFound
+	}
+	return nil
+}
+
+// Get the automatic promotions running at the given time, the oldest first
+func (m *PromotionRepository) GetAutomatic(now time.Time) ([]*entities.Promotion, error) {
+	return m.list(`SELECT `+promotionColumns+` FROM promotions p
+		WHERE p.automatic AND p.active AND (p.starts_at IS NULL OR p.starts_at <= $1) AND (p.ends_at IS NULL OR p.ends_at > $1)
+		ORDER BY p.created_at, p.id`, now)
+}
+
+// Get a coupon by its code, case insensitively, with its promotion. Both are nil when no coupon has the code
+func (m *PromotionRepository) GetByCoupon(code string) (*entities.Promotion, *entities.Coupon, error) {
+	query, err := m.Db.Query(`SELECT `+promotionColumns+`, `+couponColumns+`
+		FROM coupons c JOIN promotions p ON p.id = c.promotion_id
+		WHERE lower(c.code) = lower($1)`, code)
+	if err != nil {
+		fmt.Print(err)
+		return nil, nil, errors.ErrDatabase
+	}
+	defer query.Close()
+	if !query.Next() {
+		if err := query.Err(); err != nil {
+			return nil, nil, mapError(err)
+		}
+		return nil, nil, nil
+	}
+
+	var promotion promotionRow
+	var coupon couponRow
+	if err := query.Scan(append(promotion.fields(), coupon.fields()...)...); err != nil {
+		fmt.Print(err)
+		return nil, nil, errors.ErrDatabase
+	}
+	return promotion.entity(), coupon.entity(), nil
+}
+
+// Count the orders a coupon was used on, in total and by a user, cancelled orders excluded
+func (m *PromotionRepository) CouponUsage(couponId string, userId string) (int, int, error) {
+	var used, usedByUser int
+	err := m.Db.QueryRow(`SELECT COUNT(*), COUNT(*) FILTER (WHERE r.user_id = $2)
+		FROM coupon_redemptions r JOIN orders o ON o.id = r.order_id
+		WHERE r.coupon_id = $1 AND o.status <> 4`, couponId, userId).Scan(&used, &usedByUser)
+	if err != nil {
+		fmt.Print(err)
+		return 0, 0, errors.ErrDatabase
+	}
+	return used, usedByUser, nil
+}
+
+// Get the categories of products with all their parent categories, by product id
+func (m *PromotionRepository) GetProductCategories(productIds []string) (map[string][]string, error) {
+	query, err := m.Db.Query(`WITH RECURSIVE tree AS (
+			SELECT pc.product_id, c.id, c.parent_id
+			FROM product_categories pc JOIN categories c ON c.id = pc.category_id
+			WHERE pc.product_id = ANY($1)
+			UNION
+			SELECT t.product_id, c.id, c.parent_id
+			FROM tree t JOIN categories c ON c.id = t.parent_id
+		)
+		SELECT product_id, id FROM tree`, pq.Array(productIds))
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	defer query.Close()
+
+	categories := make(map[string][]string)
+	for query.Next() {
+		var productId, categoryId string
+		if err := query.Scan(&productId, &categoryId); err != nil {
+			fmt.Print(err)
+			return nil, errors.ErrDatabase
+		}
+		categories[productId] = append(categories[productId], categoryId)
+	}
+	return categories, nil
+}
+
+func (m *PromotionRepository) list(SQL string, args ...interface{}) ([]*entities.Promotion, error) {
+	query, err := m.Db.Query(SQL, args...)
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	defer query.Close()
+
+	promotions := []*entities.Promotion{}
+	for query.Next() {
+		promotion, err := scanPromotion(query)
+		if err != nil {
+			return nil, err
+		}
+		promotions = append(promotions, promotion)
+	}
+	return promotions, nil
+}
+
+func promotionArgs(request *entities.PromotionRequest) []interface{} {
+	productIds, categoryIds := request.ProductIds, request.CategoryIds
+	if productIds == nil {
+		productIds = []string{}
+	}
+	if categoryIds == nil {
+		categoryIds = []string{}
+	}
+	return []interface{}{request.Name, request.Description, request.Kind, request.Value, request.BuyQuantity, request.GetQuantity,
+		request.MinOrder, pq.Array(productIds), pq.Array(categoryIds), request.Automatic, request.Active, request.StartsAt, request.EndsAt}
+}
+
+// scanOne reads the single promotion of a query, nil when it returned no row
+func scanOne(query *sql.Rows) (*entities.Promotion, error) {
+	defer query.Close()
+	if !query.Next() {
+		if err := query.Err(); err != nil {
+			return nil, mapError(err)
+		}
+		return nil, nil
+	}
+	return scanPromotion(query)
+}
+
+// scanPromotion reads a row of promotionColumns
+func scanPromotion(query *sql.Rows) (*entities.Promotion, error) {
+	var row promotionRow
+	if err := query.Scan(row.fields()...); err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	return row.entity(), nil
+}
+
+// scanCoupon reads a row of couponColumns
+func scanCoupon(query *sql.Rows) (*entities.Coupon, error) {
+	var row couponRow
+	if err := query.Scan(row.fields()...); err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	return row.entity(), nil
+}
+
+// promotionRow holds a row of promotionColumns with its nullable columns
+type promotionRow struct {
+	promotion        entities.Promotion
+	productIds       pq.StringArray
+	categoryIds      pq.StringArray
+	startsAt, endsAt sql.NullTime
+}
+
+func (r *promotionRow) fields() []interface{} {
+	p := &r.promotion
+	return []interface{}{&p.Id, &p.Name, &p.Description, &p.Kind, &p.Value, &p.BuyQuantity, &p.GetQuantity, &p.MinOrder,
+		&r.productIds, &r.categoryIds, &p.Automatic, &p.Active, &r.startsAt, &r.endsAt, &p.CreatedAt, &p.UpdatedAt}
+}
+
+func (r *promotionRow) entity() *entities.Promotion {
+	p := r.promotion
+	p.ProductIds, p.CategoryIds = []string(r.productIds), []string(r.categoryIds)
+	if p.ProductIds == nil {
+		p.ProductIds = []string{}
+	}
+	if p.CategoryIds == nil {
+		p.CategoryIds = []string{}
+	}
+	if r.startsAt.Valid {
+		p.StartsAt = &r.startsAt.Time
+	}
+	if r.endsAt.Valid {
+		p.EndsAt = &r.endsAt.Time
+	}
+	return &p
+}
+
+// couponRow holds a row of couponColumns with its nullable columns
+type couponRow struct {
+	coupon                   entities.Coupon
+	usageLimit, perUserLimit sql.NullInt64
+}
+
+func (r *couponRow) fields() []interface{} {
+	c := &r.coupon
+	return []interface{}{&c.Id, &c.PromotionId, &c.Code, &r.usageLimit, &r.perUserLimit, &c.Used, &c.Active, &c.CreatedAt}
+}
+
+func (r *couponRow) entity() *entities.Coupon {
+	c := r.coupon
+	if r.usageLimit.Valid {
+		limit := int(r.usageLimit.Int64)
+		c.UsageLimit = &limit
+	}
+	if r.perUserLimit.Valid {
+		limit := int(r.perUserLimit.Int64)
+		c.PerUserLimit = &limit
+	}
+	return &c
+}
+
+// mapError translates constraint violations into application errors
+func mapError(err error) error {
+	fmt.Print(err)
+	if pqErr, ok := err.(*pq.Error); ok {
+		switch pqErr.Code {
+		case "23505": // unique_violation, another coupon has the code
+			return errors.ErrCouponExists
+		case "23503": // foreign_key_violation, the promotion was deleted
+			return errors.ErrPromotionNotFound
+		case "23514": // check_violation
+			return errors.ErrInvalidPromotion
+		}
+	}
+	return errors.ErrDatabase
+}
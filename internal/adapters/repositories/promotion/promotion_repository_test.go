@@ -0,0 +1,115 @@
+package repositories_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	repositories "github.com/shayja/go-template-api/internal/adapters/repositories/promotion"
+	"github.com/shayja/go-template-api/internal/entities"
+	appErrors "github.com/shayja/go-template-api/internal/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+var promotionColumns = []string{"id", "name", "description", "kind", "value", "buy_quantity", "get_quantity", "min_order",
+	"product_ids", "category_ids", "automatic", "active", "starts_at", "ends_at", "created_at", "updated_at"}
+
+var couponColumns = []string{"id", "promotion_id", "code", "usage_limit", "per_user_limit", "count", "active", "created_at"}
+
+func TestGetByCoupon(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.PromotionRepository{Db: db}
+	ends := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery("SELECT (.+) FROM coupons c JOIN promotions p ON p.id = c.promotion_id WHERE lower\\(c.code\\) = lower\\(\\$1\\)").
+		WithArgs("summer15").
+		WillReturnRows(sqlmock.NewRows(append(promotionColumns, couponColumns...)).AddRow(
+			"p1", "Summer sale", "", "percentage", "15.00", 0, 0, "50.00", "{}", "{c1,c2}", false, true, nil, ends, time.Now(), time.Now(),
+			"k1", "p1", "SUMMER15", nil, 1, 4, true, time.Now()))
+
+	promotion, coupon, err := repo.GetByCoupon("summer15")
+	assert.NoError(t, err)
+	assert.Equal(t, "15.00", promotion.Value.String())
+	assert.Equal(t, []string{}, promotion.ProductIds)
+	assert.Equal(t, []string{"c1", "c2"}, promotion.CategoryIds)
+	assert.Nil(t, promotion.StartsAt)
+	assert.Equal(t, ends, *promotion.EndsAt)
+	assert.Equal(t, "SUMMER15", coupon.Code)
+	assert.Nil(t, coupon.UsageLimit)
+	assert.Equal(t, 1, *coupon.PerUserLimit)
+	assert.Equal(t, 4, coupon.Used)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetByCoupon_Unknown(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.PromotionRepository{Db: db}
+
+	mock.ExpectQuery("SELECT (.+) FROM coupons c").
+		WithArgs("NOPE").
+		WillReturnRows(sqlmock.NewRows(append(promotionColumns, couponColumns...)))
+
+	promotion, coupon, err := repo.GetByCoupon("NOPE")
+	assert.NoError(t, err)
+	assert.Nil(t, promotion)
+	assert.Nil(t, coupon)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateCoupon_CodeTaken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.PromotionRepository{Db: db}
+
+	mock.ExpectQuery("INSERT INTO coupons AS c \\(promotion_id, code, usage_limit, per_user_limit\\) VALUES \\(\\$1, \\$2, \\$3, \\$4\\) RETURNING").
+		WillReturnError(&pq.Error{Code: "23505"})
+
+	_, err = repo.CreateCoupon("p1", &entities.CouponRequest{Code: "summer15"})
+	assert.ErrorIs(t, err, appErrors.ErrCouponExists)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetProductCategories_WithParents(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.PromotionRepository{Db: db}
+
+	mock.ExpectQuery("WITH RECURSIVE tree AS (.+) WHERE pc.product_id = ANY\\(\\$1\\)").
+		WithArgs(`{"a","b"}`).
+		WillReturnRows(sqlmock.NewRows([]string{"product_id", "id"}).
+			AddRow("a", "sneakers").
+			AddRow("a", "shoes").
+			AddRow("b", "hats"))
+
+	categories, err := repo.GetProductCategories([]string{"a", "b"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sneakers", "shoes"}, categories["a"])
+	assert.Equal(t, []string{"hats"}, categories["b"])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteCoupon_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.PromotionRepository{Db: db}
+
+	mock.ExpectExec("DELETE FROM coupons WHERE id = \\$1 AND promotion_id = \\$2").
+		WithArgs("k1", "p1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.ErrorIs(t, repo.DeleteCoupon("p1", "k1"), appErrors.ErrCouponNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,190 @@
+// adapters/repositories/review_repository.go
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/shayja/go-template-api/internal/adapters/repositories/pagination"
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/errors"
+	"github.com/shayja/go-template-api/pkg/constants"
+)
+
+type ReviewRepository struct {
+	Db *sql.DB
+}
+
+const reviewColumns = `id, product_id, user_id, rating, title, body, status, verified_purchase, moderated_at, created_at, updated_at`
+
+// Whitelisted review sort fields
+var reviewSortFields = map[string]pagination.SortField{
+	"created_at": {Column: "created_at", Type: "timestamp"},
+	"rating":     {Column: "rating", Type: "smallint"},
+}
+
+// Get a review by id, nil when it does not exist
+func (m *ReviewRepository) Get(id string) (*entities.Review, error) {
+	query, err := m.Db.Query(`SELECT `+reviewColumns+` FROM product_reviews WHERE id = $1`, id)
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	defer query.Close()
+
+	if !query.Next() {
+		return nil, nil
+	}
+	return scanReview(query)
+}
+
+// Get the reviews of a product in a moderation status, newest first by default
+func (m *ReviewRepository) GetByProduct(productId string, status string, list *entities.ListRequest) (*entities.List[*entities.Review], error) {
+	return m.list("product_id = $1 AND status = $2", []interface{}{productId, status}, list, "-created_at")
+}
+
+// Get the reviews of every product in a moderation status, oldest first by default
+func (m *ReviewRepository) GetByStatus(status string, list *entities.ListRequest) (*entities.List[*entities.Review], error) {
+	return m.list("status = $1", []interface{}{status}, list, "created_at")
+}
+
+// list returns a keyset page of the reviews matching a where clause
+func (m *ReviewRepository) list(where string, args []interface{}, list *entities.ListRequest, defaultSort string) (*entities.List[*entities.Review], error) {
+	page, err := pagination.New(list, reviewSortFields, defaultSort)
+	if err != nil {
+		return nil, err
+	}
+
+	var total *int64
+	if list.IncludeTotal {
+		var count int64
+		if err := m.Db.QueryRow(`SELECT COUNT(*) FROM product_reviews WHERE `+where, args...).Scan(&count); err != nil {
+			fmt.Print(err)
+			return nil, errors.ErrDatabase
+		}
+		total = &count
+	}
+
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	if keyset := page.Where(arg); keyset != "" {
+		where += " AND " + keyset
+	}
+	query, err := m.Db.Query(fmt.Sprintf(`SELECT `+reviewColumns+` FROM product_reviews WHERE %s ORDER BY %s LIMIT %s`,
+		where, page.OrderBy(), arg(page.Fetch())), args...)
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	defer query.Close()
+
+	var reviews []*entities.Review
+	for query.Next() {
+		review, err := scanReview(query)
+		if err != nil {
+			return nil, err
+		}
+		reviews = append(reviews, review)
+	}
+
+	res := pagination.Result(page, reviews, func(r *entities.Review) (interface{}, string) {
+		if strings.TrimPrefix(page.Sort, "-") == "rating" {
+			return r.Rating, r.Id
+		}
+		return r.CreatedAt, r.Id
+	})
+	res.Total = total
+	return res, nil
+}
+
+// Report whether the user ordered the product in an order that was not cancelled
+func (m *ReviewRepository) HasPurchased(productId string, userId string) (bool, error) {
+	var purchased bool
+	err := m.Db.QueryRow(`SELECT EXISTS (SELECT 1 FROM order_details d JOIN orders o ON o.id = d.order_id
+		WHERE d.product_id = $1 AND o.user_id = $2 AND o.status <> $3)`, productId, userId, constants.OrderStatusCancelled).Scan(&purchased)
+	if err != nil {
+		fmt.Print(err)
+		return false, errors.ErrDatabase
+	}
+	return purchased, nil
+}
+
+// Create a pending review of an active product
+func (m *ReviewRepository) Create(productId string, userId string, request *entities.ReviewRequest, verifiedPurchase bool) (*entities.Review, error) {
+	query, err := m.Db.Query(`INSERT INTO product_reviews (product_id, user_id, rating, title, body, verified_purchase)
+		SELECT id, $2, $3, $4, $5, $6 FROM products WHERE id = $1 AND deleted_at IS NULL
+		RETURNING `+reviewColumns, productId, userId, request.Rating, request.Title, request.Body, verifiedPurchase)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	defer query.Close()
+
+	if !query.Next() {
+		if err := query.Err(); err != nil {
+			return nil, mapError(err)
+		}
+		return nil, errors.ErrProductNotFound
+	}
+	return scanReview(query)
+}
+
+// Set the moderation status of a review
+func (m *ReviewRepository) SetStatus(id string, status string) (*entities.Review, error) {
+	query, err := m.Db.Query(`UPDATE product_reviews SET status = $2, moderated_at = NOW(), updated_at = NOW() WHERE id = $1 RETURNING `+reviewColumns, id, status)
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	defer query.Close()
+
+	if !query.Next() {
+		return nil, errors.ErrReviewNotFound
+	}
+	return scanReview(query)
+}
+
+// Delete a review
+func (m *ReviewRepository) Delete(id string) error {
+	res, err := m.Db.Exec(`DELETE FROM product_reviews WHERE id = $1`, id)
+	if err != nil {
+		fmt.Print(err)
+		return errors.ErrDatabase
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return errors.ErrReviewNotFound
+	}
+	return nil
+}
+
+// scanReview reads a row of reviewColumns
+func scanReview(query *sql.Rows) (*entities.Review, error) {
+	review := &entities.Review{}
+	var moderatedAt sql.NullTime
+	if err := query.Scan(&review.Id, &review.ProductId, &review.UserId, &review.Rating, &review.Title, &review.Body, &review.Status,
+		&review.VerifiedPurchase, &moderatedAt, &review.CreatedAt, &review.UpdatedAt); err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	if moderatedAt.Valid {
+		review.ModeratedAt = &moderatedAt.Time
+	}
+	return review, nil
+}
+
+// mapError translates constraint violations into application errors
+func mapError(err error) error {
+	fmt.Print(err)
+	if pqErr, ok := err.(*pq.Error); ok {
+		switch pqErr.Code {
+		case "23505": // unique_violation, the user already reviewed the product
+			return errors.ErrReviewExists
+		case "23503": // foreign_key_violation
+			return errors.ErrProductNotFound
+		}
+	}
+	return errors.ErrDatabase
+}
@@ -0,0 +1,118 @@
+package repositories_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	repositories "github.com/shayja/go-template-api/internal/adapters/repositories/review"
+	"github.com/shayja/go-template-api/internal/entities"
+	appErrors "github.com/shayja/go-template-api/internal/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+var reviewColumns = []string{"id", "product_id", "user_id", "rating", "title", "body", "status", "verified_purchase", "moderated_at", "created_at", "updated_at"}
+
+func TestGetByProduct_Approved(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ReviewRepository{Db: db}
+
+	mock.ExpectQuery("SELECT (.+) FROM product_reviews WHERE product_id = \\$1 AND status = \\$2 ORDER BY created_at DESC, id DESC LIMIT \\$3").
+		WithArgs("1", entities.ReviewApproved, 2).
+		WillReturnRows(sqlmock.NewRows(reviewColumns).
+			AddRow("r2", "1", "u2", 5, "Excellent", "", entities.ReviewApproved, true, time.Now(), time.Now(), time.Now()).
+			AddRow("r1", "1", "u1", 3, "Fine", "Does the job", entities.ReviewApproved, false, time.Now(), time.Now(), time.Now()))
+
+	reviews, err := repo.GetByProduct("1", entities.ReviewApproved, &entities.ListRequest{Limit: 1})
+	assert.NoError(t, err)
+	assert.Len(t, reviews.Items, 1)
+	assert.Equal(t, "r2", reviews.Items[0].Id)
+	assert.True(t, reviews.Items[0].VerifiedPurchase)
+	assert.NotNil(t, reviews.Items[0].ModeratedAt)
+	assert.NotEmpty(t, reviews.NextCursor)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetByStatus_OldestFirst(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ReviewRepository{Db: db}
+
+	mock.ExpectQuery("SELECT (.+) FROM product_reviews WHERE status = \\$1 ORDER BY created_at ASC, id ASC LIMIT \\$2").
+		WithArgs(entities.ReviewPending, 21).
+		WillReturnRows(sqlmock.NewRows(reviewColumns).
+			AddRow("r1", "1", "u1", 1, "Broke", "", entities.ReviewPending, false, nil, time.Now(), time.Now()))
+
+	reviews, err := repo.GetByStatus(entities.ReviewPending, &entities.ListRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, reviews.Items, 1)
+	assert.Nil(t, reviews.Items[0].ModeratedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHasPurchased(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ReviewRepository{Db: db}
+
+	mock.ExpectQuery("SELECT EXISTS \\(SELECT 1 FROM order_details d JOIN orders o ON o.id = d.order_id").
+		WithArgs("1", "u1", 4).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	purchased, err := repo.HasPurchased("1", "u1")
+	assert.NoError(t, err)
+	assert.True(t, purchased)
+}
+
+func TestCreate_AlreadyReviewed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ReviewRepository{Db: db}
+
+	mock.ExpectQuery("INSERT INTO product_reviews").
+		WithArgs("1", "u1", 4, "Good", "", false).
+		WillReturnError(&pq.Error{Code: "23505"})
+
+	_, err = repo.Create("1", "u1", &entities.ReviewRequest{Rating: 4, Title: "Good"}, false)
+	assert.ErrorIs(t, err, appErrors.ErrReviewExists)
+}
+
+func TestCreate_ProductNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ReviewRepository{Db: db}
+
+	mock.ExpectQuery("INSERT INTO product_reviews (.+) FROM products WHERE id = \\$1 AND deleted_at IS NULL").
+		WithArgs("1", "u1", 4, "Good", "", true).
+		WillReturnRows(sqlmock.NewRows(reviewColumns))
+
+	_, err = repo.Create("1", "u1", &entities.ReviewRequest{Rating: 4, Title: "Good"}, true)
+	assert.ErrorIs(t, err, appErrors.ErrProductNotFound)
+}
+
+func TestSetStatus_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.ReviewRepository{Db: db}
+
+	mock.ExpectQuery("UPDATE product_reviews SET status = \\$2").
+		WithArgs("r1", entities.ReviewApproved).
+		WillReturnRows(sqlmock.NewRows(reviewColumns))
+
+	_, err = repo.SetStatus("r1", entities.ReviewApproved)
+	assert.ErrorIs(t, err, appErrors.ErrReviewNotFound)
+}
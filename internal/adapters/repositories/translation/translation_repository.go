@@ -0,0 +1,141 @@
+// adapters/repositories/translation_repository.go
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/errors"
+)
+
+type TranslationRepository struct {
+	Db *sql.DB
+}
+
+// translationTable is where the translations of a kind of content are kept
+type translationTable struct {
+	table       string
+	key         string
+	description string
+	notFound    error
+}
+
+// Whitelisted translation tables, categories have no description
+var translationTables = map[string]translationTable{
+	entities.TranslationProduct:  {table: "product_translations", key: "product_id", description: "description", notFound: errors.ErrProductNotFound},
+	entities.TranslationCategory: {table: "category_translations", key: "category_id", description: "''", notFound: errors.ErrCategoryNotFound},
+}
+
+func tableOf(kind string) (translationTable, error) {
+	table, ok := translationTables[kind]
+	if !ok {
+		return table, errors.ErrInvalidInput
+	}
+	return table, nil
+}
+
+func (t translationTable) columns() string {
+	return "locale, name, " + t.description + ", created_at, updated_at"
+}
+
+// Get the translations of a product or category, ordered by locale
+func (m *TranslationRepository) GetTranslations(kind string, id string) ([]*entities.Translation, error) {
+	t, err := tableOf(kind)
+	if err != nil {
+		return nil, err
+	}
+	query, err := m.Db.Query(fmt.Sprintf(`SELECT %s FROM %s WHERE %s = $1 ORDER BY locale`, t.columns(), t.table, t.key), id)
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	defer query.Close()
+
+	translations := []*entities.Translation{}
+	for query.Next() {
+		translation := &entities.Translation{}
+		if err := query.Scan(&translation.Locale, &translation.Name, &translation.Description, &translation.CreatedAt, &translation.UpdatedAt); err != nil {
+			fmt.Print(err)
+			return nil, errors.ErrDatabase
+		}
+		translations = append(translations, translation)
+	}
+	return translations, nil
+}
+
+// Add or replace the translation of a product or category in a locale
+func (m *TranslationRepository) SaveTranslation(kind string, id string, locale string, request *entities.TranslationRequest) (*entities.Translation, error) {
+	t, err := tableOf(kind)
+	if err != nil {
+		return nil, err
+	}
+	columns, values, update := "name", "$3", "name = EXCLUDED.name"
+	args := []interface{}{id, locale, request.Name}
+	if kind == entities.TranslationProduct {
+		columns, values, update = "name, description", "$3, $4", "name = EXCLUDED.name, description = EXCLUDED.description"
+		args = append(args, request.Description)
+	}
+
+	translation := &entities.Translation{}
+	err = m.Db.QueryRow(fmt.Sprintf(`INSERT INTO %s AS t (%s, locale, %s) VALUES ($1, $2, %s)
+		ON CONFLICT (%s, locale) DO UPDATE SET %s, updated_at = NOW()
+		RETURNING %s`, t.table, t.key, columns, values, t.key, update, t.columns()), args...).
+		Scan(&translation.Locale, &translation.Name, &translation.Description, &translation.CreatedAt, &translation.UpdatedAt)
+	if err != nil {
+		fmt.Print(err)
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" { // foreign_key_violation
+			return nil, t.notFound
+		}
+		return nil, errors.ErrDatabase
+	}
+	return translation, nil
+}
+
+// Delete the translation of a product or category in a locale
+func (m *TranslationRepository) DeleteTranslation(kind string, id string, locale string) error {
+	t, err := tableOf(kind)
+	if err != nil {
+		return err
+	}
+	res, err := m.Db.Exec(fmt.Sprintf(`DELETE FROM %s WHERE %s = $1 AND locale = $2`, t.table, t.key), id, locale)
+	if err != nil {
+		fmt.Print(err)
+		return errors.ErrDatabase
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return errors.ErrTranslationNotFound
+	}
+	return nil
+}
+
+// Get the translations of the products or categories in the locales, by id and locale
+func (m *TranslationRepository) FindTranslations(kind string, ids []string, locales []string) (map[string]map[string]*entities.Translation, error) {
+	t, err := tableOf(kind)
+	if err != nil {
+		return nil, err
+	}
+	query, err := m.Db.Query(fmt.Sprintf(`SELECT %s, %s FROM %s WHERE %s = ANY($1) AND locale = ANY($2)`, t.key, t.columns(), t.table, t.key),
+		pq.Array(ids), pq.Array(locales))
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	defer query.Close()
+
+	translations := make(map[string]map[string]*entities.Translation)
+	for query.Next() {
+		var id string
+		translation := &entities.Translation{}
+		if err := query.Scan(&id, &translation.Locale, &translation.Name, &translation.Description, &translation.CreatedAt, &translation.UpdatedAt); err != nil {
+			fmt.Print(err)
+			return nil, errors.ErrDatabase
+		}
+		if translations[id] == nil {
+			translations[id] = make(map[string]*entities.Translation)
+		}
+		translations[id][translation.Locale] = translation
+	}
+	return translations, nil
+}
@@ -0,0 +1,83 @@
+package repositories_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	repositories "github.com/shayja/go-template-api/internal/adapters/repositories/translation"
+	"github.com/shayja/go-template-api/internal/entities"
+	appErrors "github.com/shayja/go-template-api/internal/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindTranslations(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.TranslationRepository{Db: db}
+
+	mock.ExpectQuery("SELECT product_id, locale, name, description, created_at, updated_at FROM product_translations WHERE product_id = ANY\\(\\$1\\) AND locale = ANY\\(\\$2\\)").
+		WithArgs(`{"1","2"}`, `{"fr-CA","fr"}`).
+		WillReturnRows(sqlmock.NewRows([]string{"product_id", "locale", "name", "description", "created_at", "updated_at"}).
+			AddRow("1", "fr", "Chapeau", "Un chapeau", time.Now(), time.Now()).
+			AddRow("1", "fr-CA", "Tuque", "", time.Now(), time.Now()))
+
+	translations, err := repo.FindTranslations(entities.TranslationProduct, []string{"1", "2"}, []string{"fr-CA", "fr"})
+	assert.NoError(t, err)
+	assert.Len(t, translations["1"], 2)
+	assert.Equal(t, "Tuque", translations["1"]["fr-CA"].Name)
+	assert.Nil(t, translations["2"])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSaveTranslation_Category(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.TranslationRepository{Db: db}
+
+	mock.ExpectQuery("INSERT INTO category_translations AS t \\(category_id, locale, name\\) VALUES \\(\\$1, \\$2, \\$3\\) ON CONFLICT \\(category_id, locale\\) DO UPDATE SET name = EXCLUDED.name, updated_at = NOW\\(\\) RETURNING locale, name, '', created_at, updated_at").
+		WithArgs("c1", "de", "Schuhe").
+		WillReturnRows(sqlmock.NewRows([]string{"locale", "name", "description", "created_at", "updated_at"}).AddRow("de", "Schuhe", "", time.Now(), time.Now()))
+
+	translation, err := repo.SaveTranslation(entities.TranslationCategory, "c1", "de", &entities.TranslationRequest{Name: "Schuhe"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Schuhe", translation.Name)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSaveTranslation_ProductNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.TranslationRepository{Db: db}
+
+	mock.ExpectQuery("INSERT INTO product_translations AS t \\(product_id, locale, name, description\\)").
+		WithArgs("1", "fr", "Chapeau", "Un chapeau").
+		WillReturnError(&pq.Error{Code: "23503"})
+
+	_, err = repo.SaveTranslation(entities.TranslationProduct, "1", "fr", &entities.TranslationRequest{Name: "Chapeau", Description: "Un chapeau"})
+	assert.Equal(t, appErrors.ErrProductNotFound, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteTranslation_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.TranslationRepository{Db: db}
+
+	mock.ExpectExec("DELETE FROM product_translations WHERE product_id = \\$1 AND locale = \\$2").
+		WithArgs("1", "fr").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repo.DeleteTranslation(entities.TranslationProduct, "1", "fr")
+	assert.Equal(t, appErrors.ErrTranslationNotFound, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
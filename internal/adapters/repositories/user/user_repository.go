@@ -32,7 +32,7 @@ func (m *UserRepository) GetUserById(id string) (*entities.User, error) {
 	if query != nil {
 		for query.Next() {
 			var otpTypesRaw string
-			err := query.Scan(&user.Id, &user.Username, &user.Password, &user.Mobile, &user.FirstName, &user.LastName, &user.Email, &otpTypesRaw, &user.Verified, &user.VerifiedAt, &user.UpdatedAt, &user.CreatedAt)
+			err := query.Scan(&user.Id, &user.Username, &user.Password, &user.Mobile, &user.FirstName, &user.LastName, &user.Email, &otpTypesRaw, &user.Verified, &user.VerifiedAt, &user.UpdatedAt, &user.CreatedAt, &user.Role)
 			if err != nil {
 				// Log error for debugging
 				fmt.Printf("Error scanning: %v\n", err)
@@ -67,7 +67,7 @@ func (m *UserRepository) GetUserByUsername(username string) (*entities.User, err
 	if query != nil {
 		for query.Next() {
 			var otpTypesRaw string
-			err := query.Scan(&user.Id, &user.Username, &user.Password, &user.Mobile, &user.FirstName, &user.LastName, &user.Email, &otpTypesRaw, &user.Verified, &user.VerifiedAt, &user.UpdatedAt, &user.CreatedAt)
+			err := query.Scan(&user.Id, &user.Username, &user.Password, &user.Mobile, &user.FirstName, &user.LastName, &user.Email, &otpTypesRaw, &user.Verified, &user.VerifiedAt, &user.UpdatedAt, &user.CreatedAt, &user.Role)
 			if err != nil {
 				// Log error for debugging
 				fmt.Printf("Error scanning: %v\n", err)
@@ -114,7 +114,7 @@ func (m *UserRepository) GetUserByMobile(mobile string) (*entities.User, error)
 		user := &entities.User{}
 		for query.Next() {
 			var otpTypesRaw string
-			err := query.Scan(&user.Id, &user.Username, &user.Password, &user.Mobile, &user.FirstName, &user.LastName, &user.Email, &otpTypesRaw, &user.Verified, &user.VerifiedAt, &user.UpdatedAt, &user.CreatedAt)
+			err := query.Scan(&user.Id, &user.Username, &user.Password, &user.Mobile, &user.FirstName, &user.LastName, &user.Email, &otpTypesRaw, &user.Verified, &user.VerifiedAt, &user.UpdatedAt, &user.CreatedAt, &user.Role)
 			if err != nil {
 				fmt.Print(err)
 				return nil, err
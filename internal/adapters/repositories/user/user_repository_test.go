@@ -28,8 +28,8 @@ func TestGetUserById_Success(t *testing.T) {
 	db, mock, repo := setupMock()
 	defer db.Close()
 
-	mockRows := sqlmock.NewRows([]string{"id", "username", "password", "mobile", "first_name", "last_name", "email", "otp_types", "verified", "verified_at", "updated_at", "created_at"}).
-		AddRow("1", "testuser", "passwordHash", "1234567890", "John", "Doe", "john.doe@example.com", "{1,2,3}", true, time.Now(), time.Now(), time.Now())
+	mockRows := sqlmock.NewRows([]string{"id", "username", "password", "mobile", "first_name", "last_name", "email", "otp_types", "verified", "verified_at", "updated_at", "created_at", "role"}).
+		AddRow("1", "testuser", "passwordHash", "1234567890", "John", "Doe", "john.doe@example.com", "{1,2,3}", true, time.Now(), time.Now(), time.Now(), "customer")
 
 	mock.ExpectQuery(`SELECT \* FROM get_user\(\$1\)`).
 		WithArgs("1").
@@ -48,7 +48,7 @@ func TestGetUserById_NotFound(t *testing.T) {
 	db, mock, repo := setupMock()
 	defer db.Close()
 
-	mockRows := sqlmock.NewRows([]string{"id", "username", "password", "mobile", "first_name", "last_name", "email", "otp_types", "verified", "verified_at", "updated_at", "created_at"})
+	mockRows := sqlmock.NewRows([]string{"id", "username", "password", "mobile", "first_name", "last_name", "email", "otp_types", "verified", "verified_at", "updated_at", "created_at", "role"})
 
 	mock.ExpectQuery(`SELECT \* FROM get_user\(\$1\)`).
 		WithArgs("99").
@@ -65,8 +65,8 @@ func TestGetUserByUsername_Success(t *testing.T) {
 	db, mock, repo := setupMock()
 	defer db.Close()
 
-	mockRows := sqlmock.NewRows([]string{"id", "username", "password", "mobile", "first_name", "last_name", "email", "otp_types", "verified", "verified_at", "updated_at", "created_at"}).
-		AddRow("1", "testuser", "passwordHash", "1234567890", "John", "Doe", "john.doe@example.com", "{1,2,3}", true, time.Now(), time.Now(), time.Now())
+	mockRows := sqlmock.NewRows([]string{"id", "username", "password", "mobile", "first_name", "last_name", "email", "otp_types", "verified", "verified_at", "updated_at", "created_at", "role"}).
+		AddRow("1", "testuser", "passwordHash", "1234567890", "John", "Doe", "john.doe@example.com", "{1,2,3}", true, time.Now(), time.Now(), time.Now(), "customer")
 
 	mock.ExpectQuery(`SELECT \* FROM get_user_by_username\(\$1\)`).
 		WithArgs("testuser").
@@ -83,7 +83,7 @@ func TestGetUserByUsername_NotFound(t *testing.T) {
 	db, mock, repo := setupMock()
 	defer db.Close()
 
-	mockRows := sqlmock.NewRows([]string{"id", "username", "password", "mobile", "first_name", "last_name", "email", "otp_types", "verified", "verified_at", "updated_at", "created_at"})
+	mockRows := sqlmock.NewRows([]string{"id", "username", "password", "mobile", "first_name", "last_name", "email", "otp_types", "verified", "verified_at", "updated_at", "created_at", "role"})
 
 	mock.ExpectQuery(`SELECT \* FROM get_user_by_username\(\$1\)`).
 		WithArgs("unknown").
@@ -151,8 +151,8 @@ func TestGetUserByMobile_Success(t *testing.T) {
 	defer db.Close()
 
 	// Mock the database query
-	rows := sqlmock.NewRows([]string{"id", "username", "password", "mobile", "first_name", "last_name", "email", "otpTypes", "verified", "verified_at", "updated_at", "created_at"}).
-		AddRow("1", "testuser", "hashedpassword", "123456789", "Test", "User", "test@example.com", "{1,2,3}", true, time.Now(), time.Now(), time.Now())
+	rows := sqlmock.NewRows([]string{"id", "username", "password", "mobile", "first_name", "last_name", "email", "otpTypes", "verified", "verified_at", "updated_at", "created_at", "role"}).
+		AddRow("1", "testuser", "hashedpassword", "123456789", "Test", "User", "test@example.com", "{1,2,3}", true, time.Now(), time.Now(), time.Now(), "customer")
 	mock.ExpectQuery("SELECT \\* FROM get_user_by_mobile\\(\\$1\\)").
 		WithArgs("123456789").
 		WillReturnRows(rows)
@@ -0,0 +1,179 @@
+// adapters/repositories/variant_repository.go
+package repositories
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/errors"
+	"github.com/shayja/go-template-api/internal/utils"
+)
+
+type VariantRepository struct {
+	Db *sql.DB
+}
+
+const variantColumns = `v.id, v.product_id, v.sku, v.price, COALESCE(v.price, p.price), v.image, v.options, v.available, v.position, v.updated_at, v.created_at`
+
+// Get the option axes of a product
+func (m *VariantRepository) GetOptions(productId string) ([]entities.ProductOption, error) {
+	query, err := m.Db.Query(`SELECT name, "values", position FROM product_options WHERE product_id = $1 ORDER BY position, name`, productId)
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	defer query.Close()
+
+	var options []entities.ProductOption
+	for query.Next() {
+		var option entities.ProductOption
+		if err := query.Scan(&option.Name, pq.Array(&option.Values), &option.Position); err != nil {
+			fmt.Print(err)
+			return nil, errors.ErrDatabase
+		}
+		options = append(options, option)
+	}
+	return options, nil
+}
+
+// Replace the option axes of a product
+func (m *VariantRepository) SetOptions(productId string, options []entities.ProductOption) error {
+	tx, err := m.Db.Begin()
+	if err != nil {
+		fmt.Print(err)
+		return errors.ErrDatabase
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM product_options WHERE product_id = $1`, productId); err != nil {
+		fmt.Print(err)
+		return errors.ErrDatabase
+	}
+	for _, option := range options {
+		_, err := tx.Exec(`INSERT INTO product_options (product_id, name, "values", position) VALUES ($1, $2, $3, $4)`,
+			productId, option.Name, pq.Array(option.Values), option.Position)
+		if err != nil {
+			fmt.Print(err)
+			return mapError(err, errors.ErrProductNotFound)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		fmt.Print(err)
+		return errors.ErrDatabase
+	}
+	return nil
+}
+
+// Get the variants of a product
+func (m *VariantRepository) GetByProduct(productId string) ([]*entities.ProductVariant, error) {
+	query, err := m.Db.Query(`SELECT `+variantColumns+` FROM product_variants v JOIN products p ON p.id = v.product_id WHERE v.product_id = $1 ORDER BY v.position, v.sku`, productId)
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	defer query.Close()
+
+	var variants []*entities.ProductVariant
+	for query.Next() {
+		variant, err := scanVariant(query)
+		if err != nil {
+			return nil, err
+		}
+		variants = append(variants, variant)
+	}
+	return variants, nil
+}
+
+// Get a single variant by id
+func (m *VariantRepository) GetById(id string) (*entities.ProductVariant, error) {
+	query, err := m.Db.Query(`SELECT `+variantColumns+` FROM product_variants v JOIN products p ON p.id = v.product_id WHERE v.id = $1`, id)
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	defer query.Close()
+
+	if !query.Next() {
+		return nil, errors.ErrVariantNotFound
+	}
+	return scanVariant(query)
+}
+
+// Create a variant of a product
+func (m *VariantRepository) Create(productId string, variant *entities.ProductVariantRequest) (string, error) {
+	options, _ := json.Marshal(variant.Options)
+	newId := utils.CreateNewUUID().String()
+	_, err := m.Db.Exec(`INSERT INTO product_variants (id, product_id, sku, price, image, options, available, position) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		newId, productId, variant.Sku, variant.Price, variant.ImageURL, options, variant.Available == nil || *variant.Available, variant.Position)
+	if err != nil {
+		fmt.Print(err)
+		return "", mapError(err, errors.ErrProductNotFound)
+	}
+
+	fmt.Printf("Variant %s created successfully (new id is %s)\n", variant.Sku, newId)
+	return newId, nil
+}
+
+// Update a variant
+func (m *VariantRepository) Update(id string, variant *entities.ProductVariantRequest) (*entities.ProductVariant, error) {
+	options, _ := json.Marshal(variant.Options)
+	res, err := m.Db.Exec(`UPDATE product_variants SET sku = $2, price = $3, image = $4, options = $5, available = $6, position = $7, updated_at = NOW() WHERE id = $1`,
+		id, variant.Sku, variant.Price, variant.ImageURL, options, variant.Available == nil || *variant.Available, variant.Position)
+	if err != nil {
+		fmt.Print(err)
+		return nil, mapError(err, errors.ErrProductNotFound)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, errors.ErrVariantNotFound
+	}
+	return m.GetById(id)
+}
+
+// Delete a variant, the variants referenced by order line items are kept
+func (m *VariantRepository) Delete(id string) (bool, error) {
+	res, err := m.Db.Exec(`DELETE FROM product_variants WHERE id = $1`, id)
+	if err != nil {
+		fmt.Print(err)
+		return false, mapError(err, errors.ErrVariantOrdered)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return false, errors.ErrVariantNotFound
+	}
+	return true, nil
+}
+
+func scanVariant(query *sql.Rows) (*entities.ProductVariant, error) {
+	variant := &entities.ProductVariant{}
+	var image sql.NullString
+	var options []byte
+	err := query.Scan(&variant.Id, &variant.ProductId, &variant.Sku, &variant.Price, &variant.EffectivePrice, &image, &options, &variant.Available, &variant.Position, &variant.UpdatedAt, &variant.CreatedAt)
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	if image.Valid {
+		variant.ImageURL = &image.String
+	}
+	if err := json.Unmarshal(options, &variant.Options); err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	return variant, nil
+}
+
+// mapError translates constraint violations into application errors,
+// fkErr being the error of a foreign key violation in the calling context
+func mapError(err error, fkErr error) error {
+	if pqErr, ok := err.(*pq.Error); ok {
+		switch pqErr.Code {
+		case "23505": // unique_violation
+			return errors.ErrVariantConflict
+		case "23503": // foreign_key_violation
+			return fkErr
+		}
+	}
+	return errors.ErrDatabase
+}
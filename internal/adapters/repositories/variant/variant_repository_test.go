@@ -0,0 +1,94 @@
+package repositories_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	repositories "github.com/shayja/go-template-api/internal/adapters/repositories/variant"
+	"github.com/shayja/go-template-api/internal/entities"
+	appErrors "github.com/shayja/go-template-api/internal/errors"
+	"github.com/shayja/go-template-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+)
+
+var variantColumns = []string{"id", "product_id", "sku", "price", "effective_price", "image", "options", "available", "position", "updated_at", "created_at"}
+
+func TestGetById_InheritsProductPrice(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.VariantRepository{Db: db}
+
+	mock.ExpectQuery("SELECT (.+) FROM product_variants v JOIN products p ON p.id = v.product_id WHERE v.id = \\$1").
+		WithArgs("v1").
+		WillReturnRows(sqlmock.NewRows(variantColumns).
+			AddRow("v1", "1", "tshirt-red-m", nil, 19.99, nil, []byte(`{"size":"M","color":"red"}`), true, 0, time.Now(), time.Now()))
+
+	variant, err := repo.GetById("v1")
+	assert.NoError(t, err)
+	assert.Nil(t, variant.Price)
+	assert.Equal(t, money.New(19, 99), variant.EffectivePrice)
+	assert.Equal(t, map[string]string{"size": "M", "color": "red"}, variant.Options)
+}
+
+func TestGetById_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.VariantRepository{Db: db}
+
+	mock.ExpectQuery("SELECT (.+) FROM product_variants").
+		WithArgs("missing").
+		WillReturnRows(sqlmock.NewRows(variantColumns))
+
+	variant, err := repo.GetById("missing")
+	assert.ErrorIs(t, err, appErrors.ErrVariantNotFound)
+	assert.Nil(t, variant)
+}
+
+func TestCreate_VariantConflict(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.VariantRepository{Db: db}
+
+	mock.ExpectExec("INSERT INTO product_variants").
+		WillReturnError(&pq.Error{Code: "23505"})
+
+	_, err = repo.Create("1", &entities.ProductVariantRequest{Sku: "tshirt-red-m", Options: map[string]string{"size": "M"}})
+	assert.ErrorIs(t, err, appErrors.ErrVariantConflict)
+}
+
+func TestCreate_ProductNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.VariantRepository{Db: db}
+
+	mock.ExpectExec("INSERT INTO product_variants").
+		WillReturnError(&pq.Error{Code: "23503"})
+
+	_, err = repo.Create("missing", &entities.ProductVariantRequest{Sku: "tshirt-red-m", Options: map[string]string{"size": "M"}})
+	assert.ErrorIs(t, err, appErrors.ErrProductNotFound)
+}
+
+func TestDelete_VariantOrdered(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.VariantRepository{Db: db}
+
+	mock.ExpectExec("DELETE FROM product_variants WHERE id = \\$1").
+		WithArgs("v1").
+		WillReturnError(&pq.Error{Code: "23503"})
+
+	_, err = repo.Delete("v1")
+	assert.ErrorIs(t, err, appErrors.ErrVariantOrdered)
+}
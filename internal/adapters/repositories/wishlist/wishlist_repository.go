@@ -0,0 +1,319 @@
+// adapters/repositories/wishlist_repository.go
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/errors"
+)
+
+type WishlistRepository struct {
+	Db *sql.DB
+}
+
+const wishlistColumns = `id, user_id, name, is_default, share_token,
+	(SELECT COUNT(*) FROM wishlist_items i WHERE i.wishlist_id = wishlists.id), created_at, updated_at`
+
+// Get the wishlists of a user, the default list first
+func (m *WishlistRepository) GetByUser(userId string) ([]*entities.Wishlist, error) {
+	query, err := m.Db.Query(`SELECT `+wishlistColumns+` FROM wishlists WHERE user_id = $1 ORDER BY is_default DESC, created_at, id`, userId)
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	defer query.Close()
+
+	wishlists := []*entities.Wishlist{}
+	for query.Next() {
+		wishlist, err := scanWishlist(query)
+		if err != nil {
+			return nil, err
+		}
+		wishlists = append(wishlists, wishlist)
+	}
+	return wishlists, nil
+}
+
+// Get a wishlist by id, nil when it does not exist
+func (m *WishlistRepository) Get(id string) (*entities.Wishlist, error) {
+	return m.get(`SELECT `+wishlistColumns+` FROM wishlists WHERE id = $1`, id)
+}
+
+// Get a shared wishlist by its share token, nil when no list is shared with the token
+func (m *WishlistRepository) GetByToken(token string) (*entities.Wishlist, error) {
+	return m.get(`SELECT `+wishlistColumns+` FROM wishlists WHERE share_token = $1`, token)
+}
+
+// Get the default wishlist of a user, creating it with the given name when the user has none
+func (m *WishlistRepository) GetDefault(userId string, name string) (*entities.Wishlist, error) {
+	// The partial unique index on the default lists settles concurrent first uses
+	_, err := m.Db.Exec(`INSERT INTO wishlists (user_id, name, is_default)
+		SELECT $1, $2, true WHERE NOT EXISTS (SELECT 1 FROM wishlists WHERE user_id = $1 AND is_default)
+		ON CONFLICT DO NOTHING`, userId, name)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return m.get(`SELECT `+wishlistColumns+` FROM wishlists WHERE user_id = $1 AND is_default`, userId)
+}
+
+// Create a wishlist unless the user reached the maximum number of lists. The first list of a user is
+// always the default list, a new default list replaces the previous one
+func (m *WishlistRepository) Create(userId string, request *entities.WishlistRequest, maxLists int) (*entities.Wishlist, error) {
+	tx, err := m.Db.Begin()
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	defer tx.Rollback()
+
+	if request.IsDefault {
+		if _, err := tx.Exec(`UPDATE wishlists SET is_default = false, updated_at = NOW() WHERE user_id = $1 AND is_default`, userId); err != nil {
+			fmt.Print(err)
+			return nil, errors.ErrDatabase
+		}
+	}
+	query, err := tx.Query(`INSERT INTO wishlists (user_id, name, is_default)
+		SELECT $1, $2, $3 OR NOT EXISTS (SELECT 1 FROM wishlists WHERE user_id = $1 AND is_default)
+		WHERE (SELECT COUNT(*) FROM wishlists WHERE user_id = $1) < $4
+		RETURNING `+wishlistColumns, userId, request.Name, request.IsDefault, maxLists)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	wishlist, err := scanOne(query)
+	if err != nil {
+		return nil, err
+	}
+	if wishlist == nil {
+		return nil, errors.ErrWishlistLimit
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, mapError(err)
+	}
+	return wishlist, nil
+}
+
+// Rename a wishlist, making it the default list of its user when asked to. The default list stays
+// the default until another list is made the default
+func (m *WishlistRepository) Update(id string, request *entities.WishlistRequest) (*entities.Wishlist, error) {
+	tx, err := m.Db.Begin()
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	defer tx.Rollback()
+
+	if request.IsDefault {
+		_, err := tx.Exec(`UPDATE wishlists SET is_default = false, updated_at = NOW()
+			WHERE user_id = (SELECT user_id FROM wishlists WHERE id = $1) AND is_default AND id <> $1`, id)
+		if err != nil {
+			fmt.Print(err)
+			return nil, errors.ErrDatabase
+		}
+	}
+	query, err := tx.Query(`UPDATE wishlists SET name = $2, is_default = is_default OR $3, updated_at = NOW() WHERE id = $1
+		RETURNING `+wishlistColumns, id, request.Name, request.IsDefault)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	wishlist, err := scanOne(query)
+	if err != nil {
+		return nil, err
+	}
+	if wishlist == nil {
+		return nil, errors.ErrWishlistNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, mapError(err)
+	}
+	return wishlist, nil
+}
+
+// Set or clear the share token of a wishlist
+func (m *WishlistRepository) SetShareToken(id string, token *string) (*entities.Wishlist, error) {
+	query, err := m.Db.Query(`UPDATE wishlists SET share_token = $2, updated_at = NOW() WHERE id = $1 RETURNING `+wishlistColumns, id, token)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	wishlist, err := scanOne(query)
+	if err != nil {
+		return nil, err
+	}
+	if wishlist == nil {
+		return nil, errors.ErrWishlistNotFound
+	}
+	return wishlist, nil
+}
+
+// Delete a wishlist and its items, the default list is kept
+func (m *WishlistRepository) Delete(id string) error {
+	res, err := m.Db.Exec(`DELETE FROM wishlists WHERE id = $1 AND NOT is_default`, id)
+	if err != nil {
+		fmt.Print(err)
+		return errors.ErrDatabase
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return errors.ErrWishlistNotFound
+	}
+	return nil
+}
+
+// Get the products of a wishlist, latest first, with their current price and availability. Products
+// are available while they are not deleted and are in stock or not stock tracked
+func (m *WishlistRepository) GetItems(id string) ([]*entities.WishlistItem, error) {
+	query, err := m.Db.Query(`SELECT p.id, p.name, p.description, p.image, p.price, p.sku, p.rating_average, p.rating_count,
+			p.updated_at, p.created_at, p.deleted_at,
+			p.deleted_at IS NULL AND COALESCE(inv.quantity > 0, true), w.added_at
+		FROM wishlist_items w
+		JOIN products p ON p.id = w.product_id
+		LEFT JOIN inventory inv ON inv.product_id = p.id
+		WHERE w.wishlist_id = $1
+		ORDER BY w.added_at DESC, p.id`, id)
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	defer query.Close()
+
+	items := []*entities.WishlistItem{}
+	for query.Next() {
+		item := &entities.WishlistItem{Product: &entities.Product{}}
+		product := item.Product
+		var deletedAt sql.NullTime
+		err := query.Scan(&product.Id, &product.Name, &product.Description, &product.ImageURL, &product.Price, &product.Sku, &product.RatingAverage, &product.RatingCount,
+			&product.UpdatedAt, &product.CreatedAt, &deletedAt, &item.Available, &item.AddedAt)
+		if err != nil {
+			fmt.Print(err)
+			return nil, errors.ErrDatabase
+		}
+		if deletedAt.Valid {
+			product.DeletedAt = &deletedAt.Time
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// Save an active product to a wishlist unless the list reached the maximum number of products.
+// Saving a product that is already in the list does nothing
+func (m *WishlistRepository) AddItem(id string, productId string, maxItems int) error {
+	var found, saved, inserted bool
+	err := m.Db.QueryRow(`WITH product AS (SELECT id FROM products WHERE id = $2 AND deleted_at IS NULL),
+		saved AS (SELECT 1 FROM wishlist_items WHERE wishlist_id = $1 AND product_id = $2),
+		inserted AS (INSERT INTO wishlist_items (wishlist_id, product_id)
+			SELECT $1, id FROM product
+			WHERE NOT EXISTS (SELECT 1 FROM saved) AND (SELECT COUNT(*) FROM wishlist_items WHERE wishlist_id = $1) < $3
+			ON CONFLICT DO NOTHING RETURNING 1)
+		SELECT EXISTS (SELECT 1 FROM product), EXISTS (SELECT 1 FROM saved), EXISTS (SELECT 1 FROM inserted)`,
+		id, productId, maxItems).Scan(&found, &saved, &inserted)
+	if err != nil {
+		return mapError(err)
+	}
+	switch {
+	case !found:
+		return errors.ErrProductNotFound
+	case !saved && !inserted:
+		return errors.ErrWishlistLimit
+	}
+	return nil
+}
+
+// Remove a product from a wishlist, removing a product that is not in the list does nothing
+func (m *WishlistRepository) RemoveItem(id string, productId string) error {
+	if _, err := m.Db.Exec(`DELETE FROM wishlist_items WHERE wishlist_id = $1 AND product_id = $2`, id, productId); err != nil {
+		fmt.Print(err)
+		return errors.ErrDatabase
+	}
+	return nil
+}
+
+// Move a product from a wishlist to another, keeping the date it was saved. Nothing moves when the
+// target list reached the maximum number of products, a product already in the target list is merged
+func (m *WishlistRepository) MoveItem(id string, targetId string, productId string, maxItems int) error {
+	tx, err := m.Db.Begin()
+	if err != nil {
+		fmt.Print(err)
+		return errors.ErrDatabase
+	}
+	defer tx.Rollback()
+
+	// The final select sees the target list as it was before the statement
+	var moved, inserted, saved bool
+	err = tx.QueryRow(`WITH moved AS (DELETE FROM wishlist_items WHERE wishlist_id = $1 AND product_id = $3 RETURNING product_id, added_at),
+		inserted AS (INSERT INTO wishlist_items (wishlist_id, product_id, added_at)
+			SELECT $2, product_id, added_at FROM moved
+			WHERE NOT EXISTS (SELECT 1 FROM wishlist_items WHERE wishlist_id = $2 AND product_id = $3)
+			AND (SELECT COUNT(*) FROM wishlist_items WHERE wishlist_id = $2) < $4
+			RETURNING 1)
+		SELECT EXISTS (SELECT 1 FROM moved), EXISTS (SELECT 1 FROM inserted),
+			EXISTS (SELECT 1 FROM wishlist_items WHERE wishlist_id = $2 AND product_id = $3)`,
+		id, targetId, productId, maxItems).Scan(&moved, &inserted, &saved)
+	if err != nil {
+		return mapError(err)
+	}
+	switch {
+	case !moved:
+		return errors.ErrProductNotFound
+	case !inserted && !saved:
+		return errors.ErrWishlistLimit
+	}
+
+	if err := tx.Commit(); err != nil {
+		return mapError(err)
+	}
+	return nil
+}
+
+func (m *WishlistRepository) get(SQL string, args ...interface{}) (*entities.Wishlist, error) {
+	query, err := m.Db.Query(SQL, args...)
+	if err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	return scanOne(query)
+}
+
+// scanOne reads the single row of a query, nil when it returned no row
+func scanOne(query *sql.Rows) (*entities.Wishlist, error) {
+	defer query.Close()
+	if !query.Next() {
+		if err := query.Err(); err != nil {
+			return nil, mapError(err)
+		}
+		return nil, nil
+	}
+	return scanWishlist(query)
+}
+
+// scanWishlist reads a row of wishlistColumns
+func scanWishlist(query *sql.Rows) (*entities.Wishlist, error) {
+	wishlist := &entities.Wishlist{}
+	var shareToken sql.NullString
+	if err := query.Scan(&wishlist.Id, &wishlist.UserId, &wishlist.Name, &wishlist.IsDefault, &shareToken, &wishlist.ItemCount,
+		&wishlist.CreatedAt, &wishlist.UpdatedAt); err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrDatabase
+	}
+	if shareToken.Valid {
+		wishlist.ShareToken = &shareToken.String
+	}
+	return wishlist, nil
+}
+
+// mapError translates constraint violations into application errors
+func mapError(err error) error {
+	fmt.Print(err)
+	if pqErr, ok := err.(*pq.Error); ok {
+		switch pqErr.Code {
+		case "23505": // unique_violation, the user has a list with the name
+			return errors.ErrWishlistExists
+		case "23503": // foreign_key_violation
+			return errors.ErrProductNotFound
+		}
+	}
+	return errors.ErrDatabase
+}
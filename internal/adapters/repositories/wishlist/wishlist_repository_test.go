@@ -0,0 +1,179 @@
+package repositories_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	repositories "github.com/shayja/go-template-api/internal/adapters/repositories/wishlist"
+	"github.com/shayja/go-template-api/internal/entities"
+	appErrors "github.com/shayja/go-template-api/internal/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+var wishlistColumns = []string{"id", "user_id", "name", "is_default", "share_token", "count", "created_at", "updated_at"}
+
+func TestGetDefault_CreatedOnFirstUse(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.WishlistRepository{Db: db}
+
+	mock.ExpectExec("INSERT INTO wishlists \\(user_id, name, is_default\\) SELECT \\$1, \\$2, true WHERE NOT EXISTS \\(SELECT 1 FROM wishlists WHERE user_id = \\$1 AND is_default\\) ON CONFLICT DO NOTHING").
+		WithArgs("u1", entities.DefaultWishlistName).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT (.+) FROM wishlists WHERE user_id = \\$1 AND is_default").
+		WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows(wishlistColumns).AddRow("w1", "u1", "Wishlist", true, nil, 0, time.Now(), time.Now()))
+
+	wishlist, err := repo.GetDefault("u1", entities.DefaultWishlistName)
+	assert.NoError(t, err)
+	assert.Equal(t, "w1", wishlist.Id)
+	assert.True(t, wishlist.IsDefault)
+	assert.Nil(t, wishlist.ShareToken)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreate_ReplacesDefault(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.WishlistRepository{Db: db}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE wishlists SET is_default = false, updated_at = NOW\\(\\) WHERE user_id = \\$1 AND is_default").
+		WithArgs("u1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("INSERT INTO wishlists (.+) WHERE \\(SELECT COUNT\\(\\*\\) FROM wishlists WHERE user_id = \\$1\\) < \\$4 RETURNING").
+		WithArgs("u1", "Birthday", true, 20).
+		WillReturnRows(sqlmock.NewRows(wishlistColumns).AddRow("w2", "u1", "Birthday", true, nil, 0, time.Now(), time.Now()))
+	mock.ExpectCommit()
+
+	wishlist, err := repo.Create("u1", &entities.WishlistRequest{Name: "Birthday", IsDefault: true}, 20)
+	assert.NoError(t, err)
+	assert.Equal(t, "w2", wishlist.Id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreate_Limit(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.WishlistRepository{Db: db}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO wishlists").
+		WithArgs("u1", "Birthday", false, 20).
+		WillReturnRows(sqlmock.NewRows(wishlistColumns))
+	mock.ExpectRollback()
+
+	_, err = repo.Create("u1", &entities.WishlistRequest{Name: "Birthday"}, 20)
+	assert.ErrorIs(t, err, appErrors.ErrWishlistLimit)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreate_NameTaken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.WishlistRepository{Db: db}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO wishlists").
+		WithArgs("u1", "Birthday", false, 20).
+		WillReturnError(&pq.Error{Code: "23505"})
+	mock.ExpectRollback()
+
+	_, err = repo.Create("u1", &entities.WishlistRequest{Name: "Birthday"}, 20)
+	assert.ErrorIs(t, err, appErrors.ErrWishlistExists)
+}
+
+func TestGetItems(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.WishlistRepository{Db: db}
+
+	columns := []string{"id", "name", "description", "image", "price", "sku", "rating_average", "rating_count", "updated_at", "created_at", "deleted_at", "available", "added_at"}
+	mock.ExpectQuery("SELECT (.+) FROM wishlist_items w JOIN products p ON p.id = w.product_id LEFT JOIN inventory inv ON inv.product_id = p.id WHERE w.wishlist_id = \\$1 ORDER BY w.added_at DESC, p.id").
+		WithArgs("w1").
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("p2", "Phone Case", "", "", "9.90", "CASE-1", 0, 0, time.Now(), time.Now(), nil, true, time.Now()).
+			AddRow("p1", "Nokia 3310", "", "", "49.90", "NOKIA-1", 4.5, 2, time.Now(), time.Now(), time.Now(), false, time.Now()))
+
+	items, err := repo.GetItems("w1")
+	assert.NoError(t, err)
+	assert.Len(t, items, 2)
+	assert.True(t, items[0].Available)
+	assert.Equal(t, "9.90", items[0].Price.String())
+	assert.False(t, items[1].Available)
+	assert.NotNil(t, items[1].DeletedAt)
+}
+
+func TestAddItem(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.WishlistRepository{Db: db}
+	flags := []string{"found", "saved", "inserted"}
+
+	mock.ExpectQuery("WITH product AS \\(SELECT id FROM products WHERE id = \\$2 AND deleted_at IS NULL\\)").
+		WithArgs("w1", "p1", 500).
+		WillReturnRows(sqlmock.NewRows(flags).AddRow(true, false, true))
+	assert.NoError(t, repo.AddItem("w1", "p1", 500))
+
+	mock.ExpectQuery("WITH product AS").
+		WithArgs("w1", "p1", 500).
+		WillReturnRows(sqlmock.NewRows(flags).AddRow(true, true, false))
+	assert.NoError(t, repo.AddItem("w1", "p1", 500))
+
+	mock.ExpectQuery("WITH product AS").
+		WithArgs("w1", "p1", 500).
+		WillReturnRows(sqlmock.NewRows(flags).AddRow(true, false, false))
+	assert.ErrorIs(t, repo.AddItem("w1", "p1", 500), appErrors.ErrWishlistLimit)
+
+	mock.ExpectQuery("WITH product AS").
+		WithArgs("w1", "p9", 500).
+		WillReturnRows(sqlmock.NewRows(flags).AddRow(false, false, false))
+	assert.ErrorIs(t, repo.AddItem("w1", "p9", 500), appErrors.ErrProductNotFound)
+}
+
+func TestMoveItem_TargetFull(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.WishlistRepository{Db: db}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("WITH moved AS \\(DELETE FROM wishlist_items WHERE wishlist_id = \\$1 AND product_id = \\$3 RETURNING product_id, added_at\\)").
+		WithArgs("w1", "w2", "p1", 500).
+		WillReturnRows(sqlmock.NewRows([]string{"moved", "inserted", "saved"}).AddRow(true, false, false))
+	mock.ExpectRollback()
+
+	err = repo.MoveItem("w1", "w2", "p1", 500)
+	assert.ErrorIs(t, err, appErrors.ErrWishlistLimit)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDelete_KeepsDefault(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &repositories.WishlistRepository{Db: db}
+
+	mock.ExpectExec("DELETE FROM wishlists WHERE id = \\$1 AND NOT is_default").
+		WithArgs("w1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repo.Delete("w1")
+	assert.ErrorIs(t, err, appErrors.ErrWishlistNotFound)
+}
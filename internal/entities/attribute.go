@@ -0,0 +1,60 @@
+// internal/entities/attribute.go
+package entities
+
+import (
+	"time"
+)
+
+// Attribute value types.
+const (
+	AttributeString  = "string"
+	AttributeNumber  = "number"
+	AttributeBoolean = "boolean"
+	AttributeEnum    = "enum"
+)
+
+// AttributeDefinition is a typed attribute products may have, e.g. brand or weight.
+type AttributeDefinition struct {
+	// The attribute key in the product attributes and listing filters
+	// example: weight
+	Code string `json:"code" example:"weight"`
+	// The display name
+	// example: Weight
+	Name string `json:"name" example:"Weight"`
+	// The value type (string, number, boolean, enum)
+	// example: number
+	Type string `json:"type" example:"number"`
+	// The unit of a number value
+	// example: g
+	Unit *string `json:"unit,omitempty" example:"g"`
+	// The allowed values of an enum
+	Values []string `json:"values,omitempty"`
+	// Whether every product must have a value
+	// example: false
+	Required bool `json:"required" example:"false"`
+	// The date and time the definition was last updated
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// AttributeDefinitionRequest represents a request to add or update an attribute definition.
+type AttributeDefinitionRequest struct {
+	// The display name
+	// example: Weight
+	Name string `json:"name" binding:"required" example:"Weight"`
+	// The value type (string, number, boolean, enum)
+	// example: number
+	Type string `json:"type" binding:"required,oneof=string number boolean enum" example:"number"`
+	// The unit of a number value
+	// example: g
+	Unit *string `json:"unit" example:"g"`
+	// The allowed values of an enum
+	Values []string `json:"values"`
+	// Whether every product must have a value
+	// example: false
+	Required bool `json:"required" example:"false"`
+}
+
+// AttributeCodeRequest represents the path parameter of an attribute definition.
+type AttributeCodeRequest struct {
+	Code string `uri:"code" binding:"required"`
+}
@@ -0,0 +1,76 @@
+// internal/entities/bundle.go
+package entities
+
+import (
+	"github.com/shayja/go-template-api/pkg/money"
+)
+
+// Bundle is a product sold as a set of other products, ordering it orders its components.
+type Bundle struct {
+	*Product
+	// The percentage taken off the price of the components, null when the bundle has a fixed price
+	// example: 15
+	DiscountPercent *money.Amount `json:"discount_percent" example:"15" swaggertype:"number"`
+	// The price of the components bought on their own
+	// example: 129.97
+	ComponentsPrice money.Amount `json:"components_price" example:"129.97" swaggertype:"number"`
+	// Whether every component is active and in stock
+	// example: true
+	Available bool `json:"available" example:"true"`
+	// The number of bundles the stock of the components makes up, null when no component is stock tracked
+	// example: 12
+	AvailableQuantity *int `json:"available_quantity" example:"12"`
+	// The products of the bundle
+	Components []*BundleComponent `json:"components"`
+}
+
+// BundleComponent is a product of a bundle.
+type BundleComponent struct {
+	*Product
+	// The units of the product in one bundle
+	// example: 2
+	Quantity int `json:"quantity" example:"2"`
+	// Whether the product is active and has stock for a bundle
+	// example: true
+	Available bool `json:"available" example:"true"`
+	// The stock level of the product, null when it is not stock tracked
+	Stock *int `json:"-"`
+}
+
+// SumComponents sets the components price of the bundle from the prices of its components
+func (b *Bundle) SumComponents() {
+	var sum money.Amount
+	for _, component := range b.Components {
+		sum = sum.Add(component.Price.Mul(int64(component.Quantity)))
+	}
+	b.ComponentsPrice = sum
+}
+
+// DiscountedPrice returns the price of the components less the discount, rounded half up to the cents
+// the product prices are stored in as refresh_bundle_prices does, nil for a bundle with its own price
+func (b *Bundle) DiscountedPrice() *money.Amount {
+	if b.DiscountPercent == nil {
+		return nil
+	}
+	price := b.ComponentsPrice.LessPercent(*b.DiscountPercent, money.Step(2), money.HalfUp)
+	return &price
+}
+
+// BundleRequest represents the components of a bundle and how it is priced.
+type BundleRequest struct {
+	// The products of the bundle in the order they are shown
+	Components []BundleComponentRequest `json:"components" binding:"required,min=1,max=50,dive"`
+	// The percentage taken off the price of the components, the bundle keeps its own price when omitted
+	// example: 15
+	DiscountPercent *money.Amount `json:"discount_percent" example:"15" swaggertype:"number"`
+}
+
+// BundleComponentRequest represents a product of a bundle.
+type BundleComponentRequest struct {
+	// The UUID of the product
+	// example: 063d0ff7-e17e-4957-8d92-a988caeda8a1
+	ProductId string `json:"product_id" binding:"required,uuid" example:"063d0ff7-e17e-4957-8d92-a988caeda8a1"`
+	// The units of the product in one bundle
+	// example: 1
+	Quantity int `json:"quantity" binding:"required,gt=0" example:"1"`
+}
@@ -0,0 +1,67 @@
+// internal/entities/category.go
+package entities
+
+import (
+	"time"
+)
+
+// Category represents a node of the product category tree.
+type Category struct {
+	// The UUID of the category
+	// example: 9b2f6a3e-1c4d-4e8f-a0b1-2c3d4e5f6a7b
+	Id string `json:"id" example:"9b2f6a3e-1c4d-4e8f-a0b1-2c3d4e5f6a7b" minLength:"36"`
+	// The UUID of the parent category, null for root categories
+	// example: 451fa817-41f4-40cf-8dc2-c9f22aa98a4f
+	ParentId *string `json:"parent_id"`
+	// The display name of the category
+	// example: Smartphones
+	Name string `json:"name" example:"Smartphones"`
+	// The locale of the name, set when the content was negotiated
+	// example: fr
+	Locale string `json:"locale,omitempty" example:"fr"`
+	// The unique URL identifier of the category
+	// example: smartphones
+	Slug string `json:"slug" example:"smartphones"`
+	// The sort position among the sibling categories
+	// example: 1
+	Position int `json:"position" example:"1" format:"int32"`
+	// The child categories, set when the tree is requested
+	Children []*Category `json:"children,omitempty"`
+	// The date and time the category was created
+	CreatedAt time.Time `json:"created_at"`
+	// The date and time the category was last updated
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CategoryRequest represents a request to create or update a category.
+type CategoryRequest struct {
+	// The UUID of the parent category, omit for a root category
+	ParentId *string `json:"parent_id" binding:"omitempty,uuid"`
+	// The display name of the category
+	// example: Smartphones
+	Name string `json:"name" binding:"required"`
+	// The unique URL identifier, generated from the name when empty
+	// example: smartphones
+	Slug string `json:"slug"`
+	// The sort position among the sibling categories
+	// example: 1
+	Position int `json:"position"`
+}
+
+// CategoryCrumb is a single step of a category breadcrumb.
+type CategoryCrumb struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// ProductCategoriesRequest represents a request to assign a product to categories.
+type ProductCategoriesRequest struct {
+	// The UUIDs of the categories, replacing the current assignment
+	CategoryIds []string `json:"category_ids" binding:"dive,uuid"`
+}
+
+// SlugRequest represents a request to get any entity by its slug.
+type SlugRequest struct {
+	Slug string `uri:"slug" binding:"required"`
+}
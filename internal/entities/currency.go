@@ -0,0 +1,67 @@
+// internal/entities/currency.go
+package entities
+
+import (
+	"time"
+
+	"github.com/shayja/go-template-api/pkg/currency"
+	"github.com/shayja/go-template-api/pkg/money"
+)
+
+// Currency is a currency prices can be shown in, with its exchange rate from the base currency.
+type Currency struct {
+	// The ISO 4217 code
+	// example: EUR
+	Code string `json:"code" example:"EUR" minLength:"3" maxLength:"3"`
+	// The units of the currency worth one unit of the base currency
+	// example: 0.92
+	Rate float64 `json:"rate" example:"0.92"`
+	// The number of decimals converted amounts are rounded to
+	// example: 2
+	Decimals int `json:"decimals" example:"2" format:"int32"`
+	// How converted amounts are rounded (half_up, half_even, up, down)
+	// example: half_up
+	Rounding string `json:"rounding" example:"half_up"`
+	// Rounds converted amounts to a multiple of the increment instead, e.g. 0.05
+	RoundingIncrement *money.Amount `json:"rounding_increment,omitempty" example:"0.05" swaggertype:"number"`
+	// Whether this is the store base currency, which all prices are stored in
+	Base bool `json:"base"`
+	// The date and time the rate was last updated
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Rule returns the rounding rule of the currency.
+func (c *Currency) Rule() currency.Rule {
+	rule := currency.Rule{Decimals: c.Decimals, Mode: c.Rounding}
+	if c.RoundingIncrement != nil {
+		rule.Increment = *c.RoundingIncrement
+	}
+	return rule
+}
+
+// CurrencyRequest represents a request to add or update a currency.
+type CurrencyRequest struct {
+	// The units of the currency worth one unit of the base currency
+	// example: 0.92
+	Rate float64 `json:"rate" binding:"required,gt=0" example:"0.92"`
+	// The number of decimals converted amounts are rounded to, defaults to 2
+	// example: 2
+	Decimals *int `json:"decimals" binding:"omitempty,min=0,max=4" example:"2"`
+	// How converted amounts are rounded (half_up, half_even, up, down), defaults to half_up
+	// example: half_up
+	Rounding string `json:"rounding" binding:"omitempty,oneof=half_up half_even up down" example:"half_up"`
+	// Rounds converted amounts to a multiple of the increment instead
+	RoundingIncrement *money.Amount `json:"rounding_increment" binding:"omitempty,gt=0" example:"0.05" swaggertype:"number"`
+}
+
+// CurrencyCodeRequest represents a request addressing a currency.
+type CurrencyCodeRequest struct {
+	Code string `uri:"code" binding:"required"`
+}
+
+// ProductCurrencyPricesRequest represents a request to set the explicit prices of a product in other currencies.
+type ProductCurrencyPricesRequest struct {
+	// The price by currency code, replacing the current explicit prices
+	// example: {"EUR": 89.9, "GBP": 79}
+	Prices map[string]money.Amount `json:"prices" binding:"required,dive,gte=0" swaggertype:"object,number"`
+}
@@ -0,0 +1,75 @@
+// internal/entities/inventory.go
+package entities
+
+import (
+	"time"
+)
+
+// Stock movement kinds recorded in the inventory ledger.
+const (
+	MovementAdjustment   = "adjustment"
+	MovementOrder        = "order"
+	MovementCancellation = "cancellation"
+)
+
+// StockLevel is the stock of a stock tracked product.
+type StockLevel struct {
+	// The UUID of the product
+	// example: 48dd8c7a-9ac1-4263-88e4-bb01b5e29001
+	ProductId string `json:"product_id" example:"48dd8c7a-9ac1-4263-88e4-bb01b5e29001" minLength:"36"`
+	// The quantity available to order
+	// example: 12
+	Quantity int `json:"quantity" example:"12" format:"int32"`
+	// The quantity at or below which the product is low on stock
+	// example: 5
+	LowStockThreshold int `json:"low_stock_threshold" example:"5" format:"int32"`
+	// Whether the quantity is at or below the low stock threshold
+	// example: false
+	LowStock bool `json:"low_stock" example:"false"`
+	// The date and time the stock last changed
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// StockMovement is an inventory ledger entry explaining a stock change.
+type StockMovement struct {
+	// The UUID of the movement
+	// example: 0b7e4a52-8f55-4d8a-9d0c-5e2b8f6c1d22
+	Id string `json:"id" example:"0b7e4a52-8f55-4d8a-9d0c-5e2b8f6c1d22" minLength:"36"`
+	// The UUID of the product
+	// example: 48dd8c7a-9ac1-4263-88e4-bb01b5e29001
+	ProductId string `json:"product_id" example:"48dd8c7a-9ac1-4263-88e4-bb01b5e29001" minLength:"36"`
+	// The signed quantity change
+	// example: -2
+	Delta int `json:"delta" example:"-2" format:"int32"`
+	// The quantity after the change
+	// example: 10
+	QuantityAfter int `json:"quantity_after" example:"10" format:"int32"`
+	// The cause of the change (adjustment, order, cancellation)
+	// example: order
+	Kind string `json:"kind" example:"order"`
+	// The reason given for an adjustment
+	// example: Stock count correction
+	Reason *string `json:"reason,omitempty" example:"Stock count correction"`
+	// The order that reserved or released the stock
+	OrderId *string `json:"order_id,omitempty"`
+	// The user that made the change
+	UserId *string `json:"user_id,omitempty"`
+	// The date and time of the change
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// StockAdjustmentRequest represents an admin stock adjustment.
+type StockAdjustmentRequest struct {
+	// The signed quantity to add or remove
+	// example: 20
+	Delta int `json:"delta" binding:"required" example:"20"`
+	// Why the stock is adjusted
+	// example: Received shipment
+	Reason string `json:"reason" binding:"required" example:"Received shipment"`
+}
+
+// StockThresholdRequest represents a request to set the low stock threshold of a product.
+type StockThresholdRequest struct {
+	// example: 5
+	LowStockThreshold int `json:"low_stock_threshold" binding:"gte=0" example:"5"`
+}
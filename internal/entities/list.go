@@ -0,0 +1,29 @@
+// internal/entities/list.go
+package entities
+
+// ListRequest represents the paging and sorting parameters of any collection listing.
+type ListRequest struct {
+	// Opaque cursor returned as next_cursor or prev_cursor by a previous listing
+	Cursor string `form:"cursor" json:"cursor"`
+	// Maximum number of items to return (capped by the server)
+	// example: 20
+	Limit int `form:"limit" json:"limit" binding:"omitempty,gte=0"`
+	// Sort field, prefixed with "-" for descending order
+	// example: -created_at
+	Sort string `form:"sort" json:"sort"`
+	// Include the total number of matching items
+	// example: false
+	IncludeTotal bool `form:"include_total" json:"include_total"`
+}
+
+// List is the envelope shared by all collection responses.
+type List[T any] struct {
+	// The items of the current page
+	Items []T `json:"items"`
+	// Cursor of the following page, empty on the last page
+	NextCursor string `json:"next_cursor"`
+	// Cursor of the preceding page, empty on the first page
+	PrevCursor string `json:"prev_cursor"`
+	// Total number of matching items, set when include_total is requested
+	Total *int64 `json:"total,omitempty"`
+}
@@ -4,6 +4,8 @@ import (
 	"database/sql/driver"
 	"fmt"
 	"time"
+
+	"github.com/shayja/go-template-api/pkg/money"
 )
 
 // Order represents an order entity.
@@ -18,7 +20,18 @@ type Order struct {
 	// The total price of the order
 	// example: 100.00
 	// required: true
-	TotalPrice float64  `json:"total_price" example:"100.00" format:"float64"`
+	TotalPrice money.Amount  `json:"total_price" example:"100.00" swaggertype:"number"`
+	// The amount the promotions took off the order, included in the total price
+	// example: 15.00
+	DiscountTotal money.Amount `json:"discount_total" example:"15.00" swaggertype:"number"`
+	// Whether a promotion made the order ship for free
+	// example: false
+	FreeShipping bool `json:"free_shipping" example:"false"`
+	// The promotions applied to the order, set when a single order is fetched
+	Discounts []*OrderDiscount `json:"discounts,omitempty"`
+	// The currency of the total price
+	// example: USD
+	Currency string `json:"currency,omitempty" example:"USD"`
 	// The status of the order (1=created/pending, 2=processing, 3=completed, 4=cancelled)
 	// example: 2
 	// required: true
@@ -44,18 +57,24 @@ type OrderDetail struct {
 	// example: 063d0ff7-e17e-4957-8d92-a988caeda8a1
 	// required: true
 	ProductId  string  `json:"product_id" example:"063d0ff7-e17e-4957-8d92-a988caeda8a1" minLength:"36"`
+	// The UUID of the ordered product variant, if the product has variants
+	// example: 3f1b8c1e-6a8e-4c55-9d7b-2b9f8e0c1a11
+	VariantId  *string `json:"variant_id,omitempty" example:"3f1b8c1e-6a8e-4c55-9d7b-2b9f8e0c1a11" minLength:"36"`
 	// The quantity of the product
 	// example: 2
 	// required: true
 	Quantity   int `json:"quantity" example:"1" format:"int32" minimum:"1"`
-	// The unit price of the product
+	// The unit price of the product, the effective price of the variant for a variant line item.
+	// Set from the catalog when the order is priced, a unit price sent by the client is ignored
 	// example: 50.00
-	// required: true
-	UnitPrice  float64 `json:"unit_price" example:"50.00" format:"float64"`
+	UnitPrice  money.Amount `json:"unit_price" example:"50.00" swaggertype:"number"`
 	// The date and time the order detail was created
 	// example: 2024-07-01T12:00:00Z
 	// required: true
-	TotalPrice float64 `json:"total_price" example:"55.00" format:"float64"`
+	TotalPrice money.Amount `json:"total_price" example:"55.00" swaggertype:"number"`
+	// The amount the promotions took off the line item
+	// example: 5.00
+	Discount money.Amount `json:"discount" example:"5.00" swaggertype:"number"`
 	// The date and time the order detail was created
 	// example: 2025-01-01T12:00:00Z
 	CreatedAt  time.Time `json:"created_at"`
@@ -66,14 +85,33 @@ type OrderDetail struct {
 
 // OrderRequest represents a request to create an order.
 type OrderRequest struct {
-	// The user that creates the order
+	// The user that creates the order, the user of the token when empty
 	// example: 451fa817-41f4-40cf-8dc2-c9f22aa98a4f
-	// required: true
 	UserId string `json:"user_id" example:"063d0ff7-e17e-4957-8d92-a988caeda8a1" minLength:"36"`
-	// The total price of the order
+	// The total price of the order. A total sent by the client must match the sum of the line items,
+	// the priced order holds the total after the discounts
 	// example: 100.00
 	// required: true
-	TotalPrice   float64 `json:"total_price" example:"100.00" format:"float64"`
+	TotalPrice   money.Amount `json:"total_price" example:"100.00" swaggertype:"number"`
+	// A coupon code to apply to the order
+	// example: SUMMER15
+	CouponCode string `json:"coupon_code,omitempty" binding:"omitempty,max=50" example:"SUMMER15"`
+	// The sum of the line items, set when the order is priced
+	// example: 100.00
+	Subtotal money.Amount `json:"subtotal" example:"100.00" swaggertype:"number"`
+	// The amount the promotions took off the order, set when the order is priced
+	// example: 15.00
+	DiscountTotal money.Amount `json:"discount_total" example:"15.00" swaggertype:"number"`
+	// Whether a promotion made the order ship for free, set when the order is priced
+	// example: false
+	FreeShipping bool `json:"free_shipping" example:"false"`
+	// The promotions applied to the order, set when the order is priced
+	Discounts []*OrderDiscount `json:"discounts,omitempty"`
+	// The currency of the prices of a quote, set when the quote is converted
+	// example: USD
+	Currency string `json:"currency,omitempty" example:"USD"`
+	// The coupon the order is placed with
+	CouponId *string `json:"-"`
 	// The status of the order (1=created/pending, 2=processing, 3=completed, 4=cancelled)
 	// example: 1
 	// required: true
@@ -86,5 +124,14 @@ type OrderRequest struct {
 
 // Convert order details to database-compatible array
 func (v OrderDetail) Value() (driver.Value, error) {
-    return []byte(fmt.Sprintf("(%s,%d,%f)", v.ProductId, v.Quantity, v.UnitPrice)), nil
+    variantId := ""
+    if v.VariantId != nil {
+        variantId = *v.VariantId
+    }
+    return []byte(fmt.Sprintf("(%s,%d,%s,%s,%s)", v.ProductId, v.Quantity, v.UnitPrice, variantId, v.Discount)), nil
+}
+
+// Total returns the unit price times the quantity of the line item
+func (v OrderDetail) Total() money.Amount {
+    return v.UnitPrice.Mul(int64(v.Quantity))
 }
\ No newline at end of file
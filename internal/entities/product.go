@@ -2,7 +2,10 @@
 package entities
 
 import (
+	"io"
 	"time"
+
+	"github.com/shayja/go-template-api/pkg/money"
 )
 
 // Product model info
@@ -13,28 +16,104 @@ type Product struct {
 	Id 			string	`json:"id" validate:"required"`
 	Name        string	`json:"name" validate:"required"`
 	Description string	`json:"description" validate:"required"`
+	// The locale of the name and description, set when the content was negotiated
+	Locale      string	`json:"locale,omitempty"`
 	ImageURL    string	`json:"image" validate:"required"`
-	Price       float64	`json:"price" validate:"required"`
+	Price       money.Amount	`json:"price" validate:"required" swaggertype:"number"`
+	// The currency of the price
+	Currency    string	`json:"currency,omitempty"`
 	Sku         string	`json:"sku" validate:"required"`
 	CreatedAt 	time.Time `json:"created_at"`
 	UpdatedAt 	time.Time `json:"updated_at"`
+	// The date and time the product was soft deleted, null while it is active
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	// Category paths (root first) of every category the product is assigned to
+	Breadcrumbs [][]CategoryCrumb `json:"breadcrumbs,omitempty"`
+	// The option axes of the variants, set on product details
+	Options []ProductOption `json:"options,omitempty"`
+	// The purchasable variants, set on product details
+	Variants []*ProductVariant `json:"variants,omitempty"`
+	// Processing state of the primary gallery image: pending, ready or failed
+	ImageStatus string `json:"image_status,omitempty"`
+	// The resized versions of the product image
+	Renditions []ImageRendition `json:"renditions,omitempty"`
+	// The product images in display order
+	Gallery []*ProductImage `json:"gallery,omitempty"`
+	// The average rating of the approved reviews, 0 when there are none
+	// example: 4.25
+	RatingAverage float64 `json:"rating_average" example:"4.25"`
+	// The number of approved reviews
+	// example: 12
+	RatingCount int `json:"rating_count" example:"12" format:"int32"`
+	// The version of the product, sent as its ETag and checked against If-Match on writes
+	// example: 3
+	Version int `json:"version" example:"3" format:"int32"`
+	// The attribute values by attribute code
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+	// The free-form tags
+	Tags []string `json:"tags,omitempty"`
 }
 
 type ProductRequest struct {
 	Name        string	`json:"name" validate:"required"`
 	Description string	`json:"description" validate:"required"`
 	ImageURL    string	`json:"image" validate:"required"`
-	Price       float64	`json:"price" validate:"required"`
+	Price       money.Amount	`json:"price" validate:"required" swaggertype:"number"`
 	Sku         string	`json:"sku" validate:"required"`
+	// Optional, the attribute values by attribute code, checked against the attribute definitions.
+	// Omitted on update to keep the current values
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+	// Optional, the free-form tags, omitted on update to keep the current tags
+	Tags []string `json:"tags,omitempty"`
+}
+
+// Validate checks the product fields, returning a message per invalid field
+func (r *ProductRequest) Validate() []string {
+	return validateStruct(r)
 }
 
+// ProductLookupRequest represents the options of a single product lookup.
+type ProductLookupRequest struct {
+	// Return the product even if it is soft deleted (admin only)
+	IncludeDeleted bool `form:"include_deleted"`
+}
 
+// SkuRequest represents a request to get a product by its SKU.
+type SkuRequest struct {
+	Sku string `uri:"sku" binding:"required,max=255"`
+}
 
+// SkuLookupRequest represents the SKUs of a batch product lookup.
+type SkuLookupRequest struct {
+	// The SKUs to look up, regardless of case
+	// example: ["NOKIA-3310","CASE-1"]
+	Skus []string `json:"skus" binding:"required,min=1,max=100,dive,required,max=255" example:"NOKIA-3310,CASE-1"`
+}
+
+// SkuLookupResult is the outcome of a batch product lookup by SKU.
+type SkuLookupResult struct {
+	// The active products found, in the order of the requested SKUs
+	Items []*Product `json:"items"`
+	// The requested SKUs no active product uses
+	// example: ["CASE-1"]
+	Missing []string `json:"missing" example:"CASE-1"`
+}
 
 type ProductPriceRequest struct {
-	Price       float64	`json:"price" validate:"required"`
+	Price       money.Amount	`json:"price" validate:"required" swaggertype:"number"`
 }
 
 type ProductImageRequest struct {
 	ImageURL	string `json:"image" validate:"required"`
 }
+
+// ImageUpload is an uploaded image file.
+type ImageUpload struct {
+	// The client side file name, used for its extension only
+	Filename string
+	// The size of the file in bytes
+	Size int64
+	// The file content
+	Content io.Reader
+}
+
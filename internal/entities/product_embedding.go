@@ -0,0 +1,48 @@
+// internal/entities/product_embedding.go
+package entities
+
+import (
+	"github.com/shayja/go-template-api/pkg/money"
+)
+
+// EmbeddingJob is a product whose name and description need to be embedded.
+type EmbeddingJob struct {
+	ProductId string
+	// The embedded text, the product name and description
+	Text string
+	// The md5 of the text, stored with the vector to detect changes
+	SourceHash string
+}
+
+// SemanticSearchRequest represents the query of a semantic product search.
+type SemanticSearchRequest struct {
+	// The text to find products similar in meaning to
+	// example: something to listen to music on the train
+	Query string `form:"q" binding:"required"`
+	// The weight of the full-text rank in the score, from 0 (similarity only) to 1, defaults to the server setting
+	// example: 0.3
+	TextWeight *float64 `form:"text_weight" binding:"omitempty,gte=0,lte=1"`
+	// Minimum product price (inclusive)
+	// example: 10.00
+	MinPrice *money.Amount `form:"min_price" binding:"omitempty,gte=0" swaggertype:"number"`
+	// Maximum product price (inclusive)
+	// example: 200.00
+	MaxPrice *money.Amount `form:"max_price" binding:"omitempty,gte=0" swaggertype:"number"`
+	// Category slug, matching the category and all of its descendants
+	// example: audio
+	Category string `form:"category"`
+	// Maximum number of products to return (capped by the server)
+	// example: 20
+	Limit int `form:"limit" binding:"omitempty,gte=0"`
+}
+
+// ProductMatch is a product found by a semantic search.
+type ProductMatch struct {
+	*Product
+	// The cosine similarity of the product and the query, 0 when the product is not embedded yet
+	// example: 0.82
+	Similarity float64 `json:"similarity" example:"0.82"`
+	// The similarity blended with the full-text rank, the matches are ordered by it
+	// example: 0.74
+	Score float64 `json:"score" example:"0.74"`
+}
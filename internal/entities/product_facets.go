@@ -0,0 +1,51 @@
+// internal/entities/product_facets.go
+package entities
+
+import (
+	"github.com/shayja/go-template-api/pkg/money"
+)
+
+// ProductList is a page of products, with the facet counts of the whole listing when requested.
+type ProductList struct {
+	List[*Product]
+	// The facet counts over all the products matching the filter, set when include_facets is requested
+	Facets *ProductFacets `json:"facets,omitempty"`
+}
+
+// ProductFacets holds the number of matching products per filter value of a listing.
+type ProductFacets struct {
+	// The matching products per category, counting the products of the descendant categories
+	Categories []FacetCount `json:"categories"`
+	// The matching products per price range, in the base currency
+	Prices []PriceBucketCount `json:"prices"`
+	// The matching products per tag
+	Tags []FacetCount `json:"tags"`
+	// The matching products per attribute value, by attribute code
+	Attributes map[string][]FacetCount `json:"attributes"`
+}
+
+// FacetCount is the number of matching products with a filter value.
+type FacetCount struct {
+	// The filter value, a category slug, tag or attribute value
+	// example: acme
+	Value interface{} `json:"value" swaggertype:"string" example:"acme"`
+	// The display name of a category
+	// example: Smartphones
+	Label string `json:"label,omitempty" example:"Smartphones"`
+	// The number of matching products
+	// example: 12
+	Count int64 `json:"count" example:"12"`
+}
+
+// PriceBucketCount is the number of matching products in a price range.
+type PriceBucketCount struct {
+	// The lowest price of the range (inclusive), null for the first range
+	// example: 100.00
+	Min *money.Amount `json:"min" swaggertype:"number" example:"100.00"`
+	// The highest price of the range (exclusive), null for the last range
+	// example: 500.00
+	Max *money.Amount `json:"max" swaggertype:"number" example:"500.00"`
+	// The number of matching products
+	// example: 40
+	Count int64 `json:"count" example:"40"`
+}
@@ -0,0 +1,62 @@
+// internal/entities/product_filter.go
+package entities
+
+import (
+	"time"
+
+	"github.com/shayja/go-template-api/pkg/money"
+)
+
+// ProductFilter represents the search, filter and paging criteria of a product listing.
+type ProductFilter struct {
+	// Full-text search query over the product name, description and SKU
+	// example: galaxy ultra
+	Query string `form:"q" json:"q"`
+	// Minimum product price (inclusive)
+	// example: 100.00
+	MinPrice *money.Amount `form:"min_price" json:"min_price" binding:"omitempty,gte=0" swaggertype:"number"`
+	// Maximum product price (inclusive)
+	// example: 1200.00
+	MaxPrice *money.Amount `form:"max_price" json:"max_price" binding:"omitempty,gte=0" swaggertype:"number"`
+	// Case-insensitive SKU prefix
+	// example: iphone-
+	SkuPrefix string `form:"sku_prefix" json:"sku_prefix"`
+	// Category slug, matching the category and all of its descendants
+	// example: smartphones
+	Category string `form:"category" json:"category"`
+	// Products created on or after this time (RFC 3339)
+	CreatedFrom *time.Time `form:"created_from" json:"created_from"`
+	// Products created on or before this time (RFC 3339)
+	CreatedTo *time.Time `form:"created_to" json:"created_to"`
+	// Products updated on or after this time (RFC 3339)
+	UpdatedFrom *time.Time `form:"updated_from" json:"updated_from"`
+	// Products updated on or before this time (RFC 3339)
+	UpdatedTo *time.Time `form:"updated_to" json:"updated_to"`
+	// Products with all of these tags
+	// example: sale
+	Tags []string `form:"tag" json:"tag"`
+	// Attribute values by attribute code, set from the attr.<code> query parameters and parsed to
+	// the attribute type. A product matches when it has one of the values of every attribute
+	Attributes map[string][]interface{} `form:"-" json:"-"`
+	// Include the facet counts of the matching products
+	// example: false
+	IncludeFacets bool `form:"include_facets" json:"include_facets"`
+	// Include soft deleted products (admin only)
+	IncludeDeleted bool `form:"include_deleted" json:"include_deleted"`
+	// Paging and sorting of the listing
+	ListRequest
+}
+
+// Validate checks that the filter ranges are consistent.
+func (f *ProductFilter) Validate() bool {
+	if f.MinPrice != nil && f.MaxPrice != nil && *f.MinPrice > *f.MaxPrice {
+		return false
+	}
+	if f.CreatedFrom != nil && f.CreatedTo != nil && f.CreatedFrom.After(*f.CreatedTo) {
+		return false
+	}
+	if f.UpdatedFrom != nil && f.UpdatedTo != nil && f.UpdatedFrom.After(*f.UpdatedTo) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,77 @@
+// internal/entities/product_image.go
+package entities
+
+import (
+	"time"
+)
+
+// Processing states of an uploaded image
+const (
+	ImageStatusPending = "pending"
+	ImageStatusReady   = "ready"
+	ImageStatusFailed  = "failed"
+)
+
+// ProductImage is an image of the product gallery.
+// The primary image is also the product image.
+type ProductImage struct {
+	Id        string `json:"id"`
+	ProductId string `json:"product_id"`
+	// URL of the largest rendition, empty until the image is processed
+	URL string `json:"url"`
+	// Alternative text describing the image
+	AltText string `json:"alt_text"`
+	// Position in the gallery, starting at 0
+	SortOrder int `json:"sort_order"`
+	// Whether this is the product image
+	Primary bool `json:"primary"`
+	// Processing state: pending, ready or failed
+	Status string `json:"status"`
+	// The resized versions of the image
+	Renditions []ImageRendition `json:"renditions,omitempty"`
+	// Storage key of the uploaded source image while it is pending
+	Source string `json:"-"`
+	// Signed expiring link to the uploaded source image while it is pending (admin only)
+	SourceURL string    `json:"source_url,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ImageRendition is a resized version of a product image.
+type ImageRendition struct {
+	// Rendition name, e.g. thumbnail
+	Name string `json:"name"`
+	// Image format: jpeg, png or webp
+	Format string `json:"format"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	URL    string `json:"url"`
+}
+
+// ProductImageUpdate represents a request to update a gallery image.
+type ProductImageUpdate struct {
+	// Alternative text describing the image
+	AltText *string `json:"alt_text" form:"alt_text" binding:"omitempty,max=255" example:"Front view"`
+	// Make the image the product image
+	Primary *bool `json:"primary" form:"primary" example:"true"`
+}
+
+// ProductImageOrderRequest represents a request to reorder the gallery of a product.
+type ProductImageOrderRequest struct {
+	// All the gallery image ids, in the new order
+	ImageIds []string `json:"image_ids" binding:"required,min=1,dive,uuid"`
+}
+
+// ImageIdRequest represents a request addressing a gallery image of a product.
+type ImageIdRequest struct {
+	Id      string `uri:"id" binding:"required"`
+	ImageId string `uri:"image_id" binding:"required"`
+}
+
+// ImageJob is an uploaded source image waiting to be processed.
+type ImageJob struct {
+	ProductId string
+	ImageId   string
+	// Storage key of the source image
+	Source string
+}
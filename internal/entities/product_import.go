@@ -0,0 +1,68 @@
+// internal/entities/product_import.go
+package entities
+
+// Product import and export file formats
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+)
+
+// Product import modes
+const (
+	// Nothing is written unless every row is valid and saved
+	ImportTransactional = "transactional"
+	// The valid rows are written, the invalid or failed ones are skipped
+	ImportBestEffort = "best_effort"
+)
+
+// Outcomes of an imported row
+const (
+	ImportCreated = "created"
+	ImportUpdated = "updated"
+	ImportInvalid = "invalid"
+	ImportFailed  = "failed"
+)
+
+// ProductImportRequest represents the options of a product import.
+type ProductImportRequest struct {
+	// The file format, detected from the content type or the file name when omitted
+	Format string `form:"format" binding:"omitempty,oneof=csv jsonl"`
+	// Validate and report without saving anything
+	DryRun bool `form:"dry_run"`
+	// transactional (default) or best_effort
+	Mode string `form:"mode" binding:"omitempty,oneof=transactional best_effort"`
+}
+
+// ProductImportRow is the outcome of a single imported row.
+type ProductImportRow struct {
+	// The line of the row in the file
+	Row int    `json:"row"`
+	Sku string `json:"sku,omitempty"`
+	// created, updated, invalid or failed
+	Action string `json:"action"`
+	// The id of the created or updated product
+	Id string `json:"id,omitempty"`
+	// Why the row is invalid or failed
+	Errors []string `json:"errors,omitempty"`
+	// The parsed product, saved when the row is valid
+	Product *ProductRequest `json:"-"`
+}
+
+// ProductImportReport summarizes a product import row by row.
+type ProductImportReport struct {
+	Mode   string `json:"mode"`
+	DryRun bool   `json:"dry_run"`
+	// Whether the changes were saved, false for dry runs and rejected transactional imports
+	Committed bool                `json:"committed"`
+	Total     int                 `json:"total"`
+	Created   int                 `json:"created"`
+	Updated   int                 `json:"updated"`
+	Invalid   int                 `json:"invalid"`
+	Failed    int                 `json:"failed"`
+	Rows      []*ProductImportRow `json:"rows"`
+}
+
+// ProductExportRequest represents the options of a product export.
+type ProductExportRequest struct {
+	Format string `form:"format" binding:"omitempty,oneof=csv jsonl"`
+}
@@ -0,0 +1,105 @@
+// internal/entities/product_price.go
+package entities
+
+import (
+	"time"
+
+	"github.com/shayja/go-template-api/pkg/money"
+)
+
+// Causes of a price change recorded in the price history.
+const (
+	// The price the product had when the history started
+	PriceSourceInitial = "initial"
+	// A product or price update
+	PriceSourceManual = "manual"
+	// A price schedule started
+	PriceSourceScheduled = "scheduled"
+	// A price schedule ended, restoring the price it replaced
+	PriceSourceReverted = "reverted"
+	// A component of a discounted bundle changed price
+	PriceSourceBundle = "bundle"
+)
+
+// Price schedule statuses.
+const (
+	PriceScheduleScheduled = "scheduled"
+	PriceScheduleActive    = "active"
+	PriceScheduleCompleted = "completed"
+	PriceScheduleCancelled = "cancelled"
+)
+
+// PriceChange is a price history entry.
+type PriceChange struct {
+	// The UUID of the entry
+	Id string `json:"id" minLength:"36"`
+	// The UUID of the product
+	// example: 48dd8c7a-9ac1-4263-88e4-bb01b5e29001
+	ProductId string `json:"product_id" example:"48dd8c7a-9ac1-4263-88e4-bb01b5e29001" minLength:"36"`
+	// The new price
+	// example: 79.9
+	Price money.Amount `json:"price" example:"79.9" swaggertype:"number"`
+	// The price before the change, null for the first entry of a product
+	// example: 99.9
+	PreviousPrice *money.Amount `json:"previous_price" example:"99.9" swaggertype:"number"`
+	// The cause of the change (initial, manual, scheduled, reverted, bundle)
+	// example: scheduled
+	Source string `json:"source" example:"scheduled"`
+	// The schedule that made the change
+	ScheduleId *string `json:"schedule_id,omitempty"`
+	// The date and time of the change
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// PriceSchedule is a future price of a product.
+type PriceSchedule struct {
+	// The UUID of the schedule
+	Id string `json:"id" minLength:"36"`
+	// The UUID of the product
+	// example: 48dd8c7a-9ac1-4263-88e4-bb01b5e29001
+	ProductId string `json:"product_id" example:"48dd8c7a-9ac1-4263-88e4-bb01b5e29001" minLength:"36"`
+	// The price applied during the schedule
+	// example: 79.9
+	Price money.Amount `json:"price" example:"79.9" swaggertype:"number"`
+	// When the price is applied
+	StartsAt time.Time `json:"starts_at"`
+	// When the replaced price is restored, null for a permanent change
+	EndsAt *time.Time `json:"ends_at"`
+	// scheduled, active, completed or cancelled
+	// example: scheduled
+	Status string `json:"status" example:"scheduled"`
+	// The price the schedule replaced, set once it starts
+	// example: 99.9
+	RevertPrice *money.Amount `json:"revert_price,omitempty" example:"99.9" swaggertype:"number"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// PriceScheduleRequest represents a request to schedule a price change.
+type PriceScheduleRequest struct {
+	// example: 79.9
+	Price money.Amount `json:"price" binding:"required,gt=0" example:"79.9" swaggertype:"number"`
+	// When to apply the price, must be in the future
+	StartsAt time.Time `json:"starts_at" binding:"required"`
+	// When to restore the replaced price, omit for a permanent change
+	EndsAt *time.Time `json:"ends_at"`
+}
+
+// PriceScheduleIdRequest represents a request addressing a price schedule of a product.
+type PriceScheduleIdRequest struct {
+	Id         string `uri:"id" binding:"required"`
+	ScheduleId string `uri:"schedule_id" binding:"required"`
+}
+
+// ProductPrices is the current price of a product with its history and schedules.
+type ProductPrices struct {
+	// The UUID of the product
+	ProductId string `json:"product_id"`
+	// The current price
+	// example: 99.9
+	Price money.Amount `json:"price" example:"99.9" swaggertype:"number"`
+	// The price changes, newest first by default
+	History *List[*PriceChange] `json:"history"`
+	// The scheduled and active price schedules, set for admins
+	Schedules []*PriceSchedule `json:"schedules,omitempty"`
+}
@@ -0,0 +1,84 @@
+// internal/entities/product_variant.go
+package entities
+
+import (
+	"time"
+
+	"github.com/shayja/go-template-api/pkg/money"
+)
+
+// ProductOption is an option axis of a product, such as size or color.
+type ProductOption struct {
+	// The option name
+	// example: size
+	Name string `json:"name" binding:"required" example:"size"`
+	// The allowed option values
+	// example: ["S","M","L"]
+	Values []string `json:"values" binding:"required,min=1,dive,required"`
+	// The display position of the option
+	// example: 1
+	Position int `json:"position" example:"1" format:"int32"`
+}
+
+// ProductVariant is a purchasable variation of a product with its own SKU.
+type ProductVariant struct {
+	// The UUID of the variant
+	// example: 3f1b8c1e-6a8e-4c55-9d7b-2b9f8e0c1a11
+	Id string `json:"id" example:"3f1b8c1e-6a8e-4c55-9d7b-2b9f8e0c1a11" minLength:"36"`
+	// The UUID of the parent product
+	// example: 48dd8c7a-9ac1-4263-88e4-bb01b5e29001
+	ProductId string `json:"product_id" example:"48dd8c7a-9ac1-4263-88e4-bb01b5e29001" minLength:"36"`
+	// The stock keeping unit of the variant
+	// example: tshirt-red-m
+	Sku string `json:"sku" example:"tshirt-red-m"`
+	// The price override, null when the variant sells at the product price
+	// example: 24.99
+	Price *money.Amount `json:"price" example:"24.99" swaggertype:"number"`
+	// The price the variant sells at
+	// example: 24.99
+	EffectivePrice money.Amount `json:"effective_price" example:"24.99" swaggertype:"number"`
+	// The variant image, null when the product image applies
+	ImageURL *string `json:"image"`
+	// The selected value of every option axis
+	// example: {"size":"M","color":"red"}
+	Options map[string]string `json:"options"`
+	// Whether the variant can be ordered
+	// example: true
+	Available bool `json:"available" example:"true"`
+	// The display position of the variant
+	Position int `json:"position" format:"int32"`
+	// The date and time the variant was created
+	CreatedAt time.Time `json:"created_at"`
+	// The date and time the variant was last updated
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ProductVariantRequest represents a request to create or update a variant.
+type ProductVariantRequest struct {
+	// The stock keeping unit of the variant
+	// example: tshirt-red-m
+	Sku string `json:"sku" binding:"required" example:"tshirt-red-m"`
+	// The price override, omit to sell at the product price
+	// example: 24.99
+	Price *money.Amount `json:"price" binding:"omitempty,gt=0" example:"24.99" swaggertype:"number"`
+	// The variant image
+	ImageURL *string `json:"image"`
+	// The selected value of every option axis of the product
+	// example: {"size":"M","color":"red"}
+	Options map[string]string `json:"options" binding:"required"`
+	// Whether the variant can be ordered, defaults to true
+	Available *bool `json:"available"`
+	// The display position of the variant
+	Position int `json:"position"`
+}
+
+// ProductOptionsRequest represents a request to replace the option axes of a product.
+type ProductOptionsRequest struct {
+	Options []ProductOption `json:"options" binding:"dive"`
+}
+
+// VariantIdRequest represents a request addressing a variant of a product.
+type VariantIdRequest struct {
+	Id        string `uri:"id" binding:"required"`
+	VariantId string `uri:"variant_id" binding:"required"`
+}
@@ -0,0 +1,161 @@
+// internal/entities/promotion.go
+package entities
+
+import (
+	"time"
+
+	"github.com/shayja/go-template-api/pkg/money"
+)
+
+// Promotion kinds
+const (
+	// A percentage off the qualifying line items
+	PromotionPercentage = "percentage"
+	// A fixed amount off the qualifying line items, spread over them by their value
+	PromotionFixed = "fixed"
+	// The order ships for free
+	PromotionFreeShipping = "free_shipping"
+	// For every buy_quantity qualifying units, get_quantity more units are free, the cheapest first
+	PromotionBuyXGetY = "buy_x_get_y"
+)
+
+// Promotion is a discount rule. Automatic promotions apply to every qualifying order while they run,
+// the others only to the orders presenting one of their coupon codes.
+type Promotion struct {
+	// The UUID of the promotion
+	// example: 2c4d8e1a-7b3f-4a6e-9d2c-5f8a1b3e7c90
+	Id string `json:"id" example:"2c4d8e1a-7b3f-4a6e-9d2c-5f8a1b3e7c90" minLength:"36"`
+	// The name shown with the discount on the order
+	// example: Summer sale
+	Name string `json:"name" example:"Summer sale"`
+	// example: 15% off all shoes
+	Description string `json:"description" example:"15% off all shoes"`
+	// percentage, fixed, free_shipping or buy_x_get_y
+	// example: percentage
+	Kind string `json:"kind" example:"percentage"`
+	// The percentage off for percentage promotions, the amount off for fixed promotions
+	// example: 15
+	Value money.Amount `json:"value" example:"15" swaggertype:"number"`
+	// The units to buy for every free units of a buy_x_get_y promotion
+	// example: 2
+	BuyQuantity int `json:"buy_quantity,omitempty" example:"2" format:"int32"`
+	// The free units of a buy_x_get_y promotion
+	// example: 1
+	GetQuantity int `json:"get_quantity,omitempty" example:"1" format:"int32"`
+	// The least order subtotal the promotion applies to
+	// example: 50
+	MinOrder money.Amount `json:"min_order" example:"50" swaggertype:"number"`
+	// The products the promotion is limited to
+	ProductIds []string `json:"product_ids"`
+	// The categories the promotion is limited to, with their subcategories
+	CategoryIds []string `json:"category_ids"`
+	// Whether the promotion applies without a coupon code
+	// example: false
+	Automatic bool `json:"automatic" example:"false"`
+	// example: true
+	Active bool `json:"active" example:"true"`
+	// When the promotion starts, null when it runs from its creation
+	StartsAt *time.Time `json:"starts_at"`
+	// When the promotion ends, null when it runs until deactivated
+	EndsAt *time.Time `json:"ends_at"`
+	// The coupon codes of the promotion, set when a single promotion is fetched
+	Coupons   []*Coupon `json:"coupons,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// PromotionRequest represents a request to create or update a promotion.
+type PromotionRequest struct {
+	// example: Summer sale
+	Name string `json:"name" binding:"required,max=100" example:"Summer sale"`
+	// example: 15% off all shoes
+	Description string `json:"description" example:"15% off all shoes"`
+	// percentage, fixed, free_shipping or buy_x_get_y
+	// example: percentage
+	Kind string `json:"kind" binding:"required,oneof=percentage fixed free_shipping buy_x_get_y" example:"percentage"`
+	// The percentage off, up to 100, or the amount off
+	// example: 15
+	Value money.Amount `json:"value" example:"15" swaggertype:"number"`
+	// example: 2
+	BuyQuantity int `json:"buy_quantity" example:"2" format:"int32"`
+	// example: 1
+	GetQuantity int `json:"get_quantity" example:"1" format:"int32"`
+	// example: 50
+	MinOrder money.Amount `json:"min_order" example:"50" swaggertype:"number"`
+	// Limit the promotion to these products
+	ProductIds []string `json:"product_ids" binding:"omitempty,dive,uuid"`
+	// Limit the promotion to these categories and their subcategories
+	CategoryIds []string `json:"category_ids" binding:"omitempty,dive,uuid"`
+	// Apply the promotion without a coupon code
+	// example: false
+	Automatic bool `json:"automatic" example:"false"`
+	// Omit to activate the promotion
+	// example: true
+	Active *bool `json:"active" example:"true"`
+	StartsAt *time.Time `json:"starts_at"`
+	EndsAt   *time.Time `json:"ends_at"`
+}
+
+// Coupon is a code that applies its promotion to an order.
+type Coupon struct {
+	// The UUID of the coupon
+	// example: 7e1f3a2b-9c4d-4e8f-a1b2-c3d4e5f6a7b8
+	Id string `json:"id" example:"7e1f3a2b-9c4d-4e8f-a1b2-c3d4e5f6a7b8" minLength:"36"`
+	// example: 2c4d8e1a-7b3f-4a6e-9d2c-5f8a1b3e7c90
+	PromotionId string `json:"promotion_id" example:"2c4d8e1a-7b3f-4a6e-9d2c-5f8a1b3e7c90" minLength:"36"`
+	// The code, matched case insensitively
+	// example: SUMMER15
+	Code string `json:"code" example:"SUMMER15"`
+	// The most orders the code can be used on, null when unlimited
+	// example: 100
+	UsageLimit *int `json:"usage_limit" example:"100" format:"int32"`
+	// The most orders of a single user the code can be used on, null when unlimited
+	// example: 1
+	PerUserLimit *int `json:"per_user_limit" example:"1" format:"int32"`
+	// The orders the code was used on, cancelled orders excluded
+	// example: 12
+	Used int `json:"used" example:"12" format:"int32"`
+	// example: true
+	Active    bool      `json:"active" example:"true"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CouponRequest represents a request to add a coupon code to a promotion.
+type CouponRequest struct {
+	// example: SUMMER15
+	Code string `json:"code" binding:"required,max=50" example:"SUMMER15"`
+	// Omit for unlimited use
+	// example: 100
+	UsageLimit *int `json:"usage_limit" binding:"omitempty,gt=0" example:"100" format:"int32"`
+	// Omit for unlimited use per user
+	// example: 1
+	PerUserLimit *int `json:"per_user_limit" binding:"omitempty,gt=0" example:"1" format:"int32"`
+}
+
+// CouponIdRequest represents a request addressing a coupon of a promotion.
+type CouponIdRequest struct {
+	Id       string `uri:"id" binding:"required,uuid"`
+	CouponId string `uri:"coupon_id" binding:"required,uuid"`
+}
+
+// OrderDiscount is a promotion applied to an order.
+type OrderDiscount struct {
+	// The UUID of the promotion, null once the promotion is deleted
+	// example: 2c4d8e1a-7b3f-4a6e-9d2c-5f8a1b3e7c90
+	PromotionId *string `json:"promotion_id" example:"2c4d8e1a-7b3f-4a6e-9d2c-5f8a1b3e7c90"`
+	// The coupon code the promotion was applied with
+	// example: SUMMER15
+	CouponCode string `json:"coupon_code,omitempty" example:"SUMMER15"`
+	// example: Summer sale
+	Name string `json:"name" example:"Summer sale"`
+	// example: percentage
+	Kind string `json:"kind" example:"percentage"`
+	// The amount taken off the order, 0 for free shipping
+	// example: 15
+	Amount money.Amount `json:"amount" example:"15" swaggertype:"number"`
+}
+
+// RunsAt reports whether an active promotion is within its validity window at the given time
+func (p *Promotion) RunsAt(t time.Time) bool {
+	return p.Active && (p.StartsAt == nil || !t.Before(*p.StartsAt)) && (p.EndsAt == nil || t.Before(*p.EndsAt))
+}
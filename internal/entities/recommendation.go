@@ -0,0 +1,36 @@
+// internal/entities/recommendation.go
+package entities
+
+// Recommendation is a product recommended next to a product or a cart.
+type Recommendation struct {
+	*Product
+	// The number of orders the product was bought in together with the viewed or cart products
+	// example: 8
+	CoPurchases int64 `json:"co_purchases" example:"8"`
+	// The blend of the co-purchases and the shared categories and tags, the recommendations are ordered by it
+	// example: 0.72
+	Score float64 `json:"score" example:"0.72"`
+}
+
+// RecommendationWeights is how much the co-purchases, shared categories and shared tags each add to a recommendation score.
+type RecommendationWeights struct {
+	CoPurchase float64
+	Category   float64
+	Tag        float64
+}
+
+// RelatedRequest represents the options of the related products of a product.
+type RelatedRequest struct {
+	// Maximum number of products to return
+	// example: 8
+	Limit int `form:"limit" binding:"omitempty,gte=0"`
+}
+
+// CartRecommendationRequest represents the products of a cart to recommend other products for.
+type CartRecommendationRequest struct {
+	// The UUIDs of the products in the cart
+	ProductIds []string `json:"product_ids" binding:"required,min=1,max=100,dive,uuid"`
+	// Maximum number of products to return
+	// example: 8
+	Limit int `json:"limit" binding:"omitempty,gte=0" example:"8"`
+}
@@ -0,0 +1,74 @@
+// internal/entities/review.go
+package entities
+
+import (
+	"time"
+)
+
+// Review moderation statuses. Only approved reviews are published and count toward the product rating.
+const (
+	ReviewPending  = "pending"
+	ReviewApproved = "approved"
+	ReviewRejected = "rejected"
+)
+
+// Review is a user review of a product.
+type Review struct {
+	// The UUID of the review
+	// example: 7c0d5a3e-2f4b-4f8e-9a61-3d2b1c0e9f44
+	Id string `json:"id" example:"7c0d5a3e-2f4b-4f8e-9a61-3d2b1c0e9f44" minLength:"36"`
+	// The UUID of the product
+	// example: 48dd8c7a-9ac1-4263-88e4-bb01b5e29001
+	ProductId string `json:"product_id" example:"48dd8c7a-9ac1-4263-88e4-bb01b5e29001" minLength:"36"`
+	// The UUID of the reviewer
+	// example: 1f6e7c5b-0a4d-4c2e-8b8f-6a9d2e3f1b70
+	UserId string `json:"user_id" example:"1f6e7c5b-0a4d-4c2e-8b8f-6a9d2e3f1b70" minLength:"36"`
+	// The rating from 1 to 5
+	// example: 4
+	Rating int `json:"rating" example:"4" format:"int32"`
+	// The review headline
+	// example: Great battery life
+	Title string `json:"title" example:"Great battery life"`
+	// The review text
+	// example: Lasts two days with normal use.
+	Body string `json:"body" example:"Lasts two days with normal use."`
+	// The moderation status (pending, approved, rejected)
+	// example: approved
+	Status string `json:"status" example:"approved"`
+	// Whether the reviewer ordered the product
+	// example: true
+	VerifiedPurchase bool `json:"verified_purchase" example:"true"`
+	// The date and time the review was approved or rejected
+	ModeratedAt *time.Time `json:"moderated_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// ReviewRequest represents a review a user submits for a product.
+type ReviewRequest struct {
+	// The rating from 1 to 5
+	// example: 4
+	Rating int `json:"rating" binding:"required,min=1,max=5" example:"4"`
+	// The review headline
+	// example: Great battery life
+	Title string `json:"title" binding:"required,max=200" example:"Great battery life"`
+	// The review text
+	// example: Lasts two days with normal use.
+	Body string `json:"body" binding:"max=5000" example:"Lasts two days with normal use."`
+}
+
+// ReviewStatusRequest represents a moderation decision.
+type ReviewStatusRequest struct {
+	// The new status (approved, rejected)
+	// example: approved
+	Status string `json:"status" binding:"required,oneof=approved rejected" example:"approved"`
+}
+
+// ReviewQueueRequest represents the moderation queue listing parameters.
+type ReviewQueueRequest struct {
+	// The status of the reviews to list, pending by default
+	// example: pending
+	Status string `form:"status" json:"status" binding:"omitempty,oneof=pending approved rejected" example:"pending"`
+	// Paging and sorting of the listing
+	ListRequest
+}
@@ -0,0 +1,45 @@
+// internal/entities/translation.go
+package entities
+
+import (
+	"time"
+)
+
+// The kinds of content that can be translated.
+const (
+	TranslationProduct  = "product"
+	TranslationCategory = "category"
+)
+
+// Translation is the content of a product or category in a locale.
+type Translation struct {
+	// The BCP 47 language tag of the translation
+	// example: fr-CA
+	Locale string `json:"locale" example:"fr-CA"`
+	// The translated name
+	// example: Chaussures de course
+	Name string `json:"name" example:"Chaussures de course"`
+	// The translated description, the description in the default locale is served when empty
+	// example: Chaussures légères pour la course sur route
+	Description string `json:"description,omitempty" example:"Chaussures légères pour la course sur route"`
+	// The date and time the translation was created
+	CreatedAt time.Time `json:"created_at"`
+	// The date and time the translation was last updated
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TranslationRequest represents the content of a product or category in a locale.
+type TranslationRequest struct {
+	// The translated name
+	// example: Chaussures de course
+	Name string `json:"name" binding:"required,max=255" example:"Chaussures de course"`
+	// The translated description, products only
+	// example: Chaussures légères pour la course sur route
+	Description string `json:"description" example:"Chaussures légères pour la course sur route"`
+}
+
+// TranslationLocaleRequest represents the path of a translation.
+type TranslationLocaleRequest struct {
+	Id     string `uri:"id" binding:"required,uuid"`
+	Locale string `uri:"locale" binding:"required"`
+}
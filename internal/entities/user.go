@@ -14,6 +14,7 @@ type User struct {
 	OtpTypes   	[]int     `json:"otp_types"`
 	Verified   	bool 	  `json:"verified"`
 	VerifiedAt	*time.Time `json:"verified_at"`
+	Role		string    `json:"role"`
 	CreatedAt 	time.Time `json:"created_at"`
 	UpdatedAt 	time.Time `json:"updated_at"`
 }
@@ -0,0 +1,45 @@
+// internal/entities/validation.go
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// requestValidator checks the validate tags of the request entities, naming fields by their json key
+var requestValidator = func() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	return v
+}()
+
+// validateStruct returns a message per field failing its validate tags, nil when the value is valid
+func validateStruct(value interface{}) []string {
+	err := requestValidator.Struct(value)
+	if err == nil {
+		return nil
+	}
+	var fieldErrors validator.ValidationErrors
+	if !errors.As(err, &fieldErrors) {
+		return []string{err.Error()}
+	}
+	messages := make([]string, len(fieldErrors))
+	for i, fieldError := range fieldErrors {
+		if fieldError.Tag() == "required" {
+			messages[i] = fmt.Sprintf("%s is required", fieldError.Field())
+		} else {
+			messages[i] = fmt.Sprintf("%s failed the %s rule", fieldError.Field(), fieldError.Tag())
+		}
+	}
+	return messages
+}
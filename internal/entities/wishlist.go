@@ -0,0 +1,84 @@
+// internal/entities/wishlist.go
+package entities
+
+import (
+	"time"
+)
+
+// DefaultWishlist addresses the default wishlist of the user in place of its id
+const DefaultWishlist = "default"
+
+// DefaultWishlistName is the name of the default wishlist when it is created on first use
+const DefaultWishlistName = "Wishlist"
+
+// Wishlist is a named list of products a user saved for later.
+type Wishlist struct {
+	// The UUID of the wishlist
+	// example: 0b7e7f2c-5d3a-4c1e-9f0a-6e2d8b4c1a90
+	Id string `json:"id" example:"0b7e7f2c-5d3a-4c1e-9f0a-6e2d8b4c1a90" minLength:"36"`
+	// The UUID of the owner
+	// example: 1f6e7c5b-0a4d-4c2e-8b8f-6a9d2e3f1b70
+	UserId string `json:"user_id,omitempty" example:"1f6e7c5b-0a4d-4c2e-8b8f-6a9d2e3f1b70" minLength:"36"`
+	// The name of the wishlist
+	// example: Birthday
+	Name string `json:"name" example:"Birthday"`
+	// Whether the products are saved to this list when no list is given
+	// example: true
+	IsDefault bool `json:"is_default" example:"true"`
+	// The token of the read-only link to the list, only shown to the owner while the list is shared
+	// example: q3Xl0m6bVd2k9s8JtYwZr4FhC1pN7uEa5gLiKoBxT0M
+	ShareToken *string `json:"share_token,omitempty" example:"q3Xl0m6bVd2k9s8JtYwZr4FhC1pN7uEa5gLiKoBxT0M"`
+	// The number of products in the list
+	// example: 3
+	ItemCount int `json:"item_count" example:"3" format:"int32"`
+	// The products in the list, latest first, set when a single list is fetched
+	Items     []*WishlistItem `json:"items,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// WishlistItem is a product saved to a wishlist, with its current price from the catalog.
+type WishlistItem struct {
+	*Product
+	// Whether the product can be ordered, false once it is deleted or out of stock
+	// example: true
+	Available bool `json:"available" example:"true"`
+	// The date and time the product was saved to the list
+	// example: 2025-01-01T12:00:00Z
+	AddedAt time.Time `json:"added_at" example:"2025-01-01T12:00:00Z"`
+}
+
+// WishlistRequest represents a request to create or rename a wishlist.
+type WishlistRequest struct {
+	// The name of the wishlist, unique among the lists of the user
+	// example: Birthday
+	Name string `json:"name" binding:"required,max=100" example:"Birthday"`
+	// Make the list the default list of the user
+	// example: false
+	IsDefault bool `json:"is_default" example:"false"`
+}
+
+// WishlistItemRequest represents a product to save to a wishlist.
+type WishlistItemRequest struct {
+	// The UUID of the product
+	// example: 48dd8c7a-9ac1-4263-88e4-bb01b5e29001
+	ProductId string `json:"product_id" binding:"required,uuid" example:"48dd8c7a-9ac1-4263-88e4-bb01b5e29001"`
+}
+
+// WishlistMoveRequest represents the wishlist to move a saved product to.
+type WishlistMoveRequest struct {
+	// The UUID of the target wishlist of the same user, or default
+	// example: 0b7e7f2c-5d3a-4c1e-9f0a-6e2d8b4c1a90
+	WishlistId string `json:"wishlist_id" binding:"required" example:"0b7e7f2c-5d3a-4c1e-9f0a-6e2d8b4c1a90"`
+}
+
+// WishlistItemUri addresses a product saved to a wishlist.
+type WishlistItemUri struct {
+	Id        string `uri:"id" binding:"required"`
+	ProductId string `uri:"product_id" binding:"required,uuid"`
+}
+
+// ShareTokenRequest addresses a shared wishlist by its token.
+type ShareTokenRequest struct {
+	Token string `uri:"token" binding:"required,max=64"`
+}
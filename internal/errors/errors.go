@@ -44,6 +44,64 @@ var (
     ErrInvalidOTP       = New("INVALID_OTP", "Invalid OTP Code", nil)
     ErrOTPNotFound      = New("OTP_NOT_FOUND", "OTP not found", nil)
     ErrInvalidMobile    = New("INVALID_MOBILE", "invalid mobile number", nil)
+    ErrInvalidCursor    = New("INVALID_CURSOR", "The provided cursor is invalid or does not match the sort order", nil)
+    ErrInvalidSort      = New("INVALID_SORT", "The requested sort field is not supported", nil)
+    ErrCategoryNotFound = New("CATEGORY_NOT_FOUND", "The requested category does not exist", nil)
+    ErrCategoryCycle    = New("CATEGORY_CYCLE", "A category cannot be moved under itself or its descendants", nil)
+    ErrCategoryNotEmpty = New("CATEGORY_NOT_EMPTY", "The category still has subcategories", nil)
+    ErrSlugConflict     = New("SLUG_CONFLICT", "The slug is already in use", nil)
+    ErrInvalidSlug      = New("INVALID_SLUG", "The slug may only contain lowercase letters, digits and dashes", nil)
+    ErrProductNotFound  = New("PRODUCT_NOT_FOUND", "The requested product does not exist", nil)
+    ErrVariantNotFound  = New("VARIANT_NOT_FOUND", "The requested product variant does not exist", nil)
+    ErrInvalidVariant   = New("INVALID_VARIANT", "The variant options do not match the product option axes", nil)
+    ErrVariantConflict  = New("VARIANT_CONFLICT", "A variant with these options already exists", nil)
+    ErrVariantUnavailable = New("VARIANT_UNAVAILABLE", "The product variant is not available", nil)
+    ErrVariantOrdered   = New("VARIANT_ORDERED", "The variant was ordered and cannot be deleted, make it unavailable instead", nil)
+    ErrOutOfStock       = New("OUT_OF_STOCK", "There is not enough stock of the product", nil)
+    ErrOrderCancelled   = New("ORDER_CANCELLED", "The order is cancelled and cannot change status", nil)
+    ErrOrderNotFound    = New("ORDER_NOT_FOUND", "The requested order does not exist", nil)
+    ErrOrderStatusForbidden = New("ORDER_STATUS_FORBIDDEN", "Customers can only cancel their own pending orders", nil)
+    ErrInvalidImage     = New("INVALID_IMAGE", "The file must be a JPEG, PNG, GIF or WebP image with a matching extension", nil)
+    ErrImageTooLarge    = New("IMAGE_TOO_LARGE", "The image exceeds the maximum upload size", nil)
+    ErrImageDimensions  = New("INVALID_IMAGE_DIMENSIONS", "The image width or height is out of the accepted range", nil)
+    ErrStorage          = New("STORAGE_ERROR", "The file could not be stored", nil)
+    ErrImageNotFound    = New("IMAGE_NOT_FOUND", "The requested product image does not exist", nil)
+    ErrInvalidImageOrder = New("INVALID_IMAGE_ORDER", "The image ids must list every image of the product once", nil)
+    ErrInvalidImportFile = New("INVALID_IMPORT_FILE", "The file must be a CSV file with a header row including sku, or JSON Lines", nil)
+    ErrImportTooLarge   = New("IMPORT_TOO_LARGE", "The import exceeds the maximum file size or number of rows", nil)
+    ErrPriceScheduleNotFound = New("PRICE_SCHEDULE_NOT_FOUND", "The requested price schedule does not exist or already ended", nil)
+    ErrInvalidPriceSchedule = New("INVALID_PRICE_SCHEDULE", "A price schedule must start in the future and end after it starts", nil)
+    ErrPriceScheduleConflict = New("PRICE_SCHEDULE_CONFLICT", "The price schedule overlaps another schedule of the product", nil)
+    ErrUnsupportedCurrency = New("UNSUPPORTED_CURRENCY", "The requested currency is not supported", nil)
+    ErrCurrencyNotFound = New("CURRENCY_NOT_FOUND", "The requested currency does not exist", nil)
+    ErrInvalidRatesFile = New("INVALID_RATES_FILE", "The file must be a CSV file with currency and rate columns", nil)
+    ErrOrderTotalMismatch = New("ORDER_TOTAL_MISMATCH", "The total price does not match the sum of the order line items", nil)
+    ErrReviewNotFound   = New("REVIEW_NOT_FOUND", "The requested review does not exist", nil)
+    ErrReviewExists     = New("REVIEW_EXISTS", "You have already reviewed this product", nil)
+    ErrPurchaseRequired = New("PURCHASE_REQUIRED", "Only customers who ordered the product can review it", nil)
+    ErrVersionMismatch  = New("VERSION_MISMATCH", "The resource was changed since it was read, fetch it again and retry", nil)
+    ErrPreconditionRequired = New("PRECONDITION_REQUIRED", "The request must be conditional on the resource version with an If-Match header", nil)
+    ErrAttributeNotFound = New("ATTRIBUTE_NOT_FOUND", "The requested attribute definition does not exist", nil)
+    ErrInvalidAttribute = New("INVALID_ATTRIBUTE", "The attribute is not defined or its value does not match the attribute type", nil)
+    ErrInvalidTag       = New("INVALID_TAG", "Tags must contain letters or digits and be at most 50 characters", nil)
+    ErrEmbedding        = New("EMBEDDING_ERROR", "The semantic search is not available, the query could not be embedded", nil)
+    ErrWishlistNotFound = New("WISHLIST_NOT_FOUND", "The requested wishlist does not exist", nil)
+    ErrWishlistExists   = New("WISHLIST_EXISTS", "You already have a wishlist with this name", nil)
+    ErrWishlistLimit    = New("WISHLIST_LIMIT", "The maximum number of wishlists or saved products was reached", nil)
+    ErrDefaultWishlist  = New("DEFAULT_WISHLIST", "The default wishlist cannot be deleted, make another list the default first", nil)
+    ErrSkuConflict      = New("SKU_CONFLICT", "The SKU is already used by another product", nil)
+    ErrPromotionNotFound = New("PROMOTION_NOT_FOUND", "The requested promotion does not exist", nil)
+    ErrInvalidPromotion  = New("INVALID_PROMOTION", "The promotion rule is not valid", nil)
+    ErrCouponNotFound    = New("COUPON_NOT_FOUND", "The requested coupon does not exist", nil)
+    ErrCouponExists      = New("COUPON_EXISTS", "The coupon code is already used", nil)
+    ErrInvalidCoupon     = New("INVALID_COUPON", "The coupon code does not exist or is not valid at this time", nil)
+    ErrCouponNotApplicable = New("COUPON_NOT_APPLICABLE", "The order does not qualify for the coupon code", nil)
+    ErrCouponExhausted   = New("COUPON_EXHAUSTED", "The coupon code reached its usage limit", nil)
+    ErrBundleNotFound    = New("BUNDLE_NOT_FOUND", "The product is not a bundle", nil)
+    ErrInvalidBundle     = New("INVALID_BUNDLE", "The bundle components are not valid", nil)
+    ErrBundleUnavailable = New("BUNDLE_UNAVAILABLE", "A component of the bundle is not available", nil)
+    ErrInvalidLocale     = New("INVALID_LOCALE", "The locale is not a valid language tag", nil)
+    ErrTranslationNotFound = New("TRANSLATION_NOT_FOUND", "The requested translation does not exist", nil)
 )
 
 // Wrap wraps an existing error with additional context.
@@ -0,0 +1,217 @@
+// usecases/attribute_usecase.go
+package usecases
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/errors"
+	"github.com/shayja/go-template-api/pkg/slug"
+)
+
+// MaxTagLength limits the length of a product tag
+const MaxTagLength = 50
+
+type AttributeRepository interface {
+	GetAll() ([]*entities.AttributeDefinition, error)
+	Save(definition *entities.AttributeDefinition) error
+	Delete(code string) error
+}
+
+type AttributeInteractor struct {
+	AttributeRepository AttributeRepository
+}
+
+func (uc *AttributeInteractor) GetAll() ([]*entities.AttributeDefinition, error) {
+	return uc.AttributeRepository.GetAll()
+}
+
+// Save adds an attribute definition or updates it. The values products already have are checked
+// against the new definition on their next update.
+func (uc *AttributeInteractor) Save(code string, request *entities.AttributeDefinitionRequest) (*entities.AttributeDefinition, error) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if !slug.IsValid(code) {
+		return nil, errors.New(errors.ErrInvalidAttribute.Code, "the attribute code may only contain lowercase letters, digits and dashes", nil)
+	}
+	definition := &entities.AttributeDefinition{Code: code, Name: strings.TrimSpace(request.Name), Type: request.Type, Required: request.Required}
+	if definition.Name == "" {
+		return nil, errors.ErrInvalidInput
+	}
+
+	if request.Unit != nil && strings.TrimSpace(*request.Unit) != "" {
+		if request.Type != entities.AttributeNumber {
+			return nil, errors.New(errors.ErrInvalidAttribute.Code, "only number attributes have a unit", nil)
+		}
+		unit := strings.TrimSpace(*request.Unit)
+		definition.Unit = &unit
+	}
+
+	switch request.Type {
+	case entities.AttributeEnum:
+		seen := make(map[string]bool)
+		for _, value := range request.Values {
+			value = strings.TrimSpace(value)
+			if value == "" || seen[value] {
+				return nil, errors.New(errors.ErrInvalidAttribute.Code, "the enum values must be distinct and not empty", nil)
+			}
+			seen[value] = true
+			definition.Values = append(definition.Values, value)
+		}
+		if len(definition.Values) == 0 {
+			return nil, errors.New(errors.ErrInvalidAttribute.Code, "an enum attribute needs at least one value", nil)
+		}
+	case entities.AttributeString, entities.AttributeNumber, entities.AttributeBoolean:
+		if len(request.Values) > 0 {
+			return nil, errors.New(errors.ErrInvalidAttribute.Code, "only enum attributes have values", nil)
+		}
+	default:
+		return nil, errors.ErrInvalidInput
+	}
+
+	if err := uc.AttributeRepository.Save(definition); err != nil {
+		return nil, err
+	}
+	return definition, nil
+}
+
+// Delete removes an attribute definition along with the values products have for it
+func (uc *AttributeInteractor) Delete(code string) error {
+	return uc.AttributeRepository.Delete(code)
+}
+
+// definitionsByCode loads the attribute definitions by code
+func definitionsByCode(repository AttributeRepository) (map[string]*entities.AttributeDefinition, error) {
+	definitions, err := repository.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	byCode := make(map[string]*entities.AttributeDefinition, len(definitions))
+	for _, definition := range definitions {
+		byCode[definition.Code] = definition
+	}
+	return byCode, nil
+}
+
+// ValidateAttributes checks the attribute values of a product against the definitions,
+// and that the product has every required attribute
+func ValidateAttributes(definitions map[string]*entities.AttributeDefinition, attributes map[string]interface{}) error {
+	codes := make([]string, 0, len(attributes))
+	for code := range attributes {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		definition, ok := definitions[code]
+		if !ok {
+			return errors.New(errors.ErrInvalidAttribute.Code, fmt.Sprintf("the attribute %s is not defined", code), nil)
+		}
+		if !validAttributeValue(definition, attributes[code]) {
+			return errors.New(errors.ErrInvalidAttribute.Code, fmt.Sprintf("the attribute %s must be %s", code, describeType(definition)), nil)
+		}
+	}
+
+	required := make([]string, 0)
+	for code, definition := range definitions {
+		if _, ok := attributes[code]; definition.Required && !ok {
+			required = append(required, code)
+		}
+	}
+	if len(required) > 0 {
+		sort.Strings(required)
+		return errors.New(errors.ErrInvalidAttribute.Code, fmt.Sprintf("the attributes %s are required", strings.Join(required, ", ")), nil)
+	}
+	return nil
+}
+
+// validAttributeValue reports whether a decoded JSON value matches the attribute type
+func validAttributeValue(definition *entities.AttributeDefinition, value interface{}) bool {
+	switch definition.Type {
+	case entities.AttributeString:
+		s, ok := value.(string)
+		return ok && strings.TrimSpace(s) != ""
+	case entities.AttributeNumber:
+		switch value.(type) {
+		case float64, float32, int, int64:
+			return true
+		}
+		return false
+	case entities.AttributeBoolean:
+		_, ok := value.(bool)
+		return ok
+	case entities.AttributeEnum:
+		s, ok := value.(string)
+		if !ok {
+			return false
+		}
+		for _, allowed := range definition.Values {
+			if s == allowed {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// describeType names the values an attribute accepts in error messages
+func describeType(definition *entities.AttributeDefinition) string {
+	if definition.Type == entities.AttributeEnum {
+		return "one of " + strings.Join(definition.Values, ", ")
+	}
+	return "a " + definition.Type
+}
+
+// ParseAttributeFilter parses the attr.<code> filter values of a listing to the attribute types,
+// so they compare equal to the stored values
+func ParseAttributeFilter(definitions map[string]*entities.AttributeDefinition, filter map[string][]interface{}) error {
+	for code, values := range filter {
+		definition, ok := definitions[code]
+		if !ok {
+			return errors.New(errors.ErrInvalidAttribute.Code, fmt.Sprintf("the attribute %s is not defined", code), nil)
+		}
+		for i, value := range values {
+			s, ok := value.(string)
+			if !ok {
+				continue
+			}
+			switch definition.Type {
+			case entities.AttributeNumber:
+				n, err := strconv.ParseFloat(s, 64)
+				if err != nil {
+					return errors.New(errors.ErrInvalidAttribute.Code, fmt.Sprintf("the attribute %s must be a number", code), nil)
+				}
+				values[i] = n
+			case entities.AttributeBoolean:
+				b, err := strconv.ParseBool(s)
+				if err != nil {
+					return errors.New(errors.ErrInvalidAttribute.Code, fmt.Sprintf("the attribute %s must be a boolean", code), nil)
+				}
+				values[i] = b
+			}
+		}
+	}
+	return nil
+}
+
+// NormalizeTags lowercases the tags into dash separated words, dropping duplicates.
+// Nil stays nil so updates without tags keep the current ones.
+func NormalizeTags(tags []string) ([]string, error) {
+	if tags == nil {
+		return nil, nil
+	}
+	normalized := []string{}
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		tag = slug.Make(tag)
+		if tag == "" || len(tag) > MaxTagLength {
+			return nil, errors.ErrInvalidTag
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized, nil
+}
@@ -0,0 +1,134 @@
+package usecases_test
+
+import (
+	"testing"
+
+	"github.com/shayja/go-template-api/internal/entities"
+	appErrors "github.com/shayja/go-template-api/internal/errors"
+	"github.com/shayja/go-template-api/internal/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAttributeRepository mocks the AttributeRepository interface
+type MockAttributeRepository struct {
+	mock.Mock
+}
+
+func (m *MockAttributeRepository) GetAll() ([]*entities.AttributeDefinition, error) {
+	args := m.Called()
+	return args.Get(0).([]*entities.AttributeDefinition), args.Error(1)
+}
+
+func (m *MockAttributeRepository) Save(definition *entities.AttributeDefinition) error {
+	args := m.Called(definition)
+	return args.Error(0)
+}
+
+func (m *MockAttributeRepository) Delete(code string) error {
+	args := m.Called(code)
+	return args.Error(0)
+}
+
+func attributeDefinitions() map[string]*entities.AttributeDefinition {
+	unit := "g"
+	return map[string]*entities.AttributeDefinition{
+		"brand":    {Code: "brand", Type: entities.AttributeString, Required: true},
+		"weight":   {Code: "weight", Type: entities.AttributeNumber, Unit: &unit},
+		"wireless": {Code: "wireless", Type: entities.AttributeBoolean},
+		"color":    {Code: "color", Type: entities.AttributeEnum, Values: []string{"red", "blue"}},
+	}
+}
+
+func TestAttributeInteractor_Save(t *testing.T) {
+	repo := new(MockAttributeRepository)
+	interactor := &usecases.AttributeInteractor{AttributeRepository: repo}
+
+	unit := " g "
+	repo.On("Save", mock.MatchedBy(func(d *entities.AttributeDefinition) bool {
+		return d.Code == "weight" && *d.Unit == "g"
+	})).Return(nil)
+
+	definition, err := interactor.Save(" Weight ", &entities.AttributeDefinitionRequest{Name: "Weight", Type: entities.AttributeNumber, Unit: &unit})
+	assert.NoError(t, err)
+	assert.Equal(t, "weight", definition.Code)
+	repo.AssertExpectations(t)
+}
+
+func TestAttributeInteractor_Save_Invalid(t *testing.T) {
+	interactor := &usecases.AttributeInteractor{AttributeRepository: new(MockAttributeRepository)}
+	unit := "cm"
+
+	tests := []struct {
+		code    string
+		request *entities.AttributeDefinitionRequest
+	}{
+		{"not valid", &entities.AttributeDefinitionRequest{Name: "Brand", Type: entities.AttributeString}},
+		{"brand", &entities.AttributeDefinitionRequest{Name: "Brand", Type: entities.AttributeString, Unit: &unit}},
+		{"brand", &entities.AttributeDefinitionRequest{Name: "Brand", Type: entities.AttributeString, Values: []string{"Acme"}}},
+		{"color", &entities.AttributeDefinitionRequest{Name: "Color", Type: entities.AttributeEnum}},
+		{"color", &entities.AttributeDefinitionRequest{Name: "Color", Type: entities.AttributeEnum, Values: []string{"red", "red"}}},
+	}
+	for _, test := range tests {
+		_, err := interactor.Save(test.code, test.request)
+		assert.ErrorContains(t, err, appErrors.ErrInvalidAttribute.Code, test.code)
+	}
+}
+
+func TestValidateAttributes(t *testing.T) {
+	definitions := attributeDefinitions()
+
+	assert.NoError(t, usecases.ValidateAttributes(definitions, map[string]interface{}{"brand": "Acme", "weight": 150.0, "wireless": true, "color": "red"}))
+
+	tests := []struct {
+		attributes map[string]interface{}
+		message    string
+	}{
+		{map[string]interface{}{"brand": "Acme", "size": "XL"}, "the attribute size is not defined"},
+		{map[string]interface{}{"brand": "Acme", "weight": "heavy"}, "the attribute weight must be a number"},
+		{map[string]interface{}{"brand": "Acme", "wireless": "yes"}, "the attribute wireless must be a boolean"},
+		{map[string]interface{}{"brand": "Acme", "color": "green"}, "the attribute color must be one of red, blue"},
+		{map[string]interface{}{"weight": 150.0}, "the attributes brand are required"},
+	}
+	for _, test := range tests {
+		err := usecases.ValidateAttributes(definitions, test.attributes)
+		assert.ErrorContains(t, err, appErrors.ErrInvalidAttribute.Code)
+		assert.ErrorContains(t, err, test.message)
+	}
+}
+
+func TestParseAttributeFilter(t *testing.T) {
+	definitions := attributeDefinitions()
+
+	filter := map[string][]interface{}{"weight": {"150"}, "wireless": {"true"}, "color": {"red", "blue"}}
+	assert.NoError(t, usecases.ParseAttributeFilter(definitions, filter))
+	assert.Equal(t, map[string][]interface{}{"weight": {150.0}, "wireless": {true}, "color": {"red", "blue"}}, filter)
+
+	assert.ErrorContains(t, usecases.ParseAttributeFilter(definitions, map[string][]interface{}{"weight": {"heavy"}}), appErrors.ErrInvalidAttribute.Code)
+	assert.ErrorContains(t, usecases.ParseAttributeFilter(definitions, map[string][]interface{}{"size": {"XL"}}), appErrors.ErrInvalidAttribute.Code)
+}
+
+func TestNormalizeTags(t *testing.T) {
+	tags, err := usecases.NormalizeTags([]string{"Sale", " Summer Deals ", "sale"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sale", "summer-deals"}, tags)
+
+	tags, err = usecases.NormalizeTags(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, tags)
+
+	_, err = usecases.NormalizeTags([]string{"  "})
+	assert.Equal(t, appErrors.ErrInvalidTag, err)
+}
+
+func TestProductInteractor_Create_UnknownAttribute(t *testing.T) {
+	repo := new(MockProductRepository)
+	attributes := new(MockAttributeRepository)
+	interactor := &usecases.ProductInteractor{ProductRepository: repo, AttributeRepository: attributes}
+
+	attributes.On("GetAll").Return([]*entities.AttributeDefinition{{Code: "brand", Type: entities.AttributeString}}, nil)
+
+	_, err := interactor.Create(&entities.ProductRequest{Name: "Anvil", Attributes: map[string]interface{}{"size": "XL"}})
+	assert.ErrorContains(t, err, appErrors.ErrInvalidAttribute.Code)
+	repo.AssertNotCalled(t, "Create")
+}
@@ -0,0 +1,113 @@
+// usecases/category_usecase.go
+package usecases
+
+import (
+	"strings"
+
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/errors"
+	"github.com/shayja/go-template-api/pkg/slug"
+)
+
+type CategoryRepository interface {
+	GetAll() ([]*entities.Category, error)
+	GetById(id string) (*entities.Category, error)
+	GetBySlug(slug string) (*entities.Category, error)
+	GetDescendantIds(id string) ([]string, error)
+	Create(category *entities.CategoryRequest) (string, error)
+	Update(id string, category *entities.CategoryRequest) (*entities.Category, error)
+	Delete(id string) (bool, error)
+	SetProductCategories(productId string, categoryIds []string) error
+	GetBreadcrumbs(productIds []string) (map[string][][]entities.CategoryCrumb, error)
+}
+
+type CategoryInteractor struct {
+	CategoryRepository CategoryRepository
+}
+
+// GetTree returns the root categories with their descendants nested as children
+func (uc *CategoryInteractor) GetTree() ([]*entities.Category, error) {
+	categories, err := uc.CategoryRepository.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	return BuildCategoryTree(categories), nil
+}
+
+func (uc *CategoryInteractor) GetBySlug(slug string) (*entities.Category, error) {
+	return uc.CategoryRepository.GetBySlug(slug)
+}
+
+func (uc *CategoryInteractor) Create(category *entities.CategoryRequest) (string, error) {
+	if err := normalizeCategory(category); err != nil {
+		return "", err
+	}
+	return uc.CategoryRepository.Create(category)
+}
+
+func (uc *CategoryInteractor) Update(id string, category *entities.CategoryRequest) (*entities.Category, error) {
+	if err := normalizeCategory(category); err != nil {
+		return nil, err
+	}
+
+	// Moving a category under itself or one of its descendants would detach a cycle from the tree
+	if category.ParentId != nil {
+		if *category.ParentId == id {
+			return nil, errors.ErrCategoryCycle
+		}
+		descendants, err := uc.CategoryRepository.GetDescendantIds(id)
+		if err != nil {
+			return nil, err
+		}
+		for _, descendantId := range descendants {
+			if descendantId == *category.ParentId {
+				return nil, errors.ErrCategoryCycle
+			}
+		}
+	}
+	return uc.CategoryRepository.Update(id, category)
+}
+
+func (uc *CategoryInteractor) Delete(id string) (bool, error) {
+	return uc.CategoryRepository.Delete(id)
+}
+
+func (uc *CategoryInteractor) SetProductCategories(productId string, request *entities.ProductCategoriesRequest) error {
+	return uc.CategoryRepository.SetProductCategories(productId, request.CategoryIds)
+}
+
+// BuildCategoryTree nests a flat, position ordered category list under the root categories
+func BuildCategoryTree(categories []*entities.Category) []*entities.Category {
+	byId := make(map[string]*entities.Category, len(categories))
+	for _, category := range categories {
+		category.Children = nil
+		byId[category.Id] = category
+	}
+
+	roots := []*entities.Category{}
+	for _, category := range categories {
+		if category.ParentId != nil {
+			if parent, ok := byId[*category.ParentId]; ok {
+				parent.Children = append(parent.Children, category)
+				continue
+			}
+		}
+		roots = append(roots, category)
+	}
+	return roots
+}
+
+func normalizeCategory(category *entities.CategoryRequest) error {
+	category.Name = strings.TrimSpace(category.Name)
+	if category.Name == "" {
+		return errors.ErrInvalidInput
+	}
+	category.Slug = strings.TrimSpace(category.Slug)
+	if category.Slug == "" {
+		category.Slug = slug.Make(category.Name)
+	}
+	if !slug.IsValid(category.Slug) {
+		return errors.ErrInvalidSlug
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package usecases_test
+
+import (
+	"testing"
+
+	"github.com/shayja/go-template-api/internal/entities"
+	appErrors "github.com/shayja/go-template-api/internal/errors"
+	"github.com/shayja/go-template-api/internal/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockCategoryRepository mocks the CategoryRepository interface
+type MockCategoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockCategoryRepository) GetAll() ([]*entities.Category, error) {
+	args := m.Called()
+	if categories, ok := args.Get(0).([]*entities.Category); ok {
+		return categories, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockCategoryRepository) GetById(id string) (*entities.Category, error) {
+	args := m.Called(id)
+	if category, ok := args.Get(0).(*entities.Category); ok {
+		return category, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockCategoryRepository) GetBySlug(slug string) (*entities.Category, error) {
+	args := m.Called(slug)
+	if category, ok := args.Get(0).(*entities.Category); ok {
+		return category, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockCategoryRepository) GetDescendantIds(id string) ([]string, error) {
+	args := m.Called(id)
+	if ids, ok := args.Get(0).([]string); ok {
+		return ids, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockCategoryRepository) Create(category *entities.CategoryRequest) (string, error) {
+	args := m.Called(category)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockCategoryRepository) Update(id string, category *entities.CategoryRequest) (*entities.Category, error) {
+	args := m.Called(id, category)
+	if updated, ok := args.Get(0).(*entities.Category); ok {
+		return updated, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockCategoryRepository) Delete(id string) (bool, error) {
+	args := m.Called(id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockCategoryRepository) SetProductCategories(productId string, categoryIds []string) error {
+	args := m.Called(productId, categoryIds)
+	return args.Error(0)
+}
+
+func (m *MockCategoryRepository) GetBreadcrumbs(productIds []string) (map[string][][]entities.CategoryCrumb, error) {
+	args := m.Called(productIds)
+	if breadcrumbs, ok := args.Get(0).(map[string][][]entities.CategoryCrumb); ok {
+		return breadcrumbs, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func TestCategoryInteractor_GetTree(t *testing.T) {
+	repo := new(MockCategoryRepository)
+	interactor := &usecases.CategoryInteractor{CategoryRepository: repo}
+
+	phones, android := "phones", "android"
+	repo.On("GetAll").Return([]*entities.Category{
+		{Id: "phones", Name: "Phones"},
+		{Id: "android", Name: "Android", ParentId: &phones},
+		{Id: "foldables", Name: "Foldables", ParentId: &android},
+		{Id: "laptops", Name: "Laptops"},
+	}, nil)
+
+	tree, err := interactor.GetTree()
+
+	assert.NoError(t, err)
+	assert.Len(t, tree, 2)
+	assert.Equal(t, "phones", tree[0].Id)
+	assert.Equal(t, "android", tree[0].Children[0].Id)
+	assert.Equal(t, "foldables", tree[0].Children[0].Children[0].Id)
+	assert.Empty(t, tree[1].Children)
+	repo.AssertExpectations(t)
+}
+
+func TestCategoryInteractor_Create_GeneratesSlug(t *testing.T) {
+	repo := new(MockCategoryRepository)
+	interactor := &usecases.CategoryInteractor{CategoryRepository: repo}
+
+	request := &entities.CategoryRequest{Name: "  Smart Phones & Tablets "}
+	repo.On("Create", mock.MatchedBy(func(c *entities.CategoryRequest) bool {
+		return c.Slug == "smart-phones-tablets" && c.Name == "Smart Phones & Tablets"
+	})).Return("1", nil)
+
+	id, err := interactor.Create(request)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1", id)
+	repo.AssertExpectations(t)
+}
+
+func TestCategoryInteractor_Create_InvalidSlug(t *testing.T) {
+	repo := new(MockCategoryRepository)
+	interactor := &usecases.CategoryInteractor{CategoryRepository: repo}
+
+	_, err := interactor.Create(&entities.CategoryRequest{Name: "Phones", Slug: "Phones!"})
+
+	assert.ErrorIs(t, err, appErrors.ErrInvalidSlug)
+	repo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestCategoryInteractor_Update_RejectsCycle(t *testing.T) {
+	repo := new(MockCategoryRepository)
+	interactor := &usecases.CategoryInteractor{CategoryRepository: repo}
+
+	self := "phones"
+	_, err := interactor.Update("phones", &entities.CategoryRequest{Name: "Phones", ParentId: &self})
+	assert.ErrorIs(t, err, appErrors.ErrCategoryCycle)
+
+	child := "android"
+	repo.On("GetDescendantIds", "phones").Return([]string{"android", "foldables"}, nil)
+	_, err = interactor.Update("phones", &entities.CategoryRequest{Name: "Phones", ParentId: &child})
+	assert.ErrorIs(t, err, appErrors.ErrCategoryCycle)
+
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestCategoryInteractor_Update(t *testing.T) {
+	repo := new(MockCategoryRepository)
+	interactor := &usecases.CategoryInteractor{CategoryRepository: repo}
+
+	parent := "electronics"
+	request := &entities.CategoryRequest{Name: "Phones", Slug: "phones", ParentId: &parent}
+	updated := &entities.Category{Id: "phones", Name: "Phones", Slug: "phones", ParentId: &parent}
+	repo.On("GetDescendantIds", "phones").Return([]string{"android"}, nil)
+	repo.On("Update", "phones", request).Return(updated, nil)
+
+	result, err := interactor.Update("phones", request)
+
+	assert.NoError(t, err)
+	assert.Equal(t, updated, result)
+	repo.AssertExpectations(t)
+}
+
+func TestProductInteractor_GetById_AddsBreadcrumbs(t *testing.T) {
+	products := new(MockProductRepository)
+	categories := new(MockCategoryRepository)
+	interactor := &usecases.ProductInteractor{ProductRepository: products, CategoryRepository: categories}
+
+	crumbs := [][]entities.CategoryCrumb{{{Id: "phones", Slug: "phones"}, {Id: "android", Slug: "android"}}}
+	products.On("GetById", "1").Return(&entities.Product{Id: "1"}, nil)
+	categories.On("GetBreadcrumbs", []string{"1"}).Return(map[string][][]entities.CategoryCrumb{"1": crumbs}, nil)
+
+	result, err := interactor.GetById("1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, crumbs, result.Breadcrumbs)
+	products.AssertExpectations(t)
+	categories.AssertExpectations(t)
+}
@@ -0,0 +1,321 @@
+// usecases/currency_usecase.go
+package usecases
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/errors"
+	"github.com/shayja/go-template-api/pkg/currency"
+	"github.com/shayja/go-template-api/pkg/money"
+)
+
+type CurrencyRepository interface {
+	GetAll() ([]*entities.Currency, error)
+	Get(code string) (*entities.Currency, error)
+	Save(currencies ...*entities.Currency) error
+	Delete(code string) error
+	GetProductPrices(productId string) (map[string]money.Amount, error)
+	GetPricesIn(code string, productIds []string) (map[string]money.Amount, error)
+	SetProductPrices(productId string, prices map[string]money.Amount) error
+}
+
+type CurrencyInteractor struct {
+	CurrencyRepository CurrencyRepository
+	// The code of the store base currency all prices are stored in
+	BaseCurrency string
+}
+
+// DefaultBaseCurrency is the base currency when none is configured
+const DefaultBaseCurrency = "USD"
+
+func (uc *CurrencyInteractor) base() *entities.Currency {
+	code, err := currency.Normalize(uc.BaseCurrency)
+	if err != nil {
+		code = DefaultBaseCurrency
+	}
+	return &entities.Currency{Code: code, Rate: 1, Decimals: 2, Rounding: currency.HalfUp, Base: true}
+}
+
+// GetAll returns the base currency followed by the currencies prices can be converted to
+func (uc *CurrencyInteractor) GetAll() ([]*entities.Currency, error) {
+	currencies, err := uc.CurrencyRepository.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	base := uc.base()
+	all := []*entities.Currency{base}
+	for _, c := range currencies {
+		if c.Code != base.Code {
+			all = append(all, c)
+		}
+	}
+	return all, nil
+}
+
+// Resolve returns the requested currency, the base currency when none was requested
+func (uc *CurrencyInteractor) Resolve(code string) (*entities.Currency, error) {
+	base := uc.base()
+	if strings.TrimSpace(code) == "" {
+		return base, nil
+	}
+	code, err := currency.Normalize(code)
+	if err != nil {
+		return nil, errors.ErrUnsupportedCurrency
+	}
+	if code == base.Code {
+		return base, nil
+	}
+	found, err := uc.CurrencyRepository.Get(code)
+	if err != nil {
+		return nil, err
+	}
+	if found == nil {
+		return nil, errors.ErrUnsupportedCurrency
+	}
+	return found, nil
+}
+
+// Save adds a currency or updates its exchange rate and rounding rule
+func (uc *CurrencyInteractor) Save(code string, request *entities.CurrencyRequest) (*entities.Currency, error) {
+	c, err := uc.fromRequest(code, request)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.CurrencyRepository.Save(c); err != nil {
+		return nil, err
+	}
+	return uc.CurrencyRepository.Get(c.Code)
+}
+
+// Delete removes a currency along with the explicit product prices in it
+func (uc *CurrencyInteractor) Delete(code string) error {
+	code, err := currency.Normalize(code)
+	if err != nil || code == uc.base().Code {
+		return errors.ErrCurrencyNotFound
+	}
+	return uc.CurrencyRepository.Delete(code)
+}
+
+// ImportRates adds or updates the currencies of a CSV file with a currency,rate[,decimals,rounding,rounding_increment]
+// header, all of them or none
+func (uc *CurrencyInteractor) ImportRates(content io.Reader) ([]*entities.Currency, error) {
+	reader := csv.NewReader(content)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.ErrInvalidRatesFile
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	if _, ok := columns["currency"]; !ok {
+		return nil, errors.ErrInvalidRatesFile
+	}
+	if _, ok := columns["rate"]; !ok {
+		return nil, errors.ErrInvalidRatesFile
+	}
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	currencies := []*entities.Currency{}
+	seen := make(map[string]bool)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.New(errors.ErrInvalidRatesFile.Code, err.Error(), err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		request := &entities.CurrencyRequest{Rounding: field(record, "rounding")}
+		if request.Rate, err = strconv.ParseFloat(field(record, "rate"), 64); err != nil {
+			return nil, ratesLineError(line, "the rate must be a number")
+		}
+		if value := field(record, "decimals"); value != "" {
+			decimals, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, ratesLineError(line, "the decimals must be a whole number")
+			}
+			request.Decimals = &decimals
+		}
+		if value := field(record, "rounding_increment"); value != "" {
+			increment, err := money.Parse(value)
+			if err != nil {
+				return nil, ratesLineError(line, "the rounding increment must be a number")
+			}
+			request.RoundingIncrement = &increment
+		}
+
+		c, err := uc.fromRequest(field(record, "currency"), request)
+		if err != nil {
+			return nil, ratesLineError(line, err.(*errors.AppError).Message)
+		}
+		if seen[c.Code] {
+			return nil, ratesLineError(line, "the currency "+c.Code+" is listed twice")
+		}
+		seen[c.Code] = true
+		currencies = append(currencies, c)
+	}
+	if len(currencies) == 0 {
+		return nil, errors.ErrInvalidRatesFile
+	}
+
+	if err := uc.CurrencyRepository.Save(currencies...); err != nil {
+		return nil, err
+	}
+	return currencies, nil
+}
+
+// GetProductPrices returns the explicit prices of a product by currency code
+func (uc *CurrencyInteractor) GetProductPrices(productId string) (map[string]money.Amount, error) {
+	return uc.CurrencyRepository.GetProductPrices(productId)
+}
+
+// SetProductPrices replaces the explicit prices of a product, rounded by the rule of their currency
+func (uc *CurrencyInteractor) SetProductPrices(productId string, request *entities.ProductCurrencyPricesRequest) (map[string]money.Amount, error) {
+	base := uc.base()
+	prices := make(map[string]money.Amount, len(request.Prices))
+	for code, price := range request.Prices {
+		c, err := uc.Resolve(code)
+		if err != nil {
+			return nil, err
+		}
+		if c.Code == base.Code || price < 0 {
+			return nil, errors.New(errors.ErrInvalidInput.Code, "The explicit prices must be positive amounts in currencies other than "+base.Code, nil)
+		}
+		prices[c.Code] = c.Rule().Round(price)
+	}
+	if err := uc.CurrencyRepository.SetProductPrices(productId, prices); err != nil {
+		return nil, err
+	}
+	return prices, nil
+}
+
+// ConvertProducts converts the prices of the products to the currency, preferring the explicit
+// price of a product in the currency to converting its base price
+func (uc *CurrencyInteractor) ConvertProducts(c *entities.Currency, products ...*entities.Product) error {
+	if c.Base {
+		for _, product := range products {
+			product.Currency = c.Code
+		}
+		return nil
+	}
+
+	ids := make([]string, 0, len(products))
+	for _, product := range products {
+		ids = append(ids, product.Id)
+	}
+	explicit, err := uc.CurrencyRepository.GetPricesIn(c.Code, ids)
+	if err != nil {
+		return err
+	}
+
+	rule := c.Rule()
+	for _, product := range products {
+		price, ok := explicit[product.Id]
+		if !ok {
+			price = rule.Convert(product.Price, c.Rate)
+		}
+		for _, variant := range product.Variants {
+			if variant.Price != nil {
+				converted := rule.Convert(*variant.Price, c.Rate)
+				variant.Price = &converted
+				variant.EffectivePrice = converted
+			} else {
+				variant.EffectivePrice = price
+			}
+		}
+		product.Price = price
+		product.Currency = c.Code
+	}
+	return nil
+}
+
+// ConvertOrders converts the totals and discounts of the orders to the currency
+func (uc *CurrencyInteractor) ConvertOrders(c *entities.Currency, orders ...*entities.Order) {
+	rule := c.Rule()
+	for _, order := range orders {
+		if !c.Base {
+			order.TotalPrice = rule.Convert(order.TotalPrice, c.Rate)
+			order.DiscountTotal = rule.Convert(order.DiscountTotal, c.Rate)
+			for _, discount := range order.Discounts {
+				discount.Amount = rule.Convert(discount.Amount, c.Rate)
+			}
+		}
+		order.Currency = c.Code
+	}
+}
+
+// ConvertQuote converts the prices, totals and discounts of a priced order to the currency
+func (uc *CurrencyInteractor) ConvertQuote(c *entities.Currency, quote *entities.OrderRequest) {
+	rule := c.Rule()
+	if !c.Base {
+		quote.Subtotal = rule.Convert(quote.Subtotal, c.Rate)
+		quote.TotalPrice = rule.Convert(quote.TotalPrice, c.Rate)
+		quote.DiscountTotal = rule.Convert(quote.DiscountTotal, c.Rate)
+		for _, discount := range quote.Discounts {
+			discount.Amount = rule.Convert(discount.Amount, c.Rate)
+		}
+		for i := range quote.OrderDetails {
+			detail := &quote.OrderDetails[i]
+			detail.UnitPrice = rule.Convert(detail.UnitPrice, c.Rate)
+			detail.TotalPrice = rule.Convert(detail.TotalPrice, c.Rate)
+			detail.Discount = rule.Convert(detail.Discount, c.Rate)
+		}
+	}
+	quote.Currency = c.Code
+}
+
+// fromRequest validates a currency and fills in the default rounding rule
+func (uc *CurrencyInteractor) fromRequest(code string, request *entities.CurrencyRequest) (*entities.Currency, error) {
+	code, err := currency.Normalize(code)
+	if err != nil {
+		return nil, errors.New(errors.ErrInvalidInput.Code, "The currency must be a three letter ISO 4217 code", err)
+	}
+	if code == uc.base().Code {
+		return nil, errors.New(errors.ErrInvalidInput.Code, "The rate of the base currency "+code+" is always 1", nil)
+	}
+	if request.Rate <= 0 {
+		return nil, errors.New(errors.ErrInvalidInput.Code, "The rate must be positive", nil)
+	}
+
+	c := &entities.Currency{Code: code, Rate: request.Rate, Decimals: 2, Rounding: currency.HalfUp, RoundingIncrement: request.RoundingIncrement}
+	if request.Decimals != nil {
+		if *request.Decimals < 0 || *request.Decimals > 4 {
+			return nil, errors.New(errors.ErrInvalidInput.Code, "The decimals must be between 0 and 4", nil)
+		}
+		c.Decimals = *request.Decimals
+	}
+	if request.Rounding != "" {
+		valid := false
+		for _, mode := range currency.Modes {
+			valid = valid || mode == request.Rounding
+		}
+		if !valid {
+			return nil, errors.New(errors.ErrInvalidInput.Code, "The rounding must be one of "+strings.Join(currency.Modes, ", "), nil)
+		}
+		c.Rounding = request.Rounding
+	}
+	if c.RoundingIncrement != nil && *c.RoundingIncrement <= 0 {
+		return nil, errors.New(errors.ErrInvalidInput.Code, "The rounding increment must be positive", nil)
+	}
+	return c, nil
+}
+
+// ratesLineError reports an invalid line of a rates file
+func ratesLineError(line int, msg string) error {
+	return errors.New(errors.ErrInvalidRatesFile.Code, "line "+strconv.Itoa(line)+": "+msg, nil)
+}
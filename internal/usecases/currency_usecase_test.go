@@ -0,0 +1,201 @@
+package usecases_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shayja/go-template-api/internal/entities"
+	appErrors "github.com/shayja/go-template-api/internal/errors"
+	"github.com/shayja/go-template-api/internal/usecases"
+	"github.com/shayja/go-template-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockCurrencyRepository mocks the CurrencyRepository interface
+type MockCurrencyRepository struct {
+	mock.Mock
+}
+
+func (m *MockCurrencyRepository) GetAll() ([]*entities.Currency, error) {
+	args := m.Called()
+	return args.Get(0).([]*entities.Currency), args.Error(1)
+}
+
+func (m *MockCurrencyRepository) Get(code string) (*entities.Currency, error) {
+	args := m.Called(code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Currency), args.Error(1)
+}
+
+func (m *MockCurrencyRepository) Save(currencies ...*entities.Currency) error {
+	args := m.Called(currencies)
+	return args.Error(0)
+}
+
+func (m *MockCurrencyRepository) Delete(code string) error {
+	args := m.Called(code)
+	return args.Error(0)
+}
+
+func (m *MockCurrencyRepository) GetProductPrices(productId string) (map[string]money.Amount, error) {
+	args := m.Called(productId)
+	return args.Get(0).(map[string]money.Amount), args.Error(1)
+}
+
+func (m *MockCurrencyRepository) GetPricesIn(code string, productIds []string) (map[string]money.Amount, error) {
+	args := m.Called(code, productIds)
+	return args.Get(0).(map[string]money.Amount), args.Error(1)
+}
+
+func (m *MockCurrencyRepository) SetProductPrices(productId string, prices map[string]money.Amount) error {
+	args := m.Called(productId, prices)
+	return args.Error(0)
+}
+
+func newCurrencyInteractor() (*usecases.CurrencyInteractor, *MockCurrencyRepository) {
+	repo := new(MockCurrencyRepository)
+	return &usecases.CurrencyInteractor{CurrencyRepository: repo, BaseCurrency: "usd"}, repo
+}
+
+func TestCurrencyInteractor_Resolve(t *testing.T) {
+	interactor, repo := newCurrencyInteractor()
+	eur := &entities.Currency{Code: "EUR", Rate: 0.92, Decimals: 2, Rounding: "half_up"}
+	repo.On("Get", "EUR").Return(eur, nil)
+	repo.On("Get", "XYZ").Return(nil, nil)
+
+	base, err := interactor.Resolve("")
+	assert.NoError(t, err)
+	assert.Equal(t, "USD", base.Code)
+	assert.True(t, base.Base)
+
+	base, err = interactor.Resolve("usd")
+	assert.NoError(t, err)
+	assert.True(t, base.Base)
+
+	found, err := interactor.Resolve("eur")
+	assert.NoError(t, err)
+	assert.Equal(t, eur, found)
+
+	_, err = interactor.Resolve("XYZ")
+	assert.ErrorIs(t, err, appErrors.ErrUnsupportedCurrency)
+	_, err = interactor.Resolve("euro")
+	assert.ErrorIs(t, err, appErrors.ErrUnsupportedCurrency)
+}
+
+func TestCurrencyInteractor_ConvertProducts(t *testing.T) {
+	interactor, repo := newCurrencyInteractor()
+	increment := money.New(0, 5)
+	chf := &entities.Currency{Code: "CHF", Rate: 0.8812, Decimals: 2, Rounding: "half_up", RoundingIncrement: &increment}
+
+	override := money.New(30, 0)
+	products := []*entities.Product{
+		{Id: "1", Price: money.New(10, 0), Variants: []*entities.ProductVariant{{EffectivePrice: money.New(10, 0)}, {Price: &override, EffectivePrice: override}}},
+		{Id: "2", Price: money.New(99, 90)},
+	}
+	repo.On("GetPricesIn", "CHF", []string{"1", "2"}).Return(map[string]money.Amount{"2": money.New(89, 0)}, nil)
+
+	assert.NoError(t, interactor.ConvertProducts(chf, products...))
+	assert.Equal(t, money.New(8, 80), products[0].Price)
+	assert.Equal(t, "CHF", products[0].Currency)
+	assert.Equal(t, money.New(8, 80), products[0].Variants[0].EffectivePrice)
+	assert.Equal(t, money.New(26, 45), *products[0].Variants[1].Price)
+	assert.Equal(t, money.New(26, 45), products[0].Variants[1].EffectivePrice)
+	assert.Equal(t, money.New(89, 0), products[1].Price)
+}
+
+func TestCurrencyInteractor_ConvertProducts_Base(t *testing.T) {
+	interactor, repo := newCurrencyInteractor()
+	base, _ := interactor.Resolve("")
+
+	product := &entities.Product{Id: "1", Price: money.New(10, 99)}
+	assert.NoError(t, interactor.ConvertProducts(base, product))
+	assert.Equal(t, money.New(10, 99), product.Price)
+	assert.Equal(t, "USD", product.Currency)
+	repo.AssertNotCalled(t, "GetPricesIn", mock.Anything, mock.Anything)
+}
+
+func TestCurrencyInteractor_ConvertOrders(t *testing.T) {
+	interactor, _ := newCurrencyInteractor()
+	jpy := &entities.Currency{Code: "JPY", Rate: 151.37, Decimals: 0, Rounding: "down"}
+
+	order := &entities.Order{Id: "1", TotalPrice: money.New(19, 99)}
+	interactor.ConvertOrders(jpy, order)
+	assert.Equal(t, money.New(3025, 0), order.TotalPrice)
+	assert.Equal(t, "JPY", order.Currency)
+}
+
+func TestCurrencyInteractor_ConvertQuote(t *testing.T) {
+	interactor, _ := newCurrencyInteractor()
+	eur := &entities.Currency{Code: "EUR", Rate: 0.92, Decimals: 2, Rounding: "half_up"}
+
+	quote := &entities.OrderRequest{
+		Subtotal: money.New(100, 0), DiscountTotal: money.New(15, 0), TotalPrice: money.New(85, 0),
+		Discounts:    []*entities.OrderDiscount{{Name: "Summer", Amount: money.New(15, 0)}},
+		OrderDetails: []entities.OrderDetail{{ProductId: "1", Quantity: 2, UnitPrice: money.New(50, 0), TotalPrice: money.New(100, 0), Discount: money.New(15, 0)}},
+	}
+	interactor.ConvertQuote(eur, quote)
+	assert.Equal(t, money.New(92, 0), quote.Subtotal)
+	assert.Equal(t, money.New(13, 80), quote.DiscountTotal)
+	assert.Equal(t, money.New(78, 20), quote.TotalPrice)
+	assert.Equal(t, money.New(13, 80), quote.Discounts[0].Amount)
+	assert.Equal(t, money.New(46, 0), quote.OrderDetails[0].UnitPrice)
+	assert.Equal(t, money.New(92, 0), quote.OrderDetails[0].TotalPrice)
+	assert.Equal(t, "EUR", quote.Currency)
+}
+
+func TestCurrencyInteractor_Save_BaseCurrency(t *testing.T) {
+	interactor, repo := newCurrencyInteractor()
+
+	_, err := interactor.Save("USD", &entities.CurrencyRequest{Rate: 2})
+	assert.ErrorContains(t, err, appErrors.ErrInvalidInput.Code)
+	repo.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestCurrencyInteractor_ImportRates(t *testing.T) {
+	interactor, repo := newCurrencyInteractor()
+	repo.On("Save", mock.Anything).Return(nil)
+
+	content := "Currency,Rate,Decimals,Rounding,Rounding_Increment\neur,0.92,,,\nJPY,151.2,0,half_even,\nCHF,0.88,2,,0.05\n"
+	currencies, err := interactor.ImportRates(strings.NewReader(content))
+	assert.NoError(t, err)
+	assert.Len(t, currencies, 3)
+	assert.Equal(t, "EUR", currencies[0].Code)
+	assert.Equal(t, 2, currencies[0].Decimals)
+	assert.Equal(t, "half_up", currencies[0].Rounding)
+	assert.Equal(t, 0, currencies[1].Decimals)
+	assert.Equal(t, money.New(0, 5), *currencies[2].RoundingIncrement)
+	repo.AssertNumberOfCalls(t, "Save", 1)
+}
+
+func TestCurrencyInteractor_ImportRates_Invalid(t *testing.T) {
+	interactor, repo := newCurrencyInteractor()
+
+	files := []string{
+		"code,price\nEUR,0.92\n",
+		"currency,rate\nEUR,abc\n",
+		"currency,rate\nEUR,0.92\neur,0.93\n",
+		"currency,rate,rounding\nEUR,0.92,sideways\n",
+		"currency,rate\n",
+	}
+	for _, content := range files {
+		_, err := interactor.ImportRates(strings.NewReader(content))
+		assert.ErrorContains(t, err, appErrors.ErrInvalidRatesFile.Code, content)
+	}
+	repo.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestCurrencyInteractor_SetProductPrices(t *testing.T) {
+	interactor, repo := newCurrencyInteractor()
+	repo.On("Get", "JPY").Return(&entities.Currency{Code: "JPY", Rate: 151.2, Decimals: 0, Rounding: "half_up"}, nil)
+	repo.On("SetProductPrices", "1", map[string]money.Amount{"JPY": money.New(1500, 0)}).Return(nil)
+
+	prices, err := interactor.SetProductPrices("1", &entities.ProductCurrencyPricesRequest{Prices: map[string]money.Amount{"jpy": money.New(1499, 60)}})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]money.Amount{"JPY": money.New(1500, 0)}, prices)
+
+	_, err = interactor.SetProductPrices("1", &entities.ProductCurrencyPricesRequest{Prices: map[string]money.Amount{"USD": money.New(10, 0)}})
+	assert.ErrorContains(t, err, appErrors.ErrInvalidInput.Code)
+}
@@ -0,0 +1,50 @@
+// usecases/inventory_usecase.go
+package usecases
+
+import (
+	"strings"
+
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/errors"
+)
+
+type InventoryRepository interface {
+	GetStock(productId string) (*entities.StockLevel, error)
+	GetLowStock() ([]*entities.StockLevel, error)
+	Adjust(productId string, userId string, adjustment *entities.StockAdjustmentRequest) (*entities.StockLevel, error)
+	SetThreshold(productId string, threshold int) (*entities.StockLevel, error)
+	GetMovements(productId string, list *entities.ListRequest) (*entities.List[*entities.StockMovement], error)
+}
+
+type InventoryInteractor struct {
+	InventoryRepository InventoryRepository
+}
+
+// GetStock returns the stock level of a product, nil when the product is not stock tracked
+func (uc *InventoryInteractor) GetStock(productId string) (*entities.StockLevel, error) {
+	return uc.InventoryRepository.GetStock(productId)
+}
+
+func (uc *InventoryInteractor) GetLowStock() ([]*entities.StockLevel, error) {
+	return uc.InventoryRepository.GetLowStock()
+}
+
+// Adjust adds (or removes, with a negative delta) stock, recording the reason in the ledger
+func (uc *InventoryInteractor) Adjust(productId string, userId string, adjustment *entities.StockAdjustmentRequest) (*entities.StockLevel, error) {
+	adjustment.Reason = strings.TrimSpace(adjustment.Reason)
+	if adjustment.Delta == 0 || adjustment.Reason == "" {
+		return nil, errors.ErrInvalidInput
+	}
+	return uc.InventoryRepository.Adjust(productId, userId, adjustment)
+}
+
+func (uc *InventoryInteractor) SetThreshold(productId string, request *entities.StockThresholdRequest) (*entities.StockLevel, error) {
+	if request.LowStockThreshold < 0 {
+		return nil, errors.ErrInvalidInput
+	}
+	return uc.InventoryRepository.SetThreshold(productId, request.LowStockThreshold)
+}
+
+func (uc *InventoryInteractor) GetMovements(productId string, list *entities.ListRequest) (*entities.List[*entities.StockMovement], error) {
+	return uc.InventoryRepository.GetMovements(productId, list)
+}
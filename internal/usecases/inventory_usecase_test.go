@@ -0,0 +1,112 @@
+package usecases_test
+
+import (
+	"testing"
+
+	"github.com/shayja/go-template-api/internal/entities"
+	appErrors "github.com/shayja/go-template-api/internal/errors"
+	"github.com/shayja/go-template-api/internal/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockInventoryRepository mocks the InventoryRepository interface
+type MockInventoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockInventoryRepository) GetStock(productId string) (*entities.StockLevel, error) {
+	args := m.Called(productId)
+	if level, ok := args.Get(0).(*entities.StockLevel); ok {
+		return level, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockInventoryRepository) GetLowStock() ([]*entities.StockLevel, error) {
+	args := m.Called()
+	if levels, ok := args.Get(0).([]*entities.StockLevel); ok {
+		return levels, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockInventoryRepository) Adjust(productId string, userId string, adjustment *entities.StockAdjustmentRequest) (*entities.StockLevel, error) {
+	args := m.Called(productId, userId, adjustment)
+	if level, ok := args.Get(0).(*entities.StockLevel); ok {
+		return level, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockInventoryRepository) SetThreshold(productId string, threshold int) (*entities.StockLevel, error) {
+	args := m.Called(productId, threshold)
+	if level, ok := args.Get(0).(*entities.StockLevel); ok {
+		return level, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockInventoryRepository) GetMovements(productId string, list *entities.ListRequest) (*entities.List[*entities.StockMovement], error) {
+	args := m.Called(productId, list)
+	if movements, ok := args.Get(0).(*entities.List[*entities.StockMovement]); ok {
+		return movements, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func TestInventoryInteractor_Adjust(t *testing.T) {
+	repo := new(MockInventoryRepository)
+	interactor := &usecases.InventoryInteractor{InventoryRepository: repo}
+
+	level := &entities.StockLevel{ProductId: "1", Quantity: 20}
+	repo.On("Adjust", "1", "admin", mock.MatchedBy(func(a *entities.StockAdjustmentRequest) bool {
+		return a.Delta == 20 && a.Reason == "Received shipment"
+	})).Return(level, nil)
+
+	result, err := interactor.Adjust("1", "admin", &entities.StockAdjustmentRequest{Delta: 20, Reason: " Received shipment "})
+
+	assert.NoError(t, err)
+	assert.Equal(t, level, result)
+	repo.AssertExpectations(t)
+}
+
+func TestInventoryInteractor_Adjust_RequiresReason(t *testing.T) {
+	repo := new(MockInventoryRepository)
+	interactor := &usecases.InventoryInteractor{InventoryRepository: repo}
+
+	_, err := interactor.Adjust("1", "admin", &entities.StockAdjustmentRequest{Delta: 5, Reason: "  "})
+	assert.ErrorIs(t, err, appErrors.ErrInvalidInput)
+
+	_, err = interactor.Adjust("1", "admin", &entities.StockAdjustmentRequest{Delta: 0, Reason: "Count"})
+	assert.ErrorIs(t, err, appErrors.ErrInvalidInput)
+
+	repo.AssertNotCalled(t, "Adjust", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestInventoryInteractor_Adjust_OutOfStock(t *testing.T) {
+	repo := new(MockInventoryRepository)
+	interactor := &usecases.InventoryInteractor{InventoryRepository: repo}
+
+	repo.On("Adjust", "1", "admin", mock.Anything).Return(nil, appErrors.ErrOutOfStock)
+
+	_, err := interactor.Adjust("1", "admin", &entities.StockAdjustmentRequest{Delta: -50, Reason: "Damaged"})
+
+	assert.ErrorIs(t, err, appErrors.ErrOutOfStock)
+}
+
+func TestOrderUsecase_Create_InvalidQuantity(t *testing.T) {
+	usecase := &usecases.OrderUsecase{}
+
+	_, err := usecase.Create(&entities.OrderRequest{OrderDetails: []entities.OrderDetail{{ProductId: "1", Quantity: -3}}})
+
+	assert.ErrorIs(t, err, appErrors.ErrInvalidInput)
+}
+
+func TestOrderUsecase_UpdateStatus_Invalid(t *testing.T) {
+	usecase := &usecases.OrderUsecase{}
+
+	_, err := usecase.UpdateStatus("1", 9, "u1", true)
+
+	assert.ErrorIs(t, err, appErrors.ErrInvalidInput)
+}
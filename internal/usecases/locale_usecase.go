@@ -0,0 +1,282 @@
+// usecases/locale_usecase.go
+package usecases
+
+import (
+	"strings"
+
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/errors"
+	"golang.org/x/text/language"
+)
+
+// DefaultLocale is the locale of the product and category content when none is configured
+const DefaultLocale = "en"
+
+// TranslationRepository keeps the product and category content in the other locales.
+type TranslationRepository interface {
+	GetTranslations(kind string, id string) ([]*entities.Translation, error)
+	SaveTranslation(kind string, id string, locale string, request *entities.TranslationRequest) (*entities.Translation, error)
+	DeleteTranslation(kind string, id string, locale string) error
+	// FindTranslations returns the translations of the ids in the locales, by id and locale
+	FindTranslations(kind string, ids []string, locales []string) (map[string]map[string]*entities.Translation, error)
+}
+
+type LocaleInteractor struct {
+	TranslationRepository TranslationRepository
+	// Optional, the locale of the content stored on the products and categories, defaults to DefaultLocale
+	DefaultLocale string
+	// Optional, the locales content can be translated to and served in, any locale when empty
+	SupportedLocales []string
+}
+
+// ParseLocale returns the canonical form of a BCP 47 language tag, e.g. pt-br is pt-BR
+func ParseLocale(s string) (string, error) {
+	tag, err := language.Parse(strings.TrimSpace(s))
+	if err != nil || tag == language.Und {
+		return "", errors.ErrInvalidLocale
+	}
+	return tag.String(), nil
+}
+
+// ParseLocales parses comma separated language tags, e.g. en,fr,fr-CA. An empty string returns nil
+func ParseLocales(s string) ([]string, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	var locales []string
+	for _, field := range strings.Split(s, ",") {
+		locale, err := ParseLocale(field)
+		if err != nil {
+			return nil, err
+		}
+		locales = append(locales, locale)
+	}
+	return locales, nil
+}
+
+func (uc *LocaleInteractor) defaultLocale() string {
+	locale, err := ParseLocale(uc.DefaultLocale)
+	if err != nil {
+		return DefaultLocale
+	}
+	return locale
+}
+
+func (uc *LocaleInteractor) supported(locale string) bool {
+	if len(uc.SupportedLocales) == 0 || locale == uc.defaultLocale() {
+		return true
+	}
+	for _, supported := range uc.SupportedLocales {
+		if strings.EqualFold(supported, locale) {
+			return true
+		}
+	}
+	return false
+}
+
+// Negotiate returns the locales to serve the content in, most preferred first and ending with the
+// default locale. The locale parameter takes precedence over the Accept-Language header, and each
+// locale falls back to its less specific forms, e.g. fr-CA to fr. Unsupported locales are left
+// out, a locale that is not a language tag fails with ErrInvalidLocale
+func (uc *LocaleInteractor) Negotiate(locale string, acceptLanguage string) ([]string, error) {
+	var requested []string
+	if strings.TrimSpace(locale) != "" {
+		parsed, err := ParseLocale(locale)
+		if err != nil {
+			return nil, err
+		}
+		requested = append(requested, parsed)
+	} else {
+		// A malformed header is served the default locale rather than failing the request
+		tags, _, _ := language.ParseAcceptLanguage(acceptLanguage)
+		for _, tag := range tags {
+			if tag != language.Und {
+				requested = append(requested, tag.String())
+			}
+		}
+	}
+
+	var chain []string
+	seen := make(map[string]bool)
+	for _, locale := range append(requested, uc.defaultLocale()) {
+		for ; locale != ""; locale = parentLocale(locale) {
+			if !seen[locale] && uc.supported(locale) {
+				seen[locale] = true
+				chain = append(chain, locale)
+			}
+		}
+	}
+	return chain, nil
+}
+
+// parentLocale drops the last subtag of a locale, empty for a bare language
+func parentLocale(locale string) string {
+	i := strings.LastIndex(locale, "-")
+	if i < 0 {
+		return ""
+	}
+	return locale[:i]
+}
+
+// translated returns the locales of a chain that are looked up in the translations, the default
+// locale and the ones after it are served from the products and categories themselves
+func (uc *LocaleInteractor) translated(chain []string) []string {
+	defaultLocale := uc.defaultLocale()
+	for i, locale := range chain {
+		if locale == defaultLocale {
+			return chain[:i]
+		}
+	}
+	return chain
+}
+
+// pick returns the first translation in the locales and its locale, nil when there is none
+func pick(locales []string, translations map[string]*entities.Translation) (*entities.Translation, string) {
+	for _, locale := range locales {
+		if translation, ok := translations[locale]; ok {
+			return translation, locale
+		}
+	}
+	return nil, ""
+}
+
+// LocalizeProducts serves the names, descriptions and breadcrumbs of the products in the first
+// locale of the chain each was translated to, setting the locale of the products to it
+func (uc *LocaleInteractor) LocalizeProducts(chain []string, products ...*entities.Product) error {
+	defaultLocale := uc.defaultLocale()
+	locales := uc.translated(chain)
+	for _, product := range products {
+		product.Locale = defaultLocale
+	}
+	if len(locales) == 0 || len(products) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(products))
+	var categoryIds []string
+	for i, product := range products {
+		ids[i] = product.Id
+		for _, path := range product.Breadcrumbs {
+			for _, crumb := range path {
+				categoryIds = append(categoryIds, crumb.Id)
+			}
+		}
+	}
+	translations, err := uc.TranslationRepository.FindTranslations(entities.TranslationProduct, ids, locales)
+	if err != nil {
+		return err
+	}
+	for _, product := range products {
+		if translation, locale := pick(locales, translations[product.Id]); translation != nil {
+			product.Name = translation.Name
+			if translation.Description != "" {
+				product.Description = translation.Description
+			}
+			product.Locale = locale
+		}
+	}
+
+	if len(categoryIds) == 0 {
+		return nil
+	}
+	names, err := uc.TranslationRepository.FindTranslations(entities.TranslationCategory, categoryIds, locales)
+	if err != nil {
+		return err
+	}
+	for _, product := range products {
+		for _, path := range product.Breadcrumbs {
+			for i := range path {
+				if translation, _ := pick(locales, names[path[i].Id]); translation != nil {
+					path[i].Name = translation.Name
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// LocalizeCategories serves the names of the categories and their subcategories in the first locale
+// of the chain each was translated to, setting the locale of the categories to it
+func (uc *LocaleInteractor) LocalizeCategories(chain []string, categories ...*entities.Category) error {
+	var all []*entities.Category
+	var walk func(categories []*entities.Category)
+	walk = func(categories []*entities.Category) {
+		for _, category := range categories {
+			all = append(all, category)
+			walk(category.Children)
+		}
+	}
+	walk(categories)
+
+	defaultLocale := uc.defaultLocale()
+	locales := uc.translated(chain)
+	for _, category := range all {
+		category.Locale = defaultLocale
+	}
+	if len(locales) == 0 || len(all) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(all))
+	for i, category := range all {
+		ids[i] = category.Id
+	}
+	translations, err := uc.TranslationRepository.FindTranslations(entities.TranslationCategory, ids, locales)
+	if err != nil {
+		return err
+	}
+	for _, category := range all {
+		if translation, locale := pick(locales, translations[category.Id]); translation != nil {
+			category.Name = translation.Name
+			category.Locale = locale
+		}
+	}
+	return nil
+}
+
+// GetTranslations returns the translations of a product or category, by locale
+func (uc *LocaleInteractor) GetTranslations(kind string, id string) ([]*entities.Translation, error) {
+	return uc.TranslationRepository.GetTranslations(kind, id)
+}
+
+// SaveTranslation adds or replaces the content of a product or category in a supported locale other
+// than the default one, which is the content of the product or category itself
+func (uc *LocaleInteractor) SaveTranslation(kind string, id string, locale string, request *entities.TranslationRequest) (*entities.Translation, error) {
+	locale, err := uc.translationLocale(locale)
+	if err != nil {
+		return nil, err
+	}
+	request.Name = strings.TrimSpace(request.Name)
+	request.Description = strings.TrimSpace(request.Description)
+	if request.Name == "" {
+		return nil, errors.New(errors.ErrInvalidInput.Code, "The name cannot be blank", nil)
+	}
+	if kind == entities.TranslationCategory && request.Description != "" {
+		return nil, errors.New(errors.ErrInvalidInput.Code, "Categories have no description", nil)
+	}
+	return uc.TranslationRepository.SaveTranslation(kind, id, locale, request)
+}
+
+// DeleteTranslation removes the content of a product or category in a locale, the next locale of
+// the fallback chain is served instead
+func (uc *LocaleInteractor) DeleteTranslation(kind string, id string, locale string) error {
+	locale, err := ParseLocale(locale)
+	if err != nil {
+		return err
+	}
+	return uc.TranslationRepository.DeleteTranslation(kind, id, locale)
+}
+
+func (uc *LocaleInteractor) translationLocale(locale string) (string, error) {
+	locale, err := ParseLocale(locale)
+	if err != nil {
+		return "", err
+	}
+	if locale == uc.defaultLocale() {
+		return "", errors.New(errors.ErrInvalidLocale.Code, "The content in the default locale is edited on the product or category itself", nil)
+	}
+	if !uc.supported(locale) {
+		return "", errors.New(errors.ErrInvalidLocale.Code, "The locale is not supported", nil)
+	}
+	return locale, nil
+}
@@ -0,0 +1,150 @@
+package usecases_test
+
+import (
+	"testing"
+
+	"github.com/shayja/go-template-api/internal/entities"
+	appErrors "github.com/shayja/go-template-api/internal/errors"
+	"github.com/shayja/go-template-api/internal/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTranslationRepository mocks the TranslationRepository interface
+type MockTranslationRepository struct {
+	mock.Mock
+}
+
+func (m *MockTranslationRepository) GetTranslations(kind string, id string) ([]*entities.Translation, error) {
+	args := m.Called(kind, id)
+	return args.Get(0).([]*entities.Translation), args.Error(1)
+}
+
+func (m *MockTranslationRepository) SaveTranslation(kind string, id string, locale string, request *entities.TranslationRequest) (*entities.Translation, error) {
+	args := m.Called(kind, id, locale, request)
+	if translation, ok := args.Get(0).(*entities.Translation); ok {
+		return translation, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockTranslationRepository) DeleteTranslation(kind string, id string, locale string) error {
+	return m.Called(kind, id, locale).Error(0)
+}
+
+func (m *MockTranslationRepository) FindTranslations(kind string, ids []string, locales []string) (map[string]map[string]*entities.Translation, error) {
+	args := m.Called(kind, ids, locales)
+	return args.Get(0).(map[string]map[string]*entities.Translation), args.Error(1)
+}
+
+func TestLocaleInteractor_Negotiate(t *testing.T) {
+	uc := &usecases.LocaleInteractor{}
+	tests := []struct {
+		locale string
+		header string
+		want   []string
+	}{
+		{"", "", []string{"en"}},
+		{"", "fr-CA, de;q=0.5, en;q=0.8", []string{"fr-CA", "fr", "en", "de"}},
+		{"", "pt-br", []string{"pt-BR", "pt", "en"}},
+		{"", "es;q=0, it", []string{"it", "en"}},
+		{"", "not a header!!", []string{"en"}},
+		// The parameter wins over the header
+		{"de-AT", "fr", []string{"de-AT", "de", "en"}},
+	}
+	for _, test := range tests {
+		chain, err := uc.Negotiate(test.locale, test.header)
+		assert.NoError(t, err)
+		assert.Equal(t, test.want, chain, test.header)
+	}
+
+	_, err := uc.Negotiate("??", "")
+	assert.Equal(t, appErrors.ErrInvalidLocale, err)
+}
+
+func TestLocaleInteractor_Negotiate_Supported(t *testing.T) {
+	uc := &usecases.LocaleInteractor{DefaultLocale: "fr", SupportedLocales: []string{"de", "en-GB"}}
+
+	chain, err := uc.Negotiate("", "de-CH, en-GB;q=0.9, es;q=0.8")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"de", "en-GB", "fr"}, chain)
+}
+
+func TestLocaleInteractor_LocalizeProducts(t *testing.T) {
+	repo := new(MockTranslationRepository)
+	uc := &usecases.LocaleInteractor{TranslationRepository: repo}
+	shoe := &entities.Product{Id: "1", Name: "Running shoe", Description: "Light road shoe",
+		Breadcrumbs: [][]entities.CategoryCrumb{{{Id: "c1", Name: "Shoes"}, {Id: "c2", Name: "Running"}}}}
+	hat := &entities.Product{Id: "2", Name: "Hat", Description: "A hat"}
+	sock := &entities.Product{Id: "3", Name: "Sock", Description: "A sock"}
+
+	repo.On("FindTranslations", entities.TranslationProduct, []string{"1", "2", "3"}, []string{"fr-CA", "fr"}).Return(map[string]map[string]*entities.Translation{
+		"1": {"fr": {Locale: "fr", Name: "Chaussure de course"}, "fr-CA": {Locale: "fr-CA", Name: "Soulier de course"}},
+		"2": {"fr": {Locale: "fr", Name: "Chapeau", Description: "Un chapeau"}},
+	}, nil)
+	repo.On("FindTranslations", entities.TranslationCategory, []string{"c1", "c2"}, []string{"fr-CA", "fr"}).Return(map[string]map[string]*entities.Translation{
+		"c1": {"fr": {Locale: "fr", Name: "Chaussures"}},
+	}, nil)
+
+	chain, _ := uc.Negotiate("fr-CA", "")
+	assert.NoError(t, uc.LocalizeProducts(chain, shoe, hat, sock))
+	// An empty translated description falls back to the default locale
+	assert.Equal(t, "Soulier de course", shoe.Name)
+	assert.Equal(t, "Light road shoe", shoe.Description)
+	assert.Equal(t, "fr-CA", shoe.Locale)
+	assert.Equal(t, "Chaussures", shoe.Breadcrumbs[0][0].Name)
+	assert.Equal(t, "Running", shoe.Breadcrumbs[0][1].Name)
+	assert.Equal(t, "Un chapeau", hat.Description)
+	assert.Equal(t, "fr", hat.Locale)
+	assert.Equal(t, "Sock", sock.Name)
+	assert.Equal(t, "en", sock.Locale)
+}
+
+func TestLocaleInteractor_LocalizeProducts_DefaultLocale(t *testing.T) {
+	repo := new(MockTranslationRepository)
+	uc := &usecases.LocaleInteractor{TranslationRepository: repo}
+	product := &entities.Product{Id: "1", Name: "Hat"}
+
+	// The products hold the content of the default locale, which is preferred over de
+	chain, _ := uc.Negotiate("", "en, de;q=0.5")
+	assert.NoError(t, uc.LocalizeProducts(chain, product))
+	assert.Equal(t, "en", product.Locale)
+	repo.AssertNotCalled(t, "FindTranslations", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestLocaleInteractor_LocalizeCategories(t *testing.T) {
+	repo := new(MockTranslationRepository)
+	uc := &usecases.LocaleInteractor{TranslationRepository: repo}
+	running := &entities.Category{Id: "c2", Name: "Running"}
+	shoes := &entities.Category{Id: "c1", Name: "Shoes", Children: []*entities.Category{running}}
+
+	repo.On("FindTranslations", entities.TranslationCategory, []string{"c1", "c2"}, []string{"de"}).Return(map[string]map[string]*entities.Translation{
+		"c2": {"de": {Locale: "de", Name: "Laufen"}},
+	}, nil)
+
+	chain, _ := uc.Negotiate("de", "")
+	assert.NoError(t, uc.LocalizeCategories(chain, shoes))
+	assert.Equal(t, "Shoes", shoes.Name)
+	assert.Equal(t, "en", shoes.Locale)
+	assert.Equal(t, "Laufen", running.Name)
+	assert.Equal(t, "de", running.Locale)
+}
+
+func TestLocaleInteractor_SaveTranslation(t *testing.T) {
+	repo := new(MockTranslationRepository)
+	uc := &usecases.LocaleInteractor{TranslationRepository: repo, SupportedLocales: []string{"fr", "pt-BR"}}
+	saved := &entities.Translation{Locale: "pt-BR", Name: "Chapéu"}
+	repo.On("SaveTranslation", entities.TranslationProduct, "1", "pt-BR", &entities.TranslationRequest{Name: "Chapéu"}).Return(saved, nil)
+
+	translation, err := uc.SaveTranslation(entities.TranslationProduct, "1", "pt-br", &entities.TranslationRequest{Name: " Chapéu "})
+	assert.NoError(t, err)
+	assert.Equal(t, saved, translation)
+
+	for _, locale := range []string{"en", "de", "??"} {
+		_, err := uc.SaveTranslation(entities.TranslationProduct, "1", locale, &entities.TranslationRequest{Name: "Hut"})
+		assert.ErrorContains(t, err, appErrors.ErrInvalidLocale.Code, locale)
+	}
+	_, err = uc.SaveTranslation(entities.TranslationCategory, "c1", "fr", &entities.TranslationRequest{Name: "Chaussures", Description: "Toutes"})
+	assert.ErrorContains(t, err, appErrors.ErrInvalidInput.Code)
+	repo.AssertNumberOfCalls(t, "SaveTranslation", 1)
+}
@@ -3,21 +3,32 @@ package usecases
 
 import (
 	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/errors"
+	"github.com/shayja/go-template-api/pkg/constants"
+	"github.com/shayja/go-template-api/pkg/money"
 )
 
 type OrderRepository interface {
-	GetAllOrders(page int, userId string) ([]*entities.Order, error)
+	GetAllOrders(list *entities.ListRequest, userId string) (*entities.List[*entities.Order], error)
 	GetById(id string) (*entities.Order, error)
 	Create(orderRequest *entities.OrderRequest) (string, error)
+	// GetPrices returns the catalog prices of the active products, by product id
+	GetPrices(productIds []string) (map[string]money.Amount, error)
 	UpdateStatus(id string, status int) (*entities.Order, error)
 }
 
 type OrderUsecase struct {
 	OrderRepo OrderRepository
+	// Optional, validates and prices the variants selected in the order line items
+	VariantRepo VariantRepository
+	// Optional, checks that the components of the ordered bundles are available
+	BundleRepo BundleRepository
+	// Optional, applies the running promotions and the coupon codes to the new orders
+	Promotions *PromotionInteractor
 }
 
-func (uc *OrderUsecase) GetOrders(page int, userId string) ([]*entities.Order, error) {
-	return uc.OrderRepo.GetAllOrders(page, userId)
+func (uc *OrderUsecase) GetOrders(list *entities.ListRequest, userId string) (*entities.List[*entities.Order], error) {
+	return uc.OrderRepo.GetAllOrders(list, userId)
 }
 
 func (uc *OrderUsecase) GetById(id string) (*entities.Order, error) {
@@ -25,9 +36,153 @@ func (uc *OrderUsecase) GetById(id string) (*entities.Order, error) {
 }
 
 func (uc *OrderUsecase) Create(orderRequest *entities.OrderRequest) (string, error) {
+	if err := uc.price(orderRequest); err != nil {
+		return "", err
+	}
 	return uc.OrderRepo.Create(orderRequest)
 }
 
-func (uc *OrderUsecase) UpdateStatus(id string, status int) (*entities.Order, error) {
+// Quote prices an order without placing it, with the discounts it would get
+func (uc *OrderUsecase) Quote(orderRequest *entities.OrderRequest) (*entities.OrderRequest, error) {
+	if err := uc.price(orderRequest); err != nil {
+		return nil, err
+	}
+	return orderRequest, nil
+}
+
+// price validates the line items and totals an order, less the discounts of the promotions
+func (uc *OrderUsecase) price(orderRequest *entities.OrderRequest) error {
+	// Non positive quantities would put stock back instead of reserving it
+	for _, detail := range orderRequest.OrderDetails {
+		if detail.Quantity < 1 {
+			return errors.ErrInvalidInput
+		}
+	}
+	if err := uc.priceLines(orderRequest.OrderDetails); err != nil {
+		return err
+	}
+	if err := uc.validateBundles(orderRequest.OrderDetails); err != nil {
+		return err
+	}
+	if err := orderTotal(orderRequest); err != nil {
+		return err
+	}
+	if uc.Promotions == nil {
+		if orderRequest.CouponCode != "" {
+			return errors.ErrInvalidCoupon
+		}
+		return nil
+	}
+	return uc.Promotions.Apply(orderRequest)
+}
+
+// UpdateStatus moves the order to the status. Admins may set any status, a customer may only cancel
+// their own order while it is pending
+func (uc *OrderUsecase) UpdateStatus(id string, status int, userId string, admin bool) (*entities.Order, error) {
+	if status < constants.OrderStatusCreated || status > constants.OrderStatusCancelled {
+		return nil, errors.ErrInvalidInput
+	}
+	if !admin {
+		order, err := uc.OrderRepo.GetById(id)
+		if err != nil {
+			return nil, err
+		}
+		// Other users' orders are reported missing rather than forbidden
+		if order == nil || order.Id == "" || order.UserId != userId {
+			return nil, errors.ErrOrderNotFound
+		}
+		if status != constants.OrderStatusCancelled || order.Status != constants.OrderStatusCreated {
+			return nil, errors.ErrOrderStatusForbidden
+		}
+	}
 	return uc.OrderRepo.UpdateStatus(id, status)
-}
\ No newline at end of file
+}
+
+// priceLines sets the unit price of every line item on the server, the unit prices sent by the client
+// are ignored. A line item with a variant is priced at the variant price, which falls back to the
+// product price, and its variant must belong to the product and be available. The others are priced
+// at the catalog price of their product, unknown and soft deleted products are not found
+func (uc *OrderUsecase) priceLines(details []entities.OrderDetail) error {
+	if len(details) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(details))
+	for _, detail := range details {
+		ids = append(ids, detail.ProductId)
+	}
+	prices, err := uc.OrderRepo.GetPrices(ids)
+	if err != nil {
+		return err
+	}
+	for i := range details {
+		detail := &details[i]
+		price, ok := prices[detail.ProductId]
+		if !ok {
+			return errors.ErrProductNotFound
+		}
+		detail.UnitPrice = price
+		if detail.VariantId == nil || uc.VariantRepo == nil {
+			continue
+		}
+		variant, err := uc.VariantRepo.GetById(*detail.VariantId)
+		if err != nil {
+			return err
+		}
+		if variant.ProductId != detail.ProductId {
+			return errors.ErrVariantNotFound
+		}
+		if !variant.Available {
+			return errors.ErrVariantUnavailable
+		}
+		detail.UnitPrice = variant.EffectivePrice
+	}
+	return nil
+}
+
+// orderTotal sums the line items into the order total, the unit prices rounded to the cents the
+// line items are stored in. A total sent by the client must match it
+func orderTotal(orderRequest *entities.OrderRequest) error {
+	var sum money.Amount
+	for i := range orderRequest.OrderDetails {
+		detail := &orderRequest.OrderDetails[i]
+		if detail.UnitPrice < 0 {
+			return errors.ErrInvalidInput
+		}
+		detail.UnitPrice = detail.UnitPrice.Round(2, money.HalfUp)
+		detail.TotalPrice = detail.Total()
+		sum = sum.Add(detail.TotalPrice)
+	}
+	if orderRequest.TotalPrice != 0 && orderRequest.TotalPrice.Round(2, money.HalfUp) != sum {
+		return errors.ErrOrderTotalMismatch
+	}
+	orderRequest.Subtotal = sum
+	orderRequest.TotalPrice = sum
+	return nil
+}
+
+// validateBundles checks that the components of every ordered bundle are active and in stock for the
+// ordered quantity, and prices a discounted bundle from its components, the others keep the price of
+// the bundle product. The order lists the components of a bundle under it at no price when it is placed
+func (uc *OrderUsecase) validateBundles(details []entities.OrderDetail) error {
+	if uc.BundleRepo == nil {
+		return nil
+	}
+	for i := range details {
+		detail := &details[i]
+		bundle, err := uc.BundleRepo.GetBundle(detail.ProductId)
+		if err != nil {
+			return err
+		}
+		if bundle == nil {
+			continue
+		}
+		deriveAvailability(bundle)
+		if !bundle.Available || (bundle.AvailableQuantity != nil && *bundle.AvailableQuantity < detail.Quantity) {
+			return errors.ErrBundleUnavailable
+		}
+		if price := bundle.DiscountedPrice(); price != nil {
+			detail.UnitPrice = *price
+		}
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package usecases_test
+
+import (
+	"testing"
+
+	"github.com/shayja/go-template-api/internal/entities"
+	appErrors "github.com/shayja/go-template-api/internal/errors"
+	"github.com/shayja/go-template-api/internal/usecases"
+	"github.com/shayja/go-template-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockOrderRepository mocks the OrderRepository interface
+type MockOrderRepository struct {
+	mock.Mock
+}
+
+func (m *MockOrderRepository) GetAllOrders(list *entities.ListRequest, userId string) (*entities.List[*entities.Order], error) {
+	args := m.Called(list, userId)
+	return args.Get(0).(*entities.List[*entities.Order]), args.Error(1)
+}
+
+func (m *MockOrderRepository) GetById(id string) (*entities.Order, error) {
+	args := m.Called(id)
+	return args.Get(0).(*entities.Order), args.Error(1)
+}
+
+func (m *MockOrderRepository) Create(orderRequest *entities.OrderRequest) (string, error) {
+	args := m.Called(orderRequest)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockOrderRepository) GetPrices(productIds []string) (map[string]money.Amount, error) {
+	args := m.Called(productIds)
+	return args.Get(0).(map[string]money.Amount), args.Error(1)
+}
+
+func (m *MockOrderRepository) UpdateStatus(id string, status int) (*entities.Order, error) {
+	args := m.Called(id, status)
+	return args.Get(0).(*entities.Order), args.Error(1)
+}
+
+func orderLine(productId string, quantity int, unitPrice string) entities.OrderDetail {
+	price, _ := money.Parse(unitPrice)
+	return entities.OrderDetail{ProductId: productId, Quantity: quantity, UnitPrice: price}
+}
+
+// catalogOf returns the unit prices of the line items as the catalog prices of their products
+func catalogOf(lines ...entities.OrderDetail) map[string]money.Amount {
+	prices := make(map[string]money.Amount)
+	for _, line := range lines {
+		prices[line.ProductId] = line.UnitPrice
+	}
+	return prices
+}
+
+func TestOrderUsecase_Create_Total(t *testing.T) {
+	repo := new(MockOrderRepository)
+	uc := &usecases.OrderUsecase{OrderRepo: repo}
+	repo.On("Create", mock.Anything).Return("1", nil)
+
+	// Added up unit by unit as float64 these lines come to 1.5999999999999999
+	request := &entities.OrderRequest{OrderDetails: []entities.OrderDetail{
+		orderLine("a", 3, "0.1"),
+		orderLine("b", 3, "0.2"),
+		orderLine("c", 1, "0.7"),
+	}}
+	repo.On("GetPrices", []string{"a", "b", "c"}).Return(catalogOf(request.OrderDetails...), nil)
+	_, err := uc.Create(request)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.60", request.TotalPrice.String())
+	assert.Equal(t, "0.60", request.OrderDetails[1].TotalPrice.String())
+}
+
+func TestOrderUsecase_Create_RoundsUnitPrices(t *testing.T) {
+	repo := new(MockOrderRepository)
+	uc := &usecases.OrderUsecase{OrderRepo: repo}
+	repo.On("Create", mock.Anything).Return("1", nil)
+
+	// The line items store cents, so the total is of the rounded unit prices
+	request := &entities.OrderRequest{TotalPrice: money.New(30, 3), OrderDetails: []entities.OrderDetail{
+		orderLine("a", 3, "10.005"),
+	}}
+	repo.On("GetPrices", []string{"a"}).Return(catalogOf(request.OrderDetails...), nil)
+	_, err := uc.Create(request)
+	assert.NoError(t, err)
+	assert.Equal(t, "10.01", request.OrderDetails[0].UnitPrice.String())
+	assert.Equal(t, money.New(30, 3), request.TotalPrice)
+}
+
+func TestOrderUsecase_Create_TotalMismatch(t *testing.T) {
+	repo := new(MockOrderRepository)
+	uc := &usecases.OrderUsecase{OrderRepo: repo}
+
+	request := &entities.OrderRequest{TotalPrice: money.New(100, 0), OrderDetails: []entities.OrderDetail{
+		orderLine("a", 2, "33.33"),
+	}}
+	repo.On("GetPrices", []string{"a"}).Return(catalogOf(request.OrderDetails...), nil)
+	_, err := uc.Create(request)
+	assert.ErrorIs(t, err, appErrors.ErrOrderTotalMismatch)
+	repo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestOrderUsecase_Create_VariantPrice(t *testing.T) {
+	repo := new(MockOrderRepository)
+	variants := new(MockVariantRepository)
+	uc := &usecases.OrderUsecase{OrderRepo: repo, VariantRepo: variants}
+	override := money.New(24, 99)
+	repo.On("Create", mock.Anything).Return("1", nil)
+	repo.On("GetPrices", []string{"a", "a", "b"}).Return(catalogOf(orderLine("a", 1, "19.99"), orderLine("b", 1, "5")), nil)
+	variants.On("GetById", "xl").Return(&entities.ProductVariant{Id: "xl", ProductId: "a", Price: &override, EffectivePrice: override, Available: true}, nil)
+	variants.On("GetById", "s").Return(&entities.ProductVariant{Id: "s", ProductId: "a", EffectivePrice: money.New(19, 99), Available: true}, nil)
+
+	// Every line is priced on the server, the variant lines at the overridden or the product price
+	xl, s := "xl", "s"
+	request := &entities.OrderRequest{OrderDetails: []entities.OrderDetail{orderLine("a", 2, "0.01"), orderLine("a", 1, "0.01"), orderLine("b", 1, "0.01")}}
+	request.OrderDetails[0].VariantId = &xl
+	request.OrderDetails[1].VariantId = &s
+	_, err := uc.Create(request)
+	assert.NoError(t, err)
+	assert.Equal(t, "24.99", request.OrderDetails[0].UnitPrice.String())
+	assert.Equal(t, "19.99", request.OrderDetails[1].UnitPrice.String())
+	assert.Equal(t, "5.00", request.OrderDetails[2].UnitPrice.String())
+	assert.Equal(t, "74.97", request.TotalPrice.String())
+}
+
+func TestOrderUsecase_Create_CatalogPrices(t *testing.T) {
+	repo := new(MockOrderRepository)
+	uc := &usecases.OrderUsecase{OrderRepo: repo}
+	repo.On("Create", mock.Anything).Return("1", nil)
+	repo.On("GetPrices", []string{"a", "b"}).Return(catalogOf(orderLine("a", 1, "50"), orderLine("b", 1, "9.99")), nil)
+
+	// The unit prices sent are ignored, the lines are priced from the catalog
+	request := &entities.OrderRequest{OrderDetails: []entities.OrderDetail{orderLine("a", 2, "0.01"), orderLine("b", 1, "0")}}
+	_, err := uc.Create(request)
+	assert.NoError(t, err)
+	assert.Equal(t, "50.00", request.OrderDetails[0].UnitPrice.String())
+	assert.Equal(t, "109.99", request.TotalPrice.String())
+
+	// A total of the tampered prices does not match the catalog
+	request = &entities.OrderRequest{TotalPrice: money.New(0, 2), OrderDetails: []entities.OrderDetail{orderLine("a", 2, "0.01")}}
+	repo.On("GetPrices", []string{"a"}).Return(catalogOf(orderLine("a", 1, "50")), nil)
+	_, err = uc.Create(request)
+	assert.ErrorIs(t, err, appErrors.ErrOrderTotalMismatch)
+
+	// Unknown and soft deleted products cannot be ordered
+	repo.On("GetPrices", []string{"gone"}).Return(map[string]money.Amount{}, nil)
+	_, err = uc.Create(&entities.OrderRequest{OrderDetails: []entities.OrderDetail{orderLine("gone", 1, "1")}})
+	assert.ErrorIs(t, err, appErrors.ErrProductNotFound)
+	repo.AssertNumberOfCalls(t, "Create", 1)
+}
+
+func TestOrderDetail_Value(t *testing.T) {
+	variantId := "v1"
+	detail := orderLine("a", 2, "1234567.89")
+	detail.VariantId = &variantId
+	detail.Discount = money.New(5, 50)
+
+	value, err := detail.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "(a,2,1234567.89,v1,5.50)", string(value.([]byte)))
+}
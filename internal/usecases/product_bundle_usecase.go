@@ -0,0 +1,113 @@
+// usecases/product_bundle_usecase.go
+package usecases
+
+import (
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/errors"
+	"github.com/shayja/go-template-api/pkg/money"
+)
+
+// BundleRepository keeps the components of the bundle products.
+type BundleRepository interface {
+	GetBundle(productId string) (*entities.Bundle, error)
+	SetBundle(productId string, request *entities.BundleRequest) error
+	DeleteBundle(productId string) error
+}
+
+// GetBundle returns an active bundle product with its components and the stock they make up
+func (uc *ProductInteractor) GetBundle(productId string) (*entities.Bundle, error) {
+	if uc.BundleRepository == nil {
+		return nil, errors.ErrBundleNotFound
+	}
+	product, err := uc.GetById(productId)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil || product.Id == "" {
+		return nil, errors.ErrProductNotFound
+	}
+	bundle, err := uc.BundleRepository.GetBundle(productId)
+	if err != nil {
+		return nil, err
+	}
+	if bundle == nil {
+		return nil, errors.ErrBundleNotFound
+	}
+	bundle.Product = product
+	deriveAvailability(bundle)
+
+	components := make([]*entities.Product, len(bundle.Components))
+	for i, component := range bundle.Components {
+		components[i] = component.Product
+	}
+	if err := uc.addGallery(components...); err != nil {
+		return nil, err
+	}
+	return bundle, nil
+}
+
+// SetBundle makes a product a bundle of the components or replaces its components. A bundle with
+// a discount is priced from its components, one without keeps the price of the product
+func (uc *ProductInteractor) SetBundle(productId string, request *entities.BundleRequest) (*entities.Bundle, error) {
+	if uc.BundleRepository == nil {
+		return nil, errors.ErrBundleNotFound
+	}
+	if err := validateBundle(productId, request); err != nil {
+		return nil, err
+	}
+	if err := uc.BundleRepository.SetBundle(productId, request); err != nil {
+		return nil, err
+	}
+	return uc.GetBundle(productId)
+}
+
+// DeleteBundle makes a bundle a plain product again, it keeps its last price
+func (uc *ProductInteractor) DeleteBundle(productId string) error {
+	if uc.BundleRepository == nil {
+		return errors.ErrBundleNotFound
+	}
+	return uc.BundleRepository.DeleteBundle(productId)
+}
+
+func validateBundle(productId string, request *entities.BundleRequest) error {
+	if request.DiscountPercent != nil && (*request.DiscountPercent < 0 || *request.DiscountPercent >= money.New(100, 0)) {
+		return errors.New(errors.ErrInvalidBundle.Code, "The discount must be at least 0 and less than 100 percent", nil)
+	}
+	seen := make(map[string]bool)
+	for _, component := range request.Components {
+		if component.ProductId == productId {
+			return errors.New(errors.ErrInvalidBundle.Code, "A bundle cannot contain itself", nil)
+		}
+		if seen[component.ProductId] {
+			return errors.New(errors.ErrInvalidBundle.Code, "A product is listed twice, set its quantity instead", nil)
+		}
+		if component.Quantity < 1 {
+			return errors.New(errors.ErrInvalidBundle.Code, "The component quantities must be positive", nil)
+		}
+		seen[component.ProductId] = true
+	}
+	return nil
+}
+
+// deriveAvailability sets the components price of a bundle, the price of a discounted bundle, and how
+// many bundles the stock of its components makes up. A deleted component or one out of stock makes the
+// bundle unavailable
+func deriveAvailability(bundle *entities.Bundle) {
+	bundle.SumComponents()
+	if price := bundle.DiscountedPrice(); price != nil && bundle.Product != nil {
+		bundle.Price = *price
+	}
+	bundle.Available = len(bundle.Components) > 0
+	bundle.AvailableQuantity = nil
+	for _, component := range bundle.Components {
+		component.Available = component.DeletedAt == nil
+		if component.Stock != nil {
+			bundles := max(*component.Stock, 0) / component.Quantity
+			component.Available = component.Available && bundles > 0
+			if bundle.AvailableQuantity == nil || bundles < *bundle.AvailableQuantity {
+				bundle.AvailableQuantity = &bundles
+			}
+		}
+		bundle.Available = bundle.Available && component.Available
+	}
+}
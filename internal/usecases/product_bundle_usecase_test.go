@@ -0,0 +1,161 @@
+package usecases_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shayja/go-template-api/internal/entities"
+	appErrors "github.com/shayja/go-template-api/internal/errors"
+	"github.com/shayja/go-template-api/internal/usecases"
+	"github.com/shayja/go-template-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockBundleRepository mocks the BundleRepository interface
+type MockBundleRepository struct {
+	mock.Mock
+}
+
+func (m *MockBundleRepository) GetBundle(productId string) (*entities.Bundle, error) {
+	args := m.Called(productId)
+	if bundle, ok := args.Get(0).(*entities.Bundle); ok {
+		return bundle, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockBundleRepository) SetBundle(productId string, request *entities.BundleRequest) error {
+	return m.Called(productId, request).Error(0)
+}
+
+func (m *MockBundleRepository) DeleteBundle(productId string) error {
+	return m.Called(productId).Error(0)
+}
+
+func stock(quantity int) *int {
+	return &quantity
+}
+
+// phoneKit is a phone, a case and 2 chargers, the case is not stock tracked
+func phoneKit() *entities.Bundle {
+	return &entities.Bundle{Components: []*entities.BundleComponent{
+		{Product: &entities.Product{Id: "phone", Price: amount("99.99")}, Quantity: 1, Stock: stock(5)},
+		{Product: &entities.Product{Id: "case", Price: amount("10")}, Quantity: 1},
+		{Product: &entities.Product{Id: "charger", Price: amount("9.99")}, Quantity: 2, Stock: stock(7)},
+	}}
+}
+
+func TestProductInteractor_GetBundle_Availability(t *testing.T) {
+	products := new(MockProductRepository)
+	bundles := new(MockBundleRepository)
+	interactor := &usecases.ProductInteractor{ProductRepository: products, BundleRepository: bundles}
+	products.On("GetById", "kit").Return(&entities.Product{Id: "kit", Price: amount("119.99")}, nil)
+	bundles.On("GetBundle", "kit").Return(phoneKit(), nil)
+
+	bundle, err := interactor.GetBundle("kit")
+	assert.NoError(t, err)
+	assert.Equal(t, "kit", bundle.Id)
+	assert.Equal(t, "129.97", bundle.ComponentsPrice.String())
+	assert.True(t, bundle.Available)
+	// The 7 chargers make up 3 kits
+	assert.Equal(t, 3, *bundle.AvailableQuantity)
+}
+
+func TestProductInteractor_GetBundle_DiscountedPrice(t *testing.T) {
+	products := new(MockProductRepository)
+	bundles := new(MockBundleRepository)
+	interactor := &usecases.ProductInteractor{ProductRepository: products, BundleRepository: bundles}
+	products.On("GetById", "kit").Return(&entities.Product{Id: "kit", Price: amount("119.99")}, nil)
+	kit := phoneKit()
+	discount := amount("15")
+	kit.DiscountPercent = &discount
+	bundles.On("GetBundle", "kit").Return(kit, nil)
+
+	// 129.97 less 15 percent is 110.4745, rounded once to the cents as the stored price is
+	bundle, err := interactor.GetBundle("kit")
+	assert.NoError(t, err)
+	assert.Equal(t, "110.47", bundle.Price.String())
+	assert.Equal(t, "110.47", bundle.DiscountedPrice().String())
+}
+
+func TestProductInteractor_GetBundle_Unavailable(t *testing.T) {
+	products := new(MockProductRepository)
+	bundles := new(MockBundleRepository)
+	interactor := &usecases.ProductInteractor{ProductRepository: products, BundleRepository: bundles}
+	products.On("GetById", "kit").Return(&entities.Product{Id: "kit"}, nil)
+	kit := phoneKit()
+	kit.Components[2].Stock = stock(1)
+	deleted := time.Now()
+	kit.Components[1].DeletedAt = &deleted
+	bundles.On("GetBundle", "kit").Return(kit, nil)
+	products.On("GetById", "phone").Return(&entities.Product{Id: "phone"}, nil)
+	bundles.On("GetBundle", "phone").Return(nil, nil)
+
+	bundle, err := interactor.GetBundle("kit")
+	assert.NoError(t, err)
+	assert.False(t, bundle.Available)
+	assert.Equal(t, 0, *bundle.AvailableQuantity)
+	assert.True(t, bundle.Components[0].Available)
+	assert.False(t, bundle.Components[1].Available)
+	assert.False(t, bundle.Components[2].Available)
+
+	_, err = interactor.GetBundle("phone")
+	assert.Equal(t, appErrors.ErrBundleNotFound, err)
+}
+
+func TestProductInteractor_SetBundle_Invalid(t *testing.T) {
+	bundles := new(MockBundleRepository)
+	interactor := &usecases.ProductInteractor{ProductRepository: new(MockProductRepository), BundleRepository: bundles}
+	discount := money.New(100, 0)
+
+	for name, request := range map[string]*entities.BundleRequest{
+		"discount":  {Components: []entities.BundleComponentRequest{{ProductId: "phone", Quantity: 1}}, DiscountPercent: &discount},
+		"itself":    {Components: []entities.BundleComponentRequest{{ProductId: "kit", Quantity: 1}}},
+		"duplicate": {Components: []entities.BundleComponentRequest{{ProductId: "phone", Quantity: 1}, {ProductId: "phone", Quantity: 1}}},
+	} {
+		_, err := interactor.SetBundle("kit", request)
+		assert.ErrorContains(t, err, appErrors.ErrInvalidBundle.Code, name)
+	}
+	bundles.AssertNotCalled(t, "SetBundle", mock.Anything, mock.Anything)
+}
+
+func TestOrderUsecase_Create_BundleStock(t *testing.T) {
+	orders := new(MockOrderRepository)
+	bundles := new(MockBundleRepository)
+	uc := &usecases.OrderUsecase{OrderRepo: orders, BundleRepo: bundles}
+	bundles.On("GetBundle", "kit").Return(phoneKit(), nil)
+	bundles.On("GetBundle", "phone").Return(nil, nil)
+	orders.On("GetPrices", mock.Anything).Return(catalogOf(orderLine("kit", 1, "119.99"), orderLine("phone", 1, "99.99")), nil)
+
+	// The stock of the chargers makes up 3 kits
+	_, err := uc.Create(&entities.OrderRequest{UserId: "u1", OrderDetails: []entities.OrderDetail{orderLine("kit", 4, "119.99")}})
+	assert.Equal(t, appErrors.ErrBundleUnavailable, err)
+	orders.AssertNotCalled(t, "Create", mock.Anything)
+
+	orders.On("Create", mock.Anything).Return("o1", nil)
+	id, err := uc.Create(&entities.OrderRequest{UserId: "u1", OrderDetails: []entities.OrderDetail{orderLine("kit", 3, "119.99"), orderLine("phone", 1, "99.99")}})
+	assert.NoError(t, err)
+	assert.Equal(t, "o1", id)
+}
+
+func TestOrderUsecase_Create_BundlePrice(t *testing.T) {
+	orders := new(MockOrderRepository)
+	bundles := new(MockBundleRepository)
+	uc := &usecases.OrderUsecase{OrderRepo: orders, BundleRepo: bundles}
+	kit := phoneKit()
+	discount := amount("15")
+	kit.DiscountPercent = &discount
+	bundles.On("GetBundle", "kit").Return(kit, nil)
+	bundles.On("GetBundle", "case").Return(nil, nil)
+	orders.On("GetPrices", []string{"kit", "case"}).Return(catalogOf(orderLine("kit", 1, "119.99"), orderLine("case", 1, "10")), nil)
+	orders.On("Create", mock.Anything).Return("o1", nil)
+
+	// The bundle is priced at its components less the discount, not at the price sent
+	request := &entities.OrderRequest{UserId: "u1", OrderDetails: []entities.OrderDetail{orderLine("kit", 2, "0.01"), orderLine("case", 1, "0.01")}}
+	_, err := uc.Create(request)
+	assert.NoError(t, err)
+	assert.Equal(t, "110.47", request.OrderDetails[0].UnitPrice.String())
+	assert.Equal(t, "10.00", request.OrderDetails[1].UnitPrice.String())
+	assert.Equal(t, "230.94", request.TotalPrice.String())
+}
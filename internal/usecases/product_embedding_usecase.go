@@ -0,0 +1,138 @@
+// usecases/product_embedding_usecase.go
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/errors"
+	"github.com/shayja/go-template-api/pkg/embedding"
+)
+
+// DefaultEmbeddingBatchSize is the number of products embedded per provider call when EmbeddingConfig.BatchSize is not set
+const DefaultEmbeddingBatchSize = 50
+
+// EmbeddingRepository stores the product vectors and finds the products similar to a query.
+type EmbeddingRepository interface {
+	GetStaleEmbeddings(model string, limit int) ([]*entities.EmbeddingJob, error)
+	SaveEmbedding(productId string, model string, sourceHash string, vector []float32) error
+	SemanticSearch(vector []float32, query string, textWeight float64, filter *entities.ProductFilter, limit int) ([]*entities.ProductMatch, error)
+	// GetEmbeddingDimensions returns the length of the vectors the store accepts
+	GetEmbeddingDimensions() (int, error)
+}
+
+// EmbeddingConfig is how the products are embedded for the semantic search.
+type EmbeddingConfig struct {
+	Provider   embedding.Provider
+	Repository EmbeddingRepository
+	// Optional, the products embedded per provider call, defaults to DefaultEmbeddingBatchSize
+	BatchSize int
+	// The weight of the full-text rank in the score of a match, from 0 (similarity only) to 1
+	TextWeight float64
+}
+
+// SemanticSearch finds the active products closest in meaning to the query
+func (uc *ProductInteractor) SemanticSearch(ctx context.Context, request *entities.SemanticSearchRequest) ([]*entities.ProductMatch, error) {
+	if uc.Embeddings == nil || uc.Embeddings.Provider == nil || uc.Embeddings.Repository == nil {
+		return nil, errors.ErrEmbedding
+	}
+	query := strings.TrimSpace(request.Query)
+	if query == "" {
+		return nil, errors.ErrInvalidInput
+	}
+	weight := uc.Embeddings.TextWeight
+	if request.TextWeight != nil {
+		weight = *request.TextWeight
+	}
+
+	vectors, err := uc.Embeddings.Provider.Embed(ctx, []string{query})
+	if err != nil {
+		fmt.Printf("Embedding the search query failed: %v\n", err)
+		return nil, errors.ErrEmbedding
+	}
+
+	filter := &entities.ProductFilter{MinPrice: request.MinPrice, MaxPrice: request.MaxPrice, Category: request.Category}
+	matches, err := uc.Embeddings.Repository.SemanticSearch(vectors[0], query, weight, filter, request.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	products := make([]*entities.Product, len(matches))
+	for i, match := range matches {
+		products[i] = match.Product
+	}
+	if err := uc.addBreadcrumbs(products...); err != nil {
+		return nil, err
+	}
+	if err := uc.addGallery(products...); err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
+// CheckEmbeddings verifies the provider returns vectors of the length the store accepts, so a
+// mismatched EMBEDDING_DIMENSIONS fails at startup rather than on every embedding
+func (uc *ProductInteractor) CheckEmbeddings() error {
+	if uc.Embeddings == nil || uc.Embeddings.Provider == nil || uc.Embeddings.Repository == nil {
+		return nil
+	}
+	dimensions, err := uc.Embeddings.Repository.GetEmbeddingDimensions()
+	if err != nil {
+		return err
+	}
+	if dimensions != uc.Embeddings.Provider.Dimensions() {
+		return fmt.Errorf("%w: the provider returns %d, the product_embeddings table stores %d", embedding.ErrDimensions, uc.Embeddings.Provider.Dimensions(), dimensions)
+	}
+	return nil
+}
+
+// Reembed embeds the products that are new, changed their name or description, or were embedded
+// by another model, one batch at a time until none are left. It returns the number of products embedded
+func (uc *ProductInteractor) Reembed(ctx context.Context) (int, error) {
+	if uc.Embeddings == nil || uc.Embeddings.Provider == nil || uc.Embeddings.Repository == nil {
+		return 0, nil
+	}
+	batchSize := uc.Embeddings.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultEmbeddingBatchSize
+	}
+	provider := uc.Embeddings.Provider
+
+	embedded := 0
+	for ctx.Err() == nil {
+		jobs, err := uc.Embeddings.Repository.GetStaleEmbeddings(provider.Model(), batchSize)
+		if err != nil {
+			return embedded, err
+		}
+		if len(jobs) == 0 {
+			return embedded, nil
+		}
+
+		texts := make([]string, len(jobs))
+		for i, job := range jobs {
+			texts[i] = job.Text
+		}
+		vectors, err := provider.Embed(ctx, texts)
+		if err != nil {
+			return embedded, err
+		}
+		if len(vectors) != len(jobs) {
+			return embedded, embedding.ErrDimensions
+		}
+		for i, job := range jobs {
+			if len(vectors[i]) != provider.Dimensions() {
+				return embedded, embedding.ErrDimensions
+			}
+			if err := uc.Embeddings.Repository.SaveEmbedding(job.ProductId, provider.Model(), job.SourceHash, vectors[i]); err != nil {
+				return embedded, err
+			}
+			embedded++
+		}
+		if len(jobs) < batchSize {
+			return embedded, nil
+		}
+	}
+	return embedded, ctx.Err()
+}
@@ -0,0 +1,120 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shayja/go-template-api/internal/entities"
+	appErrors "github.com/shayja/go-template-api/internal/errors"
+	"github.com/shayja/go-template-api/internal/usecases"
+	"github.com/shayja/go-template-api/pkg/embedding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockEmbeddingRepository mocks the EmbeddingRepository interface
+type MockEmbeddingRepository struct {
+	mock.Mock
+}
+
+func (m *MockEmbeddingRepository) GetStaleEmbeddings(model string, limit int) ([]*entities.EmbeddingJob, error) {
+	args := m.Called(model, limit)
+	return args.Get(0).([]*entities.EmbeddingJob), args.Error(1)
+}
+
+func (m *MockEmbeddingRepository) SaveEmbedding(productId string, model string, sourceHash string, vector []float32) error {
+	args := m.Called(productId, model, sourceHash, vector)
+	return args.Error(0)
+}
+
+func (m *MockEmbeddingRepository) SemanticSearch(vector []float32, query string, textWeight float64, filter *entities.ProductFilter, limit int) ([]*entities.ProductMatch, error) {
+	args := m.Called(vector, query, textWeight, filter, limit)
+	if matches, ok := args.Get(0).([]*entities.ProductMatch); ok {
+		return matches, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockEmbeddingRepository) GetEmbeddingDimensions() (int, error) {
+	args := m.Called()
+	return args.Int(0), args.Error(1)
+}
+
+// failingProvider is an embedding provider that is unreachable
+type failingProvider struct {
+	embedding.Local
+}
+
+func (p *failingProvider) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	return nil, context.DeadlineExceeded
+}
+
+func TestProductInteractor_SemanticSearch(t *testing.T) {
+	repo := new(MockEmbeddingRepository)
+	provider := &embedding.Local{Dims: 16}
+	interactor := &usecases.ProductInteractor{Embeddings: &usecases.EmbeddingConfig{Provider: provider, Repository: repo, TextWeight: 0.3}}
+
+	vectors, _ := provider.Embed(context.Background(), []string{"headphones"})
+	matches := []*entities.ProductMatch{{Product: &entities.Product{Id: "1", Name: "Wireless Headphones"}, Similarity: 0.8, Score: 0.7}}
+	repo.On("SemanticSearch", vectors[0], "headphones", 0.3, &entities.ProductFilter{Category: "audio"}, 10).Return(matches, nil)
+
+	result, err := interactor.SemanticSearch(context.Background(), &entities.SemanticSearchRequest{Query: " headphones ", Category: "audio", Limit: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, matches, result)
+	repo.AssertExpectations(t)
+}
+
+func TestProductInteractor_SemanticSearch_TextWeight(t *testing.T) {
+	repo := new(MockEmbeddingRepository)
+	interactor := &usecases.ProductInteractor{Embeddings: &usecases.EmbeddingConfig{Provider: &embedding.Local{Dims: 16}, Repository: repo, TextWeight: 0.3}}
+
+	weight := 0.0
+	repo.On("SemanticSearch", mock.Anything, "headphones", 0.0, mock.Anything, 0).Return([]*entities.ProductMatch{}, nil)
+
+	_, err := interactor.SemanticSearch(context.Background(), &entities.SemanticSearchRequest{Query: "headphones", TextWeight: &weight})
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestProductInteractor_SemanticSearch_Unavailable(t *testing.T) {
+	_, err := (&usecases.ProductInteractor{}).SemanticSearch(context.Background(), &entities.SemanticSearchRequest{Query: "headphones"})
+	assert.Equal(t, appErrors.ErrEmbedding, err)
+
+	repo := new(MockEmbeddingRepository)
+	interactor := &usecases.ProductInteractor{Embeddings: &usecases.EmbeddingConfig{Provider: &failingProvider{}, Repository: repo}}
+	_, err = interactor.SemanticSearch(context.Background(), &entities.SemanticSearchRequest{Query: "headphones"})
+	assert.Equal(t, appErrors.ErrEmbedding, err)
+	repo.AssertNotCalled(t, "SemanticSearch")
+}
+
+func TestProductInteractor_Reembed(t *testing.T) {
+	repo := new(MockEmbeddingRepository)
+	provider := &embedding.Local{Dims: 16}
+	interactor := &usecases.ProductInteractor{Embeddings: &usecases.EmbeddingConfig{Provider: provider, Repository: repo, BatchSize: 2}}
+
+	first := []*entities.EmbeddingJob{{ProductId: "1", Text: "Nokia 3310\nA phone", SourceHash: "a"}, {ProductId: "2", Text: "Anvil\nHeavy", SourceHash: "b"}}
+	second := []*entities.EmbeddingJob{{ProductId: "3", Text: "Headphones\n", SourceHash: "c"}}
+	vectors, _ := provider.Embed(context.Background(), []string{"Nokia 3310\nA phone", "Anvil\nHeavy", "Headphones\n"})
+
+	repo.On("GetStaleEmbeddings", "local-hash-16", 2).Return(first, nil).Once()
+	repo.On("GetStaleEmbeddings", "local-hash-16", 2).Return(second, nil).Once()
+	repo.On("SaveEmbedding", "1", "local-hash-16", "a", vectors[0]).Return(nil)
+	repo.On("SaveEmbedding", "2", "local-hash-16", "b", vectors[1]).Return(nil)
+	repo.On("SaveEmbedding", "3", "local-hash-16", "c", vectors[2]).Return(nil)
+
+	embedded, err := interactor.Reembed(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 3, embedded)
+	repo.AssertExpectations(t)
+}
+
+func TestProductInteractor_CheckEmbeddings(t *testing.T) {
+	repo := new(MockEmbeddingRepository)
+	repo.On("GetEmbeddingDimensions").Return(384, nil)
+
+	interactor := &usecases.ProductInteractor{Embeddings: &usecases.EmbeddingConfig{Provider: &embedding.Local{Dims: 384}, Repository: repo}}
+	assert.NoError(t, interactor.CheckEmbeddings())
+
+	interactor.Embeddings.Provider = &embedding.Local{Dims: 1536}
+	assert.ErrorIs(t, interactor.CheckEmbeddings(), embedding.ErrDimensions)
+}
@@ -0,0 +1,46 @@
+// usecases/product_facets_usecase.go
+package usecases
+
+import (
+	"strings"
+
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/errors"
+	"github.com/shayja/go-template-api/pkg/money"
+)
+
+// DefaultFacetMaxValues limits the tags and values of each attribute counted in the facets
+const DefaultFacetMaxValues = 20
+
+// DefaultPriceBuckets are the bounds of the price ranges counted in the facets
+var DefaultPriceBuckets = []money.Amount{0, money.New(50, 0), money.New(100, 0), money.New(250, 0), money.New(500, 0), money.New(1000, 0)}
+
+// ParsePriceBuckets parses comma separated price bounds, e.g. 0,100,500, which must be ascending.
+// An empty string returns nil
+func ParsePriceBuckets(s string) ([]money.Amount, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	var bounds []money.Amount
+	for _, field := range strings.Split(s, ",") {
+		bound, err := money.Parse(field)
+		if err != nil || bound < 0 || (len(bounds) > 0 && bound <= bounds[len(bounds)-1]) {
+			return nil, errors.New(errors.ErrInvalidInput.Code, "the price buckets must be ascending amounts", nil)
+		}
+		bounds = append(bounds, bound)
+	}
+	return bounds, nil
+}
+
+// facets counts the products matching the filter per category, price range, tag and attribute value
+func (uc *ProductInteractor) facets(filter *entities.ProductFilter) (*entities.ProductFacets, error) {
+	priceBuckets := uc.PriceBuckets
+	if len(priceBuckets) == 0 {
+		priceBuckets = DefaultPriceBuckets
+	}
+	maxValues := uc.FacetMaxValues
+	if maxValues <= 0 {
+		maxValues = DefaultFacetMaxValues
+	}
+	return uc.ProductRepository.Facets(filter, priceBuckets, maxValues)
+}
@@ -0,0 +1,410 @@
+// usecases/product_image_usecase.go
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/errors"
+	"github.com/shayja/go-template-api/pkg/imaging"
+	"github.com/shayja/go-template-api/pkg/storage"
+)
+
+// DefaultMaxImageSize is the upload limit used when ImageConfig.MaxSize is not set (5 MiB)
+const DefaultMaxImageSize int64 = 5 << 20
+
+// The accepted image types, detected from the file content, and their file extensions
+var imageExtensions = map[string][]string{
+	"image/jpeg": {".jpg", ".jpeg"},
+	"image/png":  {".png"},
+	"image/gif":  {".gif"},
+	"image/webp": {".webp"},
+}
+
+// ImageRepository stores the product galleries and tracks the processing of the uploaded images.
+type ImageRepository interface {
+	CreateImage(productId string, source string, altText string, primary bool) (string, error)
+	GetImage(productId string, imageId string) (*entities.ProductImage, error)
+	GetImages(productIds []string) (map[string][]*entities.ProductImage, error)
+	UpdateGalleryImage(productId string, imageId string, update *entities.ProductImageUpdate) (bool, error)
+	ReorderImages(productId string, imageIds []string) error
+	DeleteImage(productId string, imageId string) (*entities.ProductImage, error)
+	GetPendingImages(queuedBefore time.Time, limit int) ([]*entities.ImageJob, error)
+	CompleteImage(imageId string, source string, url string, renditions []entities.ImageRendition) (bool, error)
+	FailImage(imageId string, source string) error
+}
+
+// ImageConfig is where uploaded product images are stored and served from, and how they are processed.
+type ImageConfig struct {
+	Storage storage.Storage
+	// Base URL the stored objects are publicly served from
+	PublicBaseURL string
+	// Maximum upload size in bytes
+	MaxSize    int64
+	Repository ImageRepository
+	// Renders the renditions, its limits are checked on upload
+	Processor *imaging.Processor
+	// Optional, hands an upload to the worker, the jobs not handed over are picked up by ImageWorker.Requeue
+	Enqueue func(job *entities.ImageJob) bool
+	// Optional, returns a signed expiring URL of a private object
+	SignURL func(key string) string
+}
+
+// UploadImage adds an image to the gallery as the primary image, it becomes the product image once processed
+func (uc *ProductInteractor) UploadImage(id string, upload *entities.ImageUpload) (*entities.Product, error) {
+	primary := true
+	if _, err := uc.AddImage(id, upload, &entities.ProductImageUpdate{Primary: &primary}); err != nil {
+		return nil, err
+	}
+	return uc.GetById(id)
+}
+
+// AddImage validates and stores an image, adds it to the end of the product gallery and queues it for processing
+func (uc *ProductInteractor) AddImage(id string, upload *entities.ImageUpload, details *entities.ProductImageUpdate) (*entities.ProductImage, error) {
+	if uc.Images == nil || uc.Images.Storage == nil || uc.Images.Repository == nil || uc.Images.Processor == nil {
+		return nil, errors.ErrStorage
+	}
+
+	product, err := uc.GetById(id)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil || product.Id == "" {
+		return nil, errors.ErrProductNotFound
+	}
+
+	data, contentType, ext, err := uc.Images.validate(upload)
+	if err != nil {
+		return nil, err
+	}
+
+	// The source keeps the uploaded metadata, it is private until the worker has processed it
+	ctx := context.Background()
+	source := fmt.Sprintf("%sproducts/%s/%s/source%s", storage.PrivatePrefix, id, strings.ReplaceAll(uuid.NewString(), "-", ""), ext)
+	if err := uc.Images.Storage.Put(ctx, source, bytes.NewReader(data), int64(len(data)), contentType); err != nil {
+		fmt.Print(err)
+		return nil, errors.ErrStorage
+	}
+
+	altText := ""
+	if details != nil && details.AltText != nil {
+		altText = *details.AltText
+	}
+	primary := details != nil && details.Primary != nil && *details.Primary
+	imageId, err := uc.Images.Repository.CreateImage(id, source, altText, primary)
+	if err != nil {
+		uc.Images.Storage.Delete(ctx, source)
+		return nil, err
+	}
+
+	if uc.Images.Enqueue != nil {
+		uc.Images.Enqueue(&entities.ImageJob{ProductId: id, ImageId: imageId, Source: source})
+	}
+	image, err := uc.Images.Repository.GetImage(id, imageId)
+	if err != nil {
+		return nil, err
+	}
+	uc.Images.signSources(image)
+	return image, nil
+}
+
+// GetImages returns the gallery of an active product in display order,
+// with signed links to the sources of the pending images when asked to
+func (uc *ProductInteractor) GetImages(id string, withSources bool) ([]*entities.ProductImage, error) {
+	product, err := uc.GetById(id)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil || product.Id == "" {
+		return nil, errors.ErrProductNotFound
+	}
+	if product.Gallery == nil {
+		return []*entities.ProductImage{}, nil
+	}
+	if withSources {
+		uc.Images.signSources(product.Gallery...)
+	}
+	return product.Gallery, nil
+}
+
+// signSources sets the signed source URL of the pending images
+func (c *ImageConfig) signSources(images ...*entities.ProductImage) {
+	if c == nil || c.SignURL == nil {
+		return
+	}
+	for _, image := range images {
+		if image != nil && image.Source != "" {
+			image.SourceURL = c.SignURL(image.Source)
+		}
+	}
+}
+
+// UpdateGalleryImage changes the alternative text of a gallery image or makes it the primary image
+func (uc *ProductInteractor) UpdateGalleryImage(id string, imageId string, update *entities.ProductImageUpdate) (*entities.ProductImage, error) {
+	if uc.Images == nil || uc.Images.Repository == nil {
+		return nil, errors.ErrImageNotFound
+	}
+	ok, err := uc.Images.Repository.UpdateGalleryImage(id, imageId, update)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.ErrImageNotFound
+	}
+	return uc.Images.Repository.GetImage(id, imageId)
+}
+
+// ReorderImages puts the gallery of a product in the order of the given image ids
+func (uc *ProductInteractor) ReorderImages(id string, imageIds []string) ([]*entities.ProductImage, error) {
+	if uc.Images == nil || uc.Images.Repository == nil {
+		return nil, errors.ErrInvalidImageOrder
+	}
+	seen := map[string]bool{}
+	for _, imageId := range imageIds {
+		if seen[imageId] {
+			return nil, errors.ErrInvalidImageOrder
+		}
+		seen[imageId] = true
+	}
+	if err := uc.Images.Repository.ReorderImages(id, imageIds); err != nil {
+		return nil, err
+	}
+	return uc.GetImages(id, true)
+}
+
+// DeleteImage removes an image from the gallery and its files from the storage.
+// When it was the primary image the first remaining image becomes primary.
+func (uc *ProductInteractor) DeleteImage(id string, imageId string) (bool, error) {
+	if uc.Images == nil || uc.Images.Repository == nil {
+		return false, errors.ErrImageNotFound
+	}
+	image, err := uc.Images.Repository.DeleteImage(id, imageId)
+	if err != nil {
+		return false, err
+	}
+
+	// Failing to remove the files only leaves orphans
+	if uc.Images.Storage != nil {
+		var keys []string
+		if image.Source != "" {
+			keys = append(keys, image.Source)
+		}
+		urls := []string{image.URL}
+		for _, rendition := range image.Renditions {
+			urls = append(urls, rendition.URL)
+		}
+		for _, url := range urls {
+			if key, ok := storage.KeyFromURL(uc.Images.PublicBaseURL, url); ok {
+				keys = append(keys, key)
+			}
+		}
+		deleteKeys(context.Background(), uc.Images.Storage, keys)
+	}
+	return true, nil
+}
+
+// addGallery loads the galleries of the products in a single query
+func (uc *ProductInteractor) addGallery(products ...*entities.Product) error {
+	if uc.Images == nil || uc.Images.Repository == nil || len(products) == 0 {
+		return nil
+	}
+	ids := make([]string, len(products))
+	for i, product := range products {
+		ids[i] = product.Id
+	}
+	galleries, err := uc.Images.Repository.GetImages(ids)
+	if err != nil {
+		return err
+	}
+	for _, product := range products {
+		product.Gallery = galleries[product.Id]
+	}
+	return nil
+}
+
+// validate checks the size, the sniffed content type, the extension and the dimensions of an upload,
+// returning its content, content type and canonical extension
+func (c *ImageConfig) validate(upload *entities.ImageUpload) ([]byte, string, string, error) {
+	maxSize := c.MaxSize
+	if maxSize <= 0 {
+		maxSize = DefaultMaxImageSize
+	}
+	if upload.Size > maxSize {
+		return nil, "", "", errors.ErrImageTooLarge
+	}
+	if upload.Size <= 0 {
+		return nil, "", "", errors.ErrInvalidImage
+	}
+
+	// The declared size is not trusted, reading stops past the limit
+	data, err := io.ReadAll(io.LimitReader(upload.Content, maxSize+1))
+	if err != nil {
+		return nil, "", "", errors.ErrInvalidImage
+	}
+	if int64(len(data)) > maxSize {
+		return nil, "", "", errors.ErrImageTooLarge
+	}
+
+	contentType, ext, err := ImageType(upload.Filename, data)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	// Only the header is decoded here, the worker decodes the whole image
+	if _, _, _, err := c.Processor.Limits.Check(data); err != nil {
+		if err == imaging.ErrDimensions {
+			return nil, "", "", errors.ErrImageDimensions
+		}
+		return nil, "", "", errors.ErrInvalidImage
+	}
+	return data, contentType, ext, nil
+}
+
+// ImageType detects the image type of the content and checks the file name extension matches it,
+// returning the content type and the canonical extension of the image
+func ImageType(filename string, content []byte) (string, string, error) {
+	// http.DetectContentType considers at most the first 512 bytes
+	contentType := http.DetectContentType(content)
+	extensions, ok := imageExtensions[contentType]
+	if !ok {
+		return "", "", errors.ErrInvalidImage
+	}
+	ext := strings.ToLower(filepath.Ext(filename))
+	for _, allowed := range extensions {
+		if ext == allowed {
+			return contentType, extensions[0], nil
+		}
+	}
+	return "", "", errors.ErrInvalidImage
+}
+
+// ImageWorker renders the uploaded product images off the request path.
+type ImageWorker struct {
+	Images *ImageConfig
+	jobs   chan *entities.ImageJob
+}
+
+// NewImageWorker creates a worker queueing up to queueSize jobs
+func NewImageWorker(images *ImageConfig, queueSize int) *ImageWorker {
+	return &ImageWorker{Images: images, jobs: make(chan *entities.ImageJob, queueSize)}
+}
+
+// Enqueue queues a job without blocking, false when the queue is full
+func (w *ImageWorker) Enqueue(job *entities.ImageJob) bool {
+	select {
+	case w.jobs <- job:
+		return true
+	default:
+		return false
+	}
+}
+
+// Start processes the queued jobs with the given number of goroutines until the context is done
+func (w *ImageWorker) Start(ctx context.Context, workers int) {
+	for i := 0; i < max(1, workers); i++ {
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case job := <-w.jobs:
+					if err := w.Process(ctx, job); err != nil {
+						fmt.Printf("Processing image %s of product %s failed: %v\n", job.Source, job.ProductId, err)
+					}
+				}
+			}
+		}()
+	}
+}
+
+// Requeue queues the pending uploads queued before the given time again, recovering the jobs
+// that were dropped by a full queue or a restart
+func (w *ImageWorker) Requeue(queuedBefore time.Time) (int, error) {
+	jobs, err := w.Images.Repository.GetPendingImages(queuedBefore, cap(w.jobs))
+	if err != nil {
+		return 0, err
+	}
+	queued := 0
+	for _, job := range jobs {
+		if !w.Enqueue(job) {
+			break
+		}
+		queued++
+	}
+	return queued, nil
+}
+
+// Process renders and stores the renditions of an uploaded gallery image.
+// Jobs of an image deleted meanwhile are discarded.
+func (w *ImageWorker) Process(ctx context.Context, job *entities.ImageJob) error {
+	store := w.Images.Storage
+	reader, _, err := store.Open(ctx, job.Source)
+	if err == storage.ErrNotFound {
+		// Already processed
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	data, err := io.ReadAll(reader)
+	reader.Close()
+	if err != nil {
+		return err
+	}
+
+	outputs, err := w.Images.Processor.Process(data)
+	if err != nil {
+		if ferr := w.Images.Repository.FailImage(job.ImageId, job.Source); ferr != nil {
+			return ferr
+		}
+		store.Delete(ctx, job.Source)
+		return err
+	}
+
+	// Public, next to the other images of the product
+	dir := fmt.Sprintf("products/%s/%s", job.ProductId, job.ImageId)
+	var keys []string
+	var renditions []entities.ImageRendition
+	imageURL := ""
+	for _, output := range outputs {
+		key := fmt.Sprintf("%s/%s%s", dir, output.Name, output.Ext())
+		if err := store.Put(ctx, key, bytes.NewReader(output.Data), int64(len(output.Data)), output.ContentType()); err != nil {
+			deleteKeys(ctx, store, keys)
+			return err
+		}
+		keys = append(keys, key)
+
+		url := storage.PublicURL(w.Images.PublicBaseURL, key)
+		renditions = append(renditions, entities.ImageRendition{Name: output.Name, Format: output.Format, Width: output.ActualWidth, Height: output.ActualHeight, URL: url})
+		// The image URL is the largest rendition in the universally supported format
+		if output.Format != imaging.FormatWebP {
+			imageURL = url
+		}
+	}
+
+	ok, err := w.Images.Repository.CompleteImage(job.ImageId, job.Source, imageURL, renditions)
+	if err != nil || !ok {
+		// The image was deleted meanwhile
+		deleteKeys(ctx, store, append(keys, job.Source))
+		return err
+	}
+	store.Delete(ctx, job.Source)
+	return nil
+}
+
+// deleteKeys removes stored objects, logging the failures
+func deleteKeys(ctx context.Context, store storage.Storage, keys []string) {
+	for _, key := range keys {
+		if err := store.Delete(ctx, key); err != nil {
+			fmt.Print(err)
+		}
+	}
+}
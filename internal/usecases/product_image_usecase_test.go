@@ -0,0 +1,337 @@
+package usecases_test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shayja/go-template-api/internal/entities"
+	appErrors "github.com/shayja/go-template-api/internal/errors"
+	"github.com/shayja/go-template-api/internal/usecases"
+	"github.com/shayja/go-template-api/pkg/imaging"
+	"github.com/shayja/go-template-api/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockImageRepository struct {
+	mock.Mock
+}
+
+func (m *MockImageRepository) CreateImage(productId string, source string, altText string, primary bool) (string, error) {
+	args := m.Called(productId, source, altText, primary)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockImageRepository) GetImage(productId string, imageId string) (*entities.ProductImage, error) {
+	args := m.Called(productId, imageId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ProductImage), args.Error(1)
+}
+
+func (m *MockImageRepository) GetImages(productIds []string) (map[string][]*entities.ProductImage, error) {
+	args := m.Called(productIds)
+	return args.Get(0).(map[string][]*entities.ProductImage), args.Error(1)
+}
+
+func (m *MockImageRepository) UpdateGalleryImage(productId string, imageId string, update *entities.ProductImageUpdate) (bool, error) {
+	args := m.Called(productId, imageId, update)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockImageRepository) ReorderImages(productId string, imageIds []string) error {
+	args := m.Called(productId, imageIds)
+	return args.Error(0)
+}
+
+func (m *MockImageRepository) DeleteImage(productId string, imageId string) (*entities.ProductImage, error) {
+	args := m.Called(productId, imageId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ProductImage), args.Error(1)
+}
+
+func (m *MockImageRepository) GetPendingImages(queuedBefore time.Time, limit int) ([]*entities.ImageJob, error) {
+	args := m.Called(queuedBefore, limit)
+	return args.Get(0).([]*entities.ImageJob), args.Error(1)
+}
+
+func (m *MockImageRepository) CompleteImage(imageId string, source string, url string, renditions []entities.ImageRendition) (bool, error) {
+	args := m.Called(imageId, source, url, renditions)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockImageRepository) FailImage(imageId string, source string) error {
+	args := m.Called(imageId, source)
+	return args.Error(0)
+}
+
+const imageBaseURL = "https://cdn.example.com/media"
+
+// pngImage encodes a w x h PNG image
+func pngImage(t *testing.T, w, h int) []byte {
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, image.NewGray(image.Rect(0, 0, w, h))))
+	return buf.Bytes()
+}
+
+func newImageConfig(t *testing.T, repo usecases.ImageRepository) (*usecases.ImageConfig, *storage.LocalStorage) {
+	store := &storage.LocalStorage{Root: t.TempDir()}
+	return &usecases.ImageConfig{
+		Storage:       store,
+		PublicBaseURL: imageBaseURL,
+		Repository:    repo,
+		Processor: &imaging.Processor{
+			Renditions: []imaging.Rendition{{Name: "thumbnail", Width: 20, Height: 20}, {Name: "large", Width: 100, Height: 100}},
+			Limits:     imaging.Limits{MinDimension: 10, MaxDimension: 500},
+		},
+	}, store
+}
+
+func storedObject(t *testing.T, store storage.Storage, key string) []byte {
+	reader, _, err := store.Open(context.Background(), key)
+	if !assert.NoError(t, err, key) {
+		return nil
+	}
+	defer reader.Close()
+	data, _ := io.ReadAll(reader)
+	return data
+}
+
+func TestImageType(t *testing.T) {
+	contentType, ext, err := usecases.ImageType("Photo.JPEG", []byte("\xff\xd8\xff\xe0\x00\x10JFIF"))
+	assert.NoError(t, err)
+	assert.Equal(t, "image/jpeg", contentType)
+	assert.Equal(t, ".jpg", ext)
+
+	// A PNG renamed to .jpg, a script renamed to .png and a file without extension
+	_, _, err = usecases.ImageType("photo.jpg", pngImage(t, 1, 1))
+	assert.ErrorIs(t, err, appErrors.ErrInvalidImage)
+	_, _, err = usecases.ImageType("photo.png", []byte("<script>alert(1)</script>"))
+	assert.ErrorIs(t, err, appErrors.ErrInvalidImage)
+	_, _, err = usecases.ImageType("photo", pngImage(t, 1, 1))
+	assert.ErrorIs(t, err, appErrors.ErrInvalidImage)
+}
+
+func TestProductInteractor_UploadImage(t *testing.T) {
+	repo := new(MockProductRepository)
+	imageRepo := new(MockImageRepository)
+	images, store := newImageConfig(t, imageRepo)
+	var queued []*entities.ImageJob
+	images.Enqueue = func(job *entities.ImageJob) bool {
+		queued = append(queued, job)
+		return true
+	}
+	interactor := &usecases.ProductInteractor{ProductRepository: repo, Images: images}
+
+	data := pngImage(t, 40, 30)
+	repo.On("GetById", "1").Return(&entities.Product{Id: "1"}, nil)
+	imageRepo.On("GetImages", []string{"1"}).Return(map[string][]*entities.ProductImage{}, nil)
+	// Uploaded as the primary gallery image
+	imageRepo.On("CreateImage", "1", mock.MatchedBy(func(source string) bool {
+		return strings.HasPrefix(source, "private/products/1/") && strings.HasSuffix(source, "/source.png")
+	}), "", true).Return("10", nil)
+	imageRepo.On("GetImage", "1", "10").Return(&entities.ProductImage{Id: "10", Status: entities.ImageStatusPending}, nil)
+
+	_, err := interactor.UploadImage("1", &entities.ImageUpload{Filename: "photo.png", Size: int64(len(data)), Content: bytes.NewReader(data)})
+	assert.NoError(t, err)
+	imageRepo.AssertExpectations(t)
+
+	// The source is stored and handed to the worker, the product image is unchanged until it is processed
+	repo.AssertNotCalled(t, "UpdateImage", mock.Anything, mock.Anything)
+	if assert.Len(t, queued, 1) {
+		assert.Equal(t, "1", queued[0].ProductId)
+		assert.Equal(t, "10", queued[0].ImageId)
+		assert.Equal(t, data, storedObject(t, store, queued[0].Source))
+	}
+}
+
+func TestProductInteractor_AddImage(t *testing.T) {
+	repo := new(MockProductRepository)
+	imageRepo := new(MockImageRepository)
+	images, _ := newImageConfig(t, imageRepo)
+	interactor := &usecases.ProductInteractor{ProductRepository: repo, Images: images}
+
+	data := pngImage(t, 40, 30)
+	altText := "Back view"
+	repo.On("GetById", "1").Return(&entities.Product{Id: "1"}, nil)
+	imageRepo.On("GetImages", []string{"1"}).Return(map[string][]*entities.ProductImage{}, nil)
+	imageRepo.On("CreateImage", "1", mock.Anything, "Back view", false).Return("11", nil)
+	imageRepo.On("GetImage", "1", "11").Return(&entities.ProductImage{Id: "11", AltText: "Back view", Status: entities.ImageStatusPending}, nil)
+
+	image, err := interactor.AddImage("1", &entities.ImageUpload{Filename: "back.png", Size: int64(len(data)), Content: bytes.NewReader(data)}, &entities.ProductImageUpdate{AltText: &altText})
+	assert.NoError(t, err)
+	assert.Equal(t, "11", image.Id)
+	imageRepo.AssertExpectations(t)
+}
+
+func TestProductInteractor_ReorderImages_Duplicate(t *testing.T) {
+	imageRepo := new(MockImageRepository)
+	images, _ := newImageConfig(t, imageRepo)
+	interactor := &usecases.ProductInteractor{ProductRepository: new(MockProductRepository), Images: images}
+
+	_, err := interactor.ReorderImages("1", []string{"10", "11", "10"})
+	assert.ErrorIs(t, err, appErrors.ErrInvalidImageOrder)
+	imageRepo.AssertNotCalled(t, "ReorderImages", mock.Anything, mock.Anything)
+}
+
+func TestProductInteractor_DeleteImage(t *testing.T) {
+	imageRepo := new(MockImageRepository)
+	images, store := newImageConfig(t, imageRepo)
+	interactor := &usecases.ProductInteractor{ProductRepository: new(MockProductRepository), Images: images}
+	ctx := context.Background()
+
+	for _, key := range []string{"products/1/a/large.jpg", "products/1/a/thumbnail.jpg", "products/1/b/large.jpg"} {
+		store.Put(ctx, key, strings.NewReader("x"), 1, "image/jpeg")
+	}
+	imageRepo.On("DeleteImage", "1", "10").Return(&entities.ProductImage{
+		Id:         "10",
+		URL:        imageBaseURL + "/products/1/a/large.jpg",
+		Renditions: []entities.ImageRendition{{Name: "thumbnail", URL: imageBaseURL + "/products/1/a/thumbnail.jpg"}, {Name: "large", URL: imageBaseURL + "/products/1/a/large.jpg"}},
+	}, nil)
+
+	ok, err := interactor.DeleteImage("1", "10")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	for _, key := range []string{"products/1/a/large.jpg", "products/1/a/thumbnail.jpg"} {
+		_, _, err := store.Open(ctx, key)
+		assert.ErrorIs(t, err, storage.ErrNotFound, key)
+	}
+	// Other images are kept
+	assert.Equal(t, []byte("x"), storedObject(t, store, "products/1/b/large.jpg"))
+}
+
+func TestProductInteractor_UploadImage_Invalid(t *testing.T) {
+	repo := new(MockProductRepository)
+	imageRepo := new(MockImageRepository)
+	images, _ := newImageConfig(t, imageRepo)
+	images.MaxSize = 200
+	interactor := &usecases.ProductInteractor{ProductRepository: repo, Images: images}
+
+	repo.On("GetById", "1").Return(&entities.Product{Id: "1"}, nil)
+	imageRepo.On("GetImages", []string{"1"}).Return(map[string][]*entities.ProductImage{}, nil)
+
+	upload := func(data []byte, size int64) error {
+		_, err := interactor.UploadImage("1", &entities.ImageUpload{Filename: "photo.png", Size: size, Content: bytes.NewReader(data)})
+		return err
+	}
+
+	large := append(pngImage(t, 40, 30), make([]byte, 200)...)
+	assert.ErrorIs(t, upload(large, int64(len(large))), appErrors.ErrImageTooLarge)
+	// The declared size is not trusted
+	assert.ErrorIs(t, upload(large, 10), appErrors.ErrImageTooLarge)
+	assert.ErrorIs(t, upload(pngImage(t, 5, 30), 100), appErrors.ErrImageDimensions)
+	assert.ErrorIs(t, upload([]byte("\x89PNG\r\n\x1a\nbroken"), 100), appErrors.ErrInvalidImage)
+	imageRepo.AssertNotCalled(t, "CreateImage", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestImageWorker_Process(t *testing.T) {
+	imageRepo := new(MockImageRepository)
+	images, store := newImageConfig(t, imageRepo)
+	worker := usecases.NewImageWorker(images, 1)
+	ctx := context.Background()
+
+	job := &entities.ImageJob{ProductId: "1", ImageId: "10", Source: "private/products/1/new/source.png"}
+	source := pngImage(t, 200, 100)
+	store.Put(ctx, job.Source, bytes.NewReader(source), int64(len(source)), "image/png")
+
+	imageRepo.On("CompleteImage", "10", job.Source, imageBaseURL+"/products/1/10/large.jpg", []entities.ImageRendition{
+		{Name: "thumbnail", Format: "jpeg", Width: 20, Height: 10, URL: imageBaseURL + "/products/1/10/thumbnail.jpg"},
+		{Name: "large", Format: "jpeg", Width: 100, Height: 50, URL: imageBaseURL + "/products/1/10/large.jpg"},
+	}).Return(true, nil)
+
+	assert.NoError(t, worker.Process(ctx, job))
+	imageRepo.AssertExpectations(t)
+
+	thumbnail, _, err := image.DecodeConfig(bytes.NewReader(storedObject(t, store, "products/1/10/thumbnail.jpg")))
+	assert.NoError(t, err)
+	assert.Equal(t, 20, thumbnail.Width)
+
+	// The source is removed once processed
+	_, _, err = store.Open(ctx, job.Source)
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+}
+
+func TestImageWorker_Process_Deleted(t *testing.T) {
+	imageRepo := new(MockImageRepository)
+	images, store := newImageConfig(t, imageRepo)
+	worker := usecases.NewImageWorker(images, 1)
+	ctx := context.Background()
+
+	job := &entities.ImageJob{ProductId: "1", ImageId: "10", Source: "private/products/1/new/source.png"}
+	source := pngImage(t, 40, 30)
+	store.Put(ctx, job.Source, bytes.NewReader(source), int64(len(source)), "image/png")
+
+	imageRepo.On("CompleteImage", "10", job.Source, mock.Anything, mock.Anything).Return(false, nil)
+
+	assert.NoError(t, worker.Process(ctx, job))
+
+	// Nothing of the deleted image is kept
+	for _, key := range []string{job.Source, "products/1/10/thumbnail.jpg", "products/1/10/large.jpg"} {
+		_, _, err := store.Open(ctx, key)
+		assert.ErrorIs(t, err, storage.ErrNotFound, key)
+	}
+}
+
+func TestImageWorker_Process_Failed(t *testing.T) {
+	imageRepo := new(MockImageRepository)
+	images, store := newImageConfig(t, imageRepo)
+	worker := usecases.NewImageWorker(images, 1)
+	ctx := context.Background()
+
+	job := &entities.ImageJob{ProductId: "1", ImageId: "10", Source: "private/products/1/new/source.png"}
+	store.Put(ctx, job.Source, strings.NewReader("corrupt"), 7, "image/png")
+
+	imageRepo.On("FailImage", "10", job.Source).Return(nil)
+
+	assert.ErrorIs(t, worker.Process(ctx, job), imaging.ErrInvalidImage)
+	imageRepo.AssertExpectations(t)
+	imageRepo.AssertNotCalled(t, "CompleteImage", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestImageWorker_Requeue(t *testing.T) {
+	imageRepo := new(MockImageRepository)
+	images, _ := newImageConfig(t, imageRepo)
+	worker := usecases.NewImageWorker(images, 1)
+
+	before := time.Now()
+	imageRepo.On("GetPendingImages", before, 1).Return([]*entities.ImageJob{{ProductId: "1", Source: "a"}}, nil)
+
+	queued, err := worker.Requeue(before)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, queued)
+	// The queue is full
+	assert.False(t, worker.Enqueue(&entities.ImageJob{ProductId: "2", Source: "b"}))
+}
+
+func TestProductInteractor_GetImages_Sources(t *testing.T) {
+	repo := new(MockProductRepository)
+	imageRepo := new(MockImageRepository)
+	images, _ := newImageConfig(t, imageRepo)
+	images.SignURL = func(key string) string { return "https://api.example.com/images/" + key + "?signature=x" }
+	interactor := &usecases.ProductInteractor{ProductRepository: repo, Images: images}
+
+	repo.On("GetById", "1").Return(&entities.Product{Id: "1"}, nil)
+	imageRepo.On("GetImages", []string{"1"}).Return(map[string][]*entities.ProductImage{"1": {
+		{Id: "10", Status: entities.ImageStatusReady, URL: imageBaseURL + "/products/1/10/large.jpg"},
+		{Id: "11", Status: entities.ImageStatusPending, Source: "private/products/1/abc/source.png"},
+	}}, nil)
+
+	gallery, err := interactor.GetImages("1", false)
+	assert.NoError(t, err)
+	assert.Empty(t, gallery[1].SourceURL)
+
+	gallery, err = interactor.GetImages("1", true)
+	assert.NoError(t, err)
+	assert.Empty(t, gallery[0].SourceURL)
+	assert.Equal(t, "https://api.example.com/images/private/products/1/abc/source.png?signature=x", gallery[1].SourceURL)
+}
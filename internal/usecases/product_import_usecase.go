@@ -0,0 +1,294 @@
+// usecases/product_import_usecase.go
+package usecases
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/errors"
+	"github.com/shayja/go-template-api/pkg/money"
+)
+
+// Default limits of a product import
+const (
+	DefaultMaxImportSize = 10 << 20
+	DefaultMaxImportRows = 10000
+)
+
+// The longest JSON Lines row accepted
+const maxImportLine = 1 << 20
+
+// The columns of an exported CSV file, an import reads the product fields of the same names
+var exportColumns = []string{"sku", "name", "description", "price", "image", "id", "created_at", "updated_at"}
+
+// The leading characters a spreadsheet reads as the start of a formula
+const formulaPrefixes = "=+-@\t\r"
+
+// ImportProducts reads the products of a CSV or JSON Lines file, validates them like a single product
+// request and upserts the valid ones by SKU.
+// A transactional import saves nothing unless every row is valid and saved, a best effort import saves
+// the valid rows only. A dry run reports the outcome of every row without saving anything.
+func (uc *ProductInteractor) ImportProducts(content io.Reader, options *entities.ProductImportRequest) (*entities.ProductImportReport, error) {
+	maxSize := uc.MaxImportSize
+	if maxSize <= 0 {
+		maxSize = DefaultMaxImportSize
+	}
+	maxRows := uc.MaxImportRows
+	if maxRows <= 0 {
+		maxRows = DefaultMaxImportRows
+	}
+	content = &limitedReader{r: content, n: maxSize}
+
+	var rows []*entities.ProductImportRow
+	var err error
+	switch options.Format {
+	case entities.FormatCSV:
+		rows, err = parseCSVImport(content, maxRows)
+	case entities.FormatJSONL:
+		rows, err = parseJSONLImport(content, maxRows)
+	default:
+		return nil, errors.ErrInvalidImportFile
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	mode := options.Mode
+	if mode == "" {
+		mode = entities.ImportTransactional
+	}
+	report := &entities.ProductImportReport{Mode: mode, DryRun: options.DryRun, Total: len(rows), Rows: rows}
+
+	// A SKU may appear once per file, otherwise the later row would silently overwrite the earlier one
+	lines := make(map[string]int)
+	var valid []*entities.ProductImportRow
+	for _, row := range rows {
+		if len(row.Errors) == 0 {
+			row.Errors = row.Product.Validate()
+		}
+		if key := strings.ToLower(row.Sku); key != "" {
+			if line, ok := lines[key]; ok {
+				row.Errors = append(row.Errors, fmt.Sprintf("sku is a duplicate of row %d", line))
+			} else {
+				lines[key] = row.Row
+			}
+		}
+		if len(row.Errors) > 0 {
+			row.Action = entities.ImportInvalid
+			report.Invalid++
+			continue
+		}
+		valid = append(valid, row)
+	}
+
+	atomic := mode == entities.ImportTransactional
+	commit := !options.DryRun && !(atomic && report.Invalid > 0)
+	if len(valid) > 0 {
+		report.Committed, err = uc.ProductRepository.Import(valid, atomic, commit)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, row := range valid {
+		switch row.Action {
+		case entities.ImportCreated:
+			report.Created++
+		case entities.ImportUpdated:
+			report.Updated++
+		case entities.ImportFailed:
+			report.Failed++
+		}
+	}
+	return report, nil
+}
+
+// parseCSVImport reads the rows of a CSV file, matching the header row to the product fields
+func parseCSVImport(content io.Reader, maxRows int) ([]*entities.ProductImportRow, error) {
+	reader := csv.NewReader(content)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, importReadError(err, 1)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		// Spreadsheet applications may start the file with a byte order mark
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if _, ok := columns[name]; !ok {
+			columns[name] = i
+		}
+	}
+	if _, ok := columns["sku"]; !ok {
+		return nil, errors.ErrInvalidImportFile
+	}
+
+	var rows []*entities.ProductImportRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// A parse error carries its own line
+			return nil, importReadError(err, 0)
+		}
+		line, _ := reader.FieldPos(0)
+		if len(rows) == maxRows {
+			return nil, errors.ErrImportTooLarge
+		}
+		value := func(name string) string {
+			i, ok := columns[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		row := &entities.ProductImportRow{Row: line, Sku: unescapeFormula(value("sku"))}
+		row.Product = &entities.ProductRequest{
+			Name:        unescapeFormula(value("name")),
+			Description: unescapeFormula(value("description")),
+			ImageURL:    value("image"),
+			Sku:         row.Sku,
+		}
+		if price := value("price"); price != "" {
+			parsed, err := money.Parse(price)
+			if err != nil {
+				row.Errors = append(row.Errors, "price must be a number")
+			} else {
+				row.Product.Price = parsed
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// parseJSONLImport reads the rows of a JSON Lines file, one product object per line
+func parseJSONLImport(content io.Reader, maxRows int) ([]*entities.ProductImportRow, error) {
+	scanner := bufio.NewScanner(content)
+	scanner.Buffer(make([]byte, 64*1024), maxImportLine)
+
+	var rows []*entities.ProductImportRow
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		if len(rows) == maxRows {
+			return nil, errors.ErrImportTooLarge
+		}
+
+		product := &entities.ProductRequest{}
+		row := &entities.ProductImportRow{Row: line, Product: product}
+		if err := json.Unmarshal(text, product); err != nil {
+			row.Errors = []string{"invalid JSON: " + err.Error()}
+		}
+		product.Sku = strings.TrimSpace(product.Sku)
+		row.Sku = product.Sku
+		rows = append(rows, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, importReadError(err, line+1)
+	}
+	return rows, nil
+}
+
+// importReadError maps a failure to read an imported file to the error reported to the client
+func importReadError(err error, line int) error {
+	if err == errors.ErrImportTooLarge {
+		return err
+	}
+	if err == io.EOF {
+		return errors.ErrInvalidImportFile
+	}
+	if parseErr, ok := err.(*csv.ParseError); ok {
+		line = parseErr.Line
+		err = parseErr.Err
+	}
+	return errors.New(errors.ErrInvalidImportFile.Code, fmt.Sprintf("%s (line %d: %v)", errors.ErrInvalidImportFile.Message, line, err), err)
+}
+
+// limitedReader fails with ErrImportTooLarge instead of truncating the content past n bytes
+type limitedReader struct {
+	r io.Reader
+	n int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.n < 0 {
+		return 0, errors.ErrImportTooLarge
+	}
+	// Read one byte past the limit to tell a file of exactly n bytes from a larger one
+	if int64(len(p)) > l.n+1 {
+		p = p[:l.n+1]
+	}
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+	if l.n < 0 {
+		return 0, errors.ErrImportTooLarge
+	}
+	return n, err
+}
+
+// escapeFormula prefixes a cell a spreadsheet would run as a formula with a quote, so it is shown as text
+func escapeFormula(value string) string {
+	if value != "" && strings.ContainsRune(formulaPrefixes, rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// unescapeFormula removes the quote escapeFormula added, so an exported file imports back unchanged
+func unescapeFormula(value string) string {
+	if len(value) > 1 && value[0] == '\'' && strings.ContainsRune(formulaPrefixes, rune(value[1])) {
+		return value[1:]
+	}
+	return value
+}
+
+// ExportProducts streams the active products ordered by SKU as CSV or JSON Lines
+func (uc *ProductInteractor) ExportProducts(w io.Writer, format string) error {
+	switch format {
+	case entities.FormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(exportColumns); err != nil {
+			return err
+		}
+		err := uc.ProductRepository.Export(func(product *entities.Product) error {
+			return writer.Write([]string{
+				escapeFormula(product.Sku),
+				escapeFormula(product.Name),
+				escapeFormula(product.Description),
+				product.Price.String(),
+				product.ImageURL,
+				product.Id,
+				product.CreatedAt.UTC().Format(time.RFC3339),
+				product.UpdatedAt.UTC().Format(time.RFC3339),
+			})
+		})
+		if err != nil {
+			return err
+		}
+		writer.Flush()
+		return writer.Error()
+	case entities.FormatJSONL:
+		encoder := json.NewEncoder(w)
+		return uc.ProductRepository.Export(func(product *entities.Product) error {
+			return encoder.Encode(product)
+		})
+	default:
+		return errors.ErrInvalidInput
+	}
+}
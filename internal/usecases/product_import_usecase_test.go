@@ -0,0 +1,162 @@
+package usecases_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shayja/go-template-api/internal/entities"
+	appErrors "github.com/shayja/go-template-api/internal/errors"
+	"github.com/shayja/go-template-api/internal/usecases"
+	"github.com/shayja/go-template-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// saveRows marks the rows passed to the import as created, as the repository would
+func saveRows(args mock.Arguments) {
+	for _, row := range args.Get(0).([]*entities.ProductImportRow) {
+		row.Action, row.Id = entities.ImportCreated, "new-"+row.Sku
+	}
+}
+
+func TestProductInteractor_ImportProducts_CSV(t *testing.T) {
+	repo := new(MockProductRepository)
+	interactor := &usecases.ProductInteractor{ProductRepository: repo}
+
+	file := "\ufeffSKU,Name,Description,Price,Image,Ignored\n" +
+		"A-1,Phone,A phone,9.99,http://img/a.png,x\n" +
+		"A-2,,A case,abc,http://img/b.png,x\n"
+	repo.On("Import", mock.Anything, true, false).Run(saveRows).Return(false, nil)
+
+	report, err := interactor.ImportProducts(strings.NewReader(file), &entities.ProductImportRequest{Format: entities.FormatCSV})
+
+	assert.NoError(t, err)
+	assert.Equal(t, entities.ImportTransactional, report.Mode)
+	assert.False(t, report.Committed)
+	assert.Equal(t, 2, report.Total)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, 1, report.Invalid)
+
+	assert.Equal(t, 2, report.Rows[0].Row)
+	assert.Equal(t, entities.ImportCreated, report.Rows[0].Action)
+	assert.Equal(t, &entities.ProductRequest{Name: "Phone", Description: "A phone", Price: money.New(9, 99), ImageURL: "http://img/a.png", Sku: "A-1"}, report.Rows[0].Product)
+
+	assert.Equal(t, 3, report.Rows[1].Row)
+	assert.Equal(t, entities.ImportInvalid, report.Rows[1].Action)
+	assert.Equal(t, []string{"price must be a number"}, report.Rows[1].Errors)
+	repo.AssertExpectations(t)
+}
+
+func TestProductInteractor_ImportProducts_JSONLBestEffort(t *testing.T) {
+	repo := new(MockProductRepository)
+	interactor := &usecases.ProductInteractor{ProductRepository: repo}
+
+	file := `{"sku":"A-1","name":"Phone","description":"A phone","price":9.99,"image":"http://img/a.png"}
+
+{"sku":"A-2","name":"Case"}
+{"sku":"a-1","name":"Phone","description":"A phone","price":9.99,"image":"http://img/a.png"}
+not json
+`
+	repo.On("Import", mock.Anything, false, true).Run(saveRows).Return(true, nil)
+
+	report, err := interactor.ImportProducts(strings.NewReader(file), &entities.ProductImportRequest{Format: entities.FormatJSONL, Mode: entities.ImportBestEffort})
+
+	assert.NoError(t, err)
+	assert.True(t, report.Committed)
+	assert.Equal(t, 4, report.Total)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, 3, report.Invalid)
+	assert.Equal(t, 3, report.Rows[1].Row)
+	assert.ElementsMatch(t, []string{"description is required", "image is required", "price is required"}, report.Rows[1].Errors)
+	assert.Equal(t, []string{"sku is a duplicate of row 1"}, report.Rows[2].Errors)
+	assert.Equal(t, 5, report.Rows[3].Row)
+	assert.Contains(t, report.Rows[3].Errors[0], "invalid JSON")
+	repo.AssertExpectations(t)
+}
+
+func TestProductInteractor_ImportProducts_DryRun(t *testing.T) {
+	repo := new(MockProductRepository)
+	interactor := &usecases.ProductInteractor{ProductRepository: repo}
+
+	file := "sku,name,description,price,image\nA-1,Phone,A phone,9.99,http://img/a.png\n"
+	repo.On("Import", mock.Anything, true, false).Run(saveRows).Return(false, nil)
+
+	report, err := interactor.ImportProducts(strings.NewReader(file), &entities.ProductImportRequest{Format: entities.FormatCSV, DryRun: true})
+
+	assert.NoError(t, err)
+	assert.True(t, report.DryRun)
+	assert.False(t, report.Committed)
+	assert.Equal(t, 1, report.Created)
+	repo.AssertExpectations(t)
+}
+
+func TestProductInteractor_ImportProducts_InvalidFile(t *testing.T) {
+	repo := new(MockProductRepository)
+	interactor := &usecases.ProductInteractor{ProductRepository: repo}
+
+	_, err := interactor.ImportProducts(strings.NewReader("name,price\nPhone,1\n"), &entities.ProductImportRequest{Format: entities.FormatCSV})
+	assert.ErrorIs(t, err, appErrors.ErrInvalidImportFile)
+
+	_, err = interactor.ImportProducts(strings.NewReader("sku\n\"A-1\n"), &entities.ProductImportRequest{Format: entities.FormatCSV})
+	assert.ErrorContains(t, err, appErrors.ErrInvalidImportFile.Code)
+
+	_, err = interactor.ImportProducts(strings.NewReader(""), &entities.ProductImportRequest{})
+	assert.ErrorIs(t, err, appErrors.ErrInvalidImportFile)
+	repo.AssertNotCalled(t, "Import", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestProductInteractor_ImportProducts_TooLarge(t *testing.T) {
+	repo := new(MockProductRepository)
+	file := "sku\nA-1\nA-2\nA-3\n"
+
+	interactor := &usecases.ProductInteractor{ProductRepository: repo, MaxImportRows: 2}
+	_, err := interactor.ImportProducts(strings.NewReader(file), &entities.ProductImportRequest{Format: entities.FormatCSV})
+	assert.ErrorIs(t, err, appErrors.ErrImportTooLarge)
+
+	interactor = &usecases.ProductInteractor{ProductRepository: repo, MaxImportSize: int64(len(file) - 1)}
+	_, err = interactor.ImportProducts(strings.NewReader(file), &entities.ProductImportRequest{Format: entities.FormatCSV})
+	assert.ErrorIs(t, err, appErrors.ErrImportTooLarge)
+}
+
+func TestProductInteractor_ExportProducts(t *testing.T) {
+	repo := new(MockProductRepository)
+	interactor := &usecases.ProductInteractor{ProductRepository: repo}
+
+	date := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	products := []*entities.Product{{Id: "1", Name: "Phone, black", Description: "A phone", ImageURL: "http://img/a.png", Price: money.New(10, 0), Sku: "A-1", CreatedAt: date, UpdatedAt: date}}
+	repo.On("Export").Return(products, nil)
+
+	var csv bytes.Buffer
+	assert.NoError(t, interactor.ExportProducts(&csv, entities.FormatCSV))
+	assert.Equal(t, "sku,name,description,price,image,id,created_at,updated_at\n"+
+		"A-1,\"Phone, black\",A phone,10.00,http://img/a.png,1,2025-01-02T03:04:05Z,2025-01-02T03:04:05Z\n", csv.String())
+
+	var jsonl bytes.Buffer
+	assert.NoError(t, interactor.ExportProducts(&jsonl, entities.FormatJSONL))
+	assert.Equal(t, `{"id":"1","name":"Phone, black","description":"A phone","image":"http://img/a.png","price":10.00,"sku":"A-1","created_at":"2025-01-02T03:04:05Z","updated_at":"2025-01-02T03:04:05Z","rating_average":0,"rating_count":0,"version":0}`+"\n", jsonl.String())
+}
+
+func TestProductInteractor_ExportProducts_Formulas(t *testing.T) {
+	repo := new(MockProductRepository)
+	interactor := &usecases.ProductInteractor{ProductRepository: repo}
+
+	date := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	products := []*entities.Product{{Id: "1", Name: `=HYPERLINK("http://evil","Phone")`, Description: "@SUM(A1)", Price: money.New(10, 0), Sku: "-A1", CreatedAt: date, UpdatedAt: date}}
+	repo.On("Export").Return(products, nil)
+
+	// The cells a spreadsheet would run as formulas are quoted
+	var csv bytes.Buffer
+	assert.NoError(t, interactor.ExportProducts(&csv, entities.FormatCSV))
+	assert.Equal(t, "sku,name,description,price,image,id,created_at,updated_at\n"+
+		`'-A1,"'=HYPERLINK(""http://evil"",""Phone"")",'@SUM(A1),10.00,,1,2025-01-02T03:04:05Z,2025-01-02T03:04:05Z`+"\n", csv.String())
+
+	// and import back unchanged
+	repo.On("Import", mock.Anything, true, false).Run(saveRows).Return(true, nil)
+	report, err := interactor.ImportProducts(&csv, &entities.ProductImportRequest{Format: entities.FormatCSV})
+	assert.NoError(t, err)
+	assert.Equal(t, "-A1", report.Rows[0].Sku)
+	assert.Equal(t, products[0].Name, report.Rows[0].Product.Name)
+	assert.Equal(t, "@SUM(A1)", report.Rows[0].Product.Description)
+}
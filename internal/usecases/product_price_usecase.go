@@ -0,0 +1,89 @@
+// usecases/product_price_usecase.go
+package usecases
+
+import (
+	"time"
+
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/errors"
+)
+
+type PriceRepository interface {
+	GetPriceHistory(productId string, list *entities.ListRequest) (*entities.List[*entities.PriceChange], error)
+	GetPriceSchedules(productId string) ([]*entities.PriceSchedule, error)
+	CreatePriceSchedule(productId string, request *entities.PriceScheduleRequest) (*entities.PriceSchedule, error)
+	CancelPriceSchedule(productId string, scheduleId string, now time.Time) (*entities.PriceSchedule, error)
+	ApplyPriceSchedules(now time.Time) (int, int, error)
+}
+
+type PriceInteractor struct {
+	PriceRepository   PriceRepository
+	ProductRepository ProductRepository
+	// Optional, the clock of the schedules, defaults to time.Now
+	Now func() time.Time
+}
+
+// now returns the time of the schedules clock in UTC, the time zone the schedules are stored in
+func (uc *PriceInteractor) now() time.Time {
+	if uc.Now != nil {
+		return uc.Now().UTC()
+	}
+	return time.Now().UTC()
+}
+
+// GetPrices returns the current price of an active product with its price history, and the
+// pending and active price schedules when asked to
+func (uc *PriceInteractor) GetPrices(productId string, list *entities.ListRequest, withSchedules bool) (*entities.ProductPrices, error) {
+	product, err := uc.ProductRepository.GetById(productId)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil || product.Id == "" || product.DeletedAt != nil {
+		return nil, errors.ErrProductNotFound
+	}
+
+	history, err := uc.PriceRepository.GetPriceHistory(productId, list)
+	if err != nil {
+		return nil, err
+	}
+	prices := &entities.ProductPrices{ProductId: product.Id, Price: product.Price, History: history}
+	if withSchedules {
+		if prices.Schedules, err = uc.PriceRepository.GetPriceSchedules(productId); err != nil {
+			return nil, err
+		}
+	}
+	return prices, nil
+}
+
+// SchedulePrice schedules a future price of a product, restoring the replaced price at the
+// end of the schedule when it has one
+func (uc *PriceInteractor) SchedulePrice(productId string, request *entities.PriceScheduleRequest) (*entities.PriceSchedule, error) {
+	if request.Price <= 0 || !request.StartsAt.After(uc.now()) {
+		return nil, errors.ErrInvalidPriceSchedule
+	}
+	if request.EndsAt != nil && !request.EndsAt.After(request.StartsAt) {
+		return nil, errors.ErrInvalidPriceSchedule
+	}
+	return uc.PriceRepository.CreatePriceSchedule(productId, request)
+}
+
+// CancelSchedule cancels a pending price schedule, or ends an active one, restoring the price it replaced right away
+func (uc *PriceInteractor) CancelSchedule(productId string, scheduleId string) (*entities.PriceSchedule, error) {
+	now := uc.now()
+	schedule, err := uc.PriceRepository.CancelPriceSchedule(productId, scheduleId, now)
+	if err != nil {
+		return nil, err
+	}
+	if schedule.Status == entities.PriceScheduleActive {
+		if _, _, err := uc.PriceRepository.ApplyPriceSchedules(now); err != nil {
+			return nil, err
+		}
+		schedule.Status = entities.PriceScheduleCompleted
+	}
+	return schedule, nil
+}
+
+// ApplySchedules starts and ends the price schedules due by now, returning how many started and ended
+func (uc *PriceInteractor) ApplySchedules() (int, int, error) {
+	return uc.PriceRepository.ApplyPriceSchedules(uc.now())
+}
@@ -0,0 +1,148 @@
+package usecases_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shayja/go-template-api/internal/entities"
+	appErrors "github.com/shayja/go-template-api/internal/errors"
+	"github.com/shayja/go-template-api/internal/usecases"
+	"github.com/shayja/go-template-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockPriceRepository mocks the PriceRepository interface
+type MockPriceRepository struct {
+	mock.Mock
+}
+
+func (m *MockPriceRepository) GetPriceHistory(productId string, list *entities.ListRequest) (*entities.List[*entities.PriceChange], error) {
+	args := m.Called(productId, list)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.List[*entities.PriceChange]), args.Error(1)
+}
+
+func (m *MockPriceRepository) GetPriceSchedules(productId string) ([]*entities.PriceSchedule, error) {
+	args := m.Called(productId)
+	return args.Get(0).([]*entities.PriceSchedule), args.Error(1)
+}
+
+func (m *MockPriceRepository) CreatePriceSchedule(productId string, request *entities.PriceScheduleRequest) (*entities.PriceSchedule, error) {
+	args := m.Called(productId, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.PriceSchedule), args.Error(1)
+}
+
+func (m *MockPriceRepository) CancelPriceSchedule(productId string, scheduleId string, now time.Time) (*entities.PriceSchedule, error) {
+	args := m.Called(productId, scheduleId, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.PriceSchedule), args.Error(1)
+}
+
+func (m *MockPriceRepository) ApplyPriceSchedules(now time.Time) (int, int, error) {
+	args := m.Called(now)
+	return args.Int(0), args.Int(1), args.Error(2)
+}
+
+func newPriceInteractor(now time.Time) (*usecases.PriceInteractor, *MockPriceRepository, *MockProductRepository) {
+	prices := new(MockPriceRepository)
+	products := new(MockProductRepository)
+	interactor := &usecases.PriceInteractor{PriceRepository: prices, ProductRepository: products, Now: func() time.Time { return now }}
+	return interactor, prices, products
+}
+
+func TestPriceInteractor_GetPrices(t *testing.T) {
+	interactor, prices, products := newPriceInteractor(time.Now())
+
+	list := &entities.ListRequest{}
+	history := &entities.List[*entities.PriceChange]{Items: []*entities.PriceChange{{Id: "10", Price: money.New(79, 90), Source: entities.PriceSourceScheduled}}}
+	schedules := []*entities.PriceSchedule{{Id: "20", Status: entities.PriceScheduleActive}}
+	products.On("GetById", "1").Return(&entities.Product{Id: "1", Price: money.New(79, 90)}, nil)
+	prices.On("GetPriceHistory", "1", list).Return(history, nil)
+	prices.On("GetPriceSchedules", "1").Return(schedules, nil)
+
+	result, err := interactor.GetPrices("1", list, true)
+	assert.NoError(t, err)
+	assert.Equal(t, money.New(79, 90), result.Price)
+	assert.Equal(t, history, result.History)
+	assert.Equal(t, schedules, result.Schedules)
+
+	result, err = interactor.GetPrices("1", list, false)
+	assert.NoError(t, err)
+	assert.Nil(t, result.Schedules)
+	prices.AssertNumberOfCalls(t, "GetPriceSchedules", 1)
+}
+
+func TestPriceInteractor_GetPrices_DeletedProduct(t *testing.T) {
+	interactor, prices, products := newPriceInteractor(time.Now())
+
+	deletedAt := time.Now()
+	products.On("GetById", "1").Return(&entities.Product{Id: "1", DeletedAt: &deletedAt}, nil)
+
+	_, err := interactor.GetPrices("1", &entities.ListRequest{}, true)
+	assert.ErrorIs(t, err, appErrors.ErrProductNotFound)
+	prices.AssertNotCalled(t, "GetPriceHistory", mock.Anything, mock.Anything)
+}
+
+func TestPriceInteractor_SchedulePrice(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	interactor, prices, _ := newPriceInteractor(now)
+
+	endsAt := now.Add(48 * time.Hour)
+	request := &entities.PriceScheduleRequest{Price: money.New(79, 90), StartsAt: now.Add(24 * time.Hour), EndsAt: &endsAt}
+	schedule := &entities.PriceSchedule{Id: "20", Status: entities.PriceScheduleScheduled}
+	prices.On("CreatePriceSchedule", "1", request).Return(schedule, nil)
+
+	result, err := interactor.SchedulePrice("1", request)
+	assert.NoError(t, err)
+	assert.Equal(t, schedule, result)
+}
+
+func TestPriceInteractor_SchedulePrice_Invalid(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	interactor, prices, _ := newPriceInteractor(now)
+
+	endsAt := now.Add(time.Hour)
+	requests := []*entities.PriceScheduleRequest{
+		{Price: money.New(79, 90), StartsAt: now.Add(-time.Hour)},
+		{Price: money.New(79, 90), StartsAt: now.Add(2 * time.Hour), EndsAt: &endsAt},
+		{Price: 0, StartsAt: now.Add(time.Hour)},
+	}
+	for _, request := range requests {
+		_, err := interactor.SchedulePrice("1", request)
+		assert.ErrorIs(t, err, appErrors.ErrInvalidPriceSchedule)
+	}
+	prices.AssertNotCalled(t, "CreatePriceSchedule", mock.Anything, mock.Anything)
+}
+
+func TestPriceInteractor_CancelSchedule_Active(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	interactor, prices, _ := newPriceInteractor(now)
+
+	prices.On("CancelPriceSchedule", "1", "20", now).Return(&entities.PriceSchedule{Id: "20", Status: entities.PriceScheduleActive}, nil)
+	prices.On("ApplyPriceSchedules", now).Return(0, 1, nil)
+
+	result, err := interactor.CancelSchedule("1", "20")
+	assert.NoError(t, err)
+	assert.Equal(t, entities.PriceScheduleCompleted, result.Status)
+	prices.AssertExpectations(t)
+}
+
+func TestPriceInteractor_CancelSchedule_Pending(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	interactor, prices, _ := newPriceInteractor(now)
+
+	prices.On("CancelPriceSchedule", "1", "20", now).Return(&entities.PriceSchedule{Id: "20", Status: entities.PriceScheduleCancelled}, nil)
+
+	result, err := interactor.CancelSchedule("1", "20")
+	assert.NoError(t, err)
+	assert.Equal(t, entities.PriceScheduleCancelled, result.Status)
+	prices.AssertNotCalled(t, "ApplyPriceSchedules", mock.Anything)
+}
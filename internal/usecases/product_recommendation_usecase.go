@@ -0,0 +1,98 @@
+// usecases/product_recommendation_usecase.go
+package usecases
+
+import (
+	"time"
+
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/errors"
+)
+
+// Limits of the recommendations returned at once
+const (
+	DefaultRecommendationLimit = 8
+	MaxRecommendationLimit     = 50
+)
+
+// DefaultRecommendationWeights favor the products bought together over the products that are only alike
+var DefaultRecommendationWeights = entities.RecommendationWeights{CoPurchase: 0.6, Category: 0.25, Tag: 0.15}
+
+// RecommendationRepository keeps the co-purchase counts and scores the recommendations.
+type RecommendationRepository interface {
+	RefreshCoPurchases(since time.Time) (int64, error)
+	Recommend(productIds []string, weights entities.RecommendationWeights, limit int) ([]*entities.Recommendation, error)
+}
+
+// RecommendationConfig is how the related products are recommended.
+type RecommendationConfig struct {
+	Repository RecommendationRepository
+	// Optional, defaults to DefaultRecommendationWeights
+	Weights *entities.RecommendationWeights
+	// Optional, only the orders placed within the window count as co-purchases, all of them when 0
+	OrderWindow time.Duration
+}
+
+// Related recommends the products bought together with an active product or sharing its categories and tags
+func (uc *ProductInteractor) Related(id string, limit int) ([]*entities.Recommendation, error) {
+	product, err := active(uc.ProductRepository.GetById(id))
+	if err != nil {
+		return nil, err
+	}
+	if product == nil || product.Id == "" {
+		return nil, errors.ErrProductNotFound
+	}
+	return uc.recommend([]string{id}, limit)
+}
+
+// RecommendForCart recommends the products bought together with the products of a cart or sharing their
+// categories and tags, leaving out the products already in the cart
+func (uc *ProductInteractor) RecommendForCart(request *entities.CartRecommendationRequest) ([]*entities.Recommendation, error) {
+	ids := make([]string, 0, len(request.ProductIds))
+	seen := make(map[string]bool)
+	for _, id := range request.ProductIds {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return uc.recommend(ids, request.Limit)
+}
+
+func (uc *ProductInteractor) recommend(ids []string, limit int) ([]*entities.Recommendation, error) {
+	if uc.Recommendations == nil || uc.Recommendations.Repository == nil {
+		return []*entities.Recommendation{}, nil
+	}
+	if limit <= 0 {
+		limit = DefaultRecommendationLimit
+	}
+	limit = min(limit, MaxRecommendationLimit)
+	weights := DefaultRecommendationWeights
+	if uc.Recommendations.Weights != nil {
+		weights = *uc.Recommendations.Weights
+	}
+
+	recommendations, err := uc.Recommendations.Repository.Recommend(ids, weights, limit)
+	if err != nil {
+		return nil, err
+	}
+	products := make([]*entities.Product, len(recommendations))
+	for i, recommendation := range recommendations {
+		products[i] = recommendation.Product
+	}
+	if err := uc.addGallery(products...); err != nil {
+		return nil, err
+	}
+	return recommendations, nil
+}
+
+// RefreshCoPurchases rebuilds the counts of the products bought together, returning the number of product pairs
+func (uc *ProductInteractor) RefreshCoPurchases() (int64, error) {
+	if uc.Recommendations == nil || uc.Recommendations.Repository == nil {
+		return 0, nil
+	}
+	since := time.Time{}
+	if uc.Recommendations.OrderWindow > 0 {
+		since = time.Now().Add(-uc.Recommendations.OrderWindow)
+	}
+	return uc.Recommendations.Repository.RefreshCoPurchases(since)
+}
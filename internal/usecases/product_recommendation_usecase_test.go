@@ -0,0 +1,88 @@
+package usecases_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shayja/go-template-api/internal/entities"
+	appErrors "github.com/shayja/go-template-api/internal/errors"
+	"github.com/shayja/go-template-api/internal/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRecommendationRepository mocks the RecommendationRepository interface
+type MockRecommendationRepository struct {
+	mock.Mock
+}
+
+func (m *MockRecommendationRepository) RefreshCoPurchases(since time.Time) (int64, error) {
+	args := m.Called(since)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRecommendationRepository) Recommend(productIds []string, weights entities.RecommendationWeights, limit int) ([]*entities.Recommendation, error) {
+	args := m.Called(productIds, weights, limit)
+	if recommendations, ok := args.Get(0).([]*entities.Recommendation); ok {
+		return recommendations, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func TestProductInteractor_Related(t *testing.T) {
+	products := new(MockProductRepository)
+	repo := new(MockRecommendationRepository)
+	interactor := &usecases.ProductInteractor{ProductRepository: products, Recommendations: &usecases.RecommendationConfig{Repository: repo}}
+
+	recommendations := []*entities.Recommendation{{Product: &entities.Product{Id: "2", Name: "Phone Case"}, CoPurchases: 8, Score: 0.9}}
+	products.On("GetById", "1").Return(&entities.Product{Id: "1"}, nil)
+	repo.On("Recommend", []string{"1"}, usecases.DefaultRecommendationWeights, usecases.DefaultRecommendationLimit).Return(recommendations, nil)
+
+	result, err := interactor.Related("1", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, recommendations, result)
+	repo.AssertExpectations(t)
+}
+
+func TestProductInteractor_Related_NotFound(t *testing.T) {
+	products := new(MockProductRepository)
+	repo := new(MockRecommendationRepository)
+	interactor := &usecases.ProductInteractor{ProductRepository: products, Recommendations: &usecases.RecommendationConfig{Repository: repo}}
+
+	deleted := time.Now()
+	products.On("GetById", "1").Return(&entities.Product{}, nil)
+	products.On("GetById", "2").Return(&entities.Product{Id: "2", DeletedAt: &deleted}, nil)
+
+	_, err := interactor.Related("1", 5)
+	assert.Equal(t, appErrors.ErrProductNotFound, err)
+	_, err = interactor.Related("2", 5)
+	assert.Equal(t, appErrors.ErrProductNotFound, err)
+	repo.AssertNotCalled(t, "Recommend", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestProductInteractor_RecommendForCart(t *testing.T) {
+	repo := new(MockRecommendationRepository)
+	weights := entities.RecommendationWeights{CoPurchase: 1}
+	interactor := &usecases.ProductInteractor{Recommendations: &usecases.RecommendationConfig{Repository: repo, Weights: &weights}}
+
+	repo.On("Recommend", []string{"1", "2"}, weights, usecases.MaxRecommendationLimit).Return([]*entities.Recommendation{}, nil)
+
+	result, err := interactor.RecommendForCart(&entities.CartRecommendationRequest{ProductIds: []string{"1", "2", "1"}, Limit: 500})
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+	repo.AssertExpectations(t)
+}
+
+func TestProductInteractor_RefreshCoPurchases(t *testing.T) {
+	repo := new(MockRecommendationRepository)
+	interactor := &usecases.ProductInteractor{Recommendations: &usecases.RecommendationConfig{Repository: repo, OrderWindow: 24 * time.Hour}}
+
+	repo.On("RefreshCoPurchases", mock.MatchedBy(func(since time.Time) bool {
+		return time.Since(since) >= 24*time.Hour && time.Since(since) < 25*time.Hour
+	})).Return(int64(12), nil)
+
+	pairs, err := interactor.RefreshCoPurchases()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), pairs)
+	repo.AssertExpectations(t)
+}
@@ -2,48 +2,309 @@
 package usecases
 
 import (
+	"strings"
+	"time"
+
 	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/errors"
+	"github.com/shayja/go-template-api/pkg/money"
 )
 
 type ProductRepository interface {
-	GetAll(page int) ([]*entities.Product, error)
+	Search(filter *entities.ProductFilter) (*entities.List[*entities.Product], error)
 	GetById(id string) (*entities.Product, error)
+	GetBySkus(skus []string) ([]*entities.Product, error)
 	Create(product *entities.ProductRequest) (string, error)
-	Update(id string, product *entities.ProductRequest) (*entities.Product, error)
-	UpdatePrice(id string, product *entities.ProductPriceRequest) (*entities.Product, error)
+	Update(id string, product *entities.ProductRequest, version int) (*entities.Product, error)
+	UpdatePrice(id string, product *entities.ProductPriceRequest, version int) (*entities.Product, error)
 	UpdateImage(id string, product *entities.ProductImageRequest) (*entities.Product, error)
-	Delete(id string) (bool, error)
+	Delete(id string, version int) (bool, error)
+	Restore(id string) (bool, error)
+	Purge(deletedBefore time.Time) (int64, error)
+	Import(rows []*entities.ProductImportRow, atomic bool, commit bool) (bool, error)
+	Export(fn func(*entities.Product) error) error
+	Facets(filter *entities.ProductFilter, priceBounds []money.Amount, maxValues int) (*entities.ProductFacets, error)
 }
 	
 type ProductInteractor struct {
     ProductRepository ProductRepository
+    // Optional, adds category breadcrumbs to the returned products
+    CategoryRepository CategoryRepository
+    // Optional, adds the options and variants to product details
+    VariantRepository VariantRepository
+    // Optional, enables image uploads and adds the galleries to the returned products
+    Images *ImageConfig
+    // Optional, limits the size in bytes of an imported file, defaults to DefaultMaxImportSize
+    MaxImportSize int64
+    // Optional, limits the rows of an imported file, defaults to DefaultMaxImportRows
+    MaxImportRows int
+    // Optional, checks the product attributes against their definitions, products have no attributes without it
+    AttributeRepository AttributeRepository
+    // Optional, the bounds of the price ranges counted in the facets, defaults to DefaultPriceBuckets
+    PriceBuckets []money.Amount
+    // Optional, limits the tags and values of each attribute counted in the facets, defaults to DefaultFacetMaxValues
+    FacetMaxValues int
+    // Optional, enables the semantic search and embeds the products in the background
+    Embeddings *EmbeddingConfig
+    // Optional, enables the related products and cart recommendations
+    Recommendations *RecommendationConfig
+    // Optional, enables the bundle products
+    BundleRepository BundleRepository
 }
 
-func (uc *ProductInteractor) GetAll(page int) ([]*entities.Product, error) {
-	return uc.ProductRepository.GetAll(page)
+// Search lists a page of the products matching the filter, with the facet counts of all of them
+// when the filter includes the facets
+func (uc *ProductInteractor) Search(filter *entities.ProductFilter) (*entities.ProductList, error) {
+	tags, err := NormalizeTags(filter.Tags)
+	if err != nil {
+		return nil, err
+	}
+	filter.Tags = tags
+	if len(filter.Attributes) > 0 {
+		definitions, err := uc.attributeDefinitions()
+		if err != nil {
+			return nil, err
+		}
+		if err := ParseAttributeFilter(definitions, filter.Attributes); err != nil {
+			return nil, err
+		}
+	}
+
+	list, err := uc.ProductRepository.Search(filter)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.addBreadcrumbs(list.Items...); err != nil {
+		return nil, err
+	}
+	if err := uc.addGallery(list.Items...); err != nil {
+		return nil, err
+	}
+
+	res := &entities.ProductList{List: *list}
+	if filter.IncludeFacets {
+		if res.Facets, err = uc.facets(filter); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
 }
 
+// GetById returns an active product, soft deleted products are not found
 func (uc *ProductInteractor) GetById(id string) (*entities.Product, error) {
-	return uc.ProductRepository.GetById(id)
+	return uc.Find(id, false)
+}
+
+// Find returns a product, including soft deleted products when asked to
+func (uc *ProductInteractor) Find(id string, includeDeleted bool) (*entities.Product, error) {
+	product, err := uc.ProductRepository.GetById(id)
+	if err != nil {
+		return nil, err
+	}
+	if product != nil && product.DeletedAt != nil && !includeDeleted {
+		return nil, errors.ErrProductNotFound
+	}
+	if product != nil && product.Id != "" {
+		if err := uc.addDetails(product); err != nil {
+			return nil, err
+		}
+	}
+	return product, nil
+}
+
+// GetBySku returns the active product with the SKU, regardless of case
+func (uc *ProductInteractor) GetBySku(sku string) (*entities.Product, error) {
+	sku = strings.TrimSpace(sku)
+	if sku == "" {
+		return nil, errors.ErrProductNotFound
+	}
+	products, err := uc.ProductRepository.GetBySkus([]string{sku})
+	if err != nil {
+		return nil, err
+	}
+	if len(products) == 0 {
+		return nil, errors.ErrProductNotFound
+	}
+	if err := uc.addDetails(products[0]); err != nil {
+		return nil, err
+	}
+	return products[0], nil
+}
+
+// LookupSkus returns the active products with the SKUs in the requested order, and the SKUs no product uses
+func (uc *ProductInteractor) LookupSkus(request *entities.SkuLookupRequest) (*entities.SkuLookupResult, error) {
+	var skus []string
+	requested := make(map[string]bool)
+	for _, sku := range request.Skus {
+		sku = strings.TrimSpace(sku)
+		if key := strings.ToLower(sku); sku != "" && !requested[key] {
+			requested[key] = true
+			skus = append(skus, sku)
+		}
+	}
+	if len(skus) == 0 {
+		return nil, errors.ErrInvalidInput
+	}
+
+	products, err := uc.ProductRepository.GetBySkus(skus)
+	if err != nil {
+		return nil, err
+	}
+	bySku := make(map[string]*entities.Product, len(products))
+	for _, product := range products {
+		bySku[strings.ToLower(product.Sku)] = product
+	}
+	result := &entities.SkuLookupResult{Items: []*entities.Product{}, Missing: []string{}}
+	for _, sku := range skus {
+		if product, ok := bySku[strings.ToLower(sku)]; ok {
+			result.Items = append(result.Items, product)
+		} else {
+			result.Missing = append(result.Missing, sku)
+		}
+	}
+
+	if err := uc.addBreadcrumbs(result.Items...); err != nil {
+		return nil, err
+	}
+	if err := uc.addGallery(result.Items...); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// addDetails adds the breadcrumbs, variants and gallery of a single product
+func (uc *ProductInteractor) addDetails(product *entities.Product) error {
+	if err := uc.addBreadcrumbs(product); err != nil {
+		return err
+	}
+	if err := uc.addVariants(product); err != nil {
+		return err
+	}
+	return uc.addGallery(product)
+}
+
+// addVariants loads the option axes and variants of a product
+func (uc *ProductInteractor) addVariants(product *entities.Product) error {
+	if uc.VariantRepository == nil {
+		return nil
+	}
+	options, err := uc.VariantRepository.GetOptions(product.Id)
+	if err != nil {
+		return err
+	}
+	variants, err := uc.VariantRepository.GetByProduct(product.Id)
+	if err != nil {
+		return err
+	}
+	product.Options = options
+	product.Variants = variants
+	return nil
+}
+
+// addBreadcrumbs loads the category paths of the products in a single query
+func (uc *ProductInteractor) addBreadcrumbs(products ...*entities.Product) error {
+	if uc.CategoryRepository == nil || len(products) == 0 {
+		return nil
+	}
+	ids := make([]string, len(products))
+	for i, product := range products {
+		ids[i] = product.Id
+	}
+	breadcrumbs, err := uc.CategoryRepository.GetBreadcrumbs(ids)
+	if err != nil {
+		return err
+	}
+	for _, product := range products {
+		product.Breadcrumbs = breadcrumbs[product.Id]
+	}
+	return nil
 }
 
 func (uc *ProductInteractor) Create(product *entities.ProductRequest) (string, error) {
+	product.Sku = strings.TrimSpace(product.Sku)
+	if product.Attributes == nil {
+		product.Attributes = map[string]interface{}{}
+	}
+	if err := uc.checkAttributesAndTags(product); err != nil {
+		return "", err
+	}
 	return uc.ProductRepository.Create(product)
 }
 
-func (uc *ProductInteractor) Update(id string, product *entities.ProductRequest) (*entities.Product, error) {
-	return uc.ProductRepository.Update(id, product)
+// Update replaces the product details. A version other than 0 makes the update conditional on
+// the product still being at the version the caller read, failing with ErrVersionMismatch otherwise
+func (uc *ProductInteractor) Update(id string, product *entities.ProductRequest, version int) (*entities.Product, error) {
+	product.Sku = strings.TrimSpace(product.Sku)
+	if err := uc.checkAttributesAndTags(product); err != nil {
+		return nil, err
+	}
+	return active(uc.ProductRepository.Update(id, product, version))
+}
+
+// checkAttributesAndTags validates the attributes of a product request and normalizes its tags,
+// nil attributes or tags are kept as they are
+func (uc *ProductInteractor) checkAttributesAndTags(product *entities.ProductRequest) error {
+	tags, err := NormalizeTags(product.Tags)
+	if err != nil {
+		return err
+	}
+	product.Tags = tags
+
+	if product.Attributes == nil {
+		return nil
+	}
+	definitions, err := uc.attributeDefinitions()
+	if err != nil {
+		return err
+	}
+	return ValidateAttributes(definitions, product.Attributes)
+}
+
+// attributeDefinitions loads the attribute definitions by code, none without an attribute repository
+func (uc *ProductInteractor) attributeDefinitions() (map[string]*entities.AttributeDefinition, error) {
+	if uc.AttributeRepository == nil {
+		return map[string]*entities.AttributeDefinition{}, nil
+	}
+	return definitionsByCode(uc.AttributeRepository)
 }
 
-func (uc *ProductInteractor) UpdatePrice(id string, product *entities.ProductPriceRequest) (*entities.Product, error) {
-	return uc.ProductRepository.UpdatePrice(id, product)
+// UpdatePrice changes the product price, conditional on the version like Update
+func (uc *ProductInteractor) UpdatePrice(id string, product *entities.ProductPriceRequest, version int) (*entities.Product, error) {
+	return active(uc.ProductRepository.UpdatePrice(id, product, version))
 }
 
 func (uc *ProductInteractor) UpdateImage(id string, product *entities.ProductImageRequest) (*entities.Product, error) {
-	return uc.ProductRepository.UpdateImage(id, product)
+	return active(uc.ProductRepository.UpdateImage(id, product))
+}
+
+// Delete soft deletes a product, it is hidden from the catalog until restored or purged.
+// It is conditional on the version like Update
+func (uc *ProductInteractor) Delete(id string, version int) (bool, error) {
+	return uc.ProductRepository.Delete(id, version)
+}
+
+// Restore brings a soft deleted product back to the catalog
+func (uc *ProductInteractor) Restore(id string) (*entities.Product, error) {
+	if _, err := uc.ProductRepository.Restore(id); err != nil {
+		return nil, err
+	}
+	return uc.GetById(id)
+}
+
+// PurgeDeleted permanently deletes the products soft deleted longer than the retention ago,
+// keeping the ones order history still references
+func (uc *ProductInteractor) PurgeDeleted(retention time.Duration) (int64, error) {
+	return uc.ProductRepository.Purge(time.Now().Add(-retention))
 }
 
-func (uc *ProductInteractor) Delete(id string) (bool, error) {
-	return uc.ProductRepository.Delete(id)
+// active rejects the result of a write that the repository skipped because the product is soft deleted
+func active(product *entities.Product, err error) (*entities.Product, error) {
+	if err != nil {
+		return nil, err
+	}
+	if product != nil && product.DeletedAt != nil {
+		return nil, errors.ErrProductNotFound
+	}
+	return product, nil
 }
 
@@ -3,9 +3,12 @@ package usecases_test
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/shayja/go-template-api/internal/entities"
+	appErrors "github.com/shayja/go-template-api/internal/errors"
 	"github.com/shayja/go-template-api/internal/usecases"
+	"github.com/shayja/go-template-api/pkg/money"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -15,9 +18,9 @@ type MockProductRepository struct {
 	mock.Mock
 }
 
-func (m *MockProductRepository) GetAll(page int) ([]*entities.Product, error) {
-	args := m.Called(page)
-	if products, ok := args.Get(0).([]*entities.Product); ok {
+func (m *MockProductRepository) Search(filter *entities.ProductFilter) (*entities.List[*entities.Product], error) {
+	args := m.Called(filter)
+	if products, ok := args.Get(0).(*entities.List[*entities.Product]); ok {
 		return products, args.Error(1)
 	}
 	return nil, args.Error(1)
@@ -31,21 +34,29 @@ func (m *MockProductRepository) GetById(id string) (*entities.Product, error) {
 	return nil, args.Error(1)
 }
 
+func (m *MockProductRepository) GetBySkus(skus []string) ([]*entities.Product, error) {
+	args := m.Called(skus)
+	if products, ok := args.Get(0).([]*entities.Product); ok {
+		return products, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
 func (m *MockProductRepository) Create(product *entities.ProductRequest) (string, error) {
 	args := m.Called(product)
 	return args.String(0), args.Error(1)
 }
 
-func (m *MockProductRepository) Update(id string, product *entities.ProductRequest) (*entities.Product, error) {
-	args := m.Called(id, product)
+func (m *MockProductRepository) Update(id string, product *entities.ProductRequest, version int) (*entities.Product, error) {
+	args := m.Called(id, product, version)
 	if updatedProduct, ok := args.Get(0).(*entities.Product); ok {
 		return updatedProduct, args.Error(1)
 	}
 	return nil, args.Error(1)
 }
 
-func (m *MockProductRepository) UpdatePrice(id string, product *entities.ProductPriceRequest) (*entities.Product, error) {
-	args := m.Called(id, product)
+func (m *MockProductRepository) UpdatePrice(id string, product *entities.ProductPriceRequest, version int) (*entities.Product, error) {
+	args := m.Called(id, product, version)
 	if updatedProduct, ok := args.Get(0).(*entities.Product); ok {
 		return updatedProduct, args.Error(1)
 	}
@@ -60,30 +71,101 @@ func (m *MockProductRepository) UpdateImage(id string, product *entities.Product
 	return nil, args.Error(1)
 }
 
-func (m *MockProductRepository) Delete(id string) (bool, error) {
+func (m *MockProductRepository) Delete(id string, version int) (bool, error) {
+	args := m.Called(id, version)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockProductRepository) Restore(id string) (bool, error) {
 	args := m.Called(id)
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockProductRepository) Purge(deletedBefore time.Time) (int64, error) {
+	args := m.Called(deletedBefore)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockProductRepository) Import(rows []*entities.ProductImportRow, atomic bool, commit bool) (bool, error) {
+	args := m.Called(rows, atomic, commit)
+	return args.Bool(0), args.Error(1)
+}
+
+// Export feeds the products given to the mock to the callback
+func (m *MockProductRepository) Export(fn func(*entities.Product) error) error {
+	args := m.Called()
+	for _, product := range args.Get(0).([]*entities.Product) {
+		if err := fn(product); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+
+func (m *MockProductRepository) Facets(filter *entities.ProductFilter, priceBounds []money.Amount, maxValues int) (*entities.ProductFacets, error) {
+	args := m.Called(filter, priceBounds, maxValues)
+	if facets, ok := args.Get(0).(*entities.ProductFacets); ok {
+		return facets, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
 
-func TestProductInteractor_GetAll(t *testing.T) {
+func TestProductInteractor_Search(t *testing.T) {
 	repo := new(MockProductRepository)
 	interactor := &usecases.ProductInteractor{ProductRepository: repo}
 
-	products := []*entities.Product{
-		{Id: "1", Name: "Product1"},
-		{Id: "2", Name: "Product2"},
+	maxPrice := money.New(1200, 0)
+	filter := &entities.ProductFilter{Query: "galaxy", MaxPrice: &maxPrice}
+	products := &entities.List[*entities.Product]{
+		Items: []*entities.Product{{Id: "1", Name: "Samsung Galaxy S24 Ultra"}},
 	}
 
-	repo.On("GetAll", 1).Return(products, nil)
+	repo.On("Search", filter).Return(products, nil)
 
-	result, err := interactor.GetAll(1)
+	result, err := interactor.Search(filter)
 
 	assert.NoError(t, err)
-	assert.Equal(t, products, result)
+	assert.Equal(t, products, &result.List)
+	assert.Nil(t, result.Facets)
 	repo.AssertExpectations(t)
 }
 
+func TestProductInteractor_Search_Facets(t *testing.T) {
+	repo := new(MockProductRepository)
+	buckets := []money.Amount{0, money.New(100, 0)}
+	interactor := &usecases.ProductInteractor{ProductRepository: repo, PriceBuckets: buckets}
+
+	filter := &entities.ProductFilter{Query: "galaxy", IncludeFacets: true}
+	products := &entities.List[*entities.Product]{Items: []*entities.Product{{Id: "1", Name: "Samsung Galaxy S24 Ultra"}}}
+	facets := &entities.ProductFacets{Tags: []entities.FacetCount{{Value: "sale", Count: 1}}}
+
+	repo.On("Search", filter).Return(products, nil)
+	repo.On("Facets", filter, buckets, usecases.DefaultFacetMaxValues).Return(facets, nil)
+
+	result, err := interactor.Search(filter)
+
+	assert.NoError(t, err)
+	assert.Equal(t, products.Items, result.Items)
+	assert.Equal(t, facets, result.Facets)
+	repo.AssertExpectations(t)
+}
+
+func TestParsePriceBuckets(t *testing.T) {
+	bounds, err := usecases.ParsePriceBuckets("0, 99.99,500")
+	assert.NoError(t, err)
+	assert.Equal(t, []money.Amount{0, money.New(99, 99), money.New(500, 0)}, bounds)
+
+	bounds, err = usecases.ParsePriceBuckets("")
+	assert.NoError(t, err)
+	assert.Nil(t, bounds)
+
+	for _, s := range []string{"100,50", "0,0", "-1,10", "ten"} {
+		_, err := usecases.ParsePriceBuckets(s)
+		assert.ErrorContains(t, err, appErrors.ErrInvalidInput.Code, s)
+	}
+}
+
 func TestProductInteractor_GetById(t *testing.T) {
 	repo := new(MockProductRepository)
 	interactor := &usecases.ProductInteractor{ProductRepository: repo}
@@ -118,9 +200,9 @@ func TestProductInteractor_Update(t *testing.T) {
 
 	productRequest := &entities.ProductRequest{Name: "Updated Product"}
 	updatedProduct := &entities.Product{Id: "1", Name: "Updated Product"}
-	repo.On("Update", "1", productRequest).Return(updatedProduct, nil)
+	repo.On("Update", "1", productRequest, 0).Return(updatedProduct, nil)
 
-	result, err := interactor.Update("1", productRequest)
+	result, err := interactor.Update("1", productRequest, 0)
 
 	assert.NoError(t, err)
 	assert.Equal(t, updatedProduct, result)
@@ -131,11 +213,11 @@ func TestProductInteractor_UpdatePrice(t *testing.T) {
 	repo := new(MockProductRepository)
 	interactor := &usecases.ProductInteractor{ProductRepository: repo}
 
-	priceRequest := &entities.ProductPriceRequest{Price: 99.99}
-	updatedProduct := &entities.Product{Id: "1", Price: 99.99}
-	repo.On("UpdatePrice", "1", priceRequest).Return(updatedProduct, nil)
+	priceRequest := &entities.ProductPriceRequest{Price: money.New(99, 99)}
+	updatedProduct := &entities.Product{Id: "1", Price: money.New(99, 99)}
+	repo.On("UpdatePrice", "1", priceRequest, 0).Return(updatedProduct, nil)
 
-	result, err := interactor.UpdatePrice("1", priceRequest)
+	result, err := interactor.UpdatePrice("1", priceRequest, 0)
 
 	assert.NoError(t, err)
 	assert.Equal(t, updatedProduct, result)
@@ -161,22 +243,23 @@ func TestProductInteractor_Delete(t *testing.T) {
 	repo := new(MockProductRepository)
 	interactor := &usecases.ProductInteractor{ProductRepository: repo}
 
-	repo.On("Delete", "1").Return(true, nil)
+	repo.On("Delete", "1", 0).Return(true, nil)
 
-	result, err := interactor.Delete("1")
+	result, err := interactor.Delete("1", 0)
 
 	assert.NoError(t, err)
 	assert.True(t, result)
 	repo.AssertExpectations(t)
 }
 
-func TestProductInteractor_GetAll_Error(t *testing.T) {
+func TestProductInteractor_Search_Error(t *testing.T) {
 	repo := new(MockProductRepository)
 	interactor := &usecases.ProductInteractor{ProductRepository: repo}
 
-	repo.On("GetAll", 1).Return(nil, errors.New("database error"))
+	filter := &entities.ProductFilter{}
+	repo.On("Search", filter).Return(nil, errors.New("database error"))
 
-	result, err := interactor.GetAll(1)
+	result, err := interactor.Search(filter)
 
 	assert.Error(t, err)           // Assert that an error is returned
 	assert.Nil(t, result)          // Assert that the result is nil
@@ -187,11 +270,98 @@ func TestProductInteractor_Delete_Error(t *testing.T) {
 	repo := new(MockProductRepository)
 	interactor := &usecases.ProductInteractor{ProductRepository: repo}
 
-	repo.On("Delete", "1").Return(false, errors.New("delete error"))
+	repo.On("Delete", "1", 0).Return(false, errors.New("delete error"))
 
-	result, err := interactor.Delete("1")
+	result, err := interactor.Delete("1", 0)
 
 	assert.Error(t, err)
 	assert.False(t, result)
 	repo.AssertExpectations(t)
 }
+
+func TestProductInteractor_GetById_Deleted(t *testing.T) {
+	repo := new(MockProductRepository)
+	interactor := &usecases.ProductInteractor{ProductRepository: repo}
+
+	deletedAt := time.Now()
+	product := &entities.Product{Id: "1", Name: "Product1", DeletedAt: &deletedAt}
+	repo.On("GetById", "1").Return(product, nil)
+
+	_, err := interactor.GetById("1")
+	assert.ErrorIs(t, err, appErrors.ErrProductNotFound)
+
+	result, err := interactor.Find("1", true)
+	assert.NoError(t, err)
+	assert.Equal(t, product, result)
+}
+
+func TestProductInteractor_Update_Deleted(t *testing.T) {
+	repo := new(MockProductRepository)
+	interactor := &usecases.ProductInteractor{ProductRepository: repo}
+
+	deletedAt := time.Now()
+	productRequest := &entities.ProductRequest{Name: "Updated Product"}
+	repo.On("Update", "1", productRequest, 0).Return(&entities.Product{Id: "1", DeletedAt: &deletedAt}, nil)
+
+	_, err := interactor.Update("1", productRequest, 0)
+
+	assert.ErrorIs(t, err, appErrors.ErrProductNotFound)
+}
+
+func TestProductInteractor_Restore(t *testing.T) {
+	repo := new(MockProductRepository)
+	interactor := &usecases.ProductInteractor{ProductRepository: repo}
+
+	product := &entities.Product{Id: "1", Name: "Product1"}
+	repo.On("Restore", "1").Return(true, nil)
+	repo.On("GetById", "1").Return(product, nil)
+
+	result, err := interactor.Restore("1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, product, result)
+	repo.AssertExpectations(t)
+}
+
+func TestProductInteractor_PurgeDeleted(t *testing.T) {
+	repo := new(MockProductRepository)
+	interactor := &usecases.ProductInteractor{ProductRepository: repo}
+
+	repo.On("Purge", mock.MatchedBy(func(before time.Time) bool {
+		return time.Since(before) >= 30*24*time.Hour && time.Since(before) < 30*24*time.Hour+time.Minute
+	})).Return(int64(2), nil)
+
+	purged, err := interactor.PurgeDeleted(30 * 24 * time.Hour)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), purged)
+	repo.AssertExpectations(t)
+}
+
+func TestProductInteractor_LookupSkus(t *testing.T) {
+	repo := new(MockProductRepository)
+	interactor := &usecases.ProductInteractor{ProductRepository: repo}
+
+	repo.On("GetBySkus", []string{"case-1", "NOKIA-3310", "MISSING"}).Return([]*entities.Product{
+		{Id: "1", Sku: "NOKIA-3310"},
+		{Id: "2", Sku: "CASE-1"},
+	}, nil)
+
+	result, err := interactor.LookupSkus(&entities.SkuLookupRequest{Skus: []string{" case-1", "NOKIA-3310", "Case-1", "MISSING"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "2", result.Items[0].Id)
+	assert.Equal(t, "1", result.Items[1].Id)
+	assert.Equal(t, []string{"MISSING"}, result.Missing)
+	repo.AssertExpectations(t)
+}
+
+func TestProductInteractor_GetBySku_NotFound(t *testing.T) {
+	repo := new(MockProductRepository)
+	interactor := &usecases.ProductInteractor{ProductRepository: repo}
+
+	repo.On("GetBySkus", []string{"NOKIA-3310"}).Return([]*entities.Product{}, nil)
+
+	_, err := interactor.GetBySku("NOKIA-3310 ")
+	assert.Equal(t, appErrors.ErrProductNotFound, err)
+	repo.AssertExpectations(t)
+}
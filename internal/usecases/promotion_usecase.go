@@ -0,0 +1,332 @@
+// usecases/promotion_usecase.go
+package usecases
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/errors"
+	"github.com/shayja/go-template-api/pkg/money"
+)
+
+// The order the promotions of an order are applied in, the line item discounts before the order discounts
+var promotionKindOrder = map[string]int{
+	entities.PromotionBuyXGetY:     0,
+	entities.PromotionPercentage:   1,
+	entities.PromotionFixed:        2,
+	entities.PromotionFreeShipping: 3,
+}
+
+type PromotionRepository interface {
+	GetAll() ([]*entities.Promotion, error)
+	Get(id string) (*entities.Promotion, error)
+	Create(request *entities.PromotionRequest) (*entities.Promotion, error)
+	Update(id string, request *entities.PromotionRequest) (*entities.Promotion, error)
+	Delete(id string) error
+	GetCoupons(promotionId string) ([]*entities.Coupon, error)
+	CreateCoupon(promotionId string, request *entities.CouponRequest) (*entities.Coupon, error)
+	DeleteCoupon(promotionId string, couponId string) error
+	GetAutomatic(now time.Time) ([]*entities.Promotion, error)
+	GetByCoupon(code string) (*entities.Promotion, *entities.Coupon, error)
+	CouponUsage(couponId string, userId string) (int, int, error)
+	GetProductCategories(productIds []string) (map[string][]string, error)
+}
+
+type PromotionInteractor struct {
+	PromotionRepository PromotionRepository
+	// Optional, the clock of the validity windows, defaults to time.Now
+	Now func() time.Time
+}
+
+func (uc *PromotionInteractor) now() time.Time {
+	if uc.Now != nil {
+		return uc.Now()
+	}
+	return time.Now()
+}
+
+// GetAll returns every promotion, the latest first
+func (uc *PromotionInteractor) GetAll() ([]*entities.Promotion, error) {
+	return uc.PromotionRepository.GetAll()
+}
+
+// Get returns a promotion with its coupon codes
+func (uc *PromotionInteractor) Get(id string) (*entities.Promotion, error) {
+	promotion, err := uc.PromotionRepository.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if promotion == nil {
+		return nil, errors.ErrPromotionNotFound
+	}
+	if promotion.Coupons, err = uc.PromotionRepository.GetCoupons(id); err != nil {
+		return nil, err
+	}
+	return promotion, nil
+}
+
+// Create adds a promotion
+func (uc *PromotionInteractor) Create(request *entities.PromotionRequest) (*entities.Promotion, error) {
+	if err := validatePromotion(request); err != nil {
+		return nil, err
+	}
+	return uc.PromotionRepository.Create(request)
+}
+
+// Update replaces the rule of a promotion, the orders placed before keep their discounts
+func (uc *PromotionInteractor) Update(id string, request *entities.PromotionRequest) (*entities.Promotion, error) {
+	if err := validatePromotion(request); err != nil {
+		return nil, err
+	}
+	promotion, err := uc.PromotionRepository.Update(id, request)
+	if err != nil {
+		return nil, err
+	}
+	if promotion == nil {
+		return nil, errors.ErrPromotionNotFound
+	}
+	return promotion, nil
+}
+
+// Delete removes a promotion and its coupon codes, the orders placed before keep their discounts
+func (uc *PromotionInteractor) Delete(id string) error {
+	return uc.PromotionRepository.Delete(id)
+}
+
+// CreateCoupon adds a coupon code to a promotion
+func (uc *PromotionInteractor) CreateCoupon(promotionId string, request *entities.CouponRequest) (*entities.Coupon, error) {
+	request.Code = strings.TrimSpace(request.Code)
+	if request.Code == "" {
+		return nil, errors.ErrInvalidInput
+	}
+	promotion, err := uc.PromotionRepository.Get(promotionId)
+	if err != nil {
+		return nil, err
+	}
+	if promotion == nil {
+		return nil, errors.ErrPromotionNotFound
+	}
+	return uc.PromotionRepository.CreateCoupon(promotionId, request)
+}
+
+// DeleteCoupon removes a coupon code of a promotion
+func (uc *PromotionInteractor) DeleteCoupon(promotionId string, couponId string) error {
+	return uc.PromotionRepository.DeleteCoupon(promotionId, couponId)
+}
+
+// Apply discounts a priced order with the running automatic promotions and the promotion of its coupon
+// code. The line item discounts are applied before the order discounts, each one to what the
+// previous ones left to pay, and the order total becomes the subtotal less the discounts
+func (uc *PromotionInteractor) Apply(order *entities.OrderRequest) error {
+	now := uc.now()
+	promotions, err := uc.PromotionRepository.GetAutomatic(now)
+	if err != nil {
+		return err
+	}
+
+	var coupon *entities.Coupon
+	var couponPromotion string
+	order.CouponId = nil
+	if code := strings.TrimSpace(order.CouponCode); code != "" {
+		promotion, c, err := uc.coupon(code, order.UserId, now)
+		if err != nil {
+			return err
+		}
+		coupon, couponPromotion = c, promotion.Id
+		order.CouponId = &c.Id
+		if !slices.ContainsFunc(promotions, func(p *entities.Promotion) bool { return p.Id == promotion.Id }) {
+			promotions = append(promotions, promotion)
+		}
+	}
+	slices.SortStableFunc(promotions, func(a, b *entities.Promotion) int {
+		return promotionKindOrder[a.Kind] - promotionKindOrder[b.Kind]
+	})
+
+	categories, err := uc.productCategories(order, promotions)
+	if err != nil {
+		return err
+	}
+
+	remaining := make([]money.Amount, len(order.OrderDetails))
+	for i, detail := range order.OrderDetails {
+		remaining[i] = detail.TotalPrice
+	}
+	order.Discounts = nil
+	order.FreeShipping = false
+	var total money.Amount
+	for _, promotion := range promotions {
+		lines := qualifyingLines(promotion, order.OrderDetails, categories)
+		var amount money.Amount
+		applies := len(lines) > 0 && order.Subtotal >= promotion.MinOrder
+		if applies {
+			amount = promotionDiscount(promotion, order.OrderDetails, lines, remaining)
+			applies = amount > 0 || promotion.Kind == entities.PromotionFreeShipping
+		}
+		if !applies {
+			if promotion.Id == couponPromotion {
+				return errors.ErrCouponNotApplicable
+			}
+			continue
+		}
+
+		discount := &entities.OrderDiscount{PromotionId: &promotion.Id, Name: promotion.Name, Kind: promotion.Kind, Amount: amount}
+		if promotion.Id == couponPromotion {
+			discount.CouponCode = coupon.Code
+		}
+		if promotion.Kind == entities.PromotionFreeShipping {
+			order.FreeShipping = true
+		}
+		order.Discounts = append(order.Discounts, discount)
+		total = total.Add(amount)
+	}
+
+	for i := range order.OrderDetails {
+		order.OrderDetails[i].Discount = order.OrderDetails[i].TotalPrice.Sub(remaining[i])
+	}
+	order.DiscountTotal = total
+	order.TotalPrice = order.Subtotal.Sub(total)
+	return nil
+}
+
+// coupon resolves an active coupon code of a running promotion the user may still use
+func (uc *PromotionInteractor) coupon(code string, userId string, now time.Time) (*entities.Promotion, *entities.Coupon, error) {
+	promotion, coupon, err := uc.PromotionRepository.GetByCoupon(code)
+	if err != nil {
+		return nil, nil, err
+	}
+	if promotion == nil || coupon == nil || !coupon.Active || !promotion.RunsAt(now) {
+		return nil, nil, errors.ErrInvalidCoupon
+	}
+	used, usedByUser, err := uc.PromotionRepository.CouponUsage(coupon.Id, userId)
+	if err != nil {
+		return nil, nil, err
+	}
+	if (coupon.UsageLimit != nil && used >= *coupon.UsageLimit) || (coupon.PerUserLimit != nil && usedByUser >= *coupon.PerUserLimit) {
+		return nil, nil, errors.ErrCouponExhausted
+	}
+	return promotion, coupon, nil
+}
+
+// productCategories returns the categories of the ordered products with their parent categories,
+// nil when no promotion is limited to categories
+func (uc *PromotionInteractor) productCategories(order *entities.OrderRequest, promotions []*entities.Promotion) (map[string][]string, error) {
+	if !slices.ContainsFunc(promotions, func(p *entities.Promotion) bool { return len(p.CategoryIds) > 0 }) {
+		return nil, nil
+	}
+	ids := make([]string, 0, len(order.OrderDetails))
+	for _, detail := range order.OrderDetails {
+		if !slices.Contains(ids, detail.ProductId) {
+			ids = append(ids, detail.ProductId)
+		}
+	}
+	return uc.PromotionRepository.GetProductCategories(ids)
+}
+
+// qualifyingLines returns the indexes of the line items a promotion discounts
+func qualifyingLines(promotion *entities.Promotion, details []entities.OrderDetail, categories map[string][]string) []int {
+	whole := len(promotion.ProductIds) == 0 && len(promotion.CategoryIds) == 0
+	var lines []int
+	for i, detail := range details {
+		if whole || slices.Contains(promotion.ProductIds, detail.ProductId) ||
+			slices.ContainsFunc(categories[detail.ProductId], func(id string) bool { return slices.Contains(promotion.CategoryIds, id) }) {
+			lines = append(lines, i)
+		}
+	}
+	return lines
+}
+
+// promotionDiscount takes the discount of a promotion off what is left to pay on the qualifying
+// line items and returns its amount
+func promotionDiscount(promotion *entities.Promotion, details []entities.OrderDetail, lines []int, remaining []money.Amount) money.Amount {
+	var total money.Amount
+	switch promotion.Kind {
+	case entities.PromotionPercentage:
+		rate := promotion.Value.Float64() / 100
+		for _, i := range lines {
+			off := min(remaining[i].MulRate(rate, money.Step(2), money.HalfUp), remaining[i])
+			remaining[i] = remaining[i].Sub(off)
+			total = total.Add(off)
+		}
+	case entities.PromotionFixed:
+		total = spreadDiscount(promotion.Value, lines, remaining)
+	case entities.PromotionBuyXGetY:
+		units := 0
+		for _, i := range lines {
+			units += details[i].Quantity
+		}
+		free := units / (promotion.BuyQuantity + promotion.GetQuantity) * promotion.GetQuantity
+		// The cheapest units are the free ones
+		cheapest := slices.Clone(lines)
+		slices.SortStableFunc(cheapest, func(a, b int) int { return cmp.Compare(details[a].UnitPrice, details[b].UnitPrice) })
+		for _, i := range cheapest {
+			if free == 0 {
+				break
+			}
+			quantity := min(free, details[i].Quantity)
+			off := min(details[i].UnitPrice.Mul(int64(quantity)), remaining[i])
+			remaining[i] = remaining[i].Sub(off)
+			total = total.Add(off)
+			free -= quantity
+		}
+	}
+	return total
+}
+
+// spreadDiscount takes an amount off the lines in proportion to what is left to pay on them, the
+// rounding difference off the last line, and returns the amount taken
+func spreadDiscount(amount money.Amount, lines []int, remaining []money.Amount) money.Amount {
+	var base money.Amount
+	for _, i := range lines {
+		base = base.Add(remaining[i])
+	}
+	amount = min(amount, base)
+	if amount <= 0 {
+		return 0
+	}
+	left := amount
+	for n, i := range lines {
+		off := left
+		if n < len(lines)-1 {
+			off = remaining[i].MulRate(amount.Float64()/base.Float64(), money.Step(2), money.HalfUp)
+		}
+		off = min(off, remaining[i], left)
+		remaining[i] = remaining[i].Sub(off)
+		left = left.Sub(off)
+	}
+	return amount.Sub(left)
+}
+
+// validatePromotion checks that the rule of a promotion is complete for its kind
+func validatePromotion(request *entities.PromotionRequest) error {
+	request.Name = strings.TrimSpace(request.Name)
+	if request.Name == "" {
+		return errors.New(errors.ErrInvalidPromotion.Code, "The promotion must have a name", nil)
+	}
+	switch request.Kind {
+	case entities.PromotionPercentage:
+		if request.Value <= 0 || request.Value > money.New(100, 0) {
+			return errors.New(errors.ErrInvalidPromotion.Code, "The percentage must be more than 0 and at most 100", nil)
+		}
+	case entities.PromotionFixed:
+		if request.Value <= 0 {
+			return errors.New(errors.ErrInvalidPromotion.Code, "The amount off must be positive", nil)
+		}
+	case entities.PromotionBuyXGetY:
+		if request.BuyQuantity < 1 || request.GetQuantity < 1 {
+			return errors.New(errors.ErrInvalidPromotion.Code, "The buy and get quantities must be positive", nil)
+		}
+	case entities.PromotionFreeShipping:
+	default:
+		return errors.New(errors.ErrInvalidPromotion.Code, "The kind must be percentage, fixed, free_shipping or buy_x_get_y", nil)
+	}
+	if request.MinOrder < 0 {
+		return errors.New(errors.ErrInvalidPromotion.Code, "The minimum order value cannot be negative", nil)
+	}
+	if request.StartsAt != nil && request.EndsAt != nil && !request.EndsAt.After(*request.StartsAt) {
+		return errors.New(errors.ErrInvalidPromotion.Code, "The promotion must end after it starts", nil)
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+package usecases_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shayja/go-template-api/internal/entities"
+	appErrors "github.com/shayja/go-template-api/internal/errors"
+	"github.com/shayja/go-template-api/internal/usecases"
+	"github.com/shayja/go-template-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockPromotionRepository mocks the PromotionRepository interface
+type MockPromotionRepository struct {
+	mock.Mock
+}
+
+func (m *MockPromotionRepository) promotion(args mock.Arguments) (*entities.Promotion, error) {
+	if promotion, ok := args.Get(0).(*entities.Promotion); ok {
+		return promotion, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPromotionRepository) GetAll() ([]*entities.Promotion, error) {
+	args := m.Called()
+	return args.Get(0).([]*entities.Promotion), args.Error(1)
+}
+
+func (m *MockPromotionRepository) Get(id string) (*entities.Promotion, error) {
+	return m.promotion(m.Called(id))
+}
+
+func (m *MockPromotionRepository) Create(request *entities.PromotionRequest) (*entities.Promotion, error) {
+	return m.promotion(m.Called(request))
+}
+
+func (m *MockPromotionRepository) Update(id string, request *entities.PromotionRequest) (*entities.Promotion, error) {
+	return m.promotion(m.Called(id, request))
+}
+
+func (m *MockPromotionRepository) Delete(id string) error {
+	return m.Called(id).Error(0)
+}
+
+func (m *MockPromotionRepository) GetCoupons(promotionId string) ([]*entities.Coupon, error) {
+	args := m.Called(promotionId)
+	return args.Get(0).([]*entities.Coupon), args.Error(1)
+}
+
+func (m *MockPromotionRepository) CreateCoupon(promotionId string, request *entities.CouponRequest) (*entities.Coupon, error) {
+	args := m.Called(promotionId, request)
+	if coupon, ok := args.Get(0).(*entities.Coupon); ok {
+		return coupon, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPromotionRepository) DeleteCoupon(promotionId string, couponId string) error {
+	return m.Called(promotionId, couponId).Error(0)
+}
+
+func (m *MockPromotionRepository) GetAutomatic(now time.Time) ([]*entities.Promotion, error) {
+	args := m.Called(now)
+	return args.Get(0).([]*entities.Promotion), args.Error(1)
+}
+
+func (m *MockPromotionRepository) GetByCoupon(code string) (*entities.Promotion, *entities.Coupon, error) {
+	args := m.Called(code)
+	promotion, _ := args.Get(0).(*entities.Promotion)
+	coupon, _ := args.Get(1).(*entities.Coupon)
+	return promotion, coupon, args.Error(2)
+}
+
+func (m *MockPromotionRepository) CouponUsage(couponId string, userId string) (int, int, error) {
+	args := m.Called(couponId, userId)
+	return args.Int(0), args.Int(1), args.Error(2)
+}
+
+func (m *MockPromotionRepository) GetProductCategories(productIds []string) (map[string][]string, error) {
+	args := m.Called(productIds)
+	return args.Get(0).(map[string][]string), args.Error(1)
+}
+
+var promotionNow = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func promotionInteractor(repo *MockPromotionRepository) *usecases.PromotionInteractor {
+	return &usecases.PromotionInteractor{PromotionRepository: repo, Now: func() time.Time { return promotionNow }}
+}
+
+func amount(s string) money.Amount {
+	a, _ := money.Parse(s)
+	return a
+}
+
+// pricedOrder prices an order of the line items with the promotions of the repository
+func pricedOrder(t *testing.T, repo *MockPromotionRepository, couponCode string, lines ...entities.OrderDetail) (*entities.OrderRequest, error) {
+	t.Helper()
+	orders := new(MockOrderRepository)
+	orders.On("GetPrices", mock.Anything).Return(catalogOf(lines...), nil)
+	uc := &usecases.OrderUsecase{OrderRepo: orders, Promotions: promotionInteractor(repo)}
+	return uc.Quote(&entities.OrderRequest{UserId: "u1", CouponCode: couponCode, OrderDetails: lines})
+}
+
+func TestPromotionInteractor_Apply_PercentageOfCategory(t *testing.T) {
+	repo := new(MockPromotionRepository)
+	sale := &entities.Promotion{Id: "p1", Name: "Shoe sale", Kind: entities.PromotionPercentage, Value: amount("15"), CategoryIds: []string{"shoes"}, Active: true}
+	repo.On("GetAutomatic", promotionNow).Return([]*entities.Promotion{sale}, nil)
+	// The categories of a product come with their parent categories
+	repo.On("GetProductCategories", []string{"a", "b"}).Return(map[string][]string{"a": {"sneakers", "shoes"}, "b": {"hats"}}, nil)
+
+	order, err := pricedOrder(t, repo, "", orderLine("a", 2, "33.33"), orderLine("b", 1, "20"))
+	assert.NoError(t, err)
+	// 15% of 66.66 is 9.999
+	assert.Equal(t, "86.66", order.Subtotal.String())
+	assert.Equal(t, "10.00", order.DiscountTotal.String())
+	assert.Equal(t, "76.66", order.TotalPrice.String())
+	assert.Equal(t, "10.00", order.OrderDetails[0].Discount.String())
+	assert.Equal(t, "0.00", order.OrderDetails[1].Discount.String())
+	assert.Len(t, order.Discounts, 1)
+	assert.Equal(t, "Shoe sale", order.Discounts[0].Name)
+}
+
+func TestPromotionInteractor_Apply_StacksLineItemDiscountsFirst(t *testing.T) {
+	repo := new(MockPromotionRepository)
+	fixed := &entities.Promotion{Id: "p1", Name: "10 off", Kind: entities.PromotionFixed, Value: amount("10"), Active: true}
+	bogo := &entities.Promotion{Id: "p2", Name: "Buy 2 get 1", Kind: entities.PromotionBuyXGetY, BuyQuantity: 2, GetQuantity: 1, Active: true}
+	repo.On("GetAutomatic", promotionNow).Return([]*entities.Promotion{fixed, bogo}, nil)
+
+	order, err := pricedOrder(t, repo, "", orderLine("a", 2, "30"), orderLine("b", 1, "10"))
+	assert.NoError(t, err)
+	// The cheapest of the 3 units is free, then 10 is spread over the 60 left to pay
+	assert.Equal(t, "Buy 2 get 1", order.Discounts[0].Name)
+	assert.Equal(t, "10.00", order.Discounts[0].Amount.String())
+	assert.Equal(t, "10.00", order.Discounts[1].Amount.String())
+	assert.Equal(t, "10.00", order.OrderDetails[0].Discount.String())
+	assert.Equal(t, "10.00", order.OrderDetails[1].Discount.String())
+	assert.Equal(t, "50.00", order.TotalPrice.String())
+}
+
+func TestPromotionInteractor_Apply_SkipsAutomaticBelowMinimum(t *testing.T) {
+	repo := new(MockPromotionRepository)
+	shipping := &entities.Promotion{Id: "p1", Name: "Free shipping", Kind: entities.PromotionFreeShipping, MinOrder: amount("50"), Active: true}
+	repo.On("GetAutomatic", promotionNow).Return([]*entities.Promotion{shipping}, nil)
+
+	order, err := pricedOrder(t, repo, "", orderLine("a", 1, "49.99"))
+	assert.NoError(t, err)
+	assert.False(t, order.FreeShipping)
+	assert.Empty(t, order.Discounts)
+
+	order, err = pricedOrder(t, repo, "", orderLine("a", 1, "50"))
+	assert.NoError(t, err)
+	assert.True(t, order.FreeShipping)
+	assert.Equal(t, "50.00", order.TotalPrice.String())
+}
+
+func TestPromotionInteractor_Apply_Coupon(t *testing.T) {
+	repo := new(MockPromotionRepository)
+	limit := 1
+	promotion := &entities.Promotion{Id: "p1", Name: "Welcome", Kind: entities.PromotionFixed, Value: amount("5"), Active: true}
+	coupon := &entities.Coupon{Id: "c1", PromotionId: "p1", Code: "WELCOME5", PerUserLimit: &limit, Active: true}
+	repo.On("GetAutomatic", promotionNow).Return([]*entities.Promotion{}, nil)
+	repo.On("GetByCoupon", "welcome5").Return(promotion, coupon, nil)
+	repo.On("CouponUsage", "c1", "u1").Return(3, 0, nil).Once()
+
+	order, err := pricedOrder(t, repo, " welcome5 ", orderLine("a", 1, "20"))
+	assert.NoError(t, err)
+	assert.Equal(t, "c1", *order.CouponId)
+	assert.Equal(t, "WELCOME5", order.Discounts[0].CouponCode)
+	assert.Equal(t, "15.00", order.TotalPrice.String())
+
+	// The user already used the code on an order
+	repo.On("CouponUsage", "c1", "u1").Return(3, 1, nil).Once()
+	_, err = pricedOrder(t, repo, "welcome5", orderLine("a", 1, "20"))
+	assert.ErrorIs(t, err, appErrors.ErrCouponExhausted)
+}
+
+func TestPromotionInteractor_Apply_InvalidCoupon(t *testing.T) {
+	repo := new(MockPromotionRepository)
+	ended := promotionNow.Add(-time.Hour)
+	expired := &entities.Promotion{Id: "p1", Kind: entities.PromotionFixed, Value: amount("5"), Active: true, EndsAt: &ended}
+	scoped := &entities.Promotion{Id: "p2", Kind: entities.PromotionPercentage, Value: amount("10"), ProductIds: []string{"b"}, Active: true}
+	repo.On("GetAutomatic", promotionNow).Return([]*entities.Promotion{}, nil)
+	repo.On("GetByCoupon", "UNKNOWN").Return(nil, nil, nil)
+	repo.On("GetByCoupon", "OLD").Return(expired, &entities.Coupon{Id: "c1", Code: "OLD", Active: true}, nil)
+	repo.On("GetByCoupon", "HATS").Return(scoped, &entities.Coupon{Id: "c2", Code: "HATS", Active: true}, nil)
+	repo.On("CouponUsage", "c2", "u1").Return(0, 0, nil)
+
+	_, err := pricedOrder(t, repo, "UNKNOWN", orderLine("a", 1, "20"))
+	assert.ErrorIs(t, err, appErrors.ErrInvalidCoupon)
+	_, err = pricedOrder(t, repo, "OLD", orderLine("a", 1, "20"))
+	assert.ErrorIs(t, err, appErrors.ErrInvalidCoupon)
+	// None of the ordered products is discounted by the coupon
+	_, err = pricedOrder(t, repo, "HATS", orderLine("a", 1, "20"))
+	assert.ErrorIs(t, err, appErrors.ErrCouponNotApplicable)
+}
+
+func TestPromotionInteractor_Create_Validation(t *testing.T) {
+	repo := new(MockPromotionRepository)
+	uc := promotionInteractor(repo)
+	starts := promotionNow
+
+	for _, request := range []*entities.PromotionRequest{
+		{Name: "Half", Kind: entities.PromotionPercentage, Value: amount("150")},
+		{Name: "Nothing", Kind: entities.PromotionFixed},
+		{Name: "Bogo", Kind: entities.PromotionBuyXGetY, BuyQuantity: 1},
+		{Name: "Backwards", Kind: entities.PromotionFreeShipping, StartsAt: &starts, EndsAt: &starts},
+		{Name: " ", Kind: entities.PromotionFreeShipping},
+	} {
+		_, err := uc.Create(request)
+		assert.ErrorContains(t, err, appErrors.ErrInvalidPromotion.Code, request.Name)
+	}
+	repo.AssertNotCalled(t, "Create", mock.Anything)
+}
@@ -0,0 +1,78 @@
+// usecases/review_usecase.go
+package usecases
+
+import (
+	"strings"
+
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/errors"
+)
+
+type ReviewRepository interface {
+	Get(id string) (*entities.Review, error)
+	GetByProduct(productId string, status string, list *entities.ListRequest) (*entities.List[*entities.Review], error)
+	GetByStatus(status string, list *entities.ListRequest) (*entities.List[*entities.Review], error)
+	HasPurchased(productId string, userId string) (bool, error)
+	Create(productId string, userId string, request *entities.ReviewRequest, verifiedPurchase bool) (*entities.Review, error)
+	SetStatus(id string, status string) (*entities.Review, error)
+	Delete(id string) error
+}
+
+type ReviewInteractor struct {
+	ReviewRepository ReviewRepository
+	// Optional, only users who ordered the product may review it
+	VerifiedPurchaseRequired bool
+}
+
+// GetProductReviews returns the published reviews of a product
+func (uc *ReviewInteractor) GetProductReviews(productId string, list *entities.ListRequest) (*entities.List[*entities.Review], error) {
+	return uc.ReviewRepository.GetByProduct(productId, entities.ReviewApproved, list)
+}
+
+// GetQueue returns the reviews in a moderation status, the pending ones by default
+func (uc *ReviewInteractor) GetQueue(request *entities.ReviewQueueRequest) (*entities.List[*entities.Review], error) {
+	status := request.Status
+	if status == "" {
+		status = entities.ReviewPending
+	}
+	return uc.ReviewRepository.GetByStatus(status, &request.ListRequest)
+}
+
+// Create submits a review of a product for moderation, flagging it when the user ordered the product
+func (uc *ReviewInteractor) Create(productId string, userId string, request *entities.ReviewRequest) (*entities.Review, error) {
+	request.Title = strings.TrimSpace(request.Title)
+	request.Body = strings.TrimSpace(request.Body)
+	if request.Rating < 1 || request.Rating > 5 || request.Title == "" {
+		return nil, errors.ErrInvalidInput
+	}
+
+	verified, err := uc.ReviewRepository.HasPurchased(productId, userId)
+	if err != nil {
+		return nil, err
+	}
+	if uc.VerifiedPurchaseRequired && !verified {
+		return nil, errors.ErrPurchaseRequired
+	}
+	return uc.ReviewRepository.Create(productId, userId, request, verified)
+}
+
+// Moderate approves or rejects a review, the product rating only counts the approved reviews
+func (uc *ReviewInteractor) Moderate(id string, request *entities.ReviewStatusRequest) (*entities.Review, error) {
+	if request.Status != entities.ReviewApproved && request.Status != entities.ReviewRejected {
+		return nil, errors.ErrInvalidInput
+	}
+	return uc.ReviewRepository.SetStatus(id, request.Status)
+}
+
+// Delete removes a review of the user, admins may remove any review
+func (uc *ReviewInteractor) Delete(id string, userId string, admin bool) error {
+	review, err := uc.ReviewRepository.Get(id)
+	if err != nil {
+		return err
+	}
+	// Other users' reviews are reported missing rather than forbidden
+	if review == nil || (!admin && review.UserId != userId) {
+		return errors.ErrReviewNotFound
+	}
+	return uc.ReviewRepository.Delete(id)
+}
@@ -0,0 +1,134 @@
+package usecases_test
+
+import (
+	"testing"
+
+	"github.com/shayja/go-template-api/internal/entities"
+	appErrors "github.com/shayja/go-template-api/internal/errors"
+	"github.com/shayja/go-template-api/internal/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockReviewRepository mocks the ReviewRepository interface
+type MockReviewRepository struct {
+	mock.Mock
+}
+
+func (m *MockReviewRepository) Get(id string) (*entities.Review, error) {
+	args := m.Called(id)
+	if review, ok := args.Get(0).(*entities.Review); ok {
+		return review, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockReviewRepository) GetByProduct(productId string, status string, list *entities.ListRequest) (*entities.List[*entities.Review], error) {
+	args := m.Called(productId, status, list)
+	if reviews, ok := args.Get(0).(*entities.List[*entities.Review]); ok {
+		return reviews, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockReviewRepository) GetByStatus(status string, list *entities.ListRequest) (*entities.List[*entities.Review], error) {
+	args := m.Called(status, list)
+	if reviews, ok := args.Get(0).(*entities.List[*entities.Review]); ok {
+		return reviews, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockReviewRepository) HasPurchased(productId string, userId string) (bool, error) {
+	args := m.Called(productId, userId)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockReviewRepository) Create(productId string, userId string, request *entities.ReviewRequest, verifiedPurchase bool) (*entities.Review, error) {
+	args := m.Called(productId, userId, request, verifiedPurchase)
+	if review, ok := args.Get(0).(*entities.Review); ok {
+		return review, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockReviewRepository) SetStatus(id string, status string) (*entities.Review, error) {
+	args := m.Called(id, status)
+	if review, ok := args.Get(0).(*entities.Review); ok {
+		return review, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockReviewRepository) Delete(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func TestReviewInteractor_Create_FlagsVerifiedPurchase(t *testing.T) {
+	repo := new(MockReviewRepository)
+	interactor := &usecases.ReviewInteractor{ReviewRepository: repo}
+
+	review := &entities.Review{Id: "r1", Status: entities.ReviewPending, VerifiedPurchase: true}
+	repo.On("HasPurchased", "1", "u1").Return(true, nil)
+	repo.On("Create", "1", "u1", mock.MatchedBy(func(r *entities.ReviewRequest) bool {
+		return r.Title == "Great" && r.Body == "Works well"
+	}), true).Return(review, nil)
+
+	result, err := interactor.Create("1", "u1", &entities.ReviewRequest{Rating: 5, Title: " Great ", Body: " Works well "})
+
+	assert.NoError(t, err)
+	assert.Equal(t, review, result)
+	repo.AssertExpectations(t)
+}
+
+func TestReviewInteractor_Create_PurchaseRequired(t *testing.T) {
+	repo := new(MockReviewRepository)
+	interactor := &usecases.ReviewInteractor{ReviewRepository: repo, VerifiedPurchaseRequired: true}
+
+	repo.On("HasPurchased", "1", "u1").Return(false, nil)
+
+	_, err := interactor.Create("1", "u1", &entities.ReviewRequest{Rating: 5, Title: "Great"})
+
+	assert.ErrorIs(t, err, appErrors.ErrPurchaseRequired)
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestReviewInteractor_Create_InvalidRating(t *testing.T) {
+	repo := new(MockReviewRepository)
+	interactor := &usecases.ReviewInteractor{ReviewRepository: repo}
+
+	_, err := interactor.Create("1", "u1", &entities.ReviewRequest{Rating: 6, Title: "Great"})
+
+	assert.ErrorIs(t, err, appErrors.ErrInvalidInput)
+	repo.AssertNotCalled(t, "HasPurchased", mock.Anything, mock.Anything)
+}
+
+func TestReviewInteractor_GetQueue_DefaultsToPending(t *testing.T) {
+	repo := new(MockReviewRepository)
+	interactor := &usecases.ReviewInteractor{ReviewRepository: repo}
+
+	request := &entities.ReviewQueueRequest{}
+	list := &entities.List[*entities.Review]{}
+	repo.On("GetByStatus", entities.ReviewPending, &request.ListRequest).Return(list, nil)
+
+	result, err := interactor.GetQueue(request)
+
+	assert.NoError(t, err)
+	assert.Equal(t, list, result)
+}
+
+func TestReviewInteractor_Delete_OtherUser(t *testing.T) {
+	repo := new(MockReviewRepository)
+	interactor := &usecases.ReviewInteractor{ReviewRepository: repo}
+
+	repo.On("Get", "r1").Return(&entities.Review{Id: "r1", UserId: "u2"}, nil)
+	repo.On("Delete", "r1").Return(nil)
+
+	err := interactor.Delete("r1", "u1", false)
+	assert.ErrorIs(t, err, appErrors.ErrReviewNotFound)
+	repo.AssertNotCalled(t, "Delete", "r1")
+
+	assert.NoError(t, interactor.Delete("r1", "admin", true))
+	repo.AssertCalled(t, "Delete", "r1")
+}
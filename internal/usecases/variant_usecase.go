@@ -0,0 +1,119 @@
+// usecases/variant_usecase.go
+package usecases
+
+import (
+	"strings"
+
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/errors"
+)
+
+type VariantRepository interface {
+	GetOptions(productId string) ([]entities.ProductOption, error)
+	SetOptions(productId string, options []entities.ProductOption) error
+	GetByProduct(productId string) ([]*entities.ProductVariant, error)
+	GetById(id string) (*entities.ProductVariant, error)
+	Create(productId string, variant *entities.ProductVariantRequest) (string, error)
+	Update(id string, variant *entities.ProductVariantRequest) (*entities.ProductVariant, error)
+	Delete(id string) (bool, error)
+}
+
+type VariantInteractor struct {
+	VariantRepository VariantRepository
+}
+
+func (uc *VariantInteractor) GetOptions(productId string) ([]entities.ProductOption, error) {
+	return uc.VariantRepository.GetOptions(productId)
+}
+
+func (uc *VariantInteractor) SetOptions(productId string, request *entities.ProductOptionsRequest) ([]entities.ProductOption, error) {
+	seen := map[string]bool{}
+	for i := range request.Options {
+		option := &request.Options[i]
+		option.Name = strings.TrimSpace(option.Name)
+		if option.Name == "" || seen[option.Name] {
+			return nil, errors.ErrInvalidInput
+		}
+		seen[option.Name] = true
+	}
+	if err := uc.VariantRepository.SetOptions(productId, request.Options); err != nil {
+		return nil, err
+	}
+	return uc.VariantRepository.GetOptions(productId)
+}
+
+func (uc *VariantInteractor) GetByProduct(productId string) ([]*entities.ProductVariant, error) {
+	return uc.VariantRepository.GetByProduct(productId)
+}
+
+// GetById returns a variant of the given product
+func (uc *VariantInteractor) GetById(productId string, id string) (*entities.ProductVariant, error) {
+	variant, err := uc.VariantRepository.GetById(id)
+	if err != nil {
+		return nil, err
+	}
+	if variant.ProductId != productId {
+		return nil, errors.ErrVariantNotFound
+	}
+	return variant, nil
+}
+
+func (uc *VariantInteractor) Create(productId string, variant *entities.ProductVariantRequest) (string, error) {
+	if err := uc.validate(productId, variant); err != nil {
+		return "", err
+	}
+	return uc.VariantRepository.Create(productId, variant)
+}
+
+func (uc *VariantInteractor) Update(productId string, id string, variant *entities.ProductVariantRequest) (*entities.ProductVariant, error) {
+	if _, err := uc.GetById(productId, id); err != nil {
+		return nil, err
+	}
+	if err := uc.validate(productId, variant); err != nil {
+		return nil, err
+	}
+	return uc.VariantRepository.Update(id, variant)
+}
+
+func (uc *VariantInteractor) Delete(productId string, id string) (bool, error) {
+	if _, err := uc.GetById(productId, id); err != nil {
+		return false, err
+	}
+	return uc.VariantRepository.Delete(id)
+}
+
+func (uc *VariantInteractor) validate(productId string, variant *entities.ProductVariantRequest) error {
+	variant.Sku = strings.TrimSpace(variant.Sku)
+	if variant.Sku == "" {
+		return errors.ErrInvalidInput
+	}
+	options, err := uc.VariantRepository.GetOptions(productId)
+	if err != nil {
+		return err
+	}
+	return ValidateVariantOptions(options, variant.Options)
+}
+
+// ValidateVariantOptions checks that a variant selects exactly one allowed value of every option axis
+func ValidateVariantOptions(axes []entities.ProductOption, selected map[string]string) error {
+	if len(selected) != len(axes) {
+		return errors.ErrInvalidVariant
+	}
+	for _, axis := range axes {
+		value, ok := selected[axis.Name]
+		if !ok {
+			return errors.ErrInvalidVariant
+		}
+		allowed := false
+		for _, v := range axis.Values {
+			if v == value {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return errors.ErrInvalidVariant
+		}
+	}
+	return nil
+}
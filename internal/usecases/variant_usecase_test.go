@@ -0,0 +1,178 @@
+package usecases_test
+
+import (
+	"testing"
+
+	"github.com/shayja/go-template-api/internal/entities"
+	appErrors "github.com/shayja/go-template-api/internal/errors"
+	"github.com/shayja/go-template-api/internal/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockVariantRepository mocks the VariantRepository interface
+type MockVariantRepository struct {
+	mock.Mock
+}
+
+func (m *MockVariantRepository) GetOptions(productId string) ([]entities.ProductOption, error) {
+	args := m.Called(productId)
+	if options, ok := args.Get(0).([]entities.ProductOption); ok {
+		return options, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockVariantRepository) SetOptions(productId string, options []entities.ProductOption) error {
+	args := m.Called(productId, options)
+	return args.Error(0)
+}
+
+func (m *MockVariantRepository) GetByProduct(productId string) ([]*entities.ProductVariant, error) {
+	args := m.Called(productId)
+	if variants, ok := args.Get(0).([]*entities.ProductVariant); ok {
+		return variants, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockVariantRepository) GetById(id string) (*entities.ProductVariant, error) {
+	args := m.Called(id)
+	if variant, ok := args.Get(0).(*entities.ProductVariant); ok {
+		return variant, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockVariantRepository) Create(productId string, variant *entities.ProductVariantRequest) (string, error) {
+	args := m.Called(productId, variant)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockVariantRepository) Update(id string, variant *entities.ProductVariantRequest) (*entities.ProductVariant, error) {
+	args := m.Called(id, variant)
+	if updated, ok := args.Get(0).(*entities.ProductVariant); ok {
+		return updated, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockVariantRepository) Delete(id string) (bool, error) {
+	args := m.Called(id)
+	return args.Bool(0), args.Error(1)
+}
+
+var shirtOptions = []entities.ProductOption{
+	{Name: "size", Values: []string{"S", "M", "L"}},
+	{Name: "color", Values: []string{"red", "blue"}},
+}
+
+func TestValidateVariantOptions(t *testing.T) {
+	assert.NoError(t, usecases.ValidateVariantOptions(shirtOptions, map[string]string{"size": "M", "color": "red"}))
+	assert.NoError(t, usecases.ValidateVariantOptions(nil, map[string]string{}))
+
+	invalid := []map[string]string{
+		{"size": "M"},
+		{"size": "XL", "color": "red"},
+		{"size": "M", "fit": "slim"},
+		{"size": "M", "color": "red", "fit": "slim"},
+	}
+	for _, selected := range invalid {
+		assert.ErrorIs(t, usecases.ValidateVariantOptions(shirtOptions, selected), appErrors.ErrInvalidVariant)
+	}
+}
+
+func TestVariantInteractor_Create(t *testing.T) {
+	repo := new(MockVariantRepository)
+	interactor := &usecases.VariantInteractor{VariantRepository: repo}
+
+	request := &entities.ProductVariantRequest{Sku: " tshirt-red-m ", Options: map[string]string{"size": "M", "color": "red"}}
+	repo.On("GetOptions", "1").Return(shirtOptions, nil)
+	repo.On("Create", "1", mock.MatchedBy(func(v *entities.ProductVariantRequest) bool {
+		return v.Sku == "tshirt-red-m"
+	})).Return("v1", nil)
+
+	id, err := interactor.Create("1", request)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "v1", id)
+	repo.AssertExpectations(t)
+}
+
+func TestVariantInteractor_Create_InvalidOptions(t *testing.T) {
+	repo := new(MockVariantRepository)
+	interactor := &usecases.VariantInteractor{VariantRepository: repo}
+
+	repo.On("GetOptions", "1").Return(shirtOptions, nil)
+
+	_, err := interactor.Create("1", &entities.ProductVariantRequest{Sku: "tshirt", Options: map[string]string{"size": "XXL", "color": "red"}})
+
+	assert.ErrorIs(t, err, appErrors.ErrInvalidVariant)
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestVariantInteractor_GetById_OtherProduct(t *testing.T) {
+	repo := new(MockVariantRepository)
+	interactor := &usecases.VariantInteractor{VariantRepository: repo}
+
+	repo.On("GetById", "v1").Return(&entities.ProductVariant{Id: "v1", ProductId: "2"}, nil)
+
+	_, err := interactor.GetById("1", "v1")
+	assert.ErrorIs(t, err, appErrors.ErrVariantNotFound)
+
+	_, err = interactor.Delete("1", "v1")
+	assert.ErrorIs(t, err, appErrors.ErrVariantNotFound)
+	repo.AssertNotCalled(t, "Delete", mock.Anything)
+}
+
+func TestVariantInteractor_SetOptions_Duplicate(t *testing.T) {
+	repo := new(MockVariantRepository)
+	interactor := &usecases.VariantInteractor{VariantRepository: repo}
+
+	request := &entities.ProductOptionsRequest{Options: []entities.ProductOption{
+		{Name: "size", Values: []string{"S"}},
+		{Name: " size", Values: []string{"M"}},
+	}}
+
+	_, err := interactor.SetOptions("1", request)
+
+	assert.ErrorIs(t, err, appErrors.ErrInvalidInput)
+	repo.AssertNotCalled(t, "SetOptions", mock.Anything, mock.Anything)
+}
+
+func TestProductInteractor_GetById_AddsVariants(t *testing.T) {
+	products := new(MockProductRepository)
+	variants := new(MockVariantRepository)
+	interactor := &usecases.ProductInteractor{ProductRepository: products, VariantRepository: variants}
+
+	list := []*entities.ProductVariant{{Id: "v1", ProductId: "1", Options: map[string]string{"size": "M", "color": "red"}}}
+	products.On("GetById", "1").Return(&entities.Product{Id: "1"}, nil)
+	variants.On("GetOptions", "1").Return(shirtOptions, nil)
+	variants.On("GetByProduct", "1").Return(list, nil)
+
+	result, err := interactor.GetById("1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, shirtOptions, result.Options)
+	assert.Equal(t, list, result.Variants)
+	products.AssertExpectations(t)
+	variants.AssertExpectations(t)
+}
+
+func TestOrderUsecase_Create_UnavailableVariant(t *testing.T) {
+	variants := new(MockVariantRepository)
+	orders := new(MockOrderRepository)
+	usecase := &usecases.OrderUsecase{OrderRepo: orders, VariantRepo: variants}
+	orders.On("GetPrices", []string{"1"}).Return(catalogOf(orderLine("1", 1, "20")), nil)
+
+	variantId := "v1"
+	request := &entities.OrderRequest{OrderDetails: []entities.OrderDetail{{ProductId: "1", VariantId: &variantId, Quantity: 1}}}
+
+	variants.On("GetById", "v1").Return(&entities.ProductVariant{Id: "v1", ProductId: "2", Available: true}, nil).Once()
+	_, err := usecase.Create(request)
+	assert.ErrorIs(t, err, appErrors.ErrVariantNotFound)
+
+	variants.On("GetById", "v1").Return(&entities.ProductVariant{Id: "v1", ProductId: "1", Available: false}, nil).Once()
+	_, err = usecase.Create(request)
+	assert.ErrorIs(t, err, appErrors.ErrVariantUnavailable)
+}
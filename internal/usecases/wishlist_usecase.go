@@ -0,0 +1,225 @@
+// usecases/wishlist_usecase.go
+package usecases
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+
+	"github.com/shayja/go-template-api/internal/entities"
+	"github.com/shayja/go-template-api/internal/errors"
+)
+
+// Limits of the wishlists of a user when WishlistInteractor does not set them
+const (
+	DefaultMaxWishlists     = 20
+	DefaultMaxWishlistItems = 500
+)
+
+// The random bytes of a share token, encoded to 43 URL safe characters
+const shareTokenBytes = 32
+
+type WishlistRepository interface {
+	GetByUser(userId string) ([]*entities.Wishlist, error)
+	Get(id string) (*entities.Wishlist, error)
+	GetByToken(token string) (*entities.Wishlist, error)
+	GetDefault(userId string, name string) (*entities.Wishlist, error)
+	Create(userId string, request *entities.WishlistRequest, maxLists int) (*entities.Wishlist, error)
+	Update(id string, request *entities.WishlistRequest) (*entities.Wishlist, error)
+	SetShareToken(id string, token *string) (*entities.Wishlist, error)
+	Delete(id string) error
+	GetItems(id string) ([]*entities.WishlistItem, error)
+	AddItem(id string, productId string, maxItems int) error
+	RemoveItem(id string, productId string) error
+	MoveItem(id string, targetId string, productId string, maxItems int) error
+}
+
+type WishlistInteractor struct {
+	WishlistRepository WishlistRepository
+	// Optional, the most lists per user, defaults to DefaultMaxWishlists
+	MaxLists int
+	// Optional, the most products per list, defaults to DefaultMaxWishlistItems
+	MaxItems int
+}
+
+// GetAll returns the wishlists of the user, creating the default list on first use
+func (uc *WishlistInteractor) GetAll(userId string) ([]*entities.Wishlist, error) {
+	if _, err := uc.WishlistRepository.GetDefault(userId, entities.DefaultWishlistName); err != nil {
+		return nil, err
+	}
+	return uc.WishlistRepository.GetByUser(userId)
+}
+
+// Get returns a wishlist of the user with its products
+func (uc *WishlistInteractor) Get(userId string, id string) (*entities.Wishlist, error) {
+	wishlist, err := uc.owned(userId, id)
+	if err != nil {
+		return nil, err
+	}
+	return uc.withItems(wishlist)
+}
+
+// GetShared returns a shared wishlist with its products, without its owner
+func (uc *WishlistInteractor) GetShared(token string) (*entities.Wishlist, error) {
+	wishlist, err := uc.WishlistRepository.GetByToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if wishlist == nil {
+		return nil, errors.ErrWishlistNotFound
+	}
+	wishlist.UserId = ""
+	wishlist.ShareToken = nil
+	return uc.withItems(wishlist)
+}
+
+// Create adds a wishlist for the user, the first list of the user becomes the default list
+func (uc *WishlistInteractor) Create(userId string, request *entities.WishlistRequest) (*entities.Wishlist, error) {
+	request.Name = strings.TrimSpace(request.Name)
+	if request.Name == "" {
+		return nil, errors.ErrInvalidInput
+	}
+	return uc.WishlistRepository.Create(userId, request, positive(uc.MaxLists, DefaultMaxWishlists))
+}
+
+// Update renames a wishlist of the user, or makes it the default list
+func (uc *WishlistInteractor) Update(userId string, id string, request *entities.WishlistRequest) (*entities.Wishlist, error) {
+	request.Name = strings.TrimSpace(request.Name)
+	if request.Name == "" {
+		return nil, errors.ErrInvalidInput
+	}
+	wishlist, err := uc.owned(userId, id)
+	if err != nil {
+		return nil, err
+	}
+	return uc.WishlistRepository.Update(wishlist.Id, request)
+}
+
+// Delete removes a wishlist of the user and its products, except the default list
+func (uc *WishlistInteractor) Delete(userId string, id string) error {
+	wishlist, err := uc.owned(userId, id)
+	if err != nil {
+		return err
+	}
+	if wishlist.IsDefault {
+		return errors.ErrDefaultWishlist
+	}
+	return uc.WishlistRepository.Delete(wishlist.Id)
+}
+
+// AddItem saves a product to a wishlist of the user and returns the list
+func (uc *WishlistInteractor) AddItem(userId string, id string, productId string) (*entities.Wishlist, error) {
+	wishlist, err := uc.owned(userId, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.WishlistRepository.AddItem(wishlist.Id, productId, positive(uc.MaxItems, DefaultMaxWishlistItems)); err != nil {
+		return nil, err
+	}
+	return uc.reload(wishlist.Id)
+}
+
+// RemoveItem removes a product from a wishlist of the user and returns the list
+func (uc *WishlistInteractor) RemoveItem(userId string, id string, productId string) (*entities.Wishlist, error) {
+	wishlist, err := uc.owned(userId, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.WishlistRepository.RemoveItem(wishlist.Id, productId); err != nil {
+		return nil, err
+	}
+	return uc.reload(wishlist.Id)
+}
+
+// MoveItem moves a product between two wishlists of the user and returns the target list
+func (uc *WishlistInteractor) MoveItem(userId string, id string, productId string, targetId string) (*entities.Wishlist, error) {
+	wishlist, err := uc.owned(userId, id)
+	if err != nil {
+		return nil, err
+	}
+	target, err := uc.owned(userId, targetId)
+	if err != nil {
+		return nil, err
+	}
+	if target.Id != wishlist.Id {
+		if err := uc.WishlistRepository.MoveItem(wishlist.Id, target.Id, productId, positive(uc.MaxItems, DefaultMaxWishlistItems)); err != nil {
+			return nil, err
+		}
+	}
+	return uc.reload(target.Id)
+}
+
+// Share gives a wishlist of the user a new share token, the previous link stops working
+func (uc *WishlistInteractor) Share(userId string, id string) (*entities.Wishlist, error) {
+	wishlist, err := uc.owned(userId, id)
+	if err != nil {
+		return nil, err
+	}
+	token, err := newShareToken()
+	if err != nil {
+		return nil, errors.ErrInternal
+	}
+	return uc.WishlistRepository.SetShareToken(wishlist.Id, &token)
+}
+
+// Unshare removes the share token of a wishlist of the user
+func (uc *WishlistInteractor) Unshare(userId string, id string) (*entities.Wishlist, error) {
+	wishlist, err := uc.owned(userId, id)
+	if err != nil {
+		return nil, err
+	}
+	return uc.WishlistRepository.SetShareToken(wishlist.Id, nil)
+}
+
+// owned resolves a wishlist of the user by id, or the default list of the user
+func (uc *WishlistInteractor) owned(userId string, id string) (*entities.Wishlist, error) {
+	if id == entities.DefaultWishlist {
+		return uc.WishlistRepository.GetDefault(userId, entities.DefaultWishlistName)
+	}
+	wishlist, err := uc.WishlistRepository.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	// Other users' lists are reported missing rather than forbidden
+	if wishlist == nil || wishlist.UserId != userId {
+		return nil, errors.ErrWishlistNotFound
+	}
+	return wishlist, nil
+}
+
+func (uc *WishlistInteractor) reload(id string) (*entities.Wishlist, error) {
+	wishlist, err := uc.WishlistRepository.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if wishlist == nil {
+		return nil, errors.ErrWishlistNotFound
+	}
+	return uc.withItems(wishlist)
+}
+
+func (uc *WishlistInteractor) withItems(wishlist *entities.Wishlist) (*entities.Wishlist, error) {
+	items, err := uc.WishlistRepository.GetItems(wishlist.Id)
+	if err != nil {
+		return nil, err
+	}
+	wishlist.Items = items
+	return wishlist, nil
+}
+
+// positive returns the value, or the fallback when it is not set
+func positive(value int, fallback int) int {
+	if value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+// newShareToken returns an unguessable URL safe token
+func newShareToken() (string, error) {
+	token := make([]byte, shareTokenBytes)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
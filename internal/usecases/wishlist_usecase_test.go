@@ -0,0 +1,168 @@
+package usecases_test
+
+import (
+	"testing"
+
+	"github.com/shayja/go-template-api/internal/entities"
+	appErrors "github.com/shayja/go-template-api/internal/errors"
+	"github.com/shayja/go-template-api/internal/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockWishlistRepository mocks the WishlistRepository interface
+type MockWishlistRepository struct {
+	mock.Mock
+}
+
+func (m *MockWishlistRepository) wishlist(args mock.Arguments) (*entities.Wishlist, error) {
+	if wishlist, ok := args.Get(0).(*entities.Wishlist); ok {
+		return wishlist, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockWishlistRepository) GetByUser(userId string) ([]*entities.Wishlist, error) {
+	args := m.Called(userId)
+	return args.Get(0).([]*entities.Wishlist), args.Error(1)
+}
+
+func (m *MockWishlistRepository) Get(id string) (*entities.Wishlist, error) {
+	return m.wishlist(m.Called(id))
+}
+
+func (m *MockWishlistRepository) GetByToken(token string) (*entities.Wishlist, error) {
+	return m.wishlist(m.Called(token))
+}
+
+func (m *MockWishlistRepository) GetDefault(userId string, name string) (*entities.Wishlist, error) {
+	return m.wishlist(m.Called(userId, name))
+}
+
+func (m *MockWishlistRepository) Create(userId string, request *entities.WishlistRequest, maxLists int) (*entities.Wishlist, error) {
+	return m.wishlist(m.Called(userId, request, maxLists))
+}
+
+func (m *MockWishlistRepository) Update(id string, request *entities.WishlistRequest) (*entities.Wishlist, error) {
+	return m.wishlist(m.Called(id, request))
+}
+
+func (m *MockWishlistRepository) SetShareToken(id string, token *string) (*entities.Wishlist, error) {
+	return m.wishlist(m.Called(id, token))
+}
+
+func (m *MockWishlistRepository) Delete(id string) error {
+	return m.Called(id).Error(0)
+}
+
+func (m *MockWishlistRepository) GetItems(id string) ([]*entities.WishlistItem, error) {
+	args := m.Called(id)
+	return args.Get(0).([]*entities.WishlistItem), args.Error(1)
+}
+
+func (m *MockWishlistRepository) AddItem(id string, productId string, maxItems int) error {
+	return m.Called(id, productId, maxItems).Error(0)
+}
+
+func (m *MockWishlistRepository) RemoveItem(id string, productId string) error {
+	return m.Called(id, productId).Error(0)
+}
+
+func (m *MockWishlistRepository) MoveItem(id string, targetId string, productId string, maxItems int) error {
+	return m.Called(id, targetId, productId, maxItems).Error(0)
+}
+
+func TestWishlistInteractor_AddItem_DefaultList(t *testing.T) {
+	repo := new(MockWishlistRepository)
+	interactor := &usecases.WishlistInteractor{WishlistRepository: repo}
+
+	items := []*entities.WishlistItem{{Product: &entities.Product{Id: "p1"}, Available: true}}
+	repo.On("GetDefault", "u1", entities.DefaultWishlistName).Return(&entities.Wishlist{Id: "w1", UserId: "u1", IsDefault: true}, nil)
+	repo.On("AddItem", "w1", "p1", usecases.DefaultMaxWishlistItems).Return(nil)
+	repo.On("Get", "w1").Return(&entities.Wishlist{Id: "w1", UserId: "u1", IsDefault: true, ItemCount: 1}, nil)
+	repo.On("GetItems", "w1").Return(items, nil)
+
+	wishlist, err := interactor.AddItem("u1", entities.DefaultWishlist, "p1")
+	assert.NoError(t, err)
+	assert.Equal(t, items, wishlist.Items)
+	repo.AssertExpectations(t)
+}
+
+func TestWishlistInteractor_Get_OtherUser(t *testing.T) {
+	repo := new(MockWishlistRepository)
+	interactor := &usecases.WishlistInteractor{WishlistRepository: repo}
+
+	repo.On("Get", "w1").Return(&entities.Wishlist{Id: "w1", UserId: "u2"}, nil)
+
+	_, err := interactor.Get("u1", "w1")
+	assert.Equal(t, appErrors.ErrWishlistNotFound, err)
+	repo.AssertNotCalled(t, "GetItems", mock.Anything)
+}
+
+func TestWishlistInteractor_Delete_Default(t *testing.T) {
+	repo := new(MockWishlistRepository)
+	interactor := &usecases.WishlistInteractor{WishlistRepository: repo}
+
+	repo.On("Get", "w1").Return(&entities.Wishlist{Id: "w1", UserId: "u1", IsDefault: true}, nil)
+
+	err := interactor.Delete("u1", "w1")
+	assert.Equal(t, appErrors.ErrDefaultWishlist, err)
+	repo.AssertNotCalled(t, "Delete", mock.Anything)
+}
+
+func TestWishlistInteractor_MoveItem_OtherUsersTarget(t *testing.T) {
+	repo := new(MockWishlistRepository)
+	interactor := &usecases.WishlistInteractor{WishlistRepository: repo, MaxItems: 10}
+
+	repo.On("Get", "w1").Return(&entities.Wishlist{Id: "w1", UserId: "u1"}, nil)
+	repo.On("Get", "w2").Return(&entities.Wishlist{Id: "w2", UserId: "u2"}, nil)
+
+	_, err := interactor.MoveItem("u1", "w1", "p1", "w2")
+	assert.Equal(t, appErrors.ErrWishlistNotFound, err)
+	repo.AssertNotCalled(t, "MoveItem", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestWishlistInteractor_Share(t *testing.T) {
+	repo := new(MockWishlistRepository)
+	interactor := &usecases.WishlistInteractor{WishlistRepository: repo}
+
+	var tokens []string
+	repo.On("Get", "w1").Return(&entities.Wishlist{Id: "w1", UserId: "u1"}, nil)
+	repo.On("SetShareToken", "w1", mock.Anything).Run(func(args mock.Arguments) {
+		tokens = append(tokens, *args.Get(1).(*string))
+	}).Return(&entities.Wishlist{Id: "w1"}, nil)
+
+	_, err := interactor.Share("u1", "w1")
+	assert.NoError(t, err)
+	_, err = interactor.Share("u1", "w1")
+	assert.NoError(t, err)
+	assert.Len(t, tokens[0], 43)
+	assert.NotEqual(t, tokens[0], tokens[1])
+}
+
+func TestWishlistInteractor_GetShared_HidesOwner(t *testing.T) {
+	repo := new(MockWishlistRepository)
+	interactor := &usecases.WishlistInteractor{WishlistRepository: repo}
+
+	token := "q3Xl0m6bVd2k9s8JtYwZr4FhC1pN7uEa5gLiKoBxT0M"
+	repo.On("GetByToken", token).Return(&entities.Wishlist{Id: "w1", UserId: "u1", ShareToken: &token}, nil)
+	repo.On("GetItems", "w1").Return([]*entities.WishlistItem{}, nil)
+
+	wishlist, err := interactor.GetShared(token)
+	assert.NoError(t, err)
+	assert.Empty(t, wishlist.UserId)
+	assert.Nil(t, wishlist.ShareToken)
+
+	repo.On("GetByToken", "revoked").Return(nil, nil)
+	_, err = interactor.GetShared("revoked")
+	assert.Equal(t, appErrors.ErrWishlistNotFound, err)
+}
+
+func TestWishlistInteractor_Create_BlankName(t *testing.T) {
+	repo := new(MockWishlistRepository)
+	interactor := &usecases.WishlistInteractor{WishlistRepository: repo}
+
+	_, err := interactor.Create("u1", &entities.WishlistRequest{Name: "   "})
+	assert.Equal(t, appErrors.ErrInvalidInput, err)
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+}
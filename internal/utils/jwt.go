@@ -22,9 +22,10 @@ type JwtUtils struct {
 func GenerateJWT(user *entities.User) (string, error) {
 	tokenTTL, _ := strconv.Atoi(config.Config("TOKEN_TTL"))
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"id":  user.Id,
-		"iat": time.Now().Unix(),
-		"eat": time.Now().Add(time.Second * time.Duration(tokenTTL)).Unix(),
+		"id":   user.Id,
+		"role": user.Role,
+		"iat":  time.Now().Unix(),
+		"eat":  time.Now().Add(time.Second * time.Duration(tokenTTL)).Unix(),
 	})
 	return token.SignedString(privateKey)
 }
@@ -67,6 +68,32 @@ func(m *JwtUtils) CurrentUser(context *gin.Context) (*entities.User, error) {
 	return user, nil
 }
 
+// CurrentRole returns the role claim of a valid request token
+func CurrentRole(context *gin.Context) (string, error) {
+	err := ValidateJWT(context)
+	if err != nil {
+		return "", err
+	}
+
+	token, _ := getToken(context)
+	claims, _ := token.Claims.(jwt.MapClaims)
+	role, _ := claims["role"].(string)
+	return role, nil
+}
+
+// CurrentUserId returns the user id claim of a valid request token
+func CurrentUserId(context *gin.Context) (string, error) {
+	err := ValidateJWT(context)
+	if err != nil {
+		return "", err
+	}
+
+	token, _ := getToken(context)
+	claims, _ := token.Claims.(jwt.MapClaims)
+	userId, _ := claims["id"].(string)
+	return userId, nil
+}
+
 func getToken(context *gin.Context) (*jwt.Token, error) {
 	tokenString := getTokenFromRequest(context)
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
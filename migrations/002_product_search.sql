@@ -0,0 +1,81 @@
+--Product full-text search
+
+-- Column: products.search_vector
+-- Weighted document over name/sku (A) and description (B), maintained by Postgres.
+
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+        setweight(to_tsvector('simple', coalesce(sku, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(description, '')), 'B')
+    ) STORED;
+
+-- Index: idx_products_search_vector
+CREATE INDEX IF NOT EXISTS idx_products_search_vector ON products USING gin (search_vector);
+-- Index: idx_products_sku_prefix
+CREATE INDEX IF NOT EXISTS idx_products_sku_prefix ON products USING btree (lower(sku) text_pattern_ops);
+-- Index: idx_products_price
+CREATE INDEX IF NOT EXISTS idx_products_price ON products USING btree (price ASC NULLS LAST);
+-- Index: idx_products_created_at
+CREATE INDEX IF NOT EXISTS idx_products_created_at ON products USING btree (created_at ASC NULLS LAST);
+-- Index: idx_products_updated_at
+CREATE INDEX IF NOT EXISTS idx_products_updated_at ON products USING btree (updated_at ASC NULLS LAST);
+
+
+-- The product functions returned SETOF products, which breaks as soon as the table
+-- gains a column. Recreate them with an explicit row shape.
+
+DROP FUNCTION IF EXISTS get_product(uuid);
+
+CREATE OR REPLACE FUNCTION get_product(productid uuid)
+    RETURNS TABLE (
+        id uuid,
+        name character varying,
+        description text,
+        image character varying,
+        price numeric,
+        sku character varying,
+        updated_at timestamp without time zone,
+        created_at timestamp without time zone
+    )
+    LANGUAGE 'sql'
+    COST 100
+    VOLATILE PARALLEL UNSAFE
+    ROWS 1
+
+AS $BODY$
+SELECT p.id, p.name, p.description, p.image, p.price, p.sku, p.updated_at, p.created_at
+FROM products p WHERE p.id=productId
+LIMIT 1
+$BODY$;
+
+ALTER FUNCTION get_product(uuid) OWNER TO appuser;
+
+
+DROP FUNCTION IF EXISTS get_products(integer, integer);
+
+CREATE OR REPLACE FUNCTION get_products(offst integer, lmt integer)
+    RETURNS TABLE (
+        id uuid,
+        name character varying,
+        description text,
+        image character varying,
+        price numeric,
+        sku character varying,
+        updated_at timestamp without time zone,
+        created_at timestamp without time zone
+    )
+    LANGUAGE 'sql'
+    COST 100
+    VOLATILE PARALLEL UNSAFE
+    ROWS 1000
+
+AS $BODY$
+SELECT p.id, p.name, p.description, p.image, p.price, p.sku, p.updated_at, p.created_at
+FROM products p
+ORDER BY p."name", p.id
+OFFSET offst LIMIT lmt;
+$BODY$;
+
+ALTER FUNCTION get_products(integer, integer) OWNER TO appuser;
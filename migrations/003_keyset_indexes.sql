@@ -0,0 +1,38 @@
+--Keyset pagination indexes
+-- Every sortable column is paired with the id tie breaker used by the list cursors.
+-- The row comparison of a cursor never matches a NULL, so the sortable columns are NOT NULL.
+
+-- Table: products
+UPDATE products SET name = COALESCE(name, ''), price = COALESCE(price, 0), sku = COALESCE(sku, ''),
+    created_at = COALESCE(created_at, updated_at, CURRENT_TIMESTAMP), updated_at = COALESCE(updated_at, created_at, CURRENT_TIMESTAMP)
+WHERE name IS NULL OR price IS NULL OR sku IS NULL OR created_at IS NULL OR updated_at IS NULL;
+
+ALTER TABLE products
+    ALTER COLUMN name SET NOT NULL,
+    ALTER COLUMN price SET NOT NULL,
+    ALTER COLUMN sku SET NOT NULL,
+    ALTER COLUMN created_at SET DEFAULT CURRENT_TIMESTAMP,
+    ALTER COLUMN created_at SET NOT NULL,
+    ALTER COLUMN updated_at SET DEFAULT CURRENT_TIMESTAMP,
+    ALTER COLUMN updated_at SET NOT NULL;
+
+-- Table: orders
+UPDATE orders SET created_at = COALESCE(created_at, updated_at, CURRENT_TIMESTAMP), updated_at = COALESCE(updated_at, created_at, CURRENT_TIMESTAMP)
+WHERE created_at IS NULL OR updated_at IS NULL;
+
+ALTER TABLE orders
+    ALTER COLUMN created_at SET NOT NULL,
+    ALTER COLUMN updated_at SET NOT NULL;
+
+-- Table: products
+CREATE INDEX IF NOT EXISTS idx_products_name_id ON products USING btree (name, id);
+CREATE INDEX IF NOT EXISTS idx_products_price_id ON products USING btree (price, id);
+CREATE INDEX IF NOT EXISTS idx_products_sku_id ON products USING btree (sku, id);
+CREATE INDEX IF NOT EXISTS idx_products_created_at_id ON products USING btree (created_at, id);
+CREATE INDEX IF NOT EXISTS idx_products_updated_at_id ON products USING btree (updated_at, id);
+
+-- Table: orders
+CREATE INDEX IF NOT EXISTS idx_orders_user_created_at_id ON orders USING btree (user_id, created_at, id);
+CREATE INDEX IF NOT EXISTS idx_orders_user_updated_at_id ON orders USING btree (user_id, updated_at, id);
+CREATE INDEX IF NOT EXISTS idx_orders_user_total_price_id ON orders USING btree (user_id, total_price, id);
+CREATE INDEX IF NOT EXISTS idx_orders_user_status_id ON orders USING btree (user_id, status, id);
@@ -0,0 +1,143 @@
+--User roles
+
+-- Column: users.role
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role character varying(20) NOT NULL DEFAULT 'customer';
+
+-- The user functions return SETOF users and must list the new column.
+
+CREATE OR REPLACE FUNCTION get_user(
+	userid uuid)
+    RETURNS SETOF users
+    LANGUAGE 'sql'
+    COST 100
+    VOLATILE PARALLEL UNSAFE
+    ROWS 1000
+
+AS $BODY$
+SELECT id, username, passhash, mobile, first_name, last_name, email, otp_types, verified, verified_at, updated_at, created_at, role FROM users WHERE id=userId
+LIMIT 1
+$BODY$;
+
+ALTER FUNCTION get_user(uuid) OWNER TO appuser;
+
+
+CREATE OR REPLACE FUNCTION get_user_by_username(
+	user_name character varying)
+    RETURNS SETOF users
+    LANGUAGE 'sql'
+    COST 100
+    VOLATILE PARALLEL UNSAFE
+    ROWS 1000
+
+AS $BODY$
+SELECT id, username, passhash, mobile, first_name, last_name, email, otp_types, verified, verified_at, updated_at, created_at, role FROM users WHERE LOWER(username)=LOWER(user_name)
+LIMIT 1
+$BODY$;
+
+ALTER FUNCTION get_user_by_username(character varying) OWNER TO appuser;
+
+
+CREATE OR REPLACE FUNCTION get_user_by_mobile(
+	p_mobile character varying)
+    RETURNS SETOF users
+    LANGUAGE 'sql'
+    COST 100
+    VOLATILE PARALLEL UNSAFE
+    ROWS 1000
+
+AS $BODY$
+SELECT id, username, passhash, mobile, first_name, last_name, email, otp_types, verified, verified_at, updated_at, created_at, role FROM users WHERE mobile=p_mobile
+LIMIT 1
+$BODY$;
+
+ALTER FUNCTION get_user_by_mobile(character varying) OWNER TO appuser;
+
+
+--Product categories
+
+-- Table: categories
+
+CREATE TABLE IF NOT EXISTS categories
+(
+    id uuid NOT NULL,
+    parent_id uuid,
+    name character varying(255) NOT NULL,
+    slug character varying(255) NOT NULL,
+    position integer NOT NULL DEFAULT 0,
+    updated_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP,
+    created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT categories_pkey PRIMARY KEY (id),
+    CONSTRAINT categories_slug_key UNIQUE (slug),
+    CONSTRAINT fk_parent FOREIGN KEY (parent_id)
+        REFERENCES categories (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE RESTRICT
+);
+
+-- Index: idx_categories_parent_id
+CREATE INDEX IF NOT EXISTS idx_categories_parent_id ON categories USING btree (parent_id ASC NULLS FIRST, position ASC);
+
+GRANT INSERT, SELECT, UPDATE, DELETE ON TABLE categories TO appuser;
+
+
+-- Table: product_categories
+
+CREATE TABLE IF NOT EXISTS product_categories
+(
+    product_id uuid NOT NULL,
+    category_id uuid NOT NULL,
+    created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT product_categories_pkey PRIMARY KEY (product_id, category_id),
+    CONSTRAINT fk_product FOREIGN KEY (product_id)
+        REFERENCES products (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE,
+    CONSTRAINT fk_category FOREIGN KEY (category_id)
+        REFERENCES categories (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+-- Index: idx_product_categories_category_id
+CREATE INDEX IF NOT EXISTS idx_product_categories_category_id ON product_categories USING btree (category_id ASC NULLS LAST);
+
+GRANT INSERT, SELECT, UPDATE, DELETE ON TABLE product_categories TO appuser;
+
+
+--Create Functions
+
+-- Ids of a category and all of its descendants
+CREATE OR REPLACE FUNCTION get_category_tree_ids(p_category_id uuid)
+    RETURNS SETOF uuid
+    LANGUAGE 'sql'
+    STABLE PARALLEL SAFE
+
+AS $BODY$
+WITH RECURSIVE tree AS (
+    SELECT id FROM categories WHERE id = p_category_id
+    UNION
+    SELECT c.id FROM categories c JOIN tree t ON c.parent_id = t.id
+)
+SELECT id FROM tree
+$BODY$;
+
+ALTER FUNCTION get_category_tree_ids(uuid) OWNER TO appuser;
+
+
+--Create Procedures
+
+CREATE OR REPLACE PROCEDURE product_categories_set(
+	IN p_product_id uuid,
+	IN p_category_ids uuid[])
+LANGUAGE 'plpgsql'
+AS $BODY$
+BEGIN
+    DELETE FROM product_categories
+    WHERE product_id = p_product_id AND NOT (category_id = ANY(p_category_ids));
+
+    INSERT INTO product_categories (product_id, category_id)
+    SELECT p_product_id, c FROM unnest(p_category_ids) AS c
+    ON CONFLICT (product_id, category_id) DO NOTHING;
+END;
+$BODY$;
+ALTER PROCEDURE product_categories_set(uuid, uuid[]) OWNER TO appuser;
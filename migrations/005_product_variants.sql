@@ -0,0 +1,109 @@
+--Product variants
+
+-- Table: product_options
+-- The option axes of a product (e.g. size, color) and their allowed values.
+
+CREATE TABLE IF NOT EXISTS product_options
+(
+    product_id uuid NOT NULL,
+    name character varying(50) NOT NULL,
+    "values" text[] NOT NULL DEFAULT '{}'::text[],
+    position integer NOT NULL DEFAULT 0,
+    CONSTRAINT product_options_pkey PRIMARY KEY (product_id, name),
+    CONSTRAINT fk_product FOREIGN KEY (product_id)
+        REFERENCES products (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+GRANT INSERT, SELECT, UPDATE, DELETE ON TABLE product_options TO appuser;
+
+
+-- Table: product_variants
+
+CREATE TABLE IF NOT EXISTS product_variants
+(
+    id uuid NOT NULL,
+    product_id uuid NOT NULL,
+    sku character varying(255) NOT NULL,
+    price numeric(10,2),
+    image character varying(255),
+    options jsonb NOT NULL DEFAULT '{}'::jsonb,
+    available boolean NOT NULL DEFAULT true,
+    position integer NOT NULL DEFAULT 0,
+    updated_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP,
+    created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT product_variants_pkey PRIMARY KEY (id),
+    CONSTRAINT fk_product FOREIGN KEY (product_id)
+        REFERENCES products (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+-- Index: idx_product_variants_product_id
+CREATE INDEX IF NOT EXISTS idx_product_variants_product_id ON product_variants USING btree (product_id ASC NULLS LAST, position ASC);
+-- Index: idx_product_variants_options (one variant per option combination)
+CREATE UNIQUE INDEX IF NOT EXISTS idx_product_variants_options ON product_variants USING btree (product_id, options);
+
+GRANT INSERT, SELECT, UPDATE, DELETE ON TABLE product_variants TO appuser;
+
+
+-- Column: order_details.variant_id
+
+ALTER TABLE order_details ADD COLUMN IF NOT EXISTS variant_id uuid;
+ALTER TABLE order_details DROP CONSTRAINT IF EXISTS fk_variant;
+ALTER TABLE order_details ADD CONSTRAINT fk_variant FOREIGN KEY (variant_id)
+    REFERENCES product_variants (id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION;
+
+CREATE INDEX IF NOT EXISTS idx_order_details_variant_id ON order_details USING btree (variant_id ASC NULLS LAST);
+
+ALTER TYPE order_detail_type ADD ATTRIBUTE variant_id uuid;
+
+
+CREATE OR REPLACE PROCEDURE orders_insert(
+	IN p_user_id uuid,
+	IN p_total_price numeric,
+	IN p_status numeric,
+	IN p_order_details order_detail_type[],
+	INOUT next_order_id uuid)
+LANGUAGE 'plpgsql'
+AS $BODY$
+BEGIN
+
+    -- Insert the new order
+    INSERT INTO orders (id, user_id, total_price, status)
+    VALUES (
+        gen_random_uuid(),
+        p_user_id,
+        p_total_price,
+        p_status
+    )
+    RETURNING id INTO next_order_id;
+
+    -- Unnest and insert the order details
+    WITH details AS (
+        SELECT
+            next_order_id AS order_id,
+            (d).product_id,
+            (d).variant_id,
+            COALESCE((d).quantity, 1) AS quantity,
+            (d).unit_price
+        FROM unnest(p_order_details) AS d
+    )
+    INSERT INTO order_details (id, order_id, product_id, variant_id, quantity, unit_price)
+    SELECT
+        gen_random_uuid(),
+        order_id,
+        product_id,
+        variant_id,
+        quantity,
+        unit_price
+    FROM details;
+
+    COMMIT;
+END;
+$BODY$;
+
+ALTER PROCEDURE orders_insert(uuid, numeric, numeric, order_detail_type[], uuid) OWNER TO appuser;
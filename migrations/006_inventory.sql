@@ -0,0 +1,183 @@
+--Inventory tracking
+
+-- Order statuses, see pkg/constants
+INSERT INTO order_status (id, name) VALUES
+    (1, 'created'),
+    (2, 'processing'),
+    (3, 'completed'),
+    (4, 'cancelled')
+ON CONFLICT (id) DO NOTHING;
+
+
+-- Table: inventory
+-- The stock level of a product. Products without a row are not stock tracked.
+
+CREATE TABLE IF NOT EXISTS inventory
+(
+    product_id uuid NOT NULL,
+    quantity integer NOT NULL DEFAULT 0,
+    low_stock_threshold integer NOT NULL DEFAULT 0,
+    updated_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT inventory_pkey PRIMARY KEY (product_id),
+    CONSTRAINT inventory_quantity_check CHECK (quantity >= 0),
+    CONSTRAINT inventory_low_stock_threshold_check CHECK (low_stock_threshold >= 0),
+    CONSTRAINT fk_product FOREIGN KEY (product_id)
+        REFERENCES products (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+GRANT INSERT, SELECT, UPDATE, DELETE ON TABLE inventory TO appuser;
+
+
+-- Table: inventory_movements
+-- The ledger of every stock change: admin adjustments, orders and cancellations.
+
+CREATE TABLE IF NOT EXISTS inventory_movements
+(
+    id uuid NOT NULL DEFAULT gen_random_uuid(),
+    product_id uuid NOT NULL,
+    delta integer NOT NULL,
+    quantity_after integer NOT NULL,
+    kind character varying(20) NOT NULL,
+    reason text,
+    order_id uuid,
+    user_id uuid,
+    created_at timestamp without time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT inventory_movements_pkey PRIMARY KEY (id),
+    CONSTRAINT fk_product FOREIGN KEY (product_id)
+        REFERENCES products (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+-- Index: idx_inventory_movements_product_id
+CREATE INDEX IF NOT EXISTS idx_inventory_movements_product_id ON inventory_movements USING btree (product_id, created_at, id);
+-- Index: idx_inventory_movements_order_id
+CREATE INDEX IF NOT EXISTS idx_inventory_movements_order_id ON inventory_movements USING btree (order_id ASC NULLS LAST);
+
+GRANT INSERT, SELECT, UPDATE, DELETE ON TABLE inventory_movements TO appuser;
+
+
+-- Creating an order reserves the ordered quantity of every stock tracked product.
+-- The rows are locked in product order so concurrent orders queue instead of deadlocking,
+-- and the quantity check constraint aborts the whole order when any product would oversell.
+
+CREATE OR REPLACE PROCEDURE orders_insert(
+	IN p_user_id uuid,
+	IN p_total_price numeric,
+	IN p_status numeric,
+	IN p_order_details order_detail_type[],
+	INOUT next_order_id uuid)
+LANGUAGE 'plpgsql'
+AS $BODY$
+BEGIN
+
+    -- Insert the new order
+    INSERT INTO orders (id, user_id, total_price, status)
+    VALUES (
+        gen_random_uuid(),
+        p_user_id,
+        p_total_price,
+        p_status
+    )
+    RETURNING id INTO next_order_id;
+
+    -- Unnest and insert the order details
+    WITH details AS (
+        SELECT
+            next_order_id AS order_id,
+            (d).product_id,
+            (d).variant_id,
+            COALESCE((d).quantity, 1) AS quantity,
+            (d).unit_price
+        FROM unnest(p_order_details) AS d
+    )
+    INSERT INTO order_details (id, order_id, product_id, variant_id, quantity, unit_price)
+    SELECT
+        gen_random_uuid(),
+        order_id,
+        product_id,
+        variant_id,
+        quantity,
+        unit_price
+    FROM details;
+
+    -- Reserve the stock
+    PERFORM 1 FROM inventory
+    WHERE product_id IN (SELECT product_id FROM order_details WHERE order_id = next_order_id)
+    ORDER BY product_id
+    FOR UPDATE;
+
+    WITH ordered AS (
+        SELECT product_id, SUM(quantity) AS quantity
+        FROM order_details
+        WHERE order_id = next_order_id
+        GROUP BY product_id
+    ), reserved AS (
+        UPDATE inventory i
+        SET quantity = i.quantity - o.quantity, updated_at = NOW()
+        FROM ordered o
+        WHERE i.product_id = o.product_id
+        RETURNING i.product_id, -o.quantity AS delta, i.quantity
+    )
+    INSERT INTO inventory_movements (product_id, delta, quantity_after, kind, order_id, user_id)
+    SELECT product_id, delta, quantity, 'order', next_order_id, p_user_id
+    FROM reserved;
+
+    COMMIT;
+END;
+$BODY$;
+
+ALTER PROCEDURE orders_insert(uuid, numeric, numeric, order_detail_type[], uuid) OWNER TO appuser;
+
+
+-- Cancelling an order releases its reserved stock back to the products.
+
+CREATE OR REPLACE PROCEDURE orders_update_status(
+	IN p_order_id uuid,
+	IN p_status numeric)
+LANGUAGE 'plpgsql'
+AS $BODY$
+DECLARE
+    previous_status numeric;
+BEGIN
+
+    SELECT status INTO previous_status FROM orders WHERE id = p_order_id FOR UPDATE;
+
+    -- A cancelled order has released its stock and cannot be reopened
+    IF previous_status = 4 AND p_status <> 4 THEN
+        RAISE EXCEPTION 'order % is cancelled', p_order_id USING ERRCODE = 'object_not_in_prerequisite_state';
+    END IF;
+
+    UPDATE orders SET status = p_status, updated_at = NOW() WHERE id = p_order_id;
+
+    IF p_status = 4 AND previous_status IS DISTINCT FROM 4 THEN
+        PERFORM 1 FROM inventory
+        WHERE product_id IN (SELECT product_id FROM inventory_movements WHERE order_id = p_order_id)
+        ORDER BY product_id
+        FOR UPDATE;
+
+        -- Only what the order actually reserved goes back
+        WITH ordered AS (
+            SELECT product_id, -SUM(delta) AS quantity
+            FROM inventory_movements
+            WHERE order_id = p_order_id AND kind = 'order'
+            GROUP BY product_id
+        ), released AS (
+            UPDATE inventory i
+            SET quantity = i.quantity + o.quantity, updated_at = NOW()
+            FROM ordered o
+            WHERE i.product_id = o.product_id
+            RETURNING i.product_id, o.quantity AS delta, i.quantity
+        )
+        INSERT INTO inventory_movements (product_id, delta, quantity_after, kind, order_id)
+        SELECT product_id, delta, quantity, 'cancellation', p_order_id
+        FROM released;
+    END IF;
+
+    COMMIT;
+END;
+$BODY$;
+
+ALTER PROCEDURE orders_update_status(uuid, numeric) OWNER TO appuser;
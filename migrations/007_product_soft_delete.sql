@@ -0,0 +1,111 @@
+--Product soft delete
+
+-- Column: products.deleted_at
+-- Deleted products keep their row so order history stays intact; they are purged
+-- once no order references them.
+
+ALTER TABLE products ADD COLUMN IF NOT EXISTS deleted_at timestamp without time zone;
+
+-- Index: idx_products_deleted_at
+CREATE INDEX IF NOT EXISTS idx_products_deleted_at ON products USING btree (deleted_at) WHERE deleted_at IS NOT NULL;
+
+
+DROP FUNCTION IF EXISTS get_product(uuid);
+
+CREATE OR REPLACE FUNCTION get_product(productid uuid)
+    RETURNS TABLE (
+        id uuid,
+        name character varying,
+        description text,
+        image character varying,
+        price numeric,
+        sku character varying,
+        updated_at timestamp without time zone,
+        created_at timestamp without time zone,
+        deleted_at timestamp without time zone
+    )
+    LANGUAGE 'sql'
+    COST 100
+    VOLATILE PARALLEL UNSAFE
+    ROWS 1
+
+AS $BODY$
+SELECT p.id, p.name, p.description, p.image, p.price, p.sku, p.updated_at, p.created_at, p.deleted_at
+FROM products p WHERE p.id=productId
+LIMIT 1
+$BODY$;
+
+ALTER FUNCTION get_product(uuid) OWNER TO appuser;
+
+
+-- Deleted products can only be changed after they are restored
+
+CREATE OR REPLACE PROCEDURE products_update(
+	IN product_id uuid,
+	IN product_name text,
+	IN product_description text,
+	IN product_price money,
+	IN product_image text,
+	IN product_sku text)
+LANGUAGE 'plpgsql'
+AS $BODY$
+BEGIN
+  UPDATE products
+  SET name = product_name,
+  description = product_description,
+  price = product_price,
+  image = product_image,
+  sku = product_sku,
+  updated_at = NOW()
+  WHERE id = product_id AND deleted_at IS NULL;
+END;
+$BODY$;
+ALTER PROCEDURE products_update(uuid, text, text, money, text, text) OWNER TO appuser;
+
+
+CREATE OR REPLACE PROCEDURE products_update_image(
+	IN product_id uuid,
+	IN product_image text)
+LANGUAGE 'plpgsql'
+AS $BODY$
+BEGIN
+  UPDATE products SET image = product_image, updated_at = NOW() WHERE id = product_id AND deleted_at IS NULL;
+END;
+$BODY$;
+ALTER PROCEDURE products_update_image(uuid, text) OWNER TO appuser;
+
+
+CREATE OR REPLACE PROCEDURE products_update_price(
+	IN product_id uuid,
+	IN product_price money)
+LANGUAGE 'plpgsql'
+AS $BODY$
+BEGIN
+  UPDATE products SET price = product_price, updated_at = NOW() WHERE id = product_id AND deleted_at IS NULL;
+END;
+$BODY$;
+ALTER PROCEDURE products_update_price(uuid, money) OWNER TO appuser;
+
+
+-- Deleted products cannot be ordered. Raised as a foreign key violation, the same
+-- error an unknown product id produces.
+
+CREATE OR REPLACE FUNCTION order_details_check_product()
+    RETURNS trigger
+    LANGUAGE 'plpgsql'
+AS $BODY$
+BEGIN
+    IF EXISTS (SELECT 1 FROM products WHERE id = NEW.product_id AND deleted_at IS NOT NULL) THEN
+        RAISE EXCEPTION 'product % is deleted', NEW.product_id USING ERRCODE = 'foreign_key_violation';
+    END IF;
+    RETURN NEW;
+END;
+$BODY$;
+
+ALTER FUNCTION order_details_check_product() OWNER TO appuser;
+
+DROP TRIGGER IF EXISTS order_details_check_product ON order_details;
+
+CREATE TRIGGER order_details_check_product
+    BEFORE INSERT ON order_details
+    FOR EACH ROW EXECUTE FUNCTION order_details_check_product();
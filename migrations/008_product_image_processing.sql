@@ -0,0 +1,86 @@
+--Product image processing
+
+-- Columns: products.image_*
+-- An uploaded image is stored as image_source until the worker has rendered it; the
+-- renditions then replace the product image. image_queued_at lets the worker pick up
+-- the uploads it lost on a restart.
+
+ALTER TABLE products ADD COLUMN IF NOT EXISTS image_status character varying(16);
+ALTER TABLE products ADD COLUMN IF NOT EXISTS image_source text;
+ALTER TABLE products ADD COLUMN IF NOT EXISTS image_queued_at timestamp without time zone;
+ALTER TABLE products ADD COLUMN IF NOT EXISTS image_renditions jsonb;
+
+-- Index: idx_products_image_queued_at
+CREATE INDEX IF NOT EXISTS idx_products_image_queued_at ON products USING btree (image_queued_at) WHERE image_source IS NOT NULL;
+
+
+DROP FUNCTION IF EXISTS get_product(uuid);
+
+CREATE OR REPLACE FUNCTION get_product(productid uuid)
+    RETURNS TABLE (
+        id uuid,
+        name character varying,
+        description text,
+        image character varying,
+        price numeric,
+        sku character varying,
+        updated_at timestamp without time zone,
+        created_at timestamp without time zone,
+        deleted_at timestamp without time zone,
+        image_status character varying,
+        image_renditions jsonb
+    )
+    LANGUAGE 'sql'
+    COST 100
+    VOLATILE PARALLEL UNSAFE
+    ROWS 1
+
+AS $BODY$
+SELECT p.id, p.name, p.description, p.image, p.price, p.sku, p.updated_at, p.created_at, p.deleted_at, p.image_status, p.image_renditions
+FROM products p WHERE p.id=productId
+LIMIT 1
+$BODY$;
+
+ALTER FUNCTION get_product(uuid) OWNER TO appuser;
+
+
+-- Setting the image URL directly drops the renditions of the previous image
+
+CREATE OR REPLACE PROCEDURE products_update(
+	IN product_id uuid,
+	IN product_name text,
+	IN product_description text,
+	IN product_price money,
+	IN product_image text,
+	IN product_sku text)
+LANGUAGE 'plpgsql'
+AS $BODY$
+BEGIN
+  UPDATE products
+  SET name = product_name,
+  description = product_description,
+  price = product_price,
+  image_renditions = CASE WHEN image IS DISTINCT FROM product_image THEN NULL ELSE image_renditions END,
+  image = product_image,
+  sku = product_sku,
+  updated_at = NOW()
+  WHERE id = product_id AND deleted_at IS NULL;
+END;
+$BODY$;
+ALTER PROCEDURE products_update(uuid, text, text, money, text, text) OWNER TO appuser;
+
+
+CREATE OR REPLACE PROCEDURE products_update_image(
+	IN product_id uuid,
+	IN product_image text)
+LANGUAGE 'plpgsql'
+AS $BODY$
+BEGIN
+  UPDATE products
+  SET image_renditions = CASE WHEN image IS DISTINCT FROM product_image THEN NULL ELSE image_renditions END,
+  image = product_image,
+  updated_at = NOW()
+  WHERE id = product_id AND deleted_at IS NULL;
+END;
+$BODY$;
+ALTER PROCEDURE products_update_image(uuid, text) OWNER TO appuser;
@@ -0,0 +1,83 @@
+--Product image gallery
+
+-- Table: product_images
+-- Every uploaded image is a gallery item, processed by the image worker. The primary
+-- item is mirrored on the product image columns.
+
+CREATE TABLE IF NOT EXISTS product_images
+(
+    id uuid NOT NULL DEFAULT gen_random_uuid(),
+    product_id uuid NOT NULL,
+    url text,
+    alt_text character varying(255) NOT NULL DEFAULT '',
+    sort_order integer NOT NULL DEFAULT 0,
+    is_primary boolean NOT NULL DEFAULT false,
+    status character varying(16) NOT NULL,
+    source text,
+    queued_at timestamp without time zone,
+    renditions jsonb,
+    created_at timestamp without time zone NOT NULL DEFAULT NOW(),
+    updated_at timestamp without time zone NOT NULL DEFAULT NOW(),
+    CONSTRAINT product_images_pkey PRIMARY KEY (id),
+    CONSTRAINT product_images_product_id_fkey FOREIGN KEY (product_id)
+        REFERENCES products (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+ALTER TABLE IF EXISTS product_images OWNER to appuser;
+
+-- Index: idx_product_images_product_id
+CREATE INDEX IF NOT EXISTS idx_product_images_product_id ON product_images USING btree (product_id, sort_order);
+
+-- Index: idx_product_images_primary, a product has at most one primary image
+CREATE UNIQUE INDEX IF NOT EXISTS idx_product_images_primary ON product_images USING btree (product_id) WHERE is_primary;
+
+-- Index: idx_product_images_queued_at
+CREATE INDEX IF NOT EXISTS idx_product_images_queued_at ON product_images USING btree (queued_at) WHERE source IS NOT NULL;
+
+
+-- The uploaded product images become the primary gallery images
+
+INSERT INTO product_images (product_id, url, sort_order, is_primary, status, source, queued_at, renditions)
+SELECT id, CASE WHEN image_renditions IS NOT NULL THEN image END, 0, true, COALESCE(image_status, 'ready'), image_source, image_queued_at, image_renditions
+FROM products
+WHERE image_renditions IS NOT NULL OR image_source IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_products_image_queued_at;
+ALTER TABLE products DROP COLUMN IF EXISTS image_source;
+ALTER TABLE products DROP COLUMN IF EXISTS image_queued_at;
+
+
+-- The product image, its renditions and status follow the primary gallery image; the
+-- image is replaced once the new primary image is processed.
+
+CREATE OR REPLACE FUNCTION product_images_sync_product()
+    RETURNS trigger
+    LANGUAGE 'plpgsql'
+AS $BODY$
+DECLARE
+    pid uuid := CASE WHEN TG_OP = 'DELETE' THEN OLD.product_id ELSE NEW.product_id END;
+    img product_images%ROWTYPE;
+BEGIN
+    SELECT * INTO img FROM product_images WHERE product_id = pid AND is_primary;
+    IF NOT FOUND THEN
+        UPDATE products SET image = '', image_renditions = NULL, image_status = NULL
+        WHERE id = pid AND image_status IS NOT NULL;
+    ELSIF img.status = 'ready' THEN
+        UPDATE products SET image = img.url, image_renditions = img.renditions, image_status = img.status
+        WHERE id = pid;
+    ELSE
+        UPDATE products SET image_status = img.status WHERE id = pid;
+    END IF;
+    RETURN NULL;
+END;
+$BODY$;
+
+ALTER FUNCTION product_images_sync_product() OWNER TO appuser;
+
+DROP TRIGGER IF EXISTS product_images_sync_product ON product_images;
+
+CREATE TRIGGER product_images_sync_product
+    AFTER INSERT OR UPDATE OR DELETE ON product_images
+    FOR EACH ROW EXECUTE FUNCTION product_images_sync_product();
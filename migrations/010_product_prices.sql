@@ -0,0 +1,165 @@
+--Product price history and scheduled price changes
+
+-- Table: product_price_schedules
+-- A future price of a product, applied at starts_at by the price scheduler. A schedule
+-- with an end reverts the product to the price it replaced at ends_at.
+
+CREATE TABLE IF NOT EXISTS product_price_schedules
+(
+    id uuid NOT NULL DEFAULT gen_random_uuid(),
+    product_id uuid NOT NULL,
+    price numeric(10,2) NOT NULL,
+    starts_at timestamp without time zone NOT NULL,
+    ends_at timestamp without time zone,
+    status character varying(16) NOT NULL DEFAULT 'scheduled',
+    revert_price numeric(10,2),
+    created_at timestamp without time zone NOT NULL DEFAULT NOW(),
+    updated_at timestamp without time zone NOT NULL DEFAULT NOW(),
+    CONSTRAINT product_price_schedules_pkey PRIMARY KEY (id),
+    CONSTRAINT product_price_schedules_price_check CHECK (price >= 0),
+    CONSTRAINT product_price_schedules_period_check CHECK (ends_at IS NULL OR ends_at > starts_at),
+    CONSTRAINT product_price_schedules_product_id_fkey FOREIGN KEY (product_id)
+        REFERENCES products (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+ALTER TABLE IF EXISTS product_price_schedules OWNER to appuser;
+
+-- Index: idx_product_price_schedules_product_id
+CREATE INDEX IF NOT EXISTS idx_product_price_schedules_product_id ON product_price_schedules USING btree (product_id, starts_at);
+
+-- Index: idx_product_price_schedules_starts_at, the schedules waiting to start
+CREATE INDEX IF NOT EXISTS idx_product_price_schedules_starts_at ON product_price_schedules USING btree (starts_at) WHERE status = 'scheduled';
+
+-- Index: idx_product_price_schedules_ends_at, the schedules waiting to end
+CREATE INDEX IF NOT EXISTS idx_product_price_schedules_ends_at ON product_price_schedules USING btree (ends_at) WHERE status = 'active';
+
+
+-- Table: product_price_history
+-- Every price a product had, recorded by the products_record_price trigger.
+
+CREATE TABLE IF NOT EXISTS product_price_history
+(
+    id uuid NOT NULL DEFAULT gen_random_uuid(),
+    product_id uuid NOT NULL,
+    price numeric(10,2) NOT NULL,
+    previous_price numeric(10,2),
+    source character varying(16) NOT NULL,
+    schedule_id uuid,
+    created_at timestamp without time zone NOT NULL DEFAULT NOW(),
+    CONSTRAINT product_price_history_pkey PRIMARY KEY (id),
+    CONSTRAINT product_price_history_product_id_fkey FOREIGN KEY (product_id)
+        REFERENCES products (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE,
+    CONSTRAINT product_price_history_schedule_id_fkey FOREIGN KEY (schedule_id)
+        REFERENCES product_price_schedules (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE SET NULL
+);
+
+ALTER TABLE IF EXISTS product_price_history OWNER to appuser;
+
+-- Index: idx_product_price_history_product_id
+CREATE INDEX IF NOT EXISTS idx_product_price_history_product_id ON product_price_history USING btree (product_id, created_at, id);
+
+
+-- Records every new product price. The price scheduler names its changes with the
+-- app.price_source and app.price_schedule_id settings, any other change is manual.
+
+CREATE OR REPLACE FUNCTION products_record_price()
+    RETURNS trigger
+    LANGUAGE 'plpgsql'
+AS $BODY$
+BEGIN
+    IF TG_OP = 'UPDATE' AND NEW.price IS NOT DISTINCT FROM OLD.price THEN
+        RETURN NULL;
+    END IF;
+    INSERT INTO product_price_history (product_id, price, previous_price, source, schedule_id)
+    VALUES (
+        NEW.id,
+        NEW.price,
+        CASE WHEN TG_OP = 'UPDATE' THEN OLD.price END,
+        COALESCE(NULLIF(current_setting('app.price_source', true), ''), 'manual'),
+        NULLIF(current_setting('app.price_schedule_id', true), '')::uuid);
+    RETURN NULL;
+END;
+$BODY$;
+
+ALTER FUNCTION products_record_price() OWNER TO appuser;
+
+DROP TRIGGER IF EXISTS products_record_price ON products;
+
+CREATE TRIGGER products_record_price
+    AFTER INSERT OR UPDATE OF price ON products
+    FOR EACH ROW EXECUTE FUNCTION products_record_price();
+
+-- The current prices start the history
+INSERT INTO product_price_history (product_id, price, source, created_at)
+SELECT p.id, p.price, 'initial', p.created_at
+FROM products p
+WHERE NOT EXISTS (SELECT 1 FROM product_price_history h WHERE h.product_id = p.id);
+
+
+-- Applies the due price schedules: ends the active schedules past their end, restoring the
+-- price they replaced unless it was changed by hand meanwhile, then starts the schedules
+-- past their start. Products soft deleted keep their schedules until they are restored.
+
+CREATE OR REPLACE FUNCTION apply_price_schedules(p_now timestamp without time zone)
+    RETURNS TABLE (activated integer, reverted integer)
+    LANGUAGE 'plpgsql'
+AS $BODY$
+DECLARE
+    s product_price_schedules%ROWTYPE;
+    current_price numeric(10,2);
+BEGIN
+    activated := 0;
+    reverted := 0;
+
+    FOR s IN SELECT * FROM product_price_schedules
+        WHERE status = 'active' AND ends_at <= p_now
+        ORDER BY ends_at FOR UPDATE SKIP LOCKED
+    LOOP
+        PERFORM set_config('app.price_source', 'reverted', true);
+        PERFORM set_config('app.price_schedule_id', s.id::text, true);
+        UPDATE products SET price = s.revert_price, updated_at = NOW()
+        WHERE id = s.product_id AND price = s.price;
+        UPDATE product_price_schedules SET status = 'completed', updated_at = NOW() WHERE id = s.id;
+        reverted := reverted + 1;
+    END LOOP;
+
+    FOR s IN SELECT * FROM product_price_schedules
+        WHERE status = 'scheduled' AND starts_at <= p_now
+        ORDER BY starts_at FOR UPDATE SKIP LOCKED
+    LOOP
+        -- The whole period passed while the scheduler was not running
+        IF s.ends_at IS NOT NULL AND s.ends_at <= p_now THEN
+            UPDATE product_price_schedules SET status = 'completed', updated_at = NOW() WHERE id = s.id;
+            CONTINUE;
+        END IF;
+
+        SELECT price INTO current_price FROM products WHERE id = s.product_id AND deleted_at IS NULL FOR UPDATE;
+        IF NOT FOUND THEN
+            CONTINUE;
+        END IF;
+
+        PERFORM set_config('app.price_source', 'scheduled', true);
+        PERFORM set_config('app.price_schedule_id', s.id::text, true);
+        UPDATE products SET price = s.price, updated_at = NOW() WHERE id = s.product_id;
+        -- A schedule without an end is a permanent change, there is nothing to revert
+        UPDATE product_price_schedules
+        SET status = CASE WHEN s.ends_at IS NULL THEN 'completed' ELSE 'active' END,
+            revert_price = current_price,
+            updated_at = NOW()
+        WHERE id = s.id;
+        activated := activated + 1;
+    END LOOP;
+
+    PERFORM set_config('app.price_source', '', true);
+    PERFORM set_config('app.price_schedule_id', '', true);
+    RETURN NEXT;
+END;
+$BODY$;
+
+ALTER FUNCTION apply_price_schedules(timestamp without time zone) OWNER TO appuser;
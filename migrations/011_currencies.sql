@@ -0,0 +1,48 @@
+--Multi-currency pricing
+
+-- Table: currencies
+-- The currencies prices can be shown in besides the store base currency (BASE_CURRENCY),
+-- with their exchange rate from the base currency and how converted amounts are rounded.
+
+CREATE TABLE IF NOT EXISTS currencies
+(
+    code character(3) NOT NULL,
+    rate numeric(18,8) NOT NULL,
+    decimals smallint NOT NULL DEFAULT 2,
+    rounding character varying(16) NOT NULL DEFAULT 'half_up',
+    rounding_increment numeric(10,4),
+    updated_at timestamp without time zone NOT NULL DEFAULT NOW(),
+    CONSTRAINT currencies_pkey PRIMARY KEY (code),
+    CONSTRAINT currencies_rate_check CHECK (rate > 0),
+    CONSTRAINT currencies_decimals_check CHECK (decimals BETWEEN 0 AND 4),
+    CONSTRAINT currencies_rounding_check CHECK (rounding IN ('half_up', 'half_even', 'up', 'down')),
+    CONSTRAINT currencies_rounding_increment_check CHECK (rounding_increment IS NULL OR rounding_increment > 0)
+);
+
+ALTER TABLE IF EXISTS currencies OWNER to appuser;
+
+
+-- Table: product_currency_prices
+-- Explicit prices of a product in other currencies, used instead of converting its base price.
+
+CREATE TABLE IF NOT EXISTS product_currency_prices
+(
+    product_id uuid NOT NULL,
+    currency character(3) NOT NULL,
+    price numeric(10,2) NOT NULL,
+    CONSTRAINT product_currency_prices_pkey PRIMARY KEY (product_id, currency),
+    CONSTRAINT product_currency_prices_price_check CHECK (price >= 0),
+    CONSTRAINT product_currency_prices_product_id_fkey FOREIGN KEY (product_id)
+        REFERENCES products (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE,
+    CONSTRAINT product_currency_prices_currency_fkey FOREIGN KEY (currency)
+        REFERENCES currencies (code) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+ALTER TABLE IF EXISTS product_currency_prices OWNER to appuser;
+
+-- Index: idx_product_currency_prices_currency
+CREATE INDEX IF NOT EXISTS idx_product_currency_prices_currency ON product_currency_prices USING btree (currency, product_id);
@@ -0,0 +1,77 @@
+--Exact money amounts
+
+-- The product procedures took the price as money, whose precision and parsing depend on
+-- lc_monetary, while every price column is numeric(10,2). The API sends amounts as decimal
+-- strings, which numeric reads exactly. CREATE OR REPLACE with another argument type would
+-- add an overload, so the money versions are dropped first.
+
+DROP PROCEDURE IF EXISTS products_insert(text, text, money, text, text, timestamp without time zone, uuid);
+DROP PROCEDURE IF EXISTS products_update(uuid, text, text, money, text, text);
+DROP PROCEDURE IF EXISTS products_update_price(uuid, money);
+
+
+CREATE OR REPLACE PROCEDURE products_insert(
+	IN p_name text,
+	IN p_description text,
+	IN p_price numeric,
+	IN p_image text,
+	IN p_sku text,
+	IN p_create_date timestamp without time zone,
+	INOUT next_id uuid)
+LANGUAGE 'plpgsql'
+AS $BODY$
+
+BEGIN
+
+    INSERT INTO products (id, name, description, price, image, sku, updated_at, created_at)
+    SELECT gen_random_uuid(),
+           p_name,
+           p_description,
+           p_price,
+           p_image,
+           p_sku,
+		   p_create_date,
+		   p_create_date
+    RETURNING id INTO next_id;
+
+    COMMIT;
+
+END;
+$BODY$;
+ALTER PROCEDURE products_insert(text, text, numeric, text, text, timestamp without time zone, uuid) OWNER TO appuser;
+
+
+CREATE OR REPLACE PROCEDURE products_update(
+	IN product_id uuid,
+	IN product_name text,
+	IN product_description text,
+	IN product_price numeric,
+	IN product_image text,
+	IN product_sku text)
+LANGUAGE 'plpgsql'
+AS $BODY$
+BEGIN
+  UPDATE products
+  SET name = product_name,
+  description = product_description,
+  price = product_price,
+  image_renditions = CASE WHEN image IS DISTINCT FROM product_image THEN NULL ELSE image_renditions END,
+  image = product_image,
+  sku = product_sku,
+  updated_at = NOW()
+  WHERE id = product_id AND deleted_at IS NULL;
+END;
+$BODY$;
+ALTER PROCEDURE products_update(uuid, text, text, numeric, text, text) OWNER TO appuser;
+
+
+CREATE OR REPLACE PROCEDURE products_update_price(
+	IN product_id uuid,
+	IN product_price numeric)
+LANGUAGE 'plpgsql'
+AS $BODY$
+BEGIN
+  UPDATE products SET price = product_price, updated_at = NOW() WHERE id = product_id AND deleted_at IS NULL;
+END;
+$BODY$;
+ALTER PROCEDURE products_update_price(uuid, numeric) OWNER TO appuser;
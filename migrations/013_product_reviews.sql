@@ -0,0 +1,116 @@
+--Product reviews and ratings
+
+-- Table: product_reviews
+-- A user review of a product. Reviews are published once a moderator approves them,
+-- a user may review a product once.
+
+CREATE TABLE IF NOT EXISTS product_reviews
+(
+    id uuid NOT NULL DEFAULT gen_random_uuid(),
+    product_id uuid NOT NULL,
+    user_id uuid NOT NULL,
+    rating smallint NOT NULL,
+    title character varying(200) NOT NULL,
+    body text NOT NULL DEFAULT '',
+    status character varying(20) NOT NULL DEFAULT 'pending',
+    verified_purchase boolean NOT NULL DEFAULT false,
+    moderated_at timestamp without time zone,
+    updated_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP,
+    created_at timestamp without time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT product_reviews_pkey PRIMARY KEY (id),
+    CONSTRAINT product_reviews_product_user_key UNIQUE (product_id, user_id),
+    CONSTRAINT product_reviews_rating_check CHECK (rating BETWEEN 1 AND 5),
+    CONSTRAINT product_reviews_status_check CHECK (status IN ('pending', 'approved', 'rejected')),
+    CONSTRAINT fk_product FOREIGN KEY (product_id)
+        REFERENCES products (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE,
+    CONSTRAINT fk_user FOREIGN KEY (user_id)
+        REFERENCES users (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+ALTER TABLE IF EXISTS product_reviews OWNER to appuser;
+
+-- Index: idx_product_reviews_product_status
+CREATE INDEX IF NOT EXISTS idx_product_reviews_product_status ON product_reviews USING btree (product_id, status, created_at, id);
+-- Index: idx_product_reviews_status
+CREATE INDEX IF NOT EXISTS idx_product_reviews_status ON product_reviews USING btree (status, created_at, id);
+
+
+-- The rating aggregates of the approved reviews, kept on the product so listings can
+-- show and sort by them without a join.
+
+ALTER TABLE products ADD COLUMN IF NOT EXISTS rating_average numeric(3,2) NOT NULL DEFAULT 0;
+ALTER TABLE products ADD COLUMN IF NOT EXISTS rating_count integer NOT NULL DEFAULT 0;
+
+-- Index: idx_products_rating_average
+CREATE INDEX IF NOT EXISTS idx_products_rating_average ON products USING btree (rating_average, id);
+
+
+-- Recomputes the rating aggregates of the products whose reviews changed
+
+CREATE OR REPLACE FUNCTION product_reviews_update_rating()
+    RETURNS trigger
+    LANGUAGE 'plpgsql'
+AS $BODY$
+BEGIN
+    UPDATE products p
+    SET rating_average = COALESCE(r.average, 0),
+        rating_count = COALESCE(r.count, 0)
+    FROM (
+        SELECT ids.product_id,
+               round(avg(pr.rating), 2) AS average,
+               count(pr.id) AS count
+        FROM (SELECT NEW.product_id WHERE TG_OP <> 'DELETE'
+              UNION SELECT OLD.product_id WHERE TG_OP <> 'INSERT') ids (product_id)
+        LEFT JOIN product_reviews pr ON pr.product_id = ids.product_id AND pr.status = 'approved'
+        GROUP BY ids.product_id
+    ) r
+    WHERE p.id = r.product_id;
+    RETURN NULL;
+END;
+$BODY$;
+
+ALTER FUNCTION product_reviews_update_rating() OWNER TO appuser;
+
+DROP TRIGGER IF EXISTS product_reviews_update_rating ON product_reviews;
+
+CREATE TRIGGER product_reviews_update_rating
+    AFTER INSERT OR UPDATE OF rating, status OR DELETE ON product_reviews
+    FOR EACH ROW EXECUTE FUNCTION product_reviews_update_rating();
+
+
+-- The product details include the rating aggregates
+
+DROP FUNCTION IF EXISTS get_product(uuid);
+
+CREATE OR REPLACE FUNCTION get_product(productid uuid)
+    RETURNS TABLE (
+        id uuid,
+        name character varying,
+        description text,
+        image character varying,
+        price numeric,
+        sku character varying,
+        updated_at timestamp without time zone,
+        created_at timestamp without time zone,
+        deleted_at timestamp without time zone,
+        image_status character varying,
+        image_renditions jsonb,
+        rating_average numeric,
+        rating_count integer
+    )
+    LANGUAGE 'sql'
+    COST 100
+    VOLATILE PARALLEL UNSAFE
+    ROWS 1
+
+AS $BODY$
+SELECT p.id, p.name, p.description, p.image, p.price, p.sku, p.updated_at, p.created_at, p.deleted_at, p.image_status, p.image_renditions, p.rating_average, p.rating_count
+FROM products p WHERE p.id=productId
+LIMIT 1
+$BODY$;
+
+ALTER FUNCTION get_product(uuid) OWNER TO appuser;
@@ -0,0 +1,194 @@
+--Optimistic concurrency of product writes
+
+-- The version of a product, the API sends it as the ETag of the product and writes given an
+-- If-Match header only apply to the version the client read.
+
+ALTER TABLE products ADD COLUMN IF NOT EXISTS version integer NOT NULL DEFAULT 1;
+
+
+-- Every change of a product row moves it to the next version, unless the update sets it
+
+CREATE OR REPLACE FUNCTION products_bump_version()
+    RETURNS trigger
+    LANGUAGE 'plpgsql'
+AS $BODY$
+BEGIN
+    IF NEW.version = OLD.version THEN
+        NEW.version := OLD.version + 1;
+    END IF;
+    RETURN NEW;
+END;
+$BODY$;
+
+ALTER FUNCTION products_bump_version() OWNER TO appuser;
+
+DROP TRIGGER IF EXISTS products_bump_version ON products;
+
+CREATE TRIGGER products_bump_version
+    BEFORE UPDATE ON products
+    FOR EACH ROW WHEN (OLD IS DISTINCT FROM NEW)
+    EXECUTE FUNCTION products_bump_version();
+
+
+-- The product details also show the options, variants, gallery, categories and currency prices
+-- of the product, changing them moves the product to the next version too
+
+CREATE OR REPLACE FUNCTION product_children_bump_version()
+    RETURNS trigger
+    LANGUAGE 'plpgsql'
+AS $BODY$
+BEGIN
+    UPDATE products SET version = version + 1
+    WHERE id IN (
+        SELECT NEW.product_id WHERE TG_OP <> 'DELETE'
+        UNION SELECT OLD.product_id WHERE TG_OP <> 'INSERT');
+    RETURN NULL;
+END;
+$BODY$;
+
+ALTER FUNCTION product_children_bump_version() OWNER TO appuser;
+
+DROP TRIGGER IF EXISTS product_options_bump_version ON product_options;
+CREATE TRIGGER product_options_bump_version
+    AFTER INSERT OR UPDATE OR DELETE ON product_options
+    FOR EACH ROW EXECUTE FUNCTION product_children_bump_version();
+
+DROP TRIGGER IF EXISTS product_variants_bump_version ON product_variants;
+CREATE TRIGGER product_variants_bump_version
+    AFTER INSERT OR UPDATE OR DELETE ON product_variants
+    FOR EACH ROW EXECUTE FUNCTION product_children_bump_version();
+
+DROP TRIGGER IF EXISTS product_images_bump_version ON product_images;
+CREATE TRIGGER product_images_bump_version
+    AFTER INSERT OR UPDATE OR DELETE ON product_images
+    FOR EACH ROW EXECUTE FUNCTION product_children_bump_version();
+
+DROP TRIGGER IF EXISTS product_categories_bump_version ON product_categories;
+CREATE TRIGGER product_categories_bump_version
+    AFTER INSERT OR UPDATE OR DELETE ON product_categories
+    FOR EACH ROW EXECUTE FUNCTION product_children_bump_version();
+
+DROP TRIGGER IF EXISTS product_currency_prices_bump_version ON product_currency_prices;
+CREATE TRIGGER product_currency_prices_bump_version
+    AFTER INSERT OR UPDATE OR DELETE ON product_currency_prices
+    FOR EACH ROW EXECUTE FUNCTION product_children_bump_version();
+
+
+-- The product details show the breadcrumbs of their categories too, renaming, re-slugging or
+-- moving a category moves the products of the category and its subcategories to the next version
+
+CREATE OR REPLACE FUNCTION category_products_bump_version(p_category_id uuid)
+    RETURNS void
+    LANGUAGE 'sql'
+AS $BODY$
+UPDATE products SET version = version + 1
+WHERE id IN (
+    SELECT pc.product_id FROM product_categories pc
+    WHERE pc.category_id IN (SELECT get_category_tree_ids(p_category_id)));
+$BODY$;
+
+ALTER FUNCTION category_products_bump_version(uuid) OWNER TO appuser;
+
+CREATE OR REPLACE FUNCTION categories_bump_version()
+    RETURNS trigger
+    LANGUAGE 'plpgsql'
+AS $BODY$
+BEGIN
+    PERFORM category_products_bump_version(NEW.id);
+    RETURN NULL;
+END;
+$BODY$;
+
+ALTER FUNCTION categories_bump_version() OWNER TO appuser;
+
+DROP TRIGGER IF EXISTS categories_bump_version ON categories;
+CREATE TRIGGER categories_bump_version
+    AFTER UPDATE ON categories
+    FOR EACH ROW WHEN ((OLD.parent_id, OLD.name, OLD.slug) IS DISTINCT FROM (NEW.parent_id, NEW.name, NEW.slug))
+    EXECUTE FUNCTION categories_bump_version();
+
+-- The update procedures take the version the client read, NULL to skip the check, and return
+-- the new version, NULL when the product was not updated
+
+DROP PROCEDURE IF EXISTS products_update(uuid, text, text, numeric, text, text);
+DROP PROCEDURE IF EXISTS products_update_price(uuid, numeric);
+
+
+CREATE OR REPLACE PROCEDURE products_update(
+	IN product_id uuid,
+	IN product_name text,
+	IN product_description text,
+	IN product_price numeric,
+	IN product_image text,
+	IN product_sku text,
+	IN product_version integer,
+	INOUT new_version integer)
+LANGUAGE 'plpgsql'
+AS $BODY$
+BEGIN
+  UPDATE products
+  SET name = product_name,
+  description = product_description,
+  price = product_price,
+  image_renditions = CASE WHEN image IS DISTINCT FROM product_image THEN NULL ELSE image_renditions END,
+  image = product_image,
+  sku = product_sku,
+  updated_at = NOW()
+  WHERE id = product_id AND deleted_at IS NULL
+  AND (product_version IS NULL OR version = product_version)
+  RETURNING version INTO new_version;
+END;
+$BODY$;
+ALTER PROCEDURE products_update(uuid, text, text, numeric, text, text, integer, integer) OWNER TO appuser;
+
+
+CREATE OR REPLACE PROCEDURE products_update_price(
+	IN product_id uuid,
+	IN product_price numeric,
+	IN product_version integer,
+	INOUT new_version integer)
+LANGUAGE 'plpgsql'
+AS $BODY$
+BEGIN
+  UPDATE products SET price = product_price, updated_at = NOW()
+  WHERE id = product_id AND deleted_at IS NULL
+  AND (product_version IS NULL OR version = product_version)
+  RETURNING version INTO new_version;
+END;
+$BODY$;
+ALTER PROCEDURE products_update_price(uuid, numeric, integer, integer) OWNER TO appuser;
+
+
+-- The product details include the version
+
+DROP FUNCTION IF EXISTS get_product(uuid);
+
+CREATE OR REPLACE FUNCTION get_product(productid uuid)
+    RETURNS TABLE (
+        id uuid,
+        name character varying,
+        description text,
+        image character varying,
+        price numeric,
+        sku character varying,
+        updated_at timestamp without time zone,
+        created_at timestamp without time zone,
+        deleted_at timestamp without time zone,
+        image_status character varying,
+        image_renditions jsonb,
+        rating_average numeric,
+        rating_count integer,
+        version integer
+    )
+    LANGUAGE 'sql'
+    COST 100
+    VOLATILE PARALLEL UNSAFE
+    ROWS 1
+
+AS $BODY$
+SELECT p.id, p.name, p.description, p.image, p.price, p.sku, p.updated_at, p.created_at, p.deleted_at, p.image_status, p.image_renditions, p.rating_average, p.rating_count, p.version
+FROM products p WHERE p.id=productId
+LIMIT 1
+$BODY$;
+
+ALTER FUNCTION get_product(uuid) OWNER TO appuser;
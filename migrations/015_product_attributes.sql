@@ -0,0 +1,140 @@
+--Product attributes and tags
+
+-- Table: attribute_definitions
+-- The typed attributes a product may have, e.g. brand or weight. Products keep the values
+-- by attribute code in their attributes column.
+
+CREATE TABLE IF NOT EXISTS attribute_definitions
+(
+    code character varying(100) NOT NULL,
+    name character varying(255) NOT NULL,
+    type character varying(20) NOT NULL,
+    unit character varying(20),
+    "values" text[] NOT NULL DEFAULT '{}',
+    required boolean NOT NULL DEFAULT false,
+    updated_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP,
+    created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT attribute_definitions_pkey PRIMARY KEY (code),
+    CONSTRAINT attribute_definitions_type_check CHECK (type IN ('string', 'number', 'boolean', 'enum'))
+);
+
+ALTER TABLE IF EXISTS attribute_definitions OWNER to appuser;
+
+
+ALTER TABLE products ADD COLUMN IF NOT EXISTS attributes jsonb NOT NULL DEFAULT '{}'::jsonb;
+ALTER TABLE products ADD COLUMN IF NOT EXISTS tags text[] NOT NULL DEFAULT '{}';
+
+-- Index: idx_products_attributes
+CREATE INDEX IF NOT EXISTS idx_products_attributes ON products USING gin (attributes jsonb_path_ops);
+-- Index: idx_products_tags
+CREATE INDEX IF NOT EXISTS idx_products_tags ON products USING gin (tags);
+
+
+-- The product procedures take the attributes and tags, NULL keeps the current ones on update
+
+DROP PROCEDURE IF EXISTS products_insert(text, text, numeric, text, text, timestamp without time zone, uuid);
+DROP PROCEDURE IF EXISTS products_update(uuid, text, text, numeric, text, text, integer, integer);
+
+
+CREATE OR REPLACE PROCEDURE products_insert(
+	IN p_name text,
+	IN p_description text,
+	IN p_price numeric,
+	IN p_image text,
+	IN p_sku text,
+	IN p_create_date timestamp without time zone,
+	IN p_attributes jsonb,
+	IN p_tags text[],
+	INOUT next_id uuid)
+LANGUAGE 'plpgsql'
+AS $BODY$
+
+BEGIN
+
+    INSERT INTO products (id, name, description, price, image, sku, attributes, tags, updated_at, created_at)
+    SELECT gen_random_uuid(),
+           p_name,
+           p_description,
+           p_price,
+           p_image,
+           p_sku,
+           COALESCE(p_attributes, '{}'::jsonb),
+           COALESCE(p_tags, '{}'),
+		   p_create_date,
+		   p_create_date
+    RETURNING id INTO next_id;
+
+    COMMIT;
+
+END;
+$BODY$;
+ALTER PROCEDURE products_insert(text, text, numeric, text, text, timestamp without time zone, jsonb, text[], uuid) OWNER TO appuser;
+
+
+CREATE OR REPLACE PROCEDURE products_update(
+	IN product_id uuid,
+	IN product_name text,
+	IN product_description text,
+	IN product_price numeric,
+	IN product_image text,
+	IN product_sku text,
+	IN product_attributes jsonb,
+	IN product_tags text[],
+	IN product_version integer,
+	INOUT new_version integer)
+LANGUAGE 'plpgsql'
+AS $BODY$
+BEGIN
+  UPDATE products
+  SET name = product_name,
+  description = product_description,
+  price = product_price,
+  image_renditions = CASE WHEN image IS DISTINCT FROM product_image THEN NULL ELSE image_renditions END,
+  image = product_image,
+  sku = product_sku,
+  attributes = COALESCE(product_attributes, attributes),
+  tags = COALESCE(product_tags, tags),
+  updated_at = NOW()
+  WHERE id = product_id AND deleted_at IS NULL
+  AND (product_version IS NULL OR version = product_version)
+  RETURNING version INTO new_version;
+END;
+$BODY$;
+ALTER PROCEDURE products_update(uuid, text, text, numeric, text, text, jsonb, text[], integer, integer) OWNER TO appuser;
+
+
+-- The product details include the attributes and tags
+
+DROP FUNCTION IF EXISTS get_product(uuid);
+
+CREATE OR REPLACE FUNCTION get_product(productid uuid)
+    RETURNS TABLE (
+        id uuid,
+        name character varying,
+        description text,
+        image character varying,
+        price numeric,
+        sku character varying,
+        updated_at timestamp without time zone,
+        created_at timestamp without time zone,
+        deleted_at timestamp without time zone,
+        image_status character varying,
+        image_renditions jsonb,
+        rating_average numeric,
+        rating_count integer,
+        version integer,
+        attributes jsonb,
+        tags text[]
+    )
+    LANGUAGE 'sql'
+    COST 100
+    VOLATILE PARALLEL UNSAFE
+    ROWS 1
+
+AS $BODY$
+SELECT p.id, p.name, p.description, p.image, p.price, p.sku, p.updated_at, p.created_at, p.deleted_at, p.image_status, p.image_renditions, p.rating_average, p.rating_count, p.version, p.attributes, p.tags
+FROM products p WHERE p.id=productId
+LIMIT 1
+$BODY$;
+
+ALTER FUNCTION get_product(uuid) OWNER TO appuser;
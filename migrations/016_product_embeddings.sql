@@ -0,0 +1,28 @@
+--Product semantic search
+
+CREATE EXTENSION IF NOT EXISTS vector;
+
+-- Table: product_embeddings
+-- The vector of the name and description of a product, kept apart from the product so
+-- embedding it again does not move the product to a new version. The source hash is the md5
+-- of the embedded text, a product is embedded again once its text or the model changes.
+-- The vector length must match EMBEDDING_DIMENSIONS, the API checks it at startup.
+
+CREATE TABLE IF NOT EXISTS product_embeddings
+(
+    product_id uuid NOT NULL,
+    embedding vector(384) NOT NULL,
+    model character varying(100) NOT NULL,
+    source_hash character(32) NOT NULL,
+    embedded_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT product_embeddings_pkey PRIMARY KEY (product_id),
+    CONSTRAINT fk_product FOREIGN KEY (product_id)
+        REFERENCES products (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+ALTER TABLE IF EXISTS product_embeddings OWNER to appuser;
+
+-- Index: idx_product_embeddings_embedding
+CREATE INDEX IF NOT EXISTS idx_product_embeddings_embedding ON product_embeddings USING hnsw (embedding vector_cosine_ops);
@@ -0,0 +1,25 @@
+--Product recommendations
+
+-- Table: product_copurchases
+-- The number of orders a product was bought in together with another product, rebuilt
+-- periodically from the order details of the orders that were not cancelled.
+
+CREATE TABLE IF NOT EXISTS product_copurchases
+(
+    product_id uuid NOT NULL,
+    related_id uuid NOT NULL,
+    orders integer NOT NULL,
+    refreshed_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT product_copurchases_pkey PRIMARY KEY (product_id, related_id),
+    CONSTRAINT fk_product FOREIGN KEY (product_id)
+        REFERENCES products (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE,
+    CONSTRAINT fk_related FOREIGN KEY (related_id)
+        REFERENCES products (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+ALTER TABLE IF EXISTS product_copurchases OWNER to appuser;
+
@@ -0,0 +1,56 @@
+--Customer wishlists
+
+-- Table: wishlists
+-- The named lists a user saves products to for later. A user has at most one default list,
+-- created the first time it is used. A list with a share token can be read by anyone
+-- holding the token.
+
+CREATE TABLE IF NOT EXISTS wishlists
+(
+    id uuid NOT NULL DEFAULT gen_random_uuid(),
+    user_id uuid NOT NULL,
+    name character varying(100) NOT NULL,
+    is_default boolean NOT NULL DEFAULT false,
+    share_token character varying(64),
+    updated_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP,
+    created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT wishlists_pkey PRIMARY KEY (id),
+    CONSTRAINT wishlists_share_token_key UNIQUE (share_token),
+    CONSTRAINT fk_user FOREIGN KEY (user_id)
+        REFERENCES users (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+ALTER TABLE IF EXISTS wishlists OWNER to appuser;
+
+-- Index: idx_wishlists_user_name
+CREATE UNIQUE INDEX IF NOT EXISTS idx_wishlists_user_name ON wishlists USING btree (user_id, lower(name));
+-- Index: idx_wishlists_user_default
+CREATE UNIQUE INDEX IF NOT EXISTS idx_wishlists_user_default ON wishlists USING btree (user_id) WHERE is_default;
+
+
+-- Table: wishlist_items
+-- The products saved to a wishlist, once per list. The price and availability are read
+-- from the catalog, the items of deleted products stay until removed.
+
+CREATE TABLE IF NOT EXISTS wishlist_items
+(
+    wishlist_id uuid NOT NULL,
+    product_id uuid NOT NULL,
+    added_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT wishlist_items_pkey PRIMARY KEY (wishlist_id, product_id),
+    CONSTRAINT fk_wishlist FOREIGN KEY (wishlist_id)
+        REFERENCES wishlists (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE,
+    CONSTRAINT fk_product FOREIGN KEY (product_id)
+        REFERENCES products (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+ALTER TABLE IF EXISTS wishlist_items OWNER to appuser;
+
+-- Index: idx_wishlist_items_product
+CREATE INDEX IF NOT EXISTS idx_wishlist_items_product ON wishlist_items USING btree (product_id);
@@ -0,0 +1,19 @@
+--Product SKU uniqueness
+
+-- The SKU of an active product is unique regardless of case, so scanners can look products
+-- up by it. Soft deleted products keep their SKU, restoring one fails while an active product
+-- uses the SKU. Products without a SKU are left out.
+--
+-- The duplicates must be resolved before the index can be created, they are listed by:
+-- SELECT lower(sku), array_agg(id) FROM products WHERE deleted_at IS NULL AND sku <> '' GROUP BY lower(sku) HAVING COUNT(*) > 1;
+
+DO $BODY$
+BEGIN
+    IF EXISTS (SELECT 1 FROM products WHERE deleted_at IS NULL AND sku <> '' GROUP BY lower(sku) HAVING COUNT(*) > 1) THEN
+        RAISE EXCEPTION 'Active products share a SKU, resolve the duplicates listed by the query in 019_product_sku_unique.sql first';
+    END IF;
+END
+$BODY$;
+
+-- Index: idx_products_sku_unique
+CREATE UNIQUE INDEX IF NOT EXISTS idx_products_sku_unique ON products USING btree (lower(sku)) WHERE deleted_at IS NULL AND sku <> '';
@@ -0,0 +1,249 @@
+--Promotions and coupon codes
+
+-- Table: promotions
+-- A discount rule. Automatic promotions apply to every qualifying order while they run, the others
+-- only to the orders presenting one of their coupon codes. A promotion limited to products or
+-- categories only discounts those line items, one without limits discounts the whole order.
+
+CREATE TABLE IF NOT EXISTS promotions
+(
+    id uuid NOT NULL DEFAULT gen_random_uuid(),
+    name character varying(100) NOT NULL,
+    description text NOT NULL DEFAULT '',
+    kind character varying(20) NOT NULL,
+    value numeric(10,2) NOT NULL DEFAULT 0,
+    buy_quantity integer NOT NULL DEFAULT 0,
+    get_quantity integer NOT NULL DEFAULT 0,
+    min_order numeric(10,2) NOT NULL DEFAULT 0,
+    product_ids uuid[] NOT NULL DEFAULT '{}',
+    category_ids uuid[] NOT NULL DEFAULT '{}',
+    automatic boolean NOT NULL DEFAULT false,
+    active boolean NOT NULL DEFAULT true,
+    starts_at timestamp without time zone,
+    ends_at timestamp without time zone,
+    updated_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP,
+    created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT promotions_pkey PRIMARY KEY (id),
+    CONSTRAINT promotions_kind_check CHECK (kind IN ('percentage', 'fixed', 'free_shipping', 'buy_x_get_y')),
+    CONSTRAINT promotions_value_check CHECK (value >= 0 AND (kind <> 'percentage' OR value <= 100)),
+    CONSTRAINT promotions_quantity_check CHECK (kind <> 'buy_x_get_y' OR (buy_quantity > 0 AND get_quantity > 0)),
+    CONSTRAINT promotions_min_order_check CHECK (min_order >= 0),
+    CONSTRAINT promotions_window_check CHECK (ends_at IS NULL OR starts_at IS NULL OR ends_at > starts_at)
+);
+
+ALTER TABLE IF EXISTS promotions OWNER to appuser;
+
+-- Index: idx_promotions_automatic
+CREATE INDEX IF NOT EXISTS idx_promotions_automatic ON promotions USING btree (created_at) WHERE automatic AND active;
+
+
+-- Table: coupons
+-- The codes that apply a promotion to an order, matched case insensitively. The usage limits count
+-- the orders the code was used on, an order that is cancelled gives its use back.
+
+CREATE TABLE IF NOT EXISTS coupons
+(
+    id uuid NOT NULL DEFAULT gen_random_uuid(),
+    promotion_id uuid NOT NULL,
+    code character varying(50) NOT NULL,
+    usage_limit integer,
+    per_user_limit integer,
+    active boolean NOT NULL DEFAULT true,
+    created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT coupons_pkey PRIMARY KEY (id),
+    CONSTRAINT coupons_usage_limit_check CHECK (usage_limit > 0),
+    CONSTRAINT coupons_per_user_limit_check CHECK (per_user_limit > 0),
+    CONSTRAINT fk_promotion FOREIGN KEY (promotion_id)
+        REFERENCES promotions (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+ALTER TABLE IF EXISTS coupons OWNER to appuser;
+
+-- Index: idx_coupons_code
+CREATE UNIQUE INDEX IF NOT EXISTS idx_coupons_code ON coupons USING btree (lower(code));
+-- Index: idx_coupons_promotion_id
+CREATE INDEX IF NOT EXISTS idx_coupons_promotion_id ON coupons USING btree (promotion_id);
+
+
+-- Table: coupon_redemptions
+-- The orders a coupon code was used on.
+
+CREATE TABLE IF NOT EXISTS coupon_redemptions
+(
+    coupon_id uuid NOT NULL,
+    order_id uuid NOT NULL,
+    user_id uuid NOT NULL,
+    created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT coupon_redemptions_pkey PRIMARY KEY (coupon_id, order_id),
+    CONSTRAINT fk_coupon FOREIGN KEY (coupon_id)
+        REFERENCES coupons (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE,
+    CONSTRAINT fk_order FOREIGN KEY (order_id)
+        REFERENCES orders (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+ALTER TABLE IF EXISTS coupon_redemptions OWNER to appuser;
+
+-- Index: idx_coupon_redemptions_user
+CREATE INDEX IF NOT EXISTS idx_coupon_redemptions_user ON coupon_redemptions USING btree (coupon_id, user_id);
+
+
+-- Table: order_discounts
+-- The promotions applied to an order, in the order they were applied. The name, kind and code are
+-- copied so the order keeps them when the promotion changes or is deleted.
+
+CREATE TABLE IF NOT EXISTS order_discounts
+(
+    order_id uuid NOT NULL,
+    position integer NOT NULL,
+    promotion_id uuid,
+    coupon_code character varying(50),
+    name character varying(100) NOT NULL,
+    kind character varying(20) NOT NULL,
+    amount numeric(10,2) NOT NULL DEFAULT 0,
+    CONSTRAINT order_discounts_pkey PRIMARY KEY (order_id, position),
+    CONSTRAINT fk_order FOREIGN KEY (order_id)
+        REFERENCES orders (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE,
+    CONSTRAINT fk_promotion FOREIGN KEY (promotion_id)
+        REFERENCES promotions (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE SET NULL
+);
+
+ALTER TABLE IF EXISTS order_discounts OWNER to appuser;
+
+
+-- The order total is after the discounts, the line items keep their share of the discounts
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS discount_total numeric(10,2) NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS free_shipping boolean NOT NULL DEFAULT false;
+ALTER TABLE order_details ADD COLUMN IF NOT EXISTS discount numeric(10,2) NOT NULL DEFAULT 0;
+
+ALTER TYPE order_detail_type ADD ATTRIBUTE discount numeric(10,2);
+
+
+-- Creating an order now stores its discounts and the coupon code it used. The coupon row is locked
+-- so concurrent orders count each other's redemptions, and an order over a usage limit is aborted.
+-- CREATE OR REPLACE with other arguments would add an overload, so the previous version is dropped first.
+
+DROP PROCEDURE IF EXISTS orders_insert(uuid, numeric, numeric, order_detail_type[], uuid);
+
+CREATE OR REPLACE PROCEDURE orders_insert(
+	IN p_user_id uuid,
+	IN p_total_price numeric,
+	IN p_status numeric,
+	IN p_order_details order_detail_type[],
+	IN p_discount_total numeric,
+	IN p_free_shipping boolean,
+	IN p_discounts jsonb,
+	IN p_coupon_id uuid,
+	INOUT next_order_id uuid)
+LANGUAGE 'plpgsql'
+AS $BODY$
+DECLARE
+    v_usage_limit integer;
+    v_per_user_limit integer;
+    v_used integer;
+    v_used_by_user integer;
+BEGIN
+
+    IF p_coupon_id IS NOT NULL THEN
+        SELECT usage_limit, per_user_limit INTO v_usage_limit, v_per_user_limit
+        FROM coupons WHERE id = p_coupon_id AND active
+        FOR UPDATE;
+        IF NOT FOUND THEN
+            RAISE EXCEPTION 'coupon % is not active', p_coupon_id USING ERRCODE = 'no_data_found';
+        END IF;
+
+        SELECT COUNT(*), COUNT(*) FILTER (WHERE r.user_id = p_user_id) INTO v_used, v_used_by_user
+        FROM coupon_redemptions r JOIN orders o ON o.id = r.order_id
+        WHERE r.coupon_id = p_coupon_id AND o.status <> 4;
+        IF v_used >= v_usage_limit OR v_used_by_user >= v_per_user_limit THEN
+            RAISE EXCEPTION 'coupon % reached its usage limit', p_coupon_id USING ERRCODE = 'CP001';
+        END IF;
+    END IF;
+
+    -- Insert the new order
+    INSERT INTO orders (id, user_id, total_price, status, discount_total, free_shipping)
+    VALUES (
+        gen_random_uuid(),
+        p_user_id,
+        p_total_price,
+        p_status,
+        COALESCE(p_discount_total, 0),
+        COALESCE(p_free_shipping, false)
+    )
+    RETURNING id INTO next_order_id;
+
+    -- Unnest and insert the order details
+    WITH details AS (
+        SELECT
+            next_order_id AS order_id,
+            (d).product_id,
+            (d).variant_id,
+            COALESCE((d).quantity, 1) AS quantity,
+            (d).unit_price,
+            COALESCE((d).discount, 0) AS discount
+        FROM unnest(p_order_details) AS d
+    )
+    INSERT INTO order_details (id, order_id, product_id, variant_id, quantity, unit_price, discount)
+    SELECT
+        gen_random_uuid(),
+        order_id,
+        product_id,
+        variant_id,
+        quantity,
+        unit_price,
+        discount
+    FROM details;
+
+    -- Insert the applied discounts and the coupon redemption
+    INSERT INTO order_discounts (order_id, position, promotion_id, coupon_code, name, kind, amount)
+    SELECT
+        next_order_id,
+        d.position,
+        (d.discount->>'promotion_id')::uuid,
+        NULLIF(d.discount->>'coupon_code', ''),
+        d.discount->>'name',
+        d.discount->>'kind',
+        (d.discount->>'amount')::numeric
+    FROM jsonb_array_elements(COALESCE(p_discounts, '[]'::jsonb)) WITH ORDINALITY AS d(discount, position);
+
+    IF p_coupon_id IS NOT NULL THEN
+        INSERT INTO coupon_redemptions (coupon_id, order_id, user_id)
+        VALUES (p_coupon_id, next_order_id, p_user_id);
+    END IF;
+
+    -- Reserve the stock
+    PERFORM 1 FROM inventory
+    WHERE product_id IN (SELECT product_id FROM order_details WHERE order_id = next_order_id)
+    ORDER BY product_id
+    FOR UPDATE;
+
+    WITH ordered AS (
+        SELECT product_id, SUM(quantity) AS quantity
+        FROM order_details
+        WHERE order_id = next_order_id
+        GROUP BY product_id
+    ), reserved AS (
+        UPDATE inventory i
+        SET quantity = i.quantity - o.quantity, updated_at = NOW()
+        FROM ordered o
+        WHERE i.product_id = o.product_id
+        RETURNING i.product_id, -o.quantity AS delta, i.quantity
+    )
+    INSERT INTO inventory_movements (product_id, delta, quantity_after, kind, order_id, user_id)
+    SELECT product_id, delta, quantity, 'order', next_order_id, p_user_id
+    FROM reserved;
+
+    COMMIT;
+END;
+$BODY$;
+
+ALTER PROCEDURE orders_insert(uuid, numeric, numeric, order_detail_type[], numeric, boolean, jsonb, uuid, uuid) OWNER TO appuser;
@@ -0,0 +1,279 @@
+--Product bundles and kits
+
+-- Table: product_bundles
+-- A product sold as a bundle of other products. A bundle with a discount is priced at the
+-- sum of its components less the discount, kept up to date by the products_refresh_bundle_prices
+-- trigger, a bundle without one keeps the price set on the product.
+
+CREATE TABLE IF NOT EXISTS product_bundles
+(
+    product_id uuid NOT NULL,
+    discount_percent numeric(5,2),
+    updated_at timestamp without time zone NOT NULL DEFAULT NOW(),
+    created_at timestamp without time zone NOT NULL DEFAULT NOW(),
+    CONSTRAINT product_bundles_pkey PRIMARY KEY (product_id),
+    CONSTRAINT product_bundles_discount_percent_check CHECK (discount_percent >= 0 AND discount_percent < 100),
+    CONSTRAINT product_bundles_product_id_fkey FOREIGN KEY (product_id)
+        REFERENCES products (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+ALTER TABLE IF EXISTS product_bundles OWNER to appuser;
+
+
+-- Table: bundle_components
+-- The products and quantities a bundle is made of.
+
+CREATE TABLE IF NOT EXISTS bundle_components
+(
+    bundle_id uuid NOT NULL,
+    component_id uuid NOT NULL,
+    quantity integer NOT NULL,
+    position integer NOT NULL DEFAULT 0,
+    CONSTRAINT bundle_components_pkey PRIMARY KEY (bundle_id, component_id),
+    CONSTRAINT bundle_components_quantity_check CHECK (quantity > 0),
+    CONSTRAINT bundle_components_self_check CHECK (bundle_id <> component_id),
+    CONSTRAINT bundle_components_bundle_id_fkey FOREIGN KEY (bundle_id)
+        REFERENCES product_bundles (product_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE,
+    CONSTRAINT bundle_components_component_id_fkey FOREIGN KEY (component_id)
+        REFERENCES products (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+ALTER TABLE IF EXISTS bundle_components OWNER to appuser;
+
+-- Index: idx_bundle_components_component_id, the bundles a product is part of
+CREATE INDEX IF NOT EXISTS idx_bundle_components_component_id ON bundle_components USING btree (component_id);
+
+
+-- The product details show the bundle, changing it moves the bundle product to the next version
+
+DROP TRIGGER IF EXISTS product_bundles_bump_version ON product_bundles;
+CREATE TRIGGER product_bundles_bump_version
+    AFTER INSERT OR UPDATE OR DELETE ON product_bundles
+    FOR EACH ROW EXECUTE FUNCTION product_children_bump_version();
+
+
+-- Prices the discounted bundles at the sum of their components less the discount, rounded half
+-- away from zero to the cents of the price column as Bundle.DiscountedPrice does, recorded in the
+-- price history with the 'bundle' source
+
+CREATE OR REPLACE FUNCTION refresh_bundle_prices(p_bundle_ids uuid[])
+    RETURNS void
+    LANGUAGE 'plpgsql'
+AS $BODY$
+DECLARE
+    v_source text := current_setting('app.price_source', true);
+BEGIN
+    PERFORM set_config('app.price_source', 'bundle', true);
+
+    UPDATE products p
+    SET price = priced.price, updated_at = NOW()
+    FROM (
+        SELECT b.product_id, ROUND(SUM(c.price * bc.quantity) * (100 - b.discount_percent) / 100, 2) AS price
+        FROM product_bundles b
+        JOIN bundle_components bc ON bc.bundle_id = b.product_id
+        JOIN products c ON c.id = bc.component_id
+        WHERE b.product_id = ANY(p_bundle_ids) AND b.discount_percent IS NOT NULL
+        GROUP BY b.product_id, b.discount_percent
+    ) priced
+    WHERE p.id = priced.product_id AND p.price IS DISTINCT FROM priced.price;
+
+    PERFORM set_config('app.price_source', COALESCE(v_source, ''), true);
+END;
+$BODY$;
+
+ALTER FUNCTION refresh_bundle_prices(uuid[]) OWNER TO appuser;
+
+CREATE OR REPLACE FUNCTION products_refresh_bundle_prices()
+    RETURNS trigger
+    LANGUAGE 'plpgsql'
+AS $BODY$
+BEGIN
+    PERFORM refresh_bundle_prices(ARRAY(SELECT bundle_id FROM bundle_components WHERE component_id = NEW.id));
+    RETURN NULL;
+END;
+$BODY$;
+
+ALTER FUNCTION products_refresh_bundle_prices() OWNER TO appuser;
+
+DROP TRIGGER IF EXISTS products_refresh_bundle_prices ON products;
+CREATE TRIGGER products_refresh_bundle_prices
+    AFTER UPDATE OF price ON products
+    FOR EACH ROW WHEN (OLD.price IS DISTINCT FROM NEW.price)
+    EXECUTE FUNCTION products_refresh_bundle_prices();
+
+
+-- A discounted bundle follows the price of its components, a price written any other way (the
+-- product updates, the import or a price schedule) is replaced with the one of its components
+
+CREATE OR REPLACE FUNCTION products_keep_bundle_price()
+    RETURNS trigger
+    LANGUAGE 'plpgsql'
+AS $BODY$
+BEGIN
+    IF COALESCE(current_setting('app.price_source', true), '') <> 'bundle'
+        AND EXISTS (SELECT 1 FROM product_bundles WHERE product_id = NEW.id AND discount_percent IS NOT NULL) THEN
+        NEW.price := OLD.price;
+    END IF;
+    RETURN NEW;
+END;
+$BODY$;
+
+ALTER FUNCTION products_keep_bundle_price() OWNER TO appuser;
+
+DROP TRIGGER IF EXISTS products_keep_bundle_price ON products;
+CREATE TRIGGER products_keep_bundle_price
+    BEFORE UPDATE OF price ON products
+    FOR EACH ROW WHEN (OLD.price IS DISTINCT FROM NEW.price)
+    EXECUTE FUNCTION products_keep_bundle_price();
+
+-- The component lines of an ordered bundle, they cost nothing on their own and are
+-- there for the fulfillment and the stock reservation
+
+ALTER TABLE IF EXISTS order_details ADD COLUMN IF NOT EXISTS bundle_detail_id uuid;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'order_details_bundle_detail_id_fkey') THEN
+        ALTER TABLE order_details ADD CONSTRAINT order_details_bundle_detail_id_fkey FOREIGN KEY (bundle_detail_id)
+            REFERENCES order_details (id) MATCH SIMPLE
+            ON UPDATE NO ACTION
+            ON DELETE CASCADE;
+    END IF;
+END $$;
+
+-- Index: idx_order_details_bundle_detail_id
+CREATE INDEX IF NOT EXISTS idx_order_details_bundle_detail_id ON order_details USING btree (bundle_detail_id) WHERE bundle_detail_id IS NOT NULL;
+
+
+CREATE OR REPLACE PROCEDURE orders_insert(
+	IN p_user_id uuid,
+	IN p_total_price numeric,
+	IN p_status numeric,
+	IN p_order_details order_detail_type[],
+	IN p_discount_total numeric,
+	IN p_free_shipping boolean,
+	IN p_discounts jsonb,
+	IN p_coupon_id uuid,
+	INOUT next_order_id uuid)
+LANGUAGE 'plpgsql'
+AS $BODY$
+DECLARE
+    v_usage_limit integer;
+    v_per_user_limit integer;
+    v_used integer;
+    v_used_by_user integer;
+BEGIN
+
+    IF p_coupon_id IS NOT NULL THEN
+        SELECT usage_limit, per_user_limit INTO v_usage_limit, v_per_user_limit
+        FROM coupons WHERE id = p_coupon_id AND active
+        FOR UPDATE;
+        IF NOT FOUND THEN
+            RAISE EXCEPTION 'coupon % is not active', p_coupon_id USING ERRCODE = 'no_data_found';
+        END IF;
+
+        SELECT COUNT(*), COUNT(*) FILTER (WHERE r.user_id = p_user_id) INTO v_used, v_used_by_user
+        FROM coupon_redemptions r JOIN orders o ON o.id = r.order_id
+        WHERE r.coupon_id = p_coupon_id AND o.status <> 4;
+        IF v_used >= v_usage_limit OR v_used_by_user >= v_per_user_limit THEN
+            RAISE EXCEPTION 'coupon % reached its usage limit', p_coupon_id USING ERRCODE = 'CP001';
+        END IF;
+    END IF;
+
+    -- Insert the new order
+    INSERT INTO orders (id, user_id, total_price, status, discount_total, free_shipping)
+    VALUES (
+        gen_random_uuid(),
+        p_user_id,
+        p_total_price,
+        p_status,
+        COALESCE(p_discount_total, 0),
+        COALESCE(p_free_shipping, false)
+    )
+    RETURNING id INTO next_order_id;
+
+    -- Unnest and insert the order details
+    WITH details AS (
+        SELECT
+            next_order_id AS order_id,
+            (d).product_id,
+            (d).variant_id,
+            COALESCE((d).quantity, 1) AS quantity,
+            (d).unit_price,
+            COALESCE((d).discount, 0) AS discount
+        FROM unnest(p_order_details) AS d
+    )
+    INSERT INTO order_details (id, order_id, product_id, variant_id, quantity, unit_price, discount)
+    SELECT
+        gen_random_uuid(),
+        order_id,
+        product_id,
+        variant_id,
+        quantity,
+        unit_price,
+        discount
+    FROM details;
+
+    -- Add the components of the ordered bundles
+    INSERT INTO order_details (id, order_id, product_id, quantity, unit_price, bundle_detail_id)
+    SELECT
+        gen_random_uuid(),
+        next_order_id,
+        bc.component_id,
+        d.quantity * bc.quantity,
+        0,
+        d.id
+    FROM order_details d
+    JOIN bundle_components bc ON bc.bundle_id = d.product_id
+    WHERE d.order_id = next_order_id AND d.bundle_detail_id IS NULL;
+
+    -- Insert the applied discounts and the coupon redemption
+    INSERT INTO order_discounts (order_id, position, promotion_id, coupon_code, name, kind, amount)
+    SELECT
+        next_order_id,
+        d.position,
+        (d.discount->>'promotion_id')::uuid,
+        NULLIF(d.discount->>'coupon_code', ''),
+        d.discount->>'name',
+        d.discount->>'kind',
+        (d.discount->>'amount')::numeric
+    FROM jsonb_array_elements(COALESCE(p_discounts, '[]'::jsonb)) WITH ORDINALITY AS d(discount, position);
+
+    IF p_coupon_id IS NOT NULL THEN
+        INSERT INTO coupon_redemptions (coupon_id, order_id, user_id)
+        VALUES (p_coupon_id, next_order_id, p_user_id);
+    END IF;
+
+    -- Reserve the stock, of the components for the bundles
+    PERFORM 1 FROM inventory
+    WHERE product_id IN (SELECT product_id FROM order_details WHERE order_id = next_order_id)
+    ORDER BY product_id
+    FOR UPDATE;
+
+    WITH ordered AS (
+        SELECT product_id, SUM(quantity) AS quantity
+        FROM order_details
+        WHERE order_id = next_order_id
+        GROUP BY product_id
+    ), reserved AS (
+        UPDATE inventory i
+        SET quantity = i.quantity - o.quantity, updated_at = NOW()
+        FROM ordered o
+        WHERE i.product_id = o.product_id
+        RETURNING i.product_id, -o.quantity AS delta, i.quantity
+    )
+    INSERT INTO inventory_movements (product_id, delta, quantity_after, kind, order_id, user_id)
+    SELECT product_id, delta, quantity, 'order', next_order_id, p_user_id
+    FROM reserved;
+
+    COMMIT;
+END;
+$BODY$;
+
+ALTER PROCEDURE orders_insert(uuid, numeric, numeric, order_detail_type[], numeric, boolean, jsonb, uuid, uuid) OWNER TO appuser;
@@ -0,0 +1,74 @@
+--Localized product and category content
+
+-- Table: product_translations
+-- The name and description of a product in a locale (a BCP 47 tag such as fr or pt-BR). The
+-- products columns hold the content in the default locale, an empty description falls back to it.
+
+CREATE TABLE IF NOT EXISTS product_translations
+(
+    product_id uuid NOT NULL,
+    locale character varying(35) NOT NULL,
+    name character varying(255) NOT NULL,
+    description text NOT NULL DEFAULT '',
+    updated_at timestamp without time zone NOT NULL DEFAULT NOW(),
+    created_at timestamp without time zone NOT NULL DEFAULT NOW(),
+    CONSTRAINT product_translations_pkey PRIMARY KEY (product_id, locale),
+    CONSTRAINT product_translations_product_id_fkey FOREIGN KEY (product_id)
+        REFERENCES products (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+ALTER TABLE IF EXISTS product_translations OWNER to appuser;
+
+
+-- Table: category_translations
+-- The name of a category in a locale.
+
+CREATE TABLE IF NOT EXISTS category_translations
+(
+    category_id uuid NOT NULL,
+    locale character varying(35) NOT NULL,
+    name character varying(255) NOT NULL,
+    updated_at timestamp without time zone NOT NULL DEFAULT NOW(),
+    created_at timestamp without time zone NOT NULL DEFAULT NOW(),
+    CONSTRAINT category_translations_pkey PRIMARY KEY (category_id, locale),
+    CONSTRAINT category_translations_category_id_fkey FOREIGN KEY (category_id)
+        REFERENCES categories (id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+ALTER TABLE IF EXISTS category_translations OWNER to appuser;
+
+
+-- The product details show the translations and the translated breadcrumbs, changing them moves
+-- the product, or the products of the category and its subcategories, to the next version
+
+DROP TRIGGER IF EXISTS product_translations_bump_version ON product_translations;
+CREATE TRIGGER product_translations_bump_version
+    AFTER INSERT OR UPDATE OR DELETE ON product_translations
+    FOR EACH ROW EXECUTE FUNCTION product_children_bump_version();
+
+
+CREATE OR REPLACE FUNCTION category_translations_bump_version()
+    RETURNS trigger
+    LANGUAGE 'plpgsql'
+AS $BODY$
+BEGIN
+    IF TG_OP <> 'DELETE' THEN
+        PERFORM category_products_bump_version(NEW.category_id);
+    END IF;
+    IF TG_OP = 'DELETE' OR (TG_OP = 'UPDATE' AND OLD.category_id <> NEW.category_id) THEN
+        PERFORM category_products_bump_version(OLD.category_id);
+    END IF;
+    RETURN NULL;
+END;
+$BODY$;
+
+ALTER FUNCTION category_translations_bump_version() OWNER TO appuser;
+
+DROP TRIGGER IF EXISTS category_translations_bump_version ON category_translations;
+CREATE TRIGGER category_translations_bump_version
+    AFTER INSERT OR UPDATE OR DELETE ON category_translations
+    FOR EACH ROW EXECUTE FUNCTION category_translations_bump_version();
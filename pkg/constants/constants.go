@@ -4,4 +4,18 @@ package constants
 const (
 	ApiPrefix  string = "/api"
 	ApiVersion uint8  = 1
-)
\ No newline at end of file
+)
+
+// User roles
+const (
+	RoleCustomer string = "customer"
+	RoleAdmin    string = "admin"
+)
+
+// Order statuses
+const (
+	OrderStatusCreated    int = 1
+	OrderStatusProcessing int = 2
+	OrderStatusCompleted  int = 3
+	OrderStatusCancelled  int = 4
+)
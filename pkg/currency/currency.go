@@ -0,0 +1,69 @@
+// pkg/currency/currency.go
+package currency
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/shayja/go-template-api/pkg/money"
+)
+
+// Rounding modes of converted amounts
+const (
+	// Round half away from zero
+	HalfUp = money.HalfUp
+	// Round half to the even neighbour (banker's rounding)
+	HalfEven = money.HalfEven
+	// Round away from zero
+	Up = money.Up
+	// Round toward zero
+	Down = money.Down
+)
+
+// Modes lists the supported rounding modes.
+var Modes = []string{HalfUp, HalfEven, Up, Down}
+
+var ErrInvalidCode = errors.New("currency: the code must be three letters")
+
+// Normalize returns the upper case ISO 4217 style code, or an error when it is not three letters
+func Normalize(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) != 3 {
+		return "", ErrInvalidCode
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return "", ErrInvalidCode
+		}
+	}
+	return code, nil
+}
+
+// Rule tells how amounts of a currency are rounded.
+type Rule struct {
+	// The number of decimals of the currency, e.g. 2 for EUR and 0 for JPY
+	Decimals int
+	// HalfUp (default), HalfEven, Up or Down
+	Mode string
+	// Optional, rounds to a multiple of the increment instead, e.g. 0.05 for cash prices in CHF
+	Increment money.Amount
+}
+
+// step returns the amount converted amounts are a multiple of
+func (r Rule) step() money.Amount {
+	step := money.Step(r.Decimals)
+	if r.Increment > step {
+		step = r.Increment
+	}
+	return step
+}
+
+// Round rounds the amount according to the rule.
+func (r Rule) Round(amount money.Amount) money.Amount {
+	return amount.RoundTo(r.step(), r.Mode)
+}
+
+// Convert converts an amount of the base currency at the rate, in units of the currency per base unit, and rounds it.
+func (r Rule) Convert(amount money.Amount, rate float64) money.Amount {
+	return amount.MulRate(rate, r.step(), r.Mode)
+}
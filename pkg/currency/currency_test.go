@@ -0,0 +1,51 @@
+package currency_test
+
+import (
+	"testing"
+
+	"github.com/shayja/go-template-api/pkg/currency"
+	"github.com/shayja/go-template-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	code, err := currency.Normalize(" eur ")
+	assert.NoError(t, err)
+	assert.Equal(t, "EUR", code)
+
+	for _, code := range []string{"", "EU", "EURO", "E1R"} {
+		_, err := currency.Normalize(code)
+		assert.ErrorIs(t, err, currency.ErrInvalidCode, code)
+	}
+}
+
+func TestRule_Round(t *testing.T) {
+	tests := []struct {
+		rule   currency.Rule
+		amount string
+		want   string
+	}{
+		{currency.Rule{Decimals: 2}, "1.005", "1.01"},
+		{currency.Rule{Decimals: 2}, "-1.005", "-1.01"},
+		{currency.Rule{Decimals: 2, Mode: currency.HalfEven}, "1.005", "1.00"},
+		{currency.Rule{Decimals: 2, Mode: currency.HalfEven}, "1.015", "1.02"},
+		{currency.Rule{Decimals: 2, Mode: currency.Up}, "1.001", "1.01"},
+		{currency.Rule{Decimals: 2, Mode: currency.Down}, "1.009", "1.00"},
+		{currency.Rule{Decimals: 0}, "1234.5", "1235.00"},
+		{currency.Rule{Decimals: 2, Increment: money.New(0, 5)}, "1.02", "1.00"},
+		{currency.Rule{Decimals: 2, Increment: money.New(0, 5)}, "1.03", "1.05"},
+		{currency.Rule{Decimals: 2, Mode: currency.Up, Increment: money.New(0, 50)}, "10.01", "10.50"},
+	}
+	for _, test := range tests {
+		amount, err := money.Parse(test.amount)
+		assert.NoError(t, err)
+		assert.Equal(t, test.want, test.rule.Round(amount).String(), "%+v %v", test.rule, test.amount)
+	}
+}
+
+func TestRule_Convert(t *testing.T) {
+	assert.Equal(t, money.New(91, 99), currency.Rule{Decimals: 2}.Convert(money.New(99, 99), 0.92))
+	assert.Equal(t, money.New(15066, 0), currency.Rule{Decimals: 0}.Convert(money.New(99, 99), 150.68))
+	// The product is exactly 0.125, rounded half up once
+	assert.Equal(t, money.New(0, 13), currency.Rule{Decimals: 2}.Convert(money.New(12, 50), 0.01))
+}
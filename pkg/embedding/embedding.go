@@ -0,0 +1,105 @@
+// pkg/embedding/embedding.go
+package embedding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/shayja/go-template-api/config"
+)
+
+// Embedding providers selectable with EMBEDDING_PROVIDER
+const (
+	ProviderLocal  = "local"
+	ProviderOpenAI = "openai"
+)
+
+// DefaultDimensions is the length of the vectors stored in the product_embeddings table
+const DefaultDimensions = 384
+
+// ErrDimensions is returned when a provider returns vectors of an unexpected length.
+var ErrDimensions = errors.New("embedding: unexpected vector dimensions")
+
+// Provider turns texts into vectors, texts with a similar meaning getting vectors with a high cosine similarity.
+type Provider interface {
+	// Model identifies the vectors of the provider, the texts are embedded again when it changes
+	Model() string
+	// Dimensions is the length of the vectors
+	Dimensions() int
+	// Embed returns a vector per text, in the order of the texts
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// NewFromConfig returns the embedding provider selected by the env.
+func NewFromConfig() (Provider, error) {
+	dimensions := DefaultDimensions
+	if s := config.Config("EMBEDDING_DIMENSIONS"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("embedding: invalid dimensions %q", s)
+		}
+		dimensions = n
+	}
+
+	switch provider := config.Config("EMBEDDING_PROVIDER"); provider {
+	case "", ProviderLocal:
+		return &Local{Dims: dimensions}, nil
+	case ProviderOpenAI:
+		return &OpenAI{
+			URL:    config.Config("EMBEDDING_API_URL"),
+			APIKey: config.Config("EMBEDDING_API_KEY"),
+			Name:   config.Config("EMBEDDING_MODEL"),
+			Dims:   dimensions,
+		}, nil
+	default:
+		return nil, fmt.Errorf("embedding: unknown provider %q", provider)
+	}
+}
+
+// Format writes a vector in the pgvector text format, e.g. [0.1,0.2,0.3]
+func Format(vector []float32) string {
+	var b strings.Builder
+	b.WriteByte('[')
+	for i, v := range vector {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(strconv.FormatFloat(float64(v), 'g', -1, 32))
+	}
+	b.WriteByte(']')
+	return b.String()
+}
+
+// Normalize scales a vector to unit length in place, a zero vector is left as it is
+func Normalize(vector []float32) []float32 {
+	var sum float64
+	for _, v := range vector {
+		sum += float64(v) * float64(v)
+	}
+	if sum == 0 {
+		return vector
+	}
+	norm := float32(math.Sqrt(sum))
+	for i := range vector {
+		vector[i] /= norm
+	}
+	return vector
+}
+
+// Cosine returns the cosine similarity of two vectors of the same length
+func Cosine(a []float32, b []float32) float64 {
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / math.Sqrt(normA*normB)
+}
@@ -0,0 +1,85 @@
+package embedding_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shayja/go-template-api/pkg/embedding"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocal_Deterministic(t *testing.T) {
+	provider := &embedding.Local{}
+	a, err := provider.Embed(context.Background(), []string{"Wireless noise cancelling headphones"})
+	assert.NoError(t, err)
+	b, err := (&embedding.Local{}).Embed(context.Background(), []string{"Wireless noise cancelling headphones"})
+	assert.NoError(t, err)
+
+	assert.Len(t, a[0], embedding.DefaultDimensions)
+	assert.Equal(t, a, b)
+	assert.InDelta(t, 1, embedding.Cosine(a[0], a[0]), 1e-6)
+	assert.Equal(t, "local-hash-384", provider.Model())
+}
+
+func TestLocal_Similarity(t *testing.T) {
+	provider := &embedding.Local{Dims: 256}
+	vectors, err := provider.Embed(context.Background(), []string{
+		"bluetooth headphones",
+		"Wireless Bluetooth Headphones with noise cancelling",
+		"cast iron frying pan",
+		"",
+	})
+	assert.NoError(t, err)
+	assert.Len(t, vectors[0], 256)
+
+	assert.Greater(t, embedding.Cosine(vectors[0], vectors[1]), embedding.Cosine(vectors[0], vectors[2]))
+	assert.Equal(t, 0.0, embedding.Cosine(vectors[0], vectors[3]))
+}
+
+func TestFormat(t *testing.T) {
+	assert.Equal(t, "[0.5,-1,0]", embedding.Format([]float32{0.5, -1, 0}))
+	assert.Equal(t, "[]", embedding.Format(nil))
+}
+
+func TestOpenAI_Embed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		var request struct {
+			Model      string   `json:"model"`
+			Input      []string `json:"input"`
+			Dimensions int      `json:"dimensions"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Equal(t, "text-embedding-3-small", request.Model)
+		assert.Equal(t, []string{"first", "second"}, request.Input)
+		assert.Equal(t, 2, request.Dimensions)
+		w.Write([]byte(`{"data":[{"index":1,"embedding":[0,1]},{"index":0,"embedding":[1,0]}]}`))
+	}))
+	defer server.Close()
+
+	provider := &embedding.OpenAI{URL: server.URL, APIKey: "secret", Dims: 2}
+	vectors, err := provider.Embed(context.Background(), []string{"first", "second"})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]float32{{1, 0}, {0, 1}}, vectors)
+}
+
+func TestOpenAI_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/short" {
+			w.Write([]byte(`{"data":[{"index":0,"embedding":[1]}]}`))
+			return
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":{"message":"Incorrect API key"}}`))
+	}))
+	defer server.Close()
+
+	_, err := (&embedding.OpenAI{URL: server.URL, Dims: 2}).Embed(context.Background(), []string{"text"})
+	assert.ErrorContains(t, err, "Incorrect API key")
+
+	_, err = (&embedding.OpenAI{URL: server.URL + "/short", Dims: 2}).Embed(context.Background(), []string{"text"})
+	assert.ErrorIs(t, err, embedding.ErrDimensions)
+}
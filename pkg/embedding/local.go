@@ -0,0 +1,66 @@
+// pkg/embedding/local.go
+package embedding
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"unicode"
+)
+
+// The weight of the character trigrams of a word relative to the word itself
+const trigramWeight = 0.3
+
+// Local embeds texts offline by hashing their words and character trigrams into the vector, so
+// texts sharing words or word parts are similar. It is deterministic, for tests and offline use,
+// and has no notion of synonyms.
+type Local struct {
+	// The length of the vectors, DefaultDimensions when 0
+	Dims int
+}
+
+func (p *Local) Model() string {
+	return fmt.Sprintf("local-hash-%d", p.Dimensions())
+}
+
+func (p *Local) Dimensions() int {
+	if p.Dims <= 0 {
+		return DefaultDimensions
+	}
+	return p.Dims
+}
+
+func (p *Local) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		vectors[i] = p.embed(text)
+	}
+	return vectors, nil
+}
+
+func (p *Local) embed(text string) []float32 {
+	vector := make([]float32, p.Dimensions())
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		p.add(vector, "w:"+word, 1)
+		padded := []rune(" " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			p.add(vector, "t:"+string(padded[i:i+3]), trigramWeight)
+		}
+	}
+	return Normalize(vector)
+}
+
+// add hashes a feature to a dimension and a sign, so unrelated features cancel out on average
+func (p *Local) add(vector []float32, feature string, weight float32) {
+	h := fnv.New64a()
+	h.Write([]byte(feature))
+	sum := h.Sum64()
+	if sum>>63 == 1 {
+		weight = -weight
+	}
+	vector[sum%uint64(len(vector))] += weight
+}
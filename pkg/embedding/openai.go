@@ -0,0 +1,122 @@
+// pkg/embedding/openai.go
+package embedding
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultOpenAIURL is the embeddings endpoint used when OpenAI.URL is not set
+const DefaultOpenAIURL = "https://api.openai.com/v1/embeddings"
+
+// DefaultOpenAIModel is the model used when OpenAI.Name is not set
+const DefaultOpenAIModel = "text-embedding-3-small"
+
+// OpenAI embeds texts with the OpenAI embeddings API, or any service compatible with it.
+type OpenAI struct {
+	// The embeddings endpoint, DefaultOpenAIURL when empty
+	URL    string
+	APIKey string
+	// The model name, DefaultOpenAIModel when empty
+	Name string
+	// The length of the vectors the model is asked for, DefaultDimensions when 0
+	Dims int
+	// Optional, defaults to a client with a 30 second timeout
+	Client *http.Client
+}
+
+type openAIRequest struct {
+	Model      string   `json:"model"`
+	Input      []string `json:"input"`
+	Dimensions int      `json:"dimensions,omitempty"`
+}
+
+type openAIResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (p *OpenAI) Model() string {
+	if p.Name == "" {
+		return DefaultOpenAIModel
+	}
+	return p.Name
+}
+
+func (p *OpenAI) Dimensions() int {
+	if p.Dims <= 0 {
+		return DefaultDimensions
+	}
+	return p.Dims
+}
+
+func (p *OpenAI) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return [][]float32{}, nil
+	}
+	body, err := json.Marshal(openAIRequest{Model: p.Model(), Input: texts, Dimensions: p.Dimensions()})
+	if err != nil {
+		return nil, err
+	}
+	url := p.URL
+	if url == "" {
+		url = DefaultOpenAIURL
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.APIKey)
+	}
+
+	client := p.Client
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var decoded openAIResponse
+	if err := json.NewDecoder(res.Body).Decode(&decoded); err != nil {
+		return nil, fmt.Errorf("embedding: %s: %w", res.Status, err)
+	}
+	if res.StatusCode != http.StatusOK {
+		message := res.Status
+		if decoded.Error != nil {
+			message = strings.TrimSpace(res.Status + " " + decoded.Error.Message)
+		}
+		return nil, fmt.Errorf("embedding: %s", message)
+	}
+
+	vectors := make([][]float32, len(texts))
+	for _, item := range decoded.Data {
+		if item.Index < 0 || item.Index >= len(vectors) {
+			return nil, fmt.Errorf("embedding: unexpected index %d", item.Index)
+		}
+		if len(item.Embedding) != p.Dimensions() {
+			return nil, ErrDimensions
+		}
+		vectors[item.Index] = item.Embedding
+	}
+	for _, vector := range vectors {
+		if vector == nil {
+			return nil, fmt.Errorf("embedding: missing vectors")
+		}
+	}
+	return vectors, nil
+}
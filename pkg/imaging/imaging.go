@@ -0,0 +1,279 @@
+// Package imaging validates uploaded images and renders them into resized renditions.
+// Images are decoded and encoded again, which drops the EXIF and any other metadata.
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"strconv"
+	"strings"
+
+	"golang.org/x/image/draw"
+
+	// Registered image decoders
+	_ "golang.org/x/image/webp"
+	_ "image/gif"
+)
+
+var (
+	// ErrInvalidImage is returned for content that cannot be decoded as an image
+	ErrInvalidImage = errors.New("imaging: invalid image")
+	// ErrDimensions is returned for images outside of the dimension limits
+	ErrDimensions = errors.New("imaging: image dimensions out of range")
+)
+
+// Output formats
+const (
+	FormatJPEG = "jpeg"
+	FormatPNG  = "png"
+	FormatWebP = "webp"
+)
+
+// Rendition is a named bounding box an image is scaled down to fit in.
+type Rendition struct {
+	Name   string
+	Width  int
+	Height int
+}
+
+// DefaultRenditions are the renditions used when none are configured
+var DefaultRenditions = []Rendition{
+	{Name: "thumbnail", Width: 150, Height: 150},
+	{Name: "medium", Width: 600, Height: 600},
+	{Name: "large", Width: 1200, Height: 1200},
+}
+
+// ParseRenditions parses a comma separated list of name:WIDTHxHEIGHT renditions,
+// e.g. "thumbnail:150x150,medium:600x600"
+func ParseRenditions(spec string) ([]Rendition, error) {
+	var renditions []Rendition
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name, size, ok := strings.Cut(item, ":")
+		width, height, ok2 := strings.Cut(size, "x")
+		if !ok || !ok2 || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("imaging: invalid rendition %q", item)
+		}
+		w, err := strconv.Atoi(width)
+		if err != nil || w <= 0 {
+			return nil, fmt.Errorf("imaging: invalid rendition width %q", item)
+		}
+		h, err := strconv.Atoi(height)
+		if err != nil || h <= 0 {
+			return nil, fmt.Errorf("imaging: invalid rendition height %q", item)
+		}
+		renditions = append(renditions, Rendition{Name: strings.TrimSpace(name), Width: w, Height: h})
+	}
+	return renditions, nil
+}
+
+// Limits are the accepted dimensions of a source image, zero values are not checked.
+type Limits struct {
+	// Minimum width and height in pixels
+	MinDimension int
+	// Maximum width and height in pixels
+	MaxDimension int
+	// Maximum number of pixels, bounds the memory decoding takes
+	MaxPixels int
+}
+
+// Check reads the image header only and validates its format and dimensions against the limits,
+// returning the image format and its dimensions
+func (l Limits) Check(data []byte) (string, int, int, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return "", 0, 0, ErrInvalidImage
+	}
+	w, h := config.Width, config.Height
+	if w <= 0 || h <= 0 {
+		return "", 0, 0, ErrInvalidImage
+	}
+	if l.MinDimension > 0 && (w < l.MinDimension || h < l.MinDimension) {
+		return "", 0, 0, ErrDimensions
+	}
+	if l.MaxDimension > 0 && (w > l.MaxDimension || h > l.MaxDimension) {
+		return "", 0, 0, ErrDimensions
+	}
+	if l.MaxPixels > 0 && w*h > l.MaxPixels {
+		return "", 0, 0, ErrDimensions
+	}
+	return format, w, h, nil
+}
+
+// Encoder writes an image in an output format.
+type Encoder func(w io.Writer, img image.Image) error
+
+// Processor renders source images into renditions.
+type Processor struct {
+	Renditions []Rendition
+	// Limits of the source images
+	Limits Limits
+	// JPEG quality (1-100), jpeg.DefaultQuality when zero
+	Quality int
+	// Optional, also renders every rendition in WebP
+	WebP Encoder
+}
+
+// Output is a rendered rendition.
+type Output struct {
+	Rendition
+	// Output format, one of FormatJPEG, FormatPNG or FormatWebP
+	Format string
+	// The actual dimensions of the rendition
+	ActualWidth  int
+	ActualHeight int
+	Data         []byte
+}
+
+// ContentType returns the media type of the output
+func (o *Output) ContentType() string {
+	return "image/" + o.Format
+}
+
+// Ext returns the file extension of the output
+func (o *Output) Ext() string {
+	if o.Format == FormatJPEG {
+		return ".jpg"
+	}
+	return "." + o.Format
+}
+
+// Process decodes an image and renders all the renditions, honoring the EXIF orientation.
+// Opaque images are rendered as JPEG and images with transparency as PNG. Only the first
+// frame of animated images is kept.
+func (p *Processor) Process(data []byte) ([]*Output, error) {
+	if _, _, _, err := p.Limits.Check(data); err != nil {
+		return nil, err
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+	orientation := Orientation(data)
+
+	format := FormatPNG
+	if opaque(src) {
+		format = FormatJPEG
+	}
+
+	var outputs []*Output
+	for _, rendition := range p.Renditions {
+		img := resize(src, rendition, orientation)
+		output, err := p.encode(rendition, format, img)
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, output)
+
+		if p.WebP != nil {
+			output, err := p.encode(rendition, FormatWebP, img)
+			if err != nil {
+				return nil, err
+			}
+			outputs = append(outputs, output)
+		}
+	}
+	return outputs, nil
+}
+
+// encode encodes a rendered image in the given format
+func (p *Processor) encode(rendition Rendition, format string, img image.Image) (*Output, error) {
+	var buf bytes.Buffer
+	var err error
+	switch format {
+	case FormatJPEG:
+		quality := p.Quality
+		if quality <= 0 {
+			quality = jpeg.DefaultQuality
+		}
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality})
+	case FormatPNG:
+		err = png.Encode(&buf, img)
+	case FormatWebP:
+		err = p.WebP(&buf, img)
+	default:
+		err = fmt.Errorf("imaging: unsupported format %s", format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("imaging: encoding %s %s: %w", rendition.Name, format, err)
+	}
+	bounds := img.Bounds()
+	return &Output{Rendition: rendition, Format: format, ActualWidth: bounds.Dx(), ActualHeight: bounds.Dy(), Data: buf.Bytes()}, nil
+}
+
+// resize scales the image down to fit in the rendition box, then applies the orientation.
+// Images smaller than the box are not scaled up.
+func resize(src image.Image, rendition Rendition, orientation int) image.Image {
+	bounds := src.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	// The box is in displayed dimensions, which are swapped for the rotated orientations
+	boxW, boxH := rendition.Width, rendition.Height
+	if orientation >= 5 {
+		boxW, boxH = boxH, boxW
+	}
+
+	dw, dh := w, h
+	if w > boxW || h > boxH {
+		if w*boxH > h*boxW {
+			dw, dh = boxW, max(1, h*boxW/w)
+		} else {
+			dw, dh = max(1, w*boxH/h), boxH
+		}
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Src, nil)
+	return orient(dst, orientation)
+}
+
+// opaque reports whether the image has no transparent pixels
+func opaque(img image.Image) bool {
+	if o, ok := img.(interface{ Opaque() bool }); ok {
+		return o.Opaque()
+	}
+	return false
+}
+
+// orient transforms an image stored in an EXIF orientation (2-8) to its upright position
+func orient(src *image.NRGBA, orientation int) image.Image {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // mirrored horizontally
+				dx, dy = w-1-x, y
+			case 3: // rotated 180
+				dx, dy = w-1-x, h-1-y
+			case 4: // mirrored vertically
+				dx, dy = x, h-1-y
+			case 5: // transposed
+				dx, dy = y, x
+			case 6: // rotated 90 clockwise
+				dx, dy = h-1-y, x
+			case 7: // transversed
+				dx, dy = h-1-y, w-1-x
+			case 8: // rotated 90 counter clockwise
+				dx, dy = y, w-1-x
+			}
+			dst.SetNRGBA(dx, dy, src.NRGBAAt(x, y))
+		}
+	}
+	return dst
+}
@@ -0,0 +1,158 @@
+package imaging_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"testing"
+
+	"github.com/shayja/go-template-api/pkg/imaging"
+	"github.com/stretchr/testify/assert"
+)
+
+// testImage encodes a w x h image, PNG with a transparent pixel or JPEG
+func testImage(t *testing.T, w, h int, transparent bool) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if transparent {
+		img.SetNRGBA(0, 0, color.NRGBA{})
+		assert.NoError(t, png.Encode(&buf, img))
+	} else {
+		assert.NoError(t, jpeg.Encode(&buf, img, nil))
+	}
+	return buf.Bytes()
+}
+
+// withOrientation inserts an EXIF segment with the given orientation after the JPEG start marker
+func withOrientation(data []byte, orientation uint16) []byte {
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08")
+	tiff = binary.BigEndian.AppendUint16(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, 0x0112)
+	tiff = binary.BigEndian.AppendUint16(tiff, 3)
+	tiff = binary.BigEndian.AppendUint32(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, orientation)
+	tiff = append(tiff, 0, 0, 0, 0, 0, 0)
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := []byte{0xFF, 0xE1}
+	app1 = binary.BigEndian.AppendUint16(app1, uint16(len(segment)+2))
+	app1 = append(app1, segment...)
+
+	out := append([]byte{}, data[:2]...)
+	out = append(out, app1...)
+	return append(out, data[2:]...)
+}
+
+func TestParseRenditions(t *testing.T) {
+	renditions, err := imaging.ParseRenditions("thumbnail:150x100, large:1200x1200,")
+	assert.NoError(t, err)
+	assert.Equal(t, []imaging.Rendition{{Name: "thumbnail", Width: 150, Height: 100}, {Name: "large", Width: 1200, Height: 1200}}, renditions)
+
+	for _, spec := range []string{"thumbnail", "thumbnail:150", ":150x150", "thumbnail:0x150", "thumbnail:axb"} {
+		_, err := imaging.ParseRenditions(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestLimits_Check(t *testing.T) {
+	data := testImage(t, 40, 20, false)
+
+	format, w, h, err := imaging.Limits{MinDimension: 10, MaxDimension: 100}.Check(data)
+	assert.NoError(t, err)
+	assert.Equal(t, "jpeg", format)
+	assert.Equal(t, 40, w)
+	assert.Equal(t, 20, h)
+
+	_, _, _, err = imaging.Limits{MinDimension: 30}.Check(data)
+	assert.ErrorIs(t, err, imaging.ErrDimensions)
+	_, _, _, err = imaging.Limits{MaxDimension: 30}.Check(data)
+	assert.ErrorIs(t, err, imaging.ErrDimensions)
+	_, _, _, err = imaging.Limits{MaxPixels: 799}.Check(data)
+	assert.ErrorIs(t, err, imaging.ErrDimensions)
+	_, _, _, err = imaging.Limits{}.Check([]byte("not an image"))
+	assert.ErrorIs(t, err, imaging.ErrInvalidImage)
+}
+
+func TestProcessor_Process(t *testing.T) {
+	processor := &imaging.Processor{Renditions: []imaging.Rendition{
+		{Name: "thumbnail", Width: 10, Height: 10},
+		{Name: "large", Width: 100, Height: 100},
+	}}
+
+	outputs, err := processor.Process(testImage(t, 40, 20, false))
+	assert.NoError(t, err)
+	if assert.Len(t, outputs, 2) {
+		assert.Equal(t, "thumbnail", outputs[0].Name)
+		assert.Equal(t, imaging.FormatJPEG, outputs[0].Format)
+		assert.Equal(t, ".jpg", outputs[0].Ext())
+		assert.Equal(t, [2]int{10, 5}, [2]int{outputs[0].ActualWidth, outputs[0].ActualHeight})
+		// Not scaled up
+		assert.Equal(t, [2]int{40, 20}, [2]int{outputs[1].ActualWidth, outputs[1].ActualHeight})
+
+		config, format, err := image.DecodeConfig(bytes.NewReader(outputs[0].Data))
+		assert.NoError(t, err)
+		assert.Equal(t, "jpeg", format)
+		assert.Equal(t, 10, config.Width)
+	}
+
+	// Transparency is kept
+	outputs, err = processor.Process(testImage(t, 40, 20, true))
+	assert.NoError(t, err)
+	if assert.Len(t, outputs, 2) {
+		assert.Equal(t, imaging.FormatPNG, outputs[0].Format)
+		assert.Equal(t, "image/png", outputs[0].ContentType())
+	}
+}
+
+func TestProcessor_Process_Orientation(t *testing.T) {
+	processor := &imaging.Processor{Renditions: []imaging.Rendition{{Name: "medium", Width: 20, Height: 20}}}
+	data := withOrientation(testImage(t, 40, 20, false), 6)
+	assert.Equal(t, 6, imaging.Orientation(data))
+
+	outputs, err := processor.Process(data)
+	assert.NoError(t, err)
+	if assert.Len(t, outputs, 1) {
+		// Rotated upright, the 2:1 landscape source is displayed as a 1:2 portrait
+		assert.Equal(t, [2]int{10, 20}, [2]int{outputs[0].ActualWidth, outputs[0].ActualHeight})
+		// The EXIF is not copied to the rendition
+		assert.Equal(t, 1, imaging.Orientation(outputs[0].Data))
+		assert.NotContains(t, string(outputs[0].Data), "Exif")
+	}
+}
+
+func TestProcessor_Process_WebP(t *testing.T) {
+	processor := &imaging.Processor{
+		Renditions: []imaging.Rendition{{Name: "thumbnail", Width: 10, Height: 10}},
+		WebP: func(w io.Writer, img image.Image) error {
+			_, err := w.Write([]byte("RIFF----WEBP"))
+			return err
+		},
+	}
+
+	outputs, err := processor.Process(testImage(t, 40, 20, false))
+	assert.NoError(t, err)
+	if assert.Len(t, outputs, 2) {
+		assert.Equal(t, imaging.FormatWebP, outputs[1].Format)
+		assert.Equal(t, "thumbnail", outputs[1].Name)
+		assert.Equal(t, ".webp", outputs[1].Ext())
+		assert.Equal(t, []byte("RIFF----WEBP"), outputs[1].Data)
+	}
+}
+
+func TestProcessor_Process_Invalid(t *testing.T) {
+	processor := &imaging.Processor{Renditions: imaging.DefaultRenditions, Limits: imaging.Limits{MaxDimension: 30}}
+
+	_, err := processor.Process([]byte("not an image"))
+	assert.ErrorIs(t, err, imaging.ErrInvalidImage)
+	_, err = processor.Process(testImage(t, 40, 20, false))
+	assert.ErrorIs(t, err, imaging.ErrDimensions)
+}
@@ -0,0 +1,38 @@
+package imaging
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"os/exec"
+	"strconv"
+)
+
+// CWebP returns a WebP encoder piping the images through the cwebp command line encoder
+// (https://developers.google.com/speed/webp/docs/cwebp), Go has no WebP encoder of its own.
+func CWebP(path string, quality int) (Encoder, error) {
+	path, err := exec.LookPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("imaging: webp encoder: %w", err)
+	}
+	if quality <= 0 {
+		quality = 80
+	}
+	return func(w io.Writer, img image.Image) error {
+		var in bytes.Buffer
+		if err := png.Encode(&in, img); err != nil {
+			return err
+		}
+		var stderr bytes.Buffer
+		cmd := exec.Command(path, "-quiet", "-q", strconv.Itoa(quality), "-o", "-", "--", "-")
+		cmd.Stdin = &in
+		cmd.Stdout = w
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("cwebp: %v: %s", err, bytes.TrimSpace(stderr.Bytes()))
+		}
+		return nil
+	}, nil
+}
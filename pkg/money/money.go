@@ -0,0 +1,234 @@
+// pkg/money/money.go
+package money
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Scale is the number of decimals an Amount holds, enough for the minor units of every currency
+// and the rounding increments of converted amounts
+const Scale = 4
+
+// One is the Amount of a single unit of a currency.
+const One Amount = 10000
+
+// Rounding modes
+const (
+	// Round half away from zero
+	HalfUp = "half_up"
+	// Round half to the even neighbour (banker's rounding)
+	HalfEven = "half_even"
+	// Round away from zero
+	Up = "up"
+	// Round toward zero
+	Down = "down"
+)
+
+var ErrInvalidAmount = errors.New("money: invalid amount")
+
+// Amount is an exact amount of money, held as an integer number of 1/10^Scale units so
+// sums and multiples do not drift like float64 amounts do.
+// It is a decimal number in JSON and SQL, e.g. 19.99.
+type Amount int64
+
+// New returns the amount of the given units and hundredths, e.g. New(19, 99) for 19.99
+func New(units int64, cents int64) Amount {
+	return Amount(units)*One + Amount(cents)*(One/100)
+}
+
+// Parse parses a decimal number, rounding half up beyond Scale decimals
+func Parse(s string) (Amount, error) {
+	s = strings.TrimSpace(s)
+	r, ok := new(big.Rat).SetString(s)
+	if s == "" || !ok || strings.ContainsAny(s, "/") {
+		return 0, ErrInvalidAmount
+	}
+	return fromRat(r.Mul(r, big.NewRat(int64(One), 1)), 1, HalfUp)
+}
+
+// FromFloat returns the amount closest to the shortest decimal representation of f, so 0.1 is 0.1
+func FromFloat(f float64) Amount {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0
+	}
+	a, _ := Parse(strconv.FormatFloat(f, 'f', -1, 64))
+	return a
+}
+
+// Float64 returns the amount as a float64, for display and ratios only
+func (a Amount) Float64() float64 {
+	return float64(a) / float64(One)
+}
+
+// Add returns a + b
+func (a Amount) Add(b Amount) Amount {
+	return a + b
+}
+
+// Sub returns a - b
+func (a Amount) Sub(b Amount) Amount {
+	return a - b
+}
+
+// Mul returns the amount times a quantity
+func (a Amount) Mul(quantity int64) Amount {
+	return a * Amount(quantity)
+}
+
+// Round rounds the amount to a number of decimals (0 to Scale)
+func (a Amount) Round(decimals int, mode string) Amount {
+	return a.RoundTo(Step(decimals), mode)
+}
+
+// RoundTo rounds the amount to a multiple of the step, e.g. 0.05
+func (a Amount) RoundTo(step Amount, mode string) Amount {
+	if step <= 1 {
+		return a
+	}
+	rounded, _ := fromRat(new(big.Rat).SetInt64(int64(a)), int64(step), mode)
+	return rounded
+}
+
+// MulRate returns the amount times a rate, rounded to a multiple of the step. The rate is taken at its
+// shortest decimal representation and the product is rounded only once, so 10.00 at 0.92 is 9.20
+func (a Amount) MulRate(rate float64, step Amount, mode string) Amount {
+	if step < 1 {
+		step = 1
+	}
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(rate, 'f', -1, 64))
+	if !ok {
+		return 0
+	}
+	rounded, _ := fromRat(r.Mul(r, new(big.Rat).SetInt64(int64(a))), int64(step), mode)
+	return rounded
+}
+
+// LessPercent returns the amount less a percentage of it, rounded once to a multiple of the step,
+// so 129.97 less 15 percent is 110.47 at the cents
+func (a Amount) LessPercent(percent Amount, step Amount, mode string) Amount {
+	if step < 1 {
+		step = 1
+	}
+	r := new(big.Rat).SetFrac(big.NewInt(int64(100*One-percent)), big.NewInt(int64(100*One)))
+	rounded, _ := fromRat(r.Mul(r, new(big.Rat).SetInt64(int64(a))), int64(step), mode)
+	return rounded
+}
+
+// Step returns the smallest amount of a number of decimals, e.g. 0.01 for 2
+func Step(decimals int) Amount {
+	if decimals >= Scale {
+		return 1
+	}
+	if decimals < 0 {
+		decimals = 0
+	}
+	return Amount(math.Pow10(Scale - decimals))
+}
+
+// String formats the amount with at least 2 decimals, e.g. 19.90 or 0.0125
+func (a Amount) String() string {
+	sign := ""
+	v := uint64(a)
+	if a < 0 {
+		sign = "-"
+		v = uint64(-a)
+	}
+	units, fraction := v/uint64(One), v%uint64(One)
+	decimals := strings.TrimRight(fmt.Sprintf("%0*d", Scale, fraction), "0")
+	for len(decimals) < 2 {
+		decimals += "0"
+	}
+	return fmt.Sprintf("%s%d.%s", sign, units, decimals)
+}
+
+// MarshalJSON encodes the amount as a JSON number
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalJSON decodes a JSON number or numeric string, leaving the amount unchanged on null
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+// UnmarshalParam decodes a query or form parameter
+func (a *Amount) UnmarshalParam(param string) error {
+	parsed, err := Parse(param)
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+// Scan reads a numeric column
+func (a *Amount) Scan(src interface{}) error {
+	var err error
+	switch v := src.(type) {
+	case []byte:
+		*a, err = Parse(string(v))
+	case string:
+		*a, err = Parse(v)
+	case float64:
+		*a = FromFloat(v)
+	case int64:
+		*a = Amount(v) * One
+	default:
+		err = fmt.Errorf("money: cannot scan %T into an amount", src)
+	}
+	return err
+}
+
+// Value writes the amount as a decimal string, which Postgres reads exactly into numeric columns
+func (a Amount) Value() (driver.Value, error) {
+	return a.String(), nil
+}
+
+// fromRat rounds x, in 1/10^Scale units, to a multiple of the step
+func fromRat(x *big.Rat, step int64, mode string) (Amount, error) {
+	q := new(big.Rat).Quo(x, new(big.Rat).SetInt64(step))
+	n, rem := new(big.Int).QuoRem(q.Num(), q.Denom(), new(big.Int))
+	if rem.Sign() != 0 {
+		// Twice the remainder against the denominator tells below, at or above the half
+		half := new(big.Int).Abs(rem)
+		half.Lsh(half, 1)
+		cmp := half.Cmp(q.Denom())
+		away := false
+		switch mode {
+		case Up:
+			away = true
+		case Down:
+			away = false
+		case HalfEven:
+			away = cmp > 0 || (cmp == 0 && n.Bit(0) == 1)
+		default:
+			away = cmp >= 0
+		}
+		if away {
+			n.Add(n, big.NewInt(int64(q.Sign())))
+		}
+	}
+	n.Mul(n, big.NewInt(step))
+	if !n.IsInt64() {
+		return 0, ErrInvalidAmount
+	}
+	return Amount(n.Int64()), nil
+}
@@ -0,0 +1,112 @@
+package money_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/shayja/go-template-api/pkg/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want money.Amount
+	}{
+		{"19.99", money.New(19, 99)},
+		{"-0.5", -money.New(0, 50)},
+		{"100", money.New(100, 0)},
+		{"1e2", money.New(100, 0)},
+		{"0.00005", 1},
+		{"0.00004", 0},
+		{" 7.1 ", money.New(7, 10)},
+	}
+	for _, test := range tests {
+		got, err := money.Parse(test.in)
+		assert.NoError(t, err, test.in)
+		assert.Equal(t, test.want, got, test.in)
+	}
+
+	for _, in := range []string{"", "abc", "1/3", "1e30"} {
+		_, err := money.Parse(in)
+		assert.ErrorIs(t, err, money.ErrInvalidAmount, in)
+	}
+}
+
+func TestString(t *testing.T) {
+	assert.Equal(t, "19.99", money.New(19, 99).String())
+	assert.Equal(t, "100.00", money.New(100, 0).String())
+	assert.Equal(t, "0.0125", money.Amount(125).String())
+	assert.Equal(t, "-0.50", (-money.New(0, 50)).String())
+}
+
+func TestTotals(t *testing.T) {
+	// Ten lines of 0.10 and three of 33.33 sum to exact totals, unlike float64
+	var total money.Amount
+	for i := 0; i < 10; i++ {
+		total = total.Add(money.FromFloat(0.1))
+	}
+	assert.Equal(t, money.New(1, 0), total)
+
+	floatTotal := 0.0
+	for i := 0; i < 10; i++ {
+		floatTotal += 0.1
+	}
+	assert.NotEqual(t, 1.0, floatTotal)
+
+	assert.Equal(t, "99.99", money.New(33, 33).Mul(3).String())
+	assert.Equal(t, "4.35", money.FromFloat(1.45).Mul(3).String())
+}
+
+func TestRound(t *testing.T) {
+	a, _ := money.Parse("2.345")
+	assert.Equal(t, "2.35", a.Round(2, money.HalfUp).String())
+	assert.Equal(t, "2.34", a.Round(2, money.HalfEven).String())
+	assert.Equal(t, "2.35", a.Round(2, money.Up).String())
+	assert.Equal(t, "2.34", a.Round(2, money.Down).String())
+	assert.Equal(t, "2.00", a.Round(0, money.HalfUp).String())
+	assert.Equal(t, "2.35", a.RoundTo(money.New(0, 5), money.HalfUp).String())
+}
+
+func TestMulRate(t *testing.T) {
+	assert.Equal(t, "9.20", money.New(10, 0).MulRate(0.92, money.Step(2), money.HalfUp).String())
+	assert.Equal(t, "1.1574", money.New(1, 23).MulRate(0.941, 1, money.HalfUp).String())
+}
+
+func TestLessPercent(t *testing.T) {
+	assert.Equal(t, "110.47", money.New(129, 97).LessPercent(money.New(15, 0), money.Step(2), money.HalfUp).String())
+	// 8.585 is rounded once, half away from zero
+	assert.Equal(t, "8.59", money.New(10, 10).LessPercent(money.New(15, 0), money.Step(2), money.HalfUp).String())
+	assert.Equal(t, "87.4913", money.New(99, 99).LessPercent(money.New(12, 50), 1, money.HalfUp).String())
+	assert.Equal(t, "10.00", money.New(10, 0).LessPercent(0, money.Step(2), money.HalfUp).String())
+}
+
+func TestJSON(t *testing.T) {
+	var v struct {
+		Price money.Amount  `json:"price"`
+		Old   *money.Amount `json:"old"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(`{"price": 19.99, "old": "24.5"}`), &v))
+	assert.Equal(t, money.New(19, 99), v.Price)
+	assert.Equal(t, money.New(24, 50), *v.Old)
+
+	out, err := json.Marshal(v)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"price": 19.99, "old": 24.5}`, string(out))
+	assert.Contains(t, string(out), `"price":19.99`)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"price": "abc"}`), &v))
+}
+
+func TestScanValue(t *testing.T) {
+	var a money.Amount
+	assert.NoError(t, a.Scan([]byte("1234.56")))
+	assert.Equal(t, money.New(1234, 56), a)
+	assert.NoError(t, a.Scan(int64(3)))
+	assert.Equal(t, money.New(3, 0), a)
+	assert.Error(t, a.Scan(nil))
+
+	value, err := money.New(10, 5).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "10.05", value)
+}
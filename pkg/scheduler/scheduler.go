@@ -0,0 +1,35 @@
+// pkg/scheduler/scheduler.go
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Job is a unit of background work run on a schedule.
+type Job func(ctx context.Context) error
+
+// Every runs the job in the background once per interval until the context is done.
+// Failures are logged and retried on the next tick; a non positive interval disables the job.
+func Every(ctx context.Context, name string, interval time.Duration, job Job) {
+	if interval <= 0 {
+		log.Printf("scheduler: %s disabled", name)
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := job(ctx); err != nil {
+					log.Printf("scheduler: %s failed: %v", name, err)
+				}
+			}
+		}
+	}()
+}
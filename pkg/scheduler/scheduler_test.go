@@ -0,0 +1,40 @@
+package scheduler_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/shayja/go-template-api/pkg/scheduler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvery_RunsUntilCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var runs atomic.Int32
+
+	scheduler.Every(ctx, "test", time.Millisecond, func(context.Context) error {
+		runs.Add(1)
+		return nil
+	})
+
+	assert.Eventually(t, func() bool { return runs.Load() >= 3 }, time.Second, time.Millisecond)
+	cancel()
+	time.Sleep(5 * time.Millisecond)
+	stopped := runs.Load()
+	time.Sleep(5 * time.Millisecond)
+	assert.Equal(t, stopped, runs.Load())
+}
+
+func TestEvery_Disabled(t *testing.T) {
+	var runs atomic.Int32
+
+	scheduler.Every(context.Background(), "test", 0, func(context.Context) error {
+		runs.Add(1)
+		return nil
+	})
+
+	time.Sleep(5 * time.Millisecond)
+	assert.Zero(t, runs.Load())
+}
@@ -0,0 +1,74 @@
+// Package signedurl signs URL paths with an expiry time, so that private resources can be
+// shared for a limited time without any other authentication.
+package signedurl
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Query parameters of a signed URL
+const (
+	ExpiresParam   = "expires"
+	SignatureParam = "signature"
+)
+
+var (
+	// ErrInvalidSignature is returned for missing or forged signatures
+	ErrInvalidSignature = errors.New("signedurl: invalid signature")
+	// ErrExpired is returned for signatures past their expiry time
+	ErrExpired = errors.New("signedurl: expired")
+)
+
+// Signer signs and verifies URL paths with an HMAC-SHA256 key.
+type Signer struct {
+	Key []byte
+	// Optional, the current time, time.Now when not set
+	Now func() time.Time
+}
+
+// Sign returns the signature of a path valid until the expiry time
+func (s *Signer) Sign(path string, expires time.Time) string {
+	mac := hmac.New(sha256.New, s.Key)
+	mac.Write([]byte(strconv.FormatInt(expires.Unix(), 10)))
+	mac.Write([]byte{'\n'})
+	mac.Write([]byte(path))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// URL returns the base URL joined with the path, signed for the given time to live
+func (s *Signer) URL(baseURL string, path string, ttl time.Duration) string {
+	expires := s.now().Add(ttl)
+	query := url.Values{}
+	query.Set(ExpiresParam, strconv.FormatInt(expires.Unix(), 10))
+	query.Set(SignatureParam, s.Sign(path, expires))
+	return baseURL + path + "?" + query.Encode()
+}
+
+// Verify checks the signature of a path and that it has not expired, returning the expiry time
+func (s *Signer) Verify(path string, expires string, signature string) (time.Time, error) {
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || signature == "" {
+		return time.Time{}, ErrInvalidSignature
+	}
+	expiry := time.Unix(unix, 0)
+	if !hmac.Equal([]byte(s.Sign(path, expiry)), []byte(signature)) {
+		return time.Time{}, ErrInvalidSignature
+	}
+	if !s.now().Before(expiry) {
+		return time.Time{}, ErrExpired
+	}
+	return expiry, nil
+}
+
+func (s *Signer) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
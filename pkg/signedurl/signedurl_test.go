@@ -0,0 +1,41 @@
+package signedurl_test
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shayja/go-template-api/pkg/signedurl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSigner(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	signer := &signedurl.Signer{Key: []byte("secret"), Now: func() time.Time { return now }}
+
+	signed := signer.URL("https://api.example.com/images/", "private/a.png", time.Minute)
+	assert.True(t, strings.HasPrefix(signed, "https://api.example.com/images/private/a.png?"))
+	parsed, err := url.Parse(signed)
+	assert.NoError(t, err)
+	expires, signature := parsed.Query().Get(signedurl.ExpiresParam), parsed.Query().Get(signedurl.SignatureParam)
+
+	expiry, err := signer.Verify("private/a.png", expires, signature)
+	assert.NoError(t, err)
+	assert.Equal(t, now.Add(time.Minute), expiry)
+
+	// Another path, another key or a tampered expiry
+	_, err = signer.Verify("private/b.png", expires, signature)
+	assert.ErrorIs(t, err, signedurl.ErrInvalidSignature)
+	other := &signedurl.Signer{Key: []byte("other"), Now: signer.Now}
+	_, err = other.Verify("private/a.png", expires, signature)
+	assert.ErrorIs(t, err, signedurl.ErrInvalidSignature)
+	_, err = signer.Verify("private/a.png", "1800000000", signature)
+	assert.ErrorIs(t, err, signedurl.ErrInvalidSignature)
+	_, err = signer.Verify("private/a.png", expires, "")
+	assert.ErrorIs(t, err, signedurl.ErrInvalidSignature)
+
+	now = now.Add(time.Minute)
+	_, err = signer.Verify("private/a.png", expires, signature)
+	assert.ErrorIs(t, err, signedurl.ErrExpired)
+}
@@ -0,0 +1,42 @@
+// pkg/slug/slug.go
+package slug
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Make converts a display name into a lowercase, dash separated URL identifier
+func Make(input string) string {
+	var builder strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(strings.TrimSpace(input)) {
+		if isSlugRune(r) {
+			builder.WriteRune(r)
+			dash = false
+		} else if !dash && builder.Len() > 0 {
+			builder.WriteRune('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(builder.String(), "-")
+}
+
+// IsValid reports whether the input is a lowercase, dash separated URL identifier, as Make returns
+func IsValid(input string) bool {
+	if input == "" || strings.HasPrefix(input, "-") || strings.HasSuffix(input, "-") || strings.Contains(input, "--") {
+		return false
+	}
+	for _, r := range input {
+		if !(isSlugRune(r) || r == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+// isSlugRune reports whether a rune may be part of a word of a slug: a digit, a mark or a letter
+// that is already lowercase. Letters of scripts without case, such as Hebrew or Chinese, are kept
+func isSlugRune(r rune) bool {
+	return unicode.IsDigit(r) || unicode.IsMark(r) || (unicode.IsLetter(r) && unicode.ToLower(r) == r)
+}
@@ -0,0 +1,34 @@
+package slug_test
+
+import (
+	"testing"
+
+	"github.com/shayja/go-template-api/pkg/slug"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMake(t *testing.T) {
+	tests := map[string]string{
+		"Smart Phones & Tablets": "smart-phones-tablets",
+		"  Ünïcode Phones! ":     "ünïcode-phones",
+		"טלפונים חכמים":          "טלפונים-חכמים",
+		"智能手机":                   "智能手机",
+		"मोबाइल फ़ोन":            "मोबाइल-फ़ोन",
+		"!!!":                    "",
+	}
+	for name, want := range tests {
+		got := slug.Make(name)
+		assert.Equal(t, want, got, name)
+		// Every slug made from a name is valid
+		assert.Equal(t, want != "", slug.IsValid(got), name)
+	}
+}
+
+func TestIsValid(t *testing.T) {
+	for _, valid := range []string{"phones", "phones-2", "טלפונים-חכמים", "智能手机"} {
+		assert.True(t, slug.IsValid(valid), valid)
+	}
+	for _, invalid := range []string{"", "Phones", "phones!", "-phones", "phones-", "smart--phones", "smart phones"} {
+		assert.False(t, slug.IsValid(invalid), invalid)
+	}
+}
@@ -0,0 +1,93 @@
+// pkg/storage/storage.go
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/shayja/go-template-api/config"
+)
+
+// Storage drivers selectable with STORAGE_DRIVER
+const (
+	DriverLocal = "local"
+	DriverS3    = "s3"
+)
+
+// PrivatePrefix is the key prefix of the objects that are only served with a signed URL.
+const PrivatePrefix = "private/"
+
+// ErrNotFound is returned when an object does not exist.
+var ErrNotFound = errors.New("storage: object not found")
+
+// ObjectInfo describes a stored object.
+type ObjectInfo struct {
+	Size        int64
+	ContentType string
+	ModTime     time.Time
+	// Quoted entity tag of the object content, changes whenever the object does
+	ETag string
+}
+
+// Storage is a blob store addressed by slash separated keys such as "products/ab12.png".
+type Storage interface {
+	// Put stores the content under the key, replacing any existing object
+	Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error
+	// Open returns the content of the object, the caller must close it
+	Open(ctx context.Context, key string) (io.ReadCloser, *ObjectInfo, error)
+	// Delete removes the object, deleting a missing object is not an error
+	Delete(ctx context.Context, key string) error
+}
+
+// NewFromConfig returns the storage driver selected by the env.
+func NewFromConfig() (Storage, error) {
+	switch driver := config.Config("STORAGE_DRIVER"); driver {
+	case "", DriverLocal:
+		root := config.Config("STORAGE_LOCAL_ROOT")
+		if root == "" {
+			root = "./images"
+		}
+		return &LocalStorage{Root: root}, nil
+	case DriverS3:
+		return &S3Storage{
+			Endpoint:  config.Config("S3_ENDPOINT"),
+			Region:    config.Config("S3_REGION"),
+			Bucket:    config.Config("S3_BUCKET"),
+			AccessKey: config.Config("S3_ACCESS_KEY"),
+			SecretKey: config.Config("S3_SECRET_KEY"),
+		}, nil
+	default:
+		return nil, fmt.Errorf("storage: unknown driver %q", driver)
+	}
+}
+
+// PublicURL joins the public base URL the objects are served from and an object key.
+func PublicURL(baseURL string, key string) string {
+	return strings.TrimRight(baseURL, "/") + "/" + strings.TrimLeft(key, "/")
+}
+
+// KeyFromURL returns the object key of a public URL, false when the URL is not served from the base URL.
+func KeyFromURL(baseURL string, url string) (string, bool) {
+	prefix := strings.TrimRight(baseURL, "/") + "/"
+	if baseURL == "" || !strings.HasPrefix(url, prefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(url, prefix), true
+}
+
+// ValidKey rejects keys that could escape the storage root.
+func ValidKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return fmt.Errorf("storage: invalid key %q", key)
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return fmt.Errorf("storage: invalid key %q", key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package storage_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/shayja/go-template-api/pkg/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func roundTrip(t *testing.T, store storage.Storage) {
+	ctx := context.Background()
+	content := []byte("\x89PNG\r\n\x1a\nimage")
+
+	err := store.Put(ctx, "products/a1.png", bytes.NewReader(content), int64(len(content)), "image/png")
+	assert.NoError(t, err)
+
+	reader, info, err := store.Open(ctx, "products/a1.png")
+	if !assert.NoError(t, err) {
+		return
+	}
+	stored, _ := io.ReadAll(reader)
+	reader.Close()
+	assert.Equal(t, content, stored)
+	assert.Equal(t, int64(len(content)), info.Size)
+	assert.Equal(t, "image/png", info.ContentType)
+
+	assert.NoError(t, store.Delete(ctx, "products/a1.png"))
+	assert.NoError(t, store.Delete(ctx, "products/a1.png"))
+
+	_, _, err = store.Open(ctx, "products/a1.png")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+}
+
+func TestLocalStorage(t *testing.T) {
+	roundTrip(t, &storage.LocalStorage{Root: t.TempDir()})
+}
+
+func TestLocalStorage_RejectsTraversal(t *testing.T) {
+	store := &storage.LocalStorage{Root: t.TempDir()}
+
+	for _, key := range []string{"../secret.png", "/etc/passwd", "products/../../x.png", ""} {
+		err := store.Put(context.Background(), key, strings.NewReader("x"), 1, "image/png")
+		assert.Error(t, err, key)
+	}
+}
+
+// fakeS3 is a minimal in-memory stand-in of an S3 compatible service
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	types   map[string]string
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=minio/") || r.Header.Get("X-Amz-Date") == "" ||
+		!strings.Contains(auth, "host;x-amz-content-sha256;x-amz-date") || !strings.Contains(auth, "Signature=") {
+		http.Error(w, "<Error><Code>AccessDenied</Code></Error>", http.StatusForbidden)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		f.objects[r.URL.Path] = body
+		f.types[r.URL.Path] = r.Header.Get("Content-Type")
+	case http.MethodGet:
+		body, ok := f.objects[r.URL.Path]
+		if !ok {
+			http.Error(w, "<Error><Code>NoSuchKey</Code></Error>", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", f.types[r.URL.Path])
+		w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+		w.Write(body)
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestS3Storage(t *testing.T) {
+	fake := &fakeS3{objects: map[string][]byte{}, types: map[string]string{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	store := &storage.S3Storage{Endpoint: server.URL, Bucket: "images", AccessKey: "minio", SecretKey: "minio123"}
+	roundTrip(t, store)
+}
+
+func TestS3Storage_AccessDenied(t *testing.T) {
+	fake := &fakeS3{objects: map[string][]byte{}, types: map[string]string{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	store := &storage.S3Storage{Endpoint: server.URL, Bucket: "images", AccessKey: "other", SecretKey: "secret"}
+	err := store.Put(context.Background(), "products/a1.png", strings.NewReader("x"), 1, "image/png")
+	assert.ErrorContains(t, err, "403")
+}
+
+func TestPublicURL(t *testing.T) {
+	url := storage.PublicURL("https://cdn.example.com/media/", "products/a1.png")
+	assert.Equal(t, "https://cdn.example.com/media/products/a1.png", url)
+
+	key, ok := storage.KeyFromURL("https://cdn.example.com/media", url)
+	assert.True(t, ok)
+	assert.Equal(t, "products/a1.png", key)
+
+	_, ok = storage.KeyFromURL("https://cdn.example.com/media", "https://elsewhere.com/a1.png")
+	assert.False(t, ok)
+}